@@ -1,27 +1,36 @@
 package identity
 
 import (
+	"autopilot/backends/api/internal/identity/model"
 	"autopilot/backends/api/internal/identity/service"
 	"autopilot/backends/api/pkg/app"
 	"autopilot/backends/api/pkg/httpx"
 	"autopilot/backends/api/pkg/middleware"
 	"autopilot/backends/internal/types"
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 )
 
 type Authentication struct {
 	*app.Container
-	API     huma.API
-	Session service.Sessioner
+	API           huma.API
+	Impersonation service.Impersonationer
+	IPAllowlist   service.IPAllowlister
+	SCIM          service.SCIMer
+	Session       service.Sessioner
 }
 
 func NewAuthentication(container *app.Container, api huma.API, manager *service.Manager) httpx.Authenticator {
 	return &Authentication{
-		Container: container,
-		API:       api,
-		Session:   manager.Session,
+		Container:     container,
+		API:           api,
+		Impersonation: manager.Impersonation,
+		IPAllowlist:   manager.IPAllowlist,
+		SCIM:          manager.SCIM,
+		Session:       manager.Session,
 	}
 }
 
@@ -41,12 +50,18 @@ func (s *Authentication) RequireUserSession(ctx huma.Context, next func(huma.Con
 
 	entityID := middleware.GetActiveEntity(ctx.Context())
 	mode := types.GetOperationMode(ctx.Context())
-	next(httpx.WithAuthInfo(ctx, httpx.AuthInfo{
+	auth := httpx.AuthInfo{
 		Authenticated: true,
 		EntityID:      entityID,
 		UserID:        session.UserID,
 		Mode:          mode,
-	}))
+		EntityRole:    session.Role(entityID),
+	}
+	if !s.allowImpersonation(ctx, session, &auth) || !s.allowIP(ctx, auth) {
+		return
+	}
+
+	next(httpx.WithAuthInfo(ctx, auth))
 }
 
 func (s *Authentication) RequireSecretKey(ctx huma.Context, next func(huma.Context)) {
@@ -77,6 +92,37 @@ func (s *Authentication) RequireAuthenticated(ctx huma.Context, next func(huma.C
 	s.secretKey(ctx, token, next)
 }
 
+func (s *Authentication) RequireSCIMToken(ctx huma.Context, next func(huma.Context)) {
+	token, ok := strings.CutPrefix(ctx.Header("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		_ = huma.WriteErr(s.API, ctx, http.StatusUnauthorized, "Unauthenticated", httpx.ErrUnauthenticated)
+		return
+	}
+
+	scimToken, err := s.SCIM.Authenticate(ctx.Context(), token)
+	if err != nil {
+		if !errors.Is(err, httpx.ErrUnauthenticated) {
+			s.Logger.Error("Failed to authenticate SCIM token", "error", err)
+		}
+		_ = huma.WriteErr(s.API, ctx, http.StatusUnauthorized, "Unauthenticated", httpx.ErrUnauthenticated)
+		return
+	}
+
+	mode := types.GetOperationMode(ctx.Context())
+	auth := httpx.AuthInfo{
+		Authenticated: true,
+		EntityID:      scimToken.EntityID,
+		Mode:          mode,
+		APIKeyUsed:    true,
+		CredentialID:  scimToken.ID,
+	}
+	if !s.allowIP(ctx, auth) {
+		return
+	}
+
+	next(httpx.WithAuthInfo(ctx, auth))
+}
+
 func (s *Authentication) cookie(ctx huma.Context, cookie string, next func(huma.Context)) {
 	session, err := s.Session.GetByToken(ctx.Context(), cookie)
 	if err != nil {
@@ -87,15 +133,76 @@ func (s *Authentication) cookie(ctx huma.Context, cookie string, next func(huma.
 
 	entityID := middleware.GetActiveEntity(ctx.Context())
 	mode := types.GetOperationMode(ctx.Context())
-	next(httpx.WithAuthInfo(ctx, httpx.AuthInfo{
+	auth := httpx.AuthInfo{
 		Authenticated: true,
 		EntityID:      entityID,
 		UserID:        session.UserID,
 		Mode:          mode,
 		EntityRole:    session.Role(entityID),
-	}))
+	}
+	if !s.allowImpersonation(ctx, session, &auth) || !s.allowIP(ctx, auth) {
+		return
+	}
+
+	next(httpx.WithAuthInfo(ctx, auth))
+}
+
+// allowImpersonation adds the impersonator of an impersonation session to the
+// auth info and records the request against them. Impersonation sessions are
+// read-only unless the operation allows impersonation, and an error is written
+// and false returned for refused requests.
+func (s *Authentication) allowImpersonation(ctx huma.Context, session *model.Session, auth *httpx.AuthInfo) bool {
+	if !session.IsImpersonation() {
+		return true
+	}
+
+	auth.ImpersonatorID = *session.ImpersonatorID
+	auth.ImpersonatorEntityID = *session.ImpersonatorEntityID
+
+	allowed := httpx.AllowsImpersonation(ctx.Operation())
+	if err := s.Impersonation.Record(httpx.WithAuthInfo(ctx, *auth).Context(), ctx.Method(), ctx.URL().Path, allowed); err != nil {
+		s.Logger.Error("Failed to record impersonated request", "error", err)
+		_ = huma.WriteErr(s.API, ctx, http.StatusInternalServerError, "Internal Server Error", httpx.ErrUnknown)
+		return false
+	}
+
+	if !allowed {
+		_ = huma.WriteErr(s.API, ctx, http.StatusForbidden, "Not allowed while impersonating", httpx.ErrImpersonationReadOnly)
+		return false
+	}
+
+	return true
 }
 
 func (s *Authentication) secretKey(ctx huma.Context, _ string, _ func(huma.Context)) {
 	_ = huma.WriteErr(s.API, ctx, http.StatusUnauthorized, "Unauthenticated")
 }
+
+// allowIP checks the request against the IP allowlist of the active entity,
+// writing an error and returning false if it isn't allowed. Sessions without a
+// role in the active entity have no access to check, which is left to the
+// permission checks.
+func (s *Authentication) allowIP(ctx huma.Context, auth httpx.AuthInfo) bool {
+	if auth.EntityID == "" || (auth.UserID != "" && auth.EntityRole == types.RoleNone) {
+		return true
+	}
+
+	var ip string
+	if reqMetadata := middleware.GetRequestMetadata(ctx.Context()); reqMetadata != nil {
+		ip = reqMetadata.IPAddress
+	}
+
+	err := s.IPAllowlist.Check(httpx.WithAuthInfo(ctx, auth).Context(), auth.EntityID, ip)
+	if err == nil {
+		return true
+	}
+
+	if errors.Is(err, httpx.ErrIPNotAllowed) {
+		_ = huma.WriteErr(s.API, ctx, http.StatusForbidden, "IP address not allowed", httpx.ErrIPNotAllowed)
+		return false
+	}
+
+	s.Logger.Error("Failed to check IP allowlist", "error", err)
+	_ = huma.WriteErr(s.API, ctx, http.StatusInternalServerError, "Internal Server Error", httpx.ErrUnknown)
+	return false
+}
@@ -0,0 +1,48 @@
+package identity
+
+import (
+	"autopilot/backends/api/internal/identity/service"
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+)
+
+// NewAuditVerifyCmd creates the command verifying the audit log hash chains
+func NewAuditVerifyCmd(ctx context.Context, logger *slog.Logger, auditLog service.AuditLoger) *cobra.Command {
+	var entityID string
+
+	cmd := &cobra.Command{
+		Use:   "audit:verify",
+		Short: "Verify the audit log hash chains against the latest signed checkpoint",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verifications, err := auditLog.Verify(ctx, entityID)
+			if err != nil {
+				return err
+			}
+
+			broken := 0
+			for _, v := range verifications {
+				if v.IsIntact() {
+					logger.Info("Audit log chain is intact", "chain", v.Chain, "entries", v.Entries, "checkpoint", v.CheckpointAt)
+					continue
+				}
+
+				broken++
+				logger.Error("Audit log chain is broken", "chain", v.Chain, "sequence", v.BrokenAt, "reason", v.Reason)
+			}
+
+			if broken > 0 {
+				return fmt.Errorf("%d of %d audit log chains are broken", broken, len(verifications))
+			}
+
+			logger.Info(fmt.Sprintf("Verified %d audit log chains", len(verifications)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&entityID, "entity", "", "only verify the chain of this entity, or the global chain with its nil UUID")
+
+	return cmd
+}
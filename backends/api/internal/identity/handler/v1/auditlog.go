@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditLog is an entry in the audit log of an entity.
+type AuditLog struct {
+	ID           string                    `json:"id" doc:"The audit log entry ID"`
+	ActorType    *model.AuditLogActorType  `json:"actorType" enum:"api_key,system,user" doc:"The kind of actor that took the action, empty for older entries"`
+	ActorID      *string                   `json:"actorId" doc:"The ID of the API key that took the action, if taken by one"`
+	UserID       *string                   `json:"userId" doc:"The ID of the user who took the action, if taken by a user"`
+	Action       types.Action              `json:"action" doc:"The action taken"`
+	ResourceType types.Resource            `json:"resourceType" doc:"The type of the resource acted on"`
+	ResourceID   string                    `json:"resourceId" doc:"The ID of the resource acted on"`
+	IPAddress    *string                   `json:"ipAddress" doc:"The IP address the action was taken from"`
+	UserAgent    *string                   `json:"userAgent" doc:"The user agent the action was taken with"`
+	Changes      map[string]AuditLogChange `json:"changes" doc:"The fields changed by the action"`
+	Metadata     map[string]any            `json:"metadata" doc:"Additional details of the action"`
+	CreatedAt    time.Time                 `json:"createdAt" doc:"When the action was taken"`
+}
+
+// AuditLogChange is the change of a field by an audited action.
+type AuditLogChange struct {
+	Before any `json:"before" doc:"The value before the action"`
+	After  any `json:"after" doc:"The value after the action"`
+}
+
+func newAuditLog(log *model.AuditLog) AuditLog {
+	metadata := map[string]any{}
+	if len(log.Metadata) > 0 {
+		_ = json.Unmarshal(log.Metadata, &metadata)
+	}
+
+	changes := map[string]AuditLogChange{}
+	if len(log.Changes) > 0 {
+		_ = json.Unmarshal(log.Changes, &changes)
+	}
+
+	return AuditLog{
+		ID:           log.ID,
+		ActorType:    log.ActorType,
+		ActorID:      log.ActorID,
+		UserID:       log.UserID,
+		Action:       log.Action,
+		ResourceType: log.ResourceType,
+		ResourceID:   log.ResourceID,
+		IPAddress:    log.IPAddress,
+		UserAgent:    log.UserAgent,
+		Changes:      changes,
+		Metadata:     metadata,
+		CreatedAt:    log.CreatedAt,
+	}
+}
+
+// ListAuditLogsRequest is the request body for the list audit logs endpoint.
+type ListAuditLogsRequest struct {
+	httpx.CursorPagination
+	UserID       string    `query:"userId" format:"uuid" doc:"Only list the actions taken by this user"`
+	ResourceType string    `query:"resourceType" doc:"Only list the actions on this type of resource" example:"membership"`
+	Action       string    `query:"action" doc:"Only list this action" example:"update"`
+	From         time.Time `query:"from" doc:"Only list the actions taken at or after this time"`
+	To           time.Time `query:"to" doc:"Only list the actions taken before this time"`
+}
+
+// ListAuditLogsResponse is the response body for the list audit logs endpoint.
+type ListAuditLogsResponse struct {
+	Body struct {
+		AuditLogs      []AuditLog `json:"auditLogs" doc:"The audit log entries"`
+		NextCursor     string     `json:"nextCursor,omitempty" doc:"The cursor of the next page, passed as after"`
+		PreviousCursor string     `json:"previousCursor,omitempty" doc:"The cursor of the previous page, passed as before"`
+	}
+}
+
+// ListAuditLogs lists the audit log of the active entity.
+func (v *V1) ListAuditLogs(ctx context.Context, input *ListAuditLogsRequest) (*ListAuditLogsResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	filter := model.AuditLogFilter{
+		UserID:       input.UserID,
+		ResourceType: types.Resource(input.ResourceType),
+		Action:       types.Action(input.Action),
+	}
+	if !input.From.IsZero() {
+		filter.From = &input.From
+	}
+	if !input.To.IsZero() {
+		filter.To = &input.To
+	}
+
+	list, err := v.identity.AuditLog.List(ctx, auth.EntityID, filter, input.Params)
+	if err != nil {
+		v.Logger.Error("Failed to list audit logs", "error", err)
+		return nil, err
+	}
+
+	response := &ListAuditLogsResponse{}
+	response.Body.AuditLogs = make([]AuditLog, 0, len(list.Logs))
+	for _, log := range list.Logs {
+		response.Body.AuditLogs = append(response.Body.AuditLogs, newAuditLog(log))
+	}
+	response.Body.NextCursor = list.NextCursor
+	response.Body.PreviousCursor = list.PreviousCursor
+
+	return response, nil
+}
+
+// ExportAuditLogsRequest is the request body for the export audit logs endpoint.
+type ExportAuditLogsRequest struct {
+	Body struct {
+		Format       model.AuditLogExportFormat `json:"format" enum:"csv,ndjson" required:"true" doc:"The file format of the export"`
+		From         time.Time                  `json:"from" required:"true" doc:"Export the actions taken at or after this time"`
+		To           time.Time                  `json:"to" required:"true" doc:"Export the actions taken before this time, at most a year after from"`
+		UserID       string                     `json:"userId,omitempty" format:"uuid" doc:"Only export the actions taken by this user"`
+		ResourceType string                     `json:"resourceType,omitempty" doc:"Only export the actions on this type of resource"`
+		Action       string                     `json:"action,omitempty" doc:"Only export this action"`
+	}
+}
+
+// ExportAuditLogsResponse is the response body for the export audit logs endpoint.
+type ExportAuditLogsResponse struct{}
+
+// ExportAuditLogs queues an export of the audit log of the active entity,
+// which is emailed to the user once ready.
+func (v *V1) ExportAuditLogs(ctx context.Context, input *ExportAuditLogsRequest) (*ExportAuditLogsResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	filter := model.AuditLogFilter{
+		UserID:       input.Body.UserID,
+		ResourceType: types.Resource(input.Body.ResourceType),
+		Action:       types.Action(input.Body.Action),
+		From:         &input.Body.From,
+		To:           &input.Body.To,
+	}
+
+	err := v.identity.AuditLog.RequestExport(ctx, auth.EntityID, auth.UserID, filter, input.Body.Format)
+	if err != nil {
+		v.Logger.Error("Failed to request audit log export", "error", err)
+		return nil, err
+	}
+
+	return &ExportAuditLogsResponse{}, nil
+}
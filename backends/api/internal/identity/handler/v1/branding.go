@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+)
+
+// Branding is the branding of an entity, carried by the emails sent on its behalf.
+type Branding struct {
+	Logo         *string `json:"logo,omitempty" doc:"The entity's logo URL"`
+	BrandColor   *string `json:"brandColor,omitempty" doc:"The entity's brand colour"`
+	SupportEmail *string `json:"supportEmail,omitempty" doc:"The email address customers can contact the entity at"`
+}
+
+func newBranding(entity *model.Entity) Branding {
+	return Branding{
+		Logo:         entity.Logo,
+		BrandColor:   entity.BrandColor,
+		SupportEmail: entity.SupportEmail,
+	}
+}
+
+// GetBrandingRequest is the request body for the get branding endpoint.
+type GetBrandingRequest struct{}
+
+// GetBrandingResponse is the response body for the get branding endpoint.
+type GetBrandingResponse struct {
+	Body Branding
+}
+
+// GetBranding returns the branding of the active entity.
+func (v *V1) GetBranding(ctx context.Context, input *GetBrandingRequest) (*GetBrandingResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	entity, err := v.identity.Entity.GetByID(ctx, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to get entity", "error", err)
+		return nil, err
+	}
+
+	return &GetBrandingResponse{Body: newBranding(entity)}, nil
+}
+
+// UpdateBrandingRequest is the request body for the update branding endpoint.
+type UpdateBrandingRequest struct {
+	Body struct {
+		BrandColor   *string `json:"brandColor,omitempty" required:"false" pattern:"^#[0-9a-fA-F]{6}$" doc:"The brand colour as a hex colour. Leave empty to clear it." example:"#0070f3"`
+		SupportEmail *string `json:"supportEmail,omitempty" required:"false" format:"email" doc:"The email address customers can contact the entity at. Leave empty to clear it." example:"support@example.com"`
+	}
+}
+
+// UpdateBrandingResponse is the response body for the update branding endpoint.
+type UpdateBrandingResponse struct {
+	Body Branding
+}
+
+// UpdateBranding replaces the brand colour and support email of the active entity.
+func (v *V1) UpdateBranding(ctx context.Context, input *UpdateBrandingRequest) (*UpdateBrandingResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	entity, err := v.identity.Entity.UpdateBranding(ctx, auth.UserID, auth.EntityID, input.Body.BrandColor, input.Body.SupportEmail)
+	if err != nil {
+		v.Logger.Error("Failed to update branding", "error", err)
+		return nil, err
+	}
+
+	return &UpdateBrandingResponse{Body: newBranding(entity)}, nil
+}
+
+// UpdateEntityLogoRequest is the request body for the update entity logo endpoint.
+type UpdateEntityLogoRequest struct {
+	RawBody []byte `contentType:"image/*" doc:"Supports png, jpeg and webp file types."`
+}
+
+// UpdateEntityLogoResponse is the response body for the update entity logo endpoint.
+type UpdateEntityLogoResponse struct{}
+
+// UpdateEntityLogo is the handler for the update entity logo endpoint. The
+// logo is processed in the background, and replaces the entity's logo once ready.
+func (v *V1) UpdateEntityLogo(ctx context.Context, input *UpdateEntityLogoRequest) (*UpdateEntityLogoResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	if err := v.identity.Entity.UpdateLogo(ctx, auth.UserID, auth.EntityID, input.RawBody); err != nil {
+		v.Logger.Error("Failed to update entity logo", "error", err)
+		return nil, err
+	}
+
+	return &UpdateEntityLogoResponse{}, nil
+}
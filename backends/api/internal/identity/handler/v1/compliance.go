@@ -0,0 +1,285 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/service"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// ComplianceRecord is a KYB, KYC or AML record of an entity.
+type ComplianceRecord struct {
+	ID          string                 `json:"id" doc:"The compliance record ID"`
+	EntityID    string                 `json:"entityId" doc:"The ID of the entity the record is about"`
+	Type        model.ComplianceType   `json:"type" enum:"aml,kyb,kyc" doc:"The type of check"`
+	Status      model.ComplianceStatus `json:"status" enum:"pending,in_progress,approved,rejected" doc:"The review status"`
+	Information map[string]any         `json:"information" doc:"The information submitted for review"`
+	Reason      *string                `json:"reason" doc:"The note of the reviewer, set when rejected"`
+	Documents   []ComplianceDocument   `json:"documents" doc:"The documents submitted for review"`
+	SubmittedAt *time.Time             `json:"submittedAt" doc:"When the record was submitted for review, empty while a draft"`
+	VerifiedAt  *time.Time             `json:"verifiedAt" doc:"When the record was approved or rejected"`
+	VerifiedBy  *string                `json:"verifiedBy" doc:"The ID of the reviewer who approved or rejected the record"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
+
+// ComplianceDocument is a document of a compliance record.
+type ComplianceDocument struct {
+	ID          string    `json:"id" doc:"The document ID"`
+	Name        string    `json:"name" doc:"The file name of the document"`
+	ContentType string    `json:"contentType" doc:"The MIME type of the document"`
+	URL         string    `json:"url,omitempty" doc:"A short-lived download link, only given to reviewers"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func newComplianceRecord(record *model.ComplianceRecord) ComplianceRecord {
+	information := map[string]any{}
+	if len(record.Metadata) > 0 {
+		_ = json.Unmarshal(record.Metadata, &information)
+	}
+
+	documents := make([]ComplianceDocument, 0, len(record.Documents))
+	for _, document := range record.Documents {
+		documents = append(documents, ComplianceDocument{
+			ID:          document.ID,
+			Name:        document.Name,
+			ContentType: document.ContentType,
+			CreatedAt:   document.CreatedAt,
+		})
+	}
+
+	return ComplianceRecord{
+		ID:          record.ID,
+		EntityID:    record.SubjectID,
+		Type:        record.Type,
+		Status:      record.Status,
+		Information: information,
+		Reason:      record.Reason,
+		Documents:   documents,
+		SubmittedAt: record.SubmittedAt,
+		VerifiedAt:  record.VerifiedAt,
+		VerifiedBy:  record.VerifiedBy,
+		CreatedAt:   record.CreatedAt,
+		UpdatedAt:   record.UpdatedAt,
+	}
+}
+
+// ListComplianceRecordsRequest is the request body for the list compliance records endpoint.
+type ListComplianceRecordsRequest struct{}
+
+// ListComplianceRecordsResponse is the response body for the list compliance records endpoint.
+type ListComplianceRecordsResponse struct {
+	Body struct {
+		Records []ComplianceRecord `json:"records" doc:"The compliance records"`
+	}
+}
+
+// ListComplianceRecords lists the compliance records of the active entity.
+func (v *V1) ListComplianceRecords(ctx context.Context, input *ListComplianceRecordsRequest) (*ListComplianceRecordsResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	records, err := v.identity.Compliance.List(ctx, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to list compliance records", "error", err)
+		return nil, err
+	}
+
+	response := &ListComplianceRecordsResponse{}
+	response.Body.Records = make([]ComplianceRecord, 0, len(records))
+	for _, record := range records {
+		response.Body.Records = append(response.Body.Records, newComplianceRecord(record))
+	}
+
+	return response, nil
+}
+
+// SubmitKYBRequest is the request body for the submit KYB endpoint.
+type SubmitKYBRequest struct {
+	Body struct {
+		LegalName          string `json:"legalName" required:"true" minLength:"1" maxLength:"255" doc:"The registered name of the business" example:"Acme Inc."`
+		RegistrationNumber string `json:"registrationNumber" required:"true" minLength:"1" maxLength:"100" doc:"The company registration number"`
+		Country            string `json:"country" required:"true" pattern:"^[A-Z]{2}$" doc:"The ISO 3166-1 alpha-2 country of registration" example:"US"`
+		Address            string `json:"address" required:"true" minLength:"1" maxLength:"500" doc:"The registered address"`
+		Website            string `json:"website,omitempty" format:"uri" doc:"The website of the business" example:"https://acme.com"`
+	}
+}
+
+// SubmitKYBResponse is the response body for the submit KYB endpoint.
+type SubmitKYBResponse struct {
+	Body ComplianceRecord
+}
+
+// SubmitKYB submits the KYB information and uploaded documents of the active entity for review.
+func (v *V1) SubmitKYB(ctx context.Context, input *SubmitKYBRequest) (*SubmitKYBResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	record, err := v.identity.Compliance.SubmitKYB(ctx, auth.EntityID, auth.UserID, service.KYBInformation{
+		LegalName:          input.Body.LegalName,
+		RegistrationNumber: input.Body.RegistrationNumber,
+		Country:            input.Body.Country,
+		Address:            input.Body.Address,
+		Website:            input.Body.Website,
+	})
+	if err != nil {
+		v.Logger.Error("Failed to submit KYB", "error", err)
+		return nil, err
+	}
+
+	return &SubmitKYBResponse{Body: newComplianceRecord(record)}, nil
+}
+
+// CreateComplianceDocumentRequest is the request body for the create compliance document endpoint.
+type CreateComplianceDocumentRequest struct {
+	Type model.ComplianceType `path:"type" enum:"kyb" doc:"The type of the compliance record"`
+	Body struct {
+		Name        string `json:"name" required:"true" minLength:"1" maxLength:"255" doc:"The file name of the document" example:"certificate-of-incorporation.pdf"`
+		ContentType string `json:"contentType" required:"true" enum:"application/pdf,image/jpeg,image/png" doc:"The MIME type of the document"`
+	}
+}
+
+// CreateComplianceDocumentResponse is the response body for the create compliance document endpoint.
+type CreateComplianceDocumentResponse struct {
+	Body struct {
+		Document ComplianceDocument `json:"document" doc:"The registered document"`
+		Upload   struct {
+			URL       string            `json:"url" doc:"The URL to upload the document to"`
+			Method    string            `json:"method" doc:"The HTTP method of the upload"`
+			Headers   map[string]string `json:"headers,omitempty" doc:"The headers to send with the upload"`
+			ExpiresAt time.Time         `json:"expiresAt" doc:"When the upload URL expires"`
+		} `json:"upload"`
+	}
+}
+
+// CreateComplianceDocument registers a document of a compliance record of the
+// active entity and returns where to upload it.
+func (v *V1) CreateComplianceDocument(ctx context.Context, input *CreateComplianceDocumentRequest) (*CreateComplianceDocumentResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	document, upload, err := v.identity.Compliance.AddDocument(ctx, auth.EntityID, auth.UserID, input.Type, input.Body.Name, input.Body.ContentType)
+	if err != nil {
+		v.Logger.Error("Failed to create compliance document", "error", err)
+		return nil, err
+	}
+
+	response := &CreateComplianceDocumentResponse{}
+	response.Body.Document = ComplianceDocument{
+		ID:          document.ID,
+		Name:        document.Name,
+		ContentType: document.ContentType,
+		CreatedAt:   document.CreatedAt,
+	}
+	response.Body.Upload.URL = upload.URL
+	response.Body.Upload.Method = upload.Method
+	response.Body.Upload.Headers = upload.Headers
+	response.Body.Upload.ExpiresAt = upload.ExpiresAt
+
+	return response, nil
+}
+
+// DeleteComplianceDocumentRequest is the request body for the delete compliance document endpoint.
+type DeleteComplianceDocumentRequest struct {
+	ID string `path:"id" format:"uuid" doc:"The document ID"`
+}
+
+// DeleteComplianceDocumentResponse is the response body for the delete compliance document endpoint.
+type DeleteComplianceDocumentResponse struct{}
+
+// DeleteComplianceDocument removes a document of a compliance record of the active entity.
+func (v *V1) DeleteComplianceDocument(ctx context.Context, input *DeleteComplianceDocumentRequest) (*DeleteComplianceDocumentResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	if err := v.identity.Compliance.DeleteDocument(ctx, auth.EntityID, auth.UserID, input.ID); err != nil {
+		v.Logger.Error("Failed to delete compliance document", "error", err)
+		return nil, err
+	}
+
+	return &DeleteComplianceDocumentResponse{}, nil
+}
+
+// ListComplianceReviewsRequest is the request body for the list compliance reviews endpoint.
+type ListComplianceReviewsRequest struct {
+	Status string `query:"status" enum:"pending,in_progress,approved,rejected" doc:"Only list the records in this status"`
+}
+
+// ListComplianceReviewsResponse is the response body for the list compliance reviews endpoint.
+type ListComplianceReviewsResponse struct {
+	Body struct {
+		Records []ComplianceRecord `json:"records" doc:"The submitted compliance records, oldest submission first"`
+	}
+}
+
+// ListComplianceReviews lists the submitted compliance records of the entities
+// below the active platform entity.
+func (v *V1) ListComplianceReviews(ctx context.Context, input *ListComplianceReviewsRequest) (*ListComplianceReviewsResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	records, err := v.identity.Compliance.ListReviews(ctx, auth.EntityID, model.ComplianceStatus(input.Status))
+	if err != nil {
+		v.Logger.Error("Failed to list compliance reviews", "error", err)
+		return nil, err
+	}
+
+	response := &ListComplianceReviewsResponse{}
+	response.Body.Records = make([]ComplianceRecord, 0, len(records))
+	for _, record := range records {
+		response.Body.Records = append(response.Body.Records, newComplianceRecord(record))
+	}
+
+	return response, nil
+}
+
+// GetComplianceReviewRequest is the request body for the get compliance review endpoint.
+type GetComplianceReviewRequest struct {
+	ID string `path:"id" format:"uuid" doc:"The compliance record ID"`
+}
+
+// GetComplianceReviewResponse is the response body for the get compliance review endpoint.
+type GetComplianceReviewResponse struct {
+	Body ComplianceRecord
+}
+
+// GetComplianceReview returns a compliance record under review with download
+// links to its documents.
+func (v *V1) GetComplianceReview(ctx context.Context, input *GetComplianceReviewRequest) (*GetComplianceReviewResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	record, err := v.identity.Compliance.GetReview(ctx, auth.EntityID, input.ID)
+	if err != nil {
+		v.Logger.Error("Failed to get compliance review", "error", err)
+		return nil, err
+	}
+
+	response := &GetComplianceReviewResponse{Body: newComplianceRecord(record)}
+	for i, document := range record.Documents {
+		info, err := v.Storage.Identity.GenerateDownloadURL(ctx, document.Key, model.ComplianceDocumentDownloadDuration)
+		if err != nil {
+			v.Logger.Error("error generating link to storage object", "error", err)
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+		response.Body.Documents[i].URL = info.URL
+	}
+
+	return response, nil
+}
+
+// ReviewComplianceRecordRequest is the request body for the review compliance record endpoint.
+type ReviewComplianceRecordRequest struct {
+	ID   string `path:"id" format:"uuid" doc:"The compliance record ID"`
+	Body struct {
+		Status model.ComplianceStatus `json:"status" required:"true" enum:"in_progress,approved,rejected" doc:"The new review status"`
+		Reason string                 `json:"reason,omitempty" maxLength:"1000" doc:"A note for the entity, required when rejecting"`
+	}
+}
+
+// ReviewComplianceRecordResponse is the response body for the review compliance record endpoint.
+type ReviewComplianceRecordResponse struct {
+	Body ComplianceRecord
+}
+
+// ReviewComplianceRecord moves a compliance record under review to a new status.
+func (v *V1) ReviewComplianceRecord(ctx context.Context, input *ReviewComplianceRecordRequest) (*ReviewComplianceRecordResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	record, err := v.identity.Compliance.Review(ctx, auth.EntityID, auth.UserID, input.ID, input.Body.Status, input.Body.Reason)
+	if err != nil {
+		v.Logger.Error("Failed to review compliance record", "error", err)
+		return nil, err
+	}
+
+	return &ReviewComplianceRecordResponse{Body: newComplianceRecord(record)}, nil
+}
@@ -0,0 +1,244 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+	"time"
+)
+
+// EntityDomain is a domain claimed by the active entity.
+type EntityDomain struct {
+	ID          string     `json:"id" doc:"The domain ID"`
+	Domain      string     `json:"domain" doc:"The domain name"`
+	RecordName  string     `json:"recordName" doc:"The name of the DNS TXT record to publish to verify the domain"`
+	RecordValue string     `json:"recordValue" doc:"The value of the DNS TXT record to publish to verify the domain"`
+	VerifiedAt  *time.Time `json:"verifiedAt,omitempty" doc:"When the domain was verified"`
+	AutoJoin    bool       `json:"autoJoin" doc:"Whether users with a verified email address at the domain can join the entity"`
+	DefaultRole types.Role `json:"defaultRole" doc:"The role of users joining through the domain"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+func newEntityDomain(domain *model.EntityDomain) EntityDomain {
+	return EntityDomain{
+		ID:          domain.ID,
+		Domain:      domain.Domain,
+		RecordName:  domain.RecordName(),
+		RecordValue: domain.RecordValue(),
+		VerifiedAt:  domain.VerifiedAt,
+		AutoJoin:    domain.AutoJoin,
+		DefaultRole: domain.DefaultRole,
+		CreatedAt:   domain.CreatedAt,
+		UpdatedAt:   domain.UpdatedAt,
+	}
+}
+
+// JoinableEntity is an entity the user can join through the domain of their email address.
+type JoinableEntity struct {
+	ID     string     `json:"id" doc:"The entity ID"`
+	Name   string     `json:"name" doc:"The entity name"`
+	Slug   string     `json:"slug" doc:"The entity slug"`
+	Logo   *string    `json:"logo,omitempty" doc:"The entity logo"`
+	Domain string     `json:"domain" doc:"The verified domain the user can join through"`
+	Role   types.Role `json:"role" doc:"The role the user joins with"`
+}
+
+// ListEntityDomainsRequest is the request body for the list entity domains endpoint.
+type ListEntityDomainsRequest struct{}
+
+// ListEntityDomainsResponse is the response body for the list entity domains endpoint.
+type ListEntityDomainsResponse struct {
+	Body struct {
+		Domains []EntityDomain `json:"domains" doc:"The domains claimed by the entity"`
+	}
+}
+
+// ListEntityDomains returns the domains claimed by the active entity.
+func (v *V1) ListEntityDomains(ctx context.Context, input *ListEntityDomainsRequest) (*ListEntityDomainsResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	domains, err := v.identity.EntityDomain.List(ctx, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to list entity domains", "error", err)
+		return nil, err
+	}
+
+	response := &ListEntityDomainsResponse{}
+	response.Body.Domains = make([]EntityDomain, 0, len(domains))
+	for _, domain := range domains {
+		response.Body.Domains = append(response.Body.Domains, newEntityDomain(domain))
+	}
+
+	return response, nil
+}
+
+// CreateEntityDomainRequest is the request body for the create entity domain endpoint.
+type CreateEntityDomainRequest struct {
+	Body struct {
+		Domain      string     `json:"domain" required:"true" format:"hostname" maxLength:"253" doc:"The domain name to claim" example:"example.com"`
+		AutoJoin    bool       `json:"autoJoin" doc:"Whether users with a verified email address at the domain can join the entity once it is verified"`
+		DefaultRole types.Role `json:"defaultRole" required:"true" enum:"admin,viewer" doc:"The role of users joining through the domain"`
+	}
+}
+
+// CreateEntityDomainResponse is the response body for the create entity domain endpoint.
+type CreateEntityDomainResponse struct {
+	Body EntityDomain
+}
+
+// CreateEntityDomain claims a domain for the active entity. The domain must
+// then be verified by publishing the returned DNS TXT record.
+func (v *V1) CreateEntityDomain(ctx context.Context, input *CreateEntityDomainRequest) (*CreateEntityDomainResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	domain, err := v.identity.EntityDomain.Create(ctx, auth.UserID, &model.EntityDomain{
+		EntityID:    auth.EntityID,
+		Domain:      input.Body.Domain,
+		AutoJoin:    input.Body.AutoJoin,
+		DefaultRole: input.Body.DefaultRole,
+	})
+	if err != nil {
+		v.Logger.Error("Failed to create entity domain", "domain", input.Body.Domain, "error", err)
+		return nil, err
+	}
+
+	return &CreateEntityDomainResponse{Body: newEntityDomain(domain)}, nil
+}
+
+// UpdateEntityDomainRequest is the request body for the update entity domain endpoint.
+type UpdateEntityDomainRequest struct {
+	ID   string `path:"id" doc:"The domain ID"`
+	Body struct {
+		AutoJoin    bool       `json:"autoJoin" doc:"Whether users with a verified email address at the domain can join the entity"`
+		DefaultRole types.Role `json:"defaultRole" required:"true" enum:"admin,viewer" doc:"The role of users joining through the domain"`
+	}
+}
+
+// UpdateEntityDomainResponse is the response body for the update entity domain endpoint.
+type UpdateEntityDomainResponse struct {
+	Body EntityDomain
+}
+
+// UpdateEntityDomain updates the automatic joining settings of a domain of the active entity.
+func (v *V1) UpdateEntityDomain(ctx context.Context, input *UpdateEntityDomainRequest) (*UpdateEntityDomainResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	domain, err := v.identity.EntityDomain.Update(ctx, auth.UserID, &model.EntityDomain{
+		ID:          input.ID,
+		EntityID:    auth.EntityID,
+		AutoJoin:    input.Body.AutoJoin,
+		DefaultRole: input.Body.DefaultRole,
+	})
+	if err != nil {
+		v.Logger.Error("Failed to update entity domain", "entity_domain_id", input.ID, "error", err)
+		return nil, err
+	}
+
+	return &UpdateEntityDomainResponse{Body: newEntityDomain(domain)}, nil
+}
+
+// DeleteEntityDomainRequest is the request body for the delete entity domain endpoint.
+type DeleteEntityDomainRequest struct {
+	ID string `path:"id" doc:"The domain ID"`
+}
+
+// DeleteEntityDomainResponse is the response body for the delete entity domain endpoint.
+type DeleteEntityDomainResponse struct{}
+
+// DeleteEntityDomain removes a domain from the active entity.
+func (v *V1) DeleteEntityDomain(ctx context.Context, input *DeleteEntityDomainRequest) (*DeleteEntityDomainResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	if err := v.identity.EntityDomain.Delete(ctx, auth.EntityID, auth.UserID, input.ID); err != nil {
+		v.Logger.Error("Failed to delete entity domain", "entity_domain_id", input.ID, "error", err)
+		return nil, err
+	}
+
+	return &DeleteEntityDomainResponse{}, nil
+}
+
+// VerifyEntityDomainRequest is the request body for the verify entity domain endpoint.
+type VerifyEntityDomainRequest struct {
+	ID string `path:"id" doc:"The domain ID"`
+}
+
+// VerifyEntityDomainResponse is the response body for the verify entity domain endpoint.
+type VerifyEntityDomainResponse struct {
+	Body EntityDomain
+}
+
+// VerifyEntityDomain checks the DNS TXT record of a domain of the active entity.
+func (v *V1) VerifyEntityDomain(ctx context.Context, input *VerifyEntityDomainRequest) (*VerifyEntityDomainResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	domain, err := v.identity.EntityDomain.Verify(ctx, auth.EntityID, auth.UserID, input.ID)
+	if err != nil {
+		v.Logger.Error("Failed to verify entity domain", "entity_domain_id", input.ID, "error", err)
+		return nil, err
+	}
+
+	return &VerifyEntityDomainResponse{Body: newEntityDomain(domain)}, nil
+}
+
+// ListJoinableEntitiesRequest is the request body for the list joinable entities endpoint.
+type ListJoinableEntitiesRequest struct{}
+
+// ListJoinableEntitiesResponse is the response body for the list joinable entities endpoint.
+type ListJoinableEntitiesResponse struct {
+	Body struct {
+		Entities []JoinableEntity `json:"entities" doc:"The entities the user can join through the domain of their email address"`
+	}
+}
+
+// ListJoinableEntities returns the entities the user can join through the
+// verified domain of their email address.
+func (v *V1) ListJoinableEntities(ctx context.Context, input *ListJoinableEntitiesRequest) (*ListJoinableEntitiesResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	joinable, err := v.identity.EntityDomain.ListJoinable(ctx, auth.UserID)
+	if err != nil {
+		v.Logger.Error("Failed to list joinable entities", "error", err)
+		return nil, err
+	}
+
+	response := &ListJoinableEntitiesResponse{}
+	response.Body.Entities = make([]JoinableEntity, 0, len(joinable))
+	for _, j := range joinable {
+		response.Body.Entities = append(response.Body.Entities, JoinableEntity{
+			ID:     j.Entity.ID,
+			Name:   j.Entity.Name,
+			Slug:   j.Entity.Slug,
+			Logo:   j.Entity.Logo,
+			Domain: j.Domain.Domain,
+			Role:   j.Domain.DefaultRole,
+		})
+	}
+
+	return response, nil
+}
+
+// JoinEntityRequest is the request body for the join entity endpoint.
+type JoinEntityRequest struct {
+	ID string `path:"id" doc:"The entity ID"`
+}
+
+// JoinEntityResponse is the response body for the join entity endpoint.
+type JoinEntityResponse struct {
+	Body struct {
+		EntityID string     `json:"entityId" doc:"The ID of the entity joined"`
+		Role     types.Role `json:"role" doc:"The role the user joined with"`
+	}
+}
+
+// JoinEntity makes the user a member of an entity through the verified domain
+// of their email address.
+func (v *V1) JoinEntity(ctx context.Context, input *JoinEntityRequest) (*JoinEntityResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	membership, err := v.identity.EntityDomain.Join(ctx, auth.UserID, input.ID)
+	if err != nil {
+		v.Logger.Error("Failed to join entity", "entity_id", input.ID, "error", err)
+		return nil, err
+	}
+
+	response := &JoinEntityResponse{}
+	response.Body.EntityID = input.ID
+	response.Body.Role = membership.Role
+
+	return response, nil
+}
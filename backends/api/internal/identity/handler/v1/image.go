@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GetImageRequest is the request body for the get image endpoint.
+type GetImageRequest struct {
+	ID   string `path:"id" pattern:"^[A-Za-z0-9_-]{43}$" doc:"The image ID"`
+	Size int    `query:"size" enum:"64,128,512" default:"128" doc:"The width and height of the image in pixels"`
+}
+
+// GetImageResponse is the response body for the get image endpoint.
+type GetImageResponse struct {
+	Status       int
+	Location     string `header:"Location"`
+	CacheControl string `header:"Cache-Control"`
+}
+
+// GetImage redirects to a short-lived link to a variant of a processed image.
+// Images are identified by the hash of their content, so the URL of an image
+// stays the same and can be stored, while the link it redirects to expires.
+func (v *V1) GetImage(ctx context.Context, input *GetImageRequest) (*GetImageResponse, error) {
+	info, err := v.Storage.Identity.GenerateDownloadURL(ctx, model.ImageVariantKey(input.ID, input.Size), model.ImageLinkDuration)
+	if err != nil {
+		v.Logger.Error("error generating link to storage object", "error", err)
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return &GetImageResponse{
+		Status:       http.StatusFound,
+		Location:     info.URL,
+		CacheControl: fmt.Sprintf("public, max-age=%d", int(model.ImageLinkDuration.Seconds()/2)),
+	}, nil
+}
@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Impersonation describes the impersonation of the user by a platform member.
+type Impersonation struct {
+	ImpersonatorID    string    `json:"impersonatorId" doc:"The ID of the platform member impersonating the user"`
+	ImpersonatorName  string    `json:"impersonatorName" doc:"The name of the platform member impersonating the user"`
+	ImpersonatorEmail string    `json:"impersonatorEmail" doc:"The email address of the platform member impersonating the user"`
+	ExpiresAt         time.Time `json:"expiresAt" doc:"When the impersonation ends"`
+}
+
+// StartImpersonationRequest is the request body for the start impersonation endpoint.
+type StartImpersonationRequest struct {
+	Session http.Cookie `cookie:"session" doc:"The session cookie"`
+	Body    struct {
+		UserID string `json:"userId" format:"uuid" required:"true" doc:"The ID of the user to impersonate"`
+		Reason string `json:"reason" required:"true" minLength:"1" maxLength:"500" doc:"Why the user is impersonated, such as a support ticket reference" example:"Support ticket #1234"`
+	}
+}
+
+// StartImpersonationResponse is the response body for the start impersonation endpoint.
+type StartImpersonationResponse struct {
+	Body struct {
+		ExpiresAt time.Time `json:"expiresAt" doc:"When the impersonation ends"`
+	}
+
+	SetCookies []http.Cookie `header:"Set-Cookie"`
+}
+
+// StartImpersonation is the handler for the start impersonation endpoint. The
+// session cookie is swapped for the impersonation session, and the session of
+// the impersonator kept aside to switch back to when the impersonation stops.
+func (v *V1) StartImpersonation(ctx context.Context, input *StartImpersonationRequest) (*StartImpersonationResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+
+	session, err := v.identity.Impersonation.Start(ctx, auth.EntityID, auth.UserID, input.Body.UserID, input.Body.Reason)
+	if err != nil {
+		v.Logger.Error("Failed to start impersonation", "user_id", input.Body.UserID, "error", err)
+		return nil, err
+	}
+
+	maxAge := int(time.Until(session.ExpiresAt).Seconds())
+	response := &StartImpersonationResponse{
+		SetCookies: []http.Cookie{
+			v.newSessionCookie(session.Token, maxAge, session.ExpiresAt),
+			v.newImpersonatorCookie(input.Session.Value, maxAge, session.ExpiresAt),
+		},
+	}
+	response.Body.ExpiresAt = session.ExpiresAt
+
+	return response, nil
+}
+
+// StopImpersonationRequest is the request body for the stop impersonation endpoint.
+type StopImpersonationRequest struct {
+	Session             http.Cookie `cookie:"session" doc:"The session cookie"`
+	ImpersonatorSession http.Cookie `cookie:"impersonator_session" doc:"The session cookie of the impersonator"`
+}
+
+// StopImpersonationResponse is the response body for the stop impersonation endpoint.
+type StopImpersonationResponse struct {
+	SetCookies []http.Cookie `header:"Set-Cookie"`
+}
+
+// StopImpersonation is the handler for the stop impersonation endpoint. It
+// switches back to the session of the impersonator, or signs out if it has
+// ended in the meantime.
+func (v *V1) StopImpersonation(ctx context.Context, input *StopImpersonationRequest) (*StopImpersonationResponse, error) {
+	impersonator, err := v.identity.Impersonation.Stop(ctx, input.Session.Value, input.ImpersonatorSession.Value)
+	if err != nil {
+		v.Logger.Error("Failed to stop impersonation", "error", err)
+		return nil, err
+	}
+
+	sessionCookie := v.newSessionCookie("", -1, time.Time{})
+	if impersonator != nil {
+		sessionCookie = v.newSessionCookie(
+			impersonator.Token,
+			int(time.Until(impersonator.ExpiresAt).Seconds()),
+			impersonator.ExpiresAt,
+		)
+	}
+
+	response := &StopImpersonationResponse{
+		SetCookies: []http.Cookie{
+			sessionCookie,
+			v.newImpersonatorCookie("", -1, time.Time{}),
+		},
+	}
+
+	return response, nil
+}
+
+// newImpersonatorCookie creates a new cookie holding the session of an
+// impersonator while they impersonate a user
+func (v *V1) newImpersonatorCookie(value string, maxAge int, expiresAt time.Time) http.Cookie {
+	return http.Cookie{
+		Name:     "impersonator_session",
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(v.Config.App.BaseURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   maxAge,
+		Expires:  expiresAt,
+	}
+}
@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+	"time"
+)
+
+// IPAllowlistEntry is an address range the active entity allows access from.
+type IPAllowlistEntry struct {
+	ID          string    `json:"id" doc:"The entry ID"`
+	CIDR        string    `json:"cidr" doc:"The address range in CIDR notation" example:"203.0.113.0/24"`
+	Description *string   `json:"description" doc:"A note on the range, such as the office it belongs to"`
+	CreatedBy   *string   `json:"createdBy" doc:"The ID of the user who added the range"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func newIPAllowlistResponse(allowlist model.IPAllowlist) []IPAllowlistEntry {
+	entries := make([]IPAllowlistEntry, 0, len(allowlist))
+	for _, entry := range allowlist {
+		entries = append(entries, IPAllowlistEntry{
+			ID:          entry.ID,
+			CIDR:        entry.CIDR,
+			Description: entry.Description,
+			CreatedBy:   entry.CreatedBy,
+			CreatedAt:   entry.CreatedAt,
+		})
+	}
+	return entries
+}
+
+// GetIPAllowlistRequest is the request body for the get IP allowlist endpoint.
+type GetIPAllowlistRequest struct{}
+
+// GetIPAllowlistResponse is the response body for the get IP allowlist endpoint.
+type GetIPAllowlistResponse struct {
+	Body struct {
+		Entries []IPAllowlistEntry `json:"entries" doc:"The allowed address ranges, any address is allowed without entries"`
+	}
+}
+
+// GetIPAllowlist returns the IP allowlist of the active entity.
+func (v *V1) GetIPAllowlist(ctx context.Context, input *GetIPAllowlistRequest) (*GetIPAllowlistResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	allowlist, err := v.identity.IPAllowlist.List(ctx, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to get IP allowlist", "error", err)
+		return nil, err
+	}
+
+	response := &GetIPAllowlistResponse{}
+	response.Body.Entries = newIPAllowlistResponse(allowlist)
+	return response, nil
+}
+
+// UpdateIPAllowlistRequest is the request body for the update IP allowlist endpoint.
+type UpdateIPAllowlistRequest struct {
+	Body struct {
+		Entries []struct {
+			CIDR        string  `json:"cidr" required:"true" maxLength:"43" doc:"The address range in CIDR notation, or a single address" example:"203.0.113.0/24"`
+			Description *string `json:"description,omitempty" maxLength:"255" doc:"A note on the range, such as the office it belongs to"`
+		} `json:"entries" required:"true" maxItems:"100" doc:"The allowed address ranges, which replace the current ones. An empty list allows any address."`
+	}
+}
+
+// UpdateIPAllowlistResponse is the response body for the update IP allowlist endpoint.
+type UpdateIPAllowlistResponse struct {
+	Body struct {
+		Entries []IPAllowlistEntry `json:"entries" doc:"The allowed address ranges, any address is allowed without entries"`
+	}
+}
+
+// UpdateIPAllowlist replaces the IP allowlist of the active entity. The new
+// list must allow the address of the request.
+func (v *V1) UpdateIPAllowlist(ctx context.Context, input *UpdateIPAllowlistRequest) (*UpdateIPAllowlistResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	entries := make(model.IPAllowlist, 0, len(input.Body.Entries))
+	for _, entry := range input.Body.Entries {
+		entries = append(entries, &model.IPAllowlistEntry{
+			CIDR:        entry.CIDR,
+			Description: entry.Description,
+		})
+	}
+
+	allowlist, err := v.identity.IPAllowlist.Update(ctx, auth.EntityID, auth.UserID, entries)
+	if err != nil {
+		v.Logger.Error("Failed to update IP allowlist", "error", err)
+		return nil, err
+	}
+
+	response := &UpdateIPAllowlistResponse{}
+	response.Body.Entries = newIPAllowlistResponse(allowlist)
+	return response, nil
+}
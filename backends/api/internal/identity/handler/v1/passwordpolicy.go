@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+)
+
+// PasswordPolicy is the password requirements of the members of the active entity.
+type PasswordPolicy struct {
+	MinStrengthScore int `json:"minStrengthScore" minimum:"0" maximum:"4" doc:"The minimum estimated strength of new passwords, from 0 (no minimum) to 4 (very hard to guess)"`
+	HistorySize      int `json:"historySize" minimum:"0" maximum:"10" doc:"The number of previous passwords that can't be reused, 0 allows any"`
+}
+
+// GetPasswordPolicyRequest is the request body for the get password policy endpoint.
+type GetPasswordPolicyRequest struct{}
+
+// GetPasswordPolicyResponse is the response body for the get password policy endpoint.
+type GetPasswordPolicyResponse struct {
+	Body PasswordPolicy
+}
+
+// GetPasswordPolicy returns the password policy of the active entity.
+func (v *V1) GetPasswordPolicy(ctx context.Context, input *GetPasswordPolicyRequest) (*GetPasswordPolicyResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	policy, err := v.identity.PasswordPolicy.Get(ctx, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to get password policy", "error", err)
+		return nil, err
+	}
+
+	return &GetPasswordPolicyResponse{
+		Body: PasswordPolicy{
+			MinStrengthScore: policy.MinStrengthScore,
+			HistorySize:      policy.HistorySize,
+		},
+	}, nil
+}
+
+// UpdatePasswordPolicyRequest is the request body for the update password policy endpoint.
+type UpdatePasswordPolicyRequest struct {
+	Body PasswordPolicy
+}
+
+// UpdatePasswordPolicyResponse is the response body for the update password policy endpoint.
+type UpdatePasswordPolicyResponse struct {
+	Body PasswordPolicy
+}
+
+// UpdatePasswordPolicy replaces the password policy of the active entity. The
+// strictest policy of the entities of a user applies to their next password.
+func (v *V1) UpdatePasswordPolicy(ctx context.Context, input *UpdatePasswordPolicyRequest) (*UpdatePasswordPolicyResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	policy, err := v.identity.PasswordPolicy.Update(ctx, auth.EntityID, auth.UserID, &model.PasswordPolicy{
+		MinStrengthScore: input.Body.MinStrengthScore,
+		HistorySize:      input.Body.HistorySize,
+	})
+	if err != nil {
+		v.Logger.Error("Failed to update password policy", "error", err)
+		return nil, err
+	}
+
+	return &UpdatePasswordPolicyResponse{
+		Body: PasswordPolicy{
+			MinStrengthScore: policy.MinStrengthScore,
+			HistorySize:      policy.HistorySize,
+		},
+	}, nil
+}
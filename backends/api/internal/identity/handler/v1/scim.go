@@ -0,0 +1,888 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/service"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	scimContentType                 = "application/scim+json"
+	scimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+
+	// scimMaxResults is the maximum number of resources returned in a list
+	scimMaxResults = 100
+)
+
+var (
+	// scimFilterPattern matches the only filter supported, an equality check on one attribute
+	scimFilterPattern = regexp.MustCompile(`(?i)^\s*([a-z.]+)\s+eq\s+"([^"]*)"\s*$`)
+
+	// scimMemberPathPattern matches a path selecting one member of a group
+	scimMemberPathPattern = regexp.MustCompile(`(?i)^members\[value eq "([^"]+)"\]$`)
+)
+
+func SCIMPath(path string) string {
+	return "/scim/v2" + path
+}
+
+// SCIMMeta is the metadata of a SCIM resource.
+type SCIMMeta struct {
+	ResourceType string     `json:"resourceType" doc:"The type of the resource"`
+	Created      *time.Time `json:"created,omitempty" doc:"When the resource was created"`
+	LastModified *time.Time `json:"lastModified,omitempty" doc:"When the resource was last changed"`
+}
+
+// SCIMName is the name of a SCIM user.
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty" required:"false" doc:"The full name"`
+	GivenName  string `json:"givenName,omitempty" required:"false" doc:"The given name"`
+	FamilyName string `json:"familyName,omitempty" required:"false" doc:"The family name"`
+
+	_ struct{} `json:"-" additionalProperties:"true"`
+}
+
+// SCIMEmail is an email address of a SCIM user.
+type SCIMEmail struct {
+	Value   string `json:"value" doc:"The email address"`
+	Primary bool   `json:"primary" doc:"Whether this is the primary address"`
+	Type    string `json:"type,omitempty" doc:"The type of the address"`
+}
+
+// SCIMReference is a reference to another SCIM resource, such as a group member.
+type SCIMReference struct {
+	Value   string `json:"value" required:"true" doc:"The ID of the referenced resource"`
+	Display string `json:"display,omitempty" required:"false" doc:"The display name of the referenced resource"`
+
+	_ struct{} `json:"-" additionalProperties:"true"`
+}
+
+// SCIMUser is a user as a SCIM resource.
+type SCIMUser struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id" doc:"The user ID"`
+	ExternalID  *string         `json:"externalId,omitempty" doc:"The ID of the user in the directory"`
+	UserName    string          `json:"userName" doc:"The user's email address"`
+	Name        SCIMName        `json:"name"`
+	DisplayName string          `json:"displayName" doc:"The user's name"`
+	Emails      []SCIMEmail     `json:"emails"`
+	Active      bool            `json:"active" doc:"Whether the user is a member of the entity"`
+	Groups      []SCIMReference `json:"groups" doc:"The role of the user in the entity"`
+	Meta        SCIMMeta        `json:"meta"`
+}
+
+func newSCIMUser(user *model.SCIMUser) SCIMUser {
+	groups := []SCIMReference{}
+	if model.IsSCIMGroupRole(user.Role) {
+		groups = append(groups, SCIMReference{Value: user.Role.String(), Display: user.Role.String()})
+	}
+
+	return SCIMUser{
+		Schemas:     []string{scimSchemaUser},
+		ID:          user.ID,
+		ExternalID:  user.ExternalID,
+		UserName:    user.Email,
+		Name:        SCIMName{Formatted: user.Name},
+		DisplayName: user.Name,
+		Emails:      []SCIMEmail{{Value: user.Email, Primary: true, Type: "work"}},
+		Active:      user.IsActive(),
+		Groups:      groups,
+		Meta: SCIMMeta{
+			ResourceType: "User",
+			Created:      &user.CreatedAt,
+			LastModified: &user.UpdatedAt,
+		},
+	}
+}
+
+// SCIMGroup is a role of the entity as a SCIM resource.
+type SCIMGroup struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id" doc:"The group ID, which is the role"`
+	DisplayName string          `json:"displayName" doc:"The role"`
+	Members     []SCIMReference `json:"members" doc:"The users with the role"`
+	Meta        SCIMMeta        `json:"meta"`
+}
+
+func newSCIMGroup(group *model.SCIMGroup) SCIMGroup {
+	members := make([]SCIMReference, 0, len(group.MemberIDs))
+	for _, id := range group.MemberIDs {
+		members = append(members, SCIMReference{Value: id})
+	}
+
+	return SCIMGroup{
+		Schemas:     []string{scimSchemaGroup},
+		ID:          group.Role.String(),
+		DisplayName: group.Role.String(),
+		Members:     members,
+		Meta:        SCIMMeta{ResourceType: "Group"},
+	}
+}
+
+// SCIMUserBody is the request body to create or replace a SCIM user.
+type SCIMUserBody struct {
+	Schemas     []string  `json:"schemas,omitempty" required:"false"`
+	ExternalID  *string   `json:"externalId,omitempty" required:"false" doc:"The ID of the user in the directory"`
+	UserName    string    `json:"userName" required:"true" format:"email" doc:"The user's email address" example:"jane@example.com"`
+	Name        *SCIMName `json:"name,omitempty" required:"false"`
+	DisplayName string    `json:"displayName,omitempty" required:"false" doc:"The user's name"`
+	Active      *bool     `json:"active,omitempty" required:"false" doc:"Whether the user is a member of the entity" default:"true"`
+
+	_ struct{} `json:"-" additionalProperties:"true"`
+}
+
+// displayName returns the name of the user, falling back to the email address.
+func (b *SCIMUserBody) displayName() string {
+	if b.DisplayName != "" {
+		return b.DisplayName
+	}
+
+	if name := scimName(b.Name); name != "" {
+		return name
+	}
+
+	return b.UserName
+}
+
+// SCIMGroupBody is the request body to create or replace a SCIM group.
+type SCIMGroupBody struct {
+	Schemas     []string        `json:"schemas,omitempty" required:"false"`
+	DisplayName string          `json:"displayName" required:"true" doc:"The role" example:"admin"`
+	Members     []SCIMReference `json:"members,omitempty" required:"false" doc:"The users with the role"`
+
+	_ struct{} `json:"-" additionalProperties:"true"`
+}
+
+// SCIMPatchOperation is a single operation of a SCIM patch request.
+type SCIMPatchOperation struct {
+	Op    string `json:"op" required:"true" doc:"The operation, one of add, remove or replace"`
+	Path  string `json:"path,omitempty" required:"false" doc:"The attribute to change"`
+	Value any    `json:"value,omitempty" required:"false" doc:"The new value"`
+
+	_ struct{} `json:"-" additionalProperties:"true"`
+}
+
+// SCIMPatchBody is the request body of a SCIM patch request.
+type SCIMPatchBody struct {
+	Schemas    []string             `json:"schemas,omitempty" required:"false"`
+	Operations []SCIMPatchOperation `json:"Operations" required:"true"`
+
+	_ struct{} `json:"-" additionalProperties:"true"`
+}
+
+// ListSCIMUsersRequest is the request body for the list SCIM users endpoint.
+type ListSCIMUsersRequest struct {
+	Filter     string `query:"filter" required:"false" doc:"Filter on userName or externalId" example:"userName eq \"jane@example.com\""`
+	StartIndex int    `query:"startIndex" required:"false" doc:"The 1-based index of the first result" default:"1"`
+	Count      int    `query:"count" required:"false" doc:"Maximum number of results" default:"100"`
+}
+
+// ListSCIMUsersResponse is the response body for the list SCIM users endpoint.
+type ListSCIMUsersResponse struct {
+	ContentType string `header:"Content-Type"`
+	Body        struct {
+		Schemas      []string   `json:"schemas"`
+		TotalResults int        `json:"totalResults"`
+		StartIndex   int        `json:"startIndex"`
+		ItemsPerPage int        `json:"itemsPerPage"`
+		Resources    []SCIMUser `json:"Resources"`
+	}
+}
+
+// ListSCIMUsers lists the users of the entity.
+func (v *V1) ListSCIMUsers(ctx context.Context, input *ListSCIMUsersRequest) (*ListSCIMUsersResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+
+	var filter model.SCIMUserFilter
+	if input.Filter != "" {
+		attribute, value, err := parseSCIMFilter(input.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(attribute) {
+		case "username":
+			filter.Email = value
+		case "externalid":
+			filter.ExternalID = value
+		default:
+			return nil, httpx.ErrInvalidFilter
+		}
+	}
+
+	startIndex := max(input.StartIndex, 1)
+	count := min(max(input.Count, 0), scimMaxResults)
+
+	users, total, err := v.identity.SCIM.ListUsers(ctx, auth.EntityID, filter, startIndex-1, count)
+	if err != nil {
+		v.Logger.Error("Failed to list SCIM users", "error", err)
+		return nil, err
+	}
+
+	response := &ListSCIMUsersResponse{ContentType: scimContentType}
+	response.Body.Schemas = []string{scimSchemaListResponse}
+	response.Body.TotalResults = total
+	response.Body.StartIndex = startIndex
+	response.Body.ItemsPerPage = len(users)
+	response.Body.Resources = make([]SCIMUser, 0, len(users))
+	for _, user := range users {
+		response.Body.Resources = append(response.Body.Resources, newSCIMUser(user))
+	}
+
+	return response, nil
+}
+
+// SCIMUserResponse is the response body for the SCIM user endpoints.
+type SCIMUserResponse struct {
+	ContentType string `header:"Content-Type"`
+	Body        SCIMUser
+}
+
+// GetSCIMUserRequest is the request body for the get SCIM user endpoint.
+type GetSCIMUserRequest struct {
+	ID string `path:"id" format:"uuid" doc:"The user ID"`
+}
+
+// GetSCIMUser returns a user of the entity.
+func (v *V1) GetSCIMUser(ctx context.Context, input *GetSCIMUserRequest) (*SCIMUserResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	user, err := v.identity.SCIM.GetUser(ctx, auth.EntityID, input.ID)
+	if err != nil {
+		v.Logger.Error("Failed to get SCIM user", "error", err)
+		return nil, err
+	}
+
+	return &SCIMUserResponse{ContentType: scimContentType, Body: newSCIMUser(user)}, nil
+}
+
+// CreateSCIMUserRequest is the request body for the create SCIM user endpoint.
+type CreateSCIMUserRequest struct {
+	Body SCIMUserBody
+}
+
+// CreateSCIMUser provisions a user into the entity.
+func (v *V1) CreateSCIMUser(ctx context.Context, input *CreateSCIMUserRequest) (*SCIMUserResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	active := input.Body.Active == nil || *input.Body.Active
+
+	user, err := v.identity.SCIM.CreateUser(ctx, auth.EntityID, &model.SCIMUser{
+		Name:       input.Body.displayName(),
+		Email:      input.Body.UserName,
+		ExternalID: input.Body.ExternalID,
+	}, active)
+	if err != nil {
+		v.Logger.Error("Failed to create SCIM user", "error", err)
+		return nil, err
+	}
+
+	return &SCIMUserResponse{ContentType: scimContentType, Body: newSCIMUser(user)}, nil
+}
+
+// ReplaceSCIMUserRequest is the request body for the replace SCIM user endpoint.
+type ReplaceSCIMUserRequest struct {
+	ID   string `path:"id" format:"uuid" doc:"The user ID"`
+	Body SCIMUserBody
+}
+
+// ReplaceSCIMUser replaces the attributes of a user of the entity.
+func (v *V1) ReplaceSCIMUser(ctx context.Context, input *ReplaceSCIMUserRequest) (*SCIMUserResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	name := input.Body.displayName()
+	active := input.Body.Active == nil || *input.Body.Active
+
+	user, err := v.identity.SCIM.UpdateUser(ctx, auth.EntityID, input.ID, service.SCIMUserUpdate{
+		Active:     &active,
+		Email:      &input.Body.UserName,
+		ExternalID: input.Body.ExternalID,
+		Name:       &name,
+	})
+	if err != nil {
+		v.Logger.Error("Failed to replace SCIM user", "error", err)
+		return nil, err
+	}
+
+	return &SCIMUserResponse{ContentType: scimContentType, Body: newSCIMUser(user)}, nil
+}
+
+// PatchSCIMUserRequest is the request body for the patch SCIM user endpoint.
+type PatchSCIMUserRequest struct {
+	ID   string `path:"id" format:"uuid" doc:"The user ID"`
+	Body SCIMPatchBody
+}
+
+// PatchSCIMUser changes attributes of a user of the entity. Setting active to
+// false deprovisions the user.
+func (v *V1) PatchSCIMUser(ctx context.Context, input *PatchSCIMUserRequest) (*SCIMUserResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+
+	update, err := newSCIMUserUpdate(input.Body.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := v.identity.SCIM.UpdateUser(ctx, auth.EntityID, input.ID, update)
+	if err != nil {
+		v.Logger.Error("Failed to patch SCIM user", "error", err)
+		return nil, err
+	}
+
+	return &SCIMUserResponse{ContentType: scimContentType, Body: newSCIMUser(user)}, nil
+}
+
+// DeleteSCIMUserRequest is the request body for the delete SCIM user endpoint.
+type DeleteSCIMUserRequest struct {
+	ID string `path:"id" format:"uuid" doc:"The user ID"`
+}
+
+// DeleteSCIMUserResponse is the response body for the delete SCIM user endpoint.
+type DeleteSCIMUserResponse struct{}
+
+// DeleteSCIMUser deprovisions a user from the entity. The user loses access to
+// the entity, but the user itself is kept.
+func (v *V1) DeleteSCIMUser(ctx context.Context, input *DeleteSCIMUserRequest) (*DeleteSCIMUserResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	if err := v.identity.SCIM.DeleteUser(ctx, auth.EntityID, input.ID); err != nil {
+		v.Logger.Error("Failed to delete SCIM user", "error", err)
+		return nil, err
+	}
+
+	return &DeleteSCIMUserResponse{}, nil
+}
+
+// ListSCIMGroupsRequest is the request body for the list SCIM groups endpoint.
+type ListSCIMGroupsRequest struct {
+	Filter     string `query:"filter" required:"false" doc:"Filter on displayName" example:"displayName eq \"admin\""`
+	StartIndex int    `query:"startIndex" required:"false" doc:"The 1-based index of the first result" default:"1"`
+	Count      int    `query:"count" required:"false" doc:"Maximum number of results" default:"100"`
+}
+
+// ListSCIMGroupsResponse is the response body for the list SCIM groups endpoint.
+type ListSCIMGroupsResponse struct {
+	ContentType string `header:"Content-Type"`
+	Body        struct {
+		Schemas      []string    `json:"schemas"`
+		TotalResults int         `json:"totalResults"`
+		StartIndex   int         `json:"startIndex"`
+		ItemsPerPage int         `json:"itemsPerPage"`
+		Resources    []SCIMGroup `json:"Resources"`
+	}
+}
+
+// ListSCIMGroups lists the groups of the entity, one for each assignable role.
+func (v *V1) ListSCIMGroups(ctx context.Context, input *ListSCIMGroupsRequest) (*ListSCIMGroupsResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+
+	displayName := ""
+	if input.Filter != "" {
+		attribute, value, err := parseSCIMFilter(input.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if !strings.EqualFold(attribute, "displayName") {
+			return nil, httpx.ErrInvalidFilter
+		}
+		displayName = value
+	}
+
+	groups, err := v.identity.SCIM.ListGroups(ctx, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to list SCIM groups", "error", err)
+		return nil, err
+	}
+
+	resources := make([]SCIMGroup, 0, len(groups))
+	for _, group := range groups {
+		if displayName == "" || strings.EqualFold(displayName, group.Role.String()) {
+			resources = append(resources, newSCIMGroup(group))
+		}
+	}
+	total := len(resources)
+
+	startIndex := max(input.StartIndex, 1)
+	count := min(max(input.Count, 0), scimMaxResults)
+	resources = resources[min(startIndex-1, total):min(startIndex-1+count, total)]
+
+	response := &ListSCIMGroupsResponse{ContentType: scimContentType}
+	response.Body.Schemas = []string{scimSchemaListResponse}
+	response.Body.TotalResults = total
+	response.Body.StartIndex = startIndex
+	response.Body.ItemsPerPage = len(resources)
+	response.Body.Resources = resources
+
+	return response, nil
+}
+
+// SCIMGroupResponse is the response body for the SCIM group endpoints.
+type SCIMGroupResponse struct {
+	ContentType string `header:"Content-Type"`
+	Body        SCIMGroup
+}
+
+// GetSCIMGroupRequest is the request body for the get SCIM group endpoint.
+type GetSCIMGroupRequest struct {
+	ID string `path:"id" doc:"The group ID" example:"admin"`
+}
+
+// GetSCIMGroup returns a group of the entity.
+func (v *V1) GetSCIMGroup(ctx context.Context, input *GetSCIMGroupRequest) (*SCIMGroupResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	group, err := v.identity.SCIM.GetGroup(ctx, auth.EntityID, types.Role(input.ID))
+	if err != nil {
+		v.Logger.Error("Failed to get SCIM group", "error", err)
+		return nil, err
+	}
+
+	return &SCIMGroupResponse{ContentType: scimContentType, Body: newSCIMGroup(group)}, nil
+}
+
+// CreateSCIMGroupRequest is the request body for the create SCIM group endpoint.
+type CreateSCIMGroupRequest struct {
+	Body SCIMGroupBody
+}
+
+// CreateSCIMGroup links a directory group to the role with the same name, as
+// groups can't be created. Members in the request are added to the role.
+func (v *V1) CreateSCIMGroup(ctx context.Context, input *CreateSCIMGroupRequest) (*SCIMGroupResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	role := types.Role(strings.ToLower(input.Body.DisplayName))
+
+	group, err := v.identity.SCIM.UpdateGroup(ctx, auth.EntityID, role, scimReferenceIDs(input.Body.Members), nil)
+	if err != nil {
+		v.Logger.Error("Failed to create SCIM group", "error", err)
+		return nil, err
+	}
+
+	return &SCIMGroupResponse{ContentType: scimContentType, Body: newSCIMGroup(group)}, nil
+}
+
+// ReplaceSCIMGroupRequest is the request body for the replace SCIM group endpoint.
+type ReplaceSCIMGroupRequest struct {
+	ID   string `path:"id" doc:"The group ID" example:"admin"`
+	Body SCIMGroupBody
+}
+
+// ReplaceSCIMGroup sets the members of a group of the entity.
+func (v *V1) ReplaceSCIMGroup(ctx context.Context, input *ReplaceSCIMGroupRequest) (*SCIMGroupResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	if !strings.EqualFold(input.Body.DisplayName, input.ID) {
+		return nil, httpx.ErrImmutableAttribute
+	}
+
+	group, err := v.identity.SCIM.ReplaceGroup(ctx, auth.EntityID, types.Role(input.ID), scimReferenceIDs(input.Body.Members))
+	if err != nil {
+		v.Logger.Error("Failed to replace SCIM group", "error", err)
+		return nil, err
+	}
+
+	return &SCIMGroupResponse{ContentType: scimContentType, Body: newSCIMGroup(group)}, nil
+}
+
+// PatchSCIMGroupRequest is the request body for the patch SCIM group endpoint.
+type PatchSCIMGroupRequest struct {
+	ID   string `path:"id" doc:"The group ID" example:"admin"`
+	Body SCIMPatchBody
+}
+
+// PatchSCIMGroup adds, removes or replaces members of a group of the entity.
+func (v *V1) PatchSCIMGroup(ctx context.Context, input *PatchSCIMGroupRequest) (*SCIMGroupResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	role := types.Role(input.ID)
+
+	patch, err := newSCIMGroupPatch(role, input.Body.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	var group *model.SCIMGroup
+	if patch.replace != nil {
+		group, err = v.identity.SCIM.ReplaceGroup(ctx, auth.EntityID, role, patch.replace)
+		if err != nil {
+			v.Logger.Error("Failed to patch SCIM group", "error", err)
+			return nil, err
+		}
+	}
+
+	if len(patch.add) > 0 || len(patch.remove) > 0 || group == nil {
+		group, err = v.identity.SCIM.UpdateGroup(ctx, auth.EntityID, role, patch.add, patch.remove)
+		if err != nil {
+			v.Logger.Error("Failed to patch SCIM group", "error", err)
+			return nil, err
+		}
+	}
+
+	return &SCIMGroupResponse{ContentType: scimContentType, Body: newSCIMGroup(group)}, nil
+}
+
+// GetSCIMServiceProviderConfigRequest is the request body for the SCIM service provider config endpoint.
+type GetSCIMServiceProviderConfigRequest struct{}
+
+// SCIMSupported describes whether an optional SCIM feature is supported.
+type SCIMSupported struct {
+	Supported bool `json:"supported"`
+}
+
+// SCIMAuthenticationScheme describes how SCIM clients authenticate.
+type SCIMAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GetSCIMServiceProviderConfigResponse is the response body for the SCIM service provider config endpoint.
+type GetSCIMServiceProviderConfigResponse struct {
+	ContentType string `header:"Content-Type"`
+	Body        struct {
+		Schemas               []string                   `json:"schemas"`
+		Patch                 SCIMSupported              `json:"patch"`
+		Bulk                  SCIMSupported              `json:"bulk"`
+		Filter                SCIMSupported              `json:"filter"`
+		ChangePassword        SCIMSupported              `json:"changePassword"`
+		Sort                  SCIMSupported              `json:"sort"`
+		ETag                  SCIMSupported              `json:"etag"`
+		AuthenticationSchemes []SCIMAuthenticationScheme `json:"authenticationSchemes"`
+	}
+}
+
+// GetSCIMServiceProviderConfig describes the SCIM features supported.
+func (v *V1) GetSCIMServiceProviderConfig(ctx context.Context, input *GetSCIMServiceProviderConfigRequest) (*GetSCIMServiceProviderConfigResponse, error) {
+	response := &GetSCIMServiceProviderConfigResponse{ContentType: scimContentType}
+	response.Body.Schemas = []string{scimSchemaServiceProviderConfig}
+	response.Body.Patch.Supported = true
+	response.Body.Filter.Supported = true
+	response.Body.AuthenticationSchemes = []SCIMAuthenticationScheme{{
+		Type:        "oauthbearertoken",
+		Name:        "OAuth Bearer Token",
+		Description: "Authentication with an entity's SCIM token",
+	}}
+
+	return response, nil
+}
+
+// SCIMToken is a SCIM token of an entity.
+type SCIMToken struct {
+	ID         string     `json:"id" doc:"The token ID"`
+	Name       string     `json:"name" doc:"The token name"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" doc:"When the token was last used"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+func newSCIMToken(token *model.SCIMToken) SCIMToken {
+	return SCIMToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+// ListSCIMTokensRequest is the request body for the list SCIM tokens endpoint.
+type ListSCIMTokensRequest struct{}
+
+// ListSCIMTokensResponse is the response body for the list SCIM tokens endpoint.
+type ListSCIMTokensResponse struct {
+	Body []SCIMToken
+}
+
+// ListSCIMTokens lists the SCIM tokens of the active entity.
+func (v *V1) ListSCIMTokens(ctx context.Context, input *ListSCIMTokensRequest) (*ListSCIMTokensResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	tokens, err := v.identity.SCIM.ListTokens(ctx, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to list SCIM tokens", "error", err)
+		return nil, err
+	}
+
+	response := &ListSCIMTokensResponse{Body: make([]SCIMToken, 0, len(tokens))}
+	for _, token := range tokens {
+		response.Body = append(response.Body, newSCIMToken(token))
+	}
+
+	return response, nil
+}
+
+// CreateSCIMTokenRequest is the request body for the create SCIM token endpoint.
+type CreateSCIMTokenRequest struct {
+	Body struct {
+		Name string `json:"name" required:"true" minLength:"1" maxLength:"100" doc:"The token name" example:"Okta"`
+	}
+}
+
+// CreateSCIMTokenResponse is the response body for the create SCIM token endpoint.
+type CreateSCIMTokenResponse struct {
+	Body struct {
+		SCIMToken
+		Token string `json:"token" doc:"The bearer token, only shown once"`
+	}
+}
+
+// CreateSCIMToken creates a SCIM token for the active entity.
+func (v *V1) CreateSCIMToken(ctx context.Context, input *CreateSCIMTokenRequest) (*CreateSCIMTokenResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	scimToken, token, err := v.identity.SCIM.CreateToken(ctx, auth.UserID, auth.EntityID, input.Body.Name)
+	if err != nil {
+		v.Logger.Error("Failed to create SCIM token", "error", err)
+		return nil, err
+	}
+
+	response := &CreateSCIMTokenResponse{}
+	response.Body.SCIMToken = newSCIMToken(scimToken)
+	response.Body.Token = token
+
+	return response, nil
+}
+
+// DeleteSCIMTokenRequest is the request body for the delete SCIM token endpoint.
+type DeleteSCIMTokenRequest struct {
+	ID string `path:"id" format:"uuid" doc:"The token ID"`
+}
+
+// DeleteSCIMTokenResponse is the response body for the delete SCIM token endpoint.
+type DeleteSCIMTokenResponse struct{}
+
+// DeleteSCIMToken revokes a SCIM token of the active entity.
+func (v *V1) DeleteSCIMToken(ctx context.Context, input *DeleteSCIMTokenRequest) (*DeleteSCIMTokenResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	if err := v.identity.SCIM.DeleteToken(ctx, auth.UserID, auth.EntityID, input.ID); err != nil {
+		v.Logger.Error("Failed to delete SCIM token", "error", err)
+		return nil, err
+	}
+
+	return &DeleteSCIMTokenResponse{}, nil
+}
+
+// parseSCIMFilter returns the attribute and value of an equality filter.
+func parseSCIMFilter(filter string) (string, string, error) {
+	matches := scimFilterPattern.FindStringSubmatch(filter)
+	if matches == nil {
+		return "", "", httpx.ErrInvalidFilter
+	}
+
+	return matches[1], matches[2], nil
+}
+
+// newSCIMUserUpdate collects the changes of user patch operations. Attributes
+// that aren't stored are ignored, as directories send many of them.
+func newSCIMUserUpdate(operations []SCIMPatchOperation) (service.SCIMUserUpdate, error) {
+	var update service.SCIMUserUpdate
+
+	for _, operation := range operations {
+		switch strings.ToLower(operation.Op) {
+		case "add", "replace":
+		case "remove":
+			// None of the stored attributes can be removed
+			continue
+		default:
+			return update, httpx.ErrInvalidValue
+		}
+
+		values := map[string]any{operation.Path: operation.Value}
+		if operation.Path == "" {
+			object, ok := operation.Value.(map[string]any)
+			if !ok {
+				return update, httpx.ErrInvalidValue
+			}
+			values = object
+		}
+
+		for path, value := range values {
+			if err := setSCIMUserAttribute(&update, path, value); err != nil {
+				return update, err
+			}
+		}
+	}
+
+	return update, nil
+}
+
+// setSCIMUserAttribute sets an attribute of a user update from a patch value.
+func setSCIMUserAttribute(update *service.SCIMUserUpdate, path string, value any) error {
+	switch strings.ToLower(path) {
+	case "active":
+		active, ok := scimBool(value)
+		if !ok {
+			return httpx.ErrInvalidValue
+		}
+		update.Active = &active
+	case "displayname", "name.formatted":
+		name, ok := value.(string)
+		if !ok {
+			return httpx.ErrInvalidValue
+		}
+		update.Name = &name
+	case "name":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return httpx.ErrInvalidValue
+		}
+		formatted, _ := object["formatted"].(string)
+		givenName, _ := object["givenName"].(string)
+		familyName, _ := object["familyName"].(string)
+		if name := scimName(&SCIMName{Formatted: formatted, GivenName: givenName, FamilyName: familyName}); name != "" && update.Name == nil {
+			update.Name = &name
+		}
+	case "externalid":
+		externalID, ok := value.(string)
+		if !ok {
+			return httpx.ErrInvalidValue
+		}
+		update.ExternalID = &externalID
+	case "username":
+		email, ok := value.(string)
+		if !ok {
+			return httpx.ErrInvalidValue
+		}
+		update.Email = &email
+	}
+
+	return nil
+}
+
+// scimGroupPatch holds the member changes of group patch operations.
+type scimGroupPatch struct {
+	add     []string
+	remove  []string
+	replace []string
+}
+
+// newSCIMGroupPatch collects the member changes of group patch operations.
+func newSCIMGroupPatch(role types.Role, operations []SCIMPatchOperation) (*scimGroupPatch, error) {
+	patch := &scimGroupPatch{}
+
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		path := operation.Path
+
+		// A path-less operation holds the attributes in its value
+		value := operation.Value
+		if path == "" {
+			object, ok := operation.Value.(map[string]any)
+			if !ok {
+				return nil, httpx.ErrInvalidValue
+			}
+
+			if displayName, ok := object["displayName"].(string); ok && !strings.EqualFold(displayName, role.String()) {
+				return nil, httpx.ErrImmutableAttribute
+			}
+
+			members, ok := object["members"]
+			if !ok {
+				continue
+			}
+			path, value = "members", members
+		}
+
+		if matches := scimMemberPathPattern.FindStringSubmatch(path); matches != nil && op == "remove" {
+			patch.remove = append(patch.remove, matches[1])
+			continue
+		}
+
+		switch {
+		case strings.EqualFold(path, "displayName"):
+			if displayName, ok := value.(string); !ok || !strings.EqualFold(displayName, role.String()) {
+				return nil, httpx.ErrImmutableAttribute
+			}
+			continue
+		case !strings.EqualFold(path, "members"):
+			return nil, httpx.ErrInvalidValue
+		}
+
+		ids, ok := scimMemberIDs(value)
+		if !ok {
+			return nil, httpx.ErrInvalidValue
+		}
+
+		switch op {
+		case "add":
+			patch.add = append(patch.add, ids...)
+		case "remove":
+			if value == nil {
+				patch.replace = []string{}
+				continue
+			}
+			patch.remove = append(patch.remove, ids...)
+		case "replace":
+			patch.replace = ids
+		default:
+			return nil, httpx.ErrInvalidValue
+		}
+	}
+
+	return patch, nil
+}
+
+// scimMemberIDs returns the IDs of a list of member references from a patch value.
+func scimMemberIDs(value any) ([]string, bool) {
+	if value == nil {
+		return nil, true
+	}
+
+	members, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		object, ok := member.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		id, ok := object["value"].(string)
+		if !ok {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, true
+}
+
+// scimReferenceIDs returns the IDs of references.
+func scimReferenceIDs(references []SCIMReference) []string {
+	ids := make([]string, 0, len(references))
+	for _, reference := range references {
+		ids = append(ids, reference.Value)
+	}
+
+	return ids
+}
+
+// scimName returns the full name of a SCIM name.
+func scimName(name *SCIMName) string {
+	if name == nil {
+		return ""
+	}
+
+	if name.Formatted != "" {
+		return name.Formatted
+	}
+
+	return strings.TrimSpace(name.GivenName + " " + name.FamilyName)
+}
+
+// scimBool parses a boolean patch value, which some directories send as a string.
+func scimBool(value any) (bool, bool) {
+	switch value := value.(type) {
+	case bool:
+		return value, true
+	case string:
+		switch strings.ToLower(value) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+
+	return false, false
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseSCIMOperations(t *testing.T, body string) []SCIMPatchOperation {
+	t.Helper()
+
+	var patch SCIMPatchBody
+	require.NoError(t, json.Unmarshal([]byte(body), &patch))
+
+	return patch.Operations
+}
+
+func TestNewSCIMUserUpdate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should parse path operations with string booleans", func(t *testing.T) {
+		update, err := newSCIMUserUpdate(parseSCIMOperations(t, `{"Operations":[
+			{"op":"Replace","path":"active","value":"False"},
+			{"op":"Replace","path":"displayName","value":"Jane Doe"},
+			{"op":"Add","path":"externalId","value":"00u1"}
+		]}`))
+		require.NoError(t, err)
+		require.NotNil(t, update.Active)
+		assert.False(t, *update.Active)
+		assert.Equal(t, "Jane Doe", *update.Name)
+		assert.Equal(t, "00u1", *update.ExternalID)
+		assert.Nil(t, update.Email)
+	})
+
+	t.Run("should parse path-less operations and ignore unknown attributes", func(t *testing.T) {
+		update, err := newSCIMUserUpdate(parseSCIMOperations(t, `{"Operations":[
+			{"op":"replace","value":{"active":true,"name":{"givenName":"Jane","familyName":"Doe"},"title":"CTO"}}
+		]}`))
+		require.NoError(t, err)
+		assert.True(t, *update.Active)
+		assert.Equal(t, "Jane Doe", *update.Name)
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		_, err := newSCIMUserUpdate(parseSCIMOperations(t, `{"Operations":[{"op":"replace","path":"active","value":"maybe"}]}`))
+		assert.ErrorIs(t, err, httpx.ErrInvalidValue)
+
+		_, err = newSCIMUserUpdate(parseSCIMOperations(t, `{"Operations":[{"op":"move","path":"active","value":true}]}`))
+		assert.ErrorIs(t, err, httpx.ErrInvalidValue)
+	})
+}
+
+func TestNewSCIMGroupPatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should collect added and removed members", func(t *testing.T) {
+		patch, err := newSCIMGroupPatch(types.RoleAdmin, parseSCIMOperations(t, `{"Operations":[
+			{"op":"add","path":"members","value":[{"value":"u1"},{"value":"u2"}]},
+			{"op":"remove","path":"members[value eq \"u3\"]"},
+			{"op":"remove","path":"members","value":[{"value":"u4"}]}
+		]}`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u2"}, patch.add)
+		assert.Equal(t, []string{"u3", "u4"}, patch.remove)
+		assert.Nil(t, patch.replace)
+	})
+
+	t.Run("should replace members", func(t *testing.T) {
+		patch, err := newSCIMGroupPatch(types.RoleAdmin, parseSCIMOperations(t, `{"Operations":[
+			{"op":"replace","value":{"displayName":"Admin","members":[{"value":"u1"}]}}
+		]}`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u1"}, patch.replace)
+	})
+
+	t.Run("should clear members on a remove without value", func(t *testing.T) {
+		patch, err := newSCIMGroupPatch(types.RoleViewer, parseSCIMOperations(t, `{"Operations":[{"op":"remove","path":"members"}]}`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{}, patch.replace)
+	})
+
+	t.Run("should reject renaming the group", func(t *testing.T) {
+		_, err := newSCIMGroupPatch(types.RoleAdmin, parseSCIMOperations(t, `{"Operations":[{"op":"replace","path":"displayName","value":"Engineering"}]}`))
+		assert.ErrorIs(t, err, httpx.ErrImmutableAttribute)
+	})
+}
+
+func TestParseSCIMFilter(t *testing.T) {
+	t.Parallel()
+
+	attribute, value, err := parseSCIMFilter(`userName eq "jane@example.com"`)
+	require.NoError(t, err)
+	assert.Equal(t, "userName", attribute)
+	assert.Equal(t, "jane@example.com", value)
+
+	_, _, err = parseSCIMFilter(`userName co "jane"`)
+	assert.ErrorIs(t, err, httpx.ErrInvalidFilter)
+}
@@ -14,16 +14,17 @@ import (
 
 // SessionUser is the user object for the session.
 type SessionUser struct {
-	ID                 string        `json:"id" doc:"The user's ID"`
-	Email              string        `json:"email" doc:"The user's email address"`
-	Name               string        `json:"name" doc:"The user's name"`
-	Image              *string       `json:"image,omitempty" doc:"The user's profile image URL"`
-	IsVerified         bool          `json:"isVerified" doc:"Whether the user's email is verified"`
-	IsTwoFactorEnabled bool          `json:"isTwoFactorEnabled" doc:"Whether two-factor authentication is enabled"`
-	LastActiveAt       *time.Time    `json:"lastActiveAt,omitempty" doc:"The user's last activity time"`
-	LastLoggedInAt     *time.Time    `json:"lastLoggedInAt,omitempty" doc:"The user's last login time"`
-	SessionExpiresAt   time.Time     `json:"sessionExpiresAt" doc:"When the current session will expire"`
-	Memberships        []*Membership `json:"memberships,omitempty" doc:"The user's entity memberships"`
+	ID                  string        `json:"id" doc:"The user's ID"`
+	Email               string        `json:"email" doc:"The user's email address"`
+	Name                string        `json:"name" doc:"The user's name"`
+	Image               *string       `json:"image,omitempty" doc:"The user's profile image URL"`
+	IsVerified          bool          `json:"isVerified" doc:"Whether the user's email is verified"`
+	IsTwoFactorEnabled  bool          `json:"isTwoFactorEnabled" doc:"Whether two-factor authentication is enabled"`
+	LastActiveAt        *time.Time    `json:"lastActiveAt,omitempty" doc:"The user's last activity time"`
+	LastLoggedInAt      *time.Time    `json:"lastLoggedInAt,omitempty" doc:"The user's last login time"`
+	DeletionScheduledAt *time.Time    `json:"deletionScheduledAt,omitempty" doc:"When the user's account is scheduled to be deleted"`
+	SessionExpiresAt    time.Time     `json:"sessionExpiresAt" doc:"When the current session will expire"`
+	Memberships         []*Membership `json:"memberships,omitempty" doc:"The user's entity memberships"`
 }
 
 // Membership represents a user's membership in an entity
@@ -36,14 +37,15 @@ type Membership struct {
 
 // Entity represents an entity in the session
 type Entity struct {
-	ID       string  `json:"id" doc:"The entity's ID"`
-	Name     string  `json:"name" doc:"The entity's name"`
-	Slug     string  `json:"slug" doc:"The entity's slug"`
-	Type     string  `json:"type" doc:"The entity's type"`
-	Status   string  `json:"status" doc:"The entity's status"`
-	ParentID *string `json:"parentId,omitempty" doc:"The parent entity's ID"`
-	Logo     *string `json:"logo,omitempty" doc:"The entity's logo URL"`
-	Domain   *string `json:"domain,omitempty" doc:"The entity's domain"`
+	ID         string  `json:"id" doc:"The entity's ID"`
+	Name       string  `json:"name" doc:"The entity's name"`
+	Slug       string  `json:"slug" doc:"The entity's slug"`
+	Type       string  `json:"type" doc:"The entity's type"`
+	Status     string  `json:"status" doc:"The entity's status"`
+	ParentID   *string `json:"parentId,omitempty" doc:"The parent entity's ID"`
+	Logo       *string `json:"logo,omitempty" doc:"The entity's logo URL"`
+	BrandColor *string `json:"brandColor,omitempty" doc:"The entity's brand colour"`
+	Domain     *string `json:"domain,omitempty" doc:"The entity's domain"`
 }
 
 type (
@@ -76,10 +78,12 @@ type MeRequest struct {
 // MeResponse is the response body for the get session endpoint.
 type MeResponse struct {
 	Body struct {
-		IsTwoFactorPending bool        `json:"isTwoFactorPending" doc:"Whether two-factor authentication is pending"`
-		User               SessionUser `json:"user" doc:"The user object"`
-		ActiveEntity       *Entity     `json:"activeEntity,omitempty" doc:"The currently active entity"`
-		EntityRole         *EntityRole `json:"entityRole,omitempty" doc:"The permissions for the currently active entity"`
+		IsTwoFactorPending bool           `json:"isTwoFactorPending" doc:"Whether two-factor authentication is pending"`
+		IsImpersonating    bool           `json:"isImpersonating" doc:"Whether a platform member is impersonating the user, to show a banner"`
+		User               SessionUser    `json:"user" doc:"The user object"`
+		ActiveEntity       *Entity        `json:"activeEntity,omitempty" doc:"The currently active entity"`
+		EntityRole         *EntityRole    `json:"entityRole,omitempty" doc:"The permissions for the currently active entity"`
+		Impersonation      *Impersonation `json:"impersonation,omitempty" doc:"The impersonation of the user, if any"`
 	}
 }
 
@@ -120,14 +124,15 @@ func (v *V1) Me(ctx context.Context, input *MeRequest) (*MeResponse, error) {
 			EntityID: m.EntityID,
 			Role:     string(m.Role),
 			Entity: &Entity{
-				ID:       entity.ID,
-				Name:     entity.Name,
-				Slug:     entity.Slug,
-				Type:     string(entity.Type),
-				Status:   string(entity.Status),
-				ParentID: entity.ParentID,
-				Logo:     entity.Logo,
-				Domain:   entity.Domain,
+				ID:         entity.ID,
+				Name:       entity.Name,
+				Slug:       entity.Slug,
+				Type:       string(entity.Type),
+				Status:     string(entity.Status),
+				ParentID:   entity.ParentID,
+				Logo:       entity.Logo,
+				BrandColor: entity.BrandColor,
+				Domain:     entity.Domain,
 			},
 		}
 	}
@@ -141,9 +146,26 @@ func (v *V1) Me(ctx context.Context, input *MeRequest) (*MeResponse, error) {
 	response.Body.User.IsTwoFactorEnabled = isTwoFactorEnabled
 	response.Body.User.LastActiveAt = user.LastActiveAt
 	response.Body.User.LastLoggedInAt = user.LastLoggedInAt
+	response.Body.User.DeletionScheduledAt = user.DeletionScheduledAt
 	response.Body.User.SessionExpiresAt = session.ExpiresAt
 	response.Body.User.Memberships = responseMemberships
 
+	if session.IsImpersonation() {
+		impersonator, err := v.identity.User.GetByID(ctx, *session.ImpersonatorID)
+		if err != nil {
+			v.Logger.Error("Failed to get impersonator", "error", err)
+			return nil, err
+		}
+
+		response.Body.IsImpersonating = true
+		response.Body.Impersonation = &Impersonation{
+			ImpersonatorID:    impersonator.ID,
+			ImpersonatorName:  impersonator.Name,
+			ImpersonatorEmail: impersonator.Email,
+			ExpiresAt:         session.ExpiresAt,
+		}
+	}
+
 	// If there's an active entity, get its details
 	entityID := middleware.GetActiveEntity(ctx)
 	var entity *model.Entity
@@ -163,14 +185,15 @@ func (v *V1) Me(ctx context.Context, input *MeRequest) (*MeResponse, error) {
 
 	if entity != nil {
 		response.Body.ActiveEntity = &Entity{
-			ID:       entity.ID,
-			Name:     entity.Name,
-			Slug:     entity.Slug,
-			Type:     string(entity.Type),
-			Status:   string(entity.Status),
-			ParentID: entity.ParentID,
-			Logo:     entity.Logo,
-			Domain:   entity.Domain,
+			ID:         entity.ID,
+			Name:       entity.Name,
+			Slug:       entity.Slug,
+			Type:       string(entity.Type),
+			Status:     string(entity.Status),
+			ParentID:   entity.ParentID,
+			Logo:       entity.Logo,
+			BrandColor: entity.BrandColor,
+			Domain:     entity.Domain,
 		}
 		role := session.Role(entity.ID)
 		if perms, ok := types.RolePermissions[role]; ok {
@@ -186,7 +209,8 @@ func (v *V1) Me(ctx context.Context, input *MeRequest) (*MeResponse, error) {
 
 // SignInRequest is the request body for the sign in endpoint.
 type SignInRequest struct {
-	Body struct {
+	TrustedDevice http.Cookie `cookie:"trusted_device" doc:"The trusted device cookie, to skip two-factor authentication"`
+	Body          struct {
 		CfTurnstileToken httpx.TurnstileToken `json:"cfTurnstileToken" required:"true" doc:"The Cloudflare Turnstile token" example:"XXX.DUMMY.TOKEN"`
 		Email            string               `json:"email" required:"true" doc:"The user's email address" format:"email" example:"john_doe@example.com"`
 		Password         string               `json:"password" required:"true" doc:"The user's password" example:"password123"`
@@ -204,34 +228,68 @@ type SignInResponse struct {
 
 // SignIn is the handler for the sign in endpoint.
 func (v *V1) SignIn(ctx context.Context, input *SignInRequest) (*SignInResponse, error) {
-	session, err := v.identity.Session.Create(ctx, input.Body.Email, input.Body.Password)
+	session, err := v.identity.Session.Create(ctx, input.Body.Email, input.Body.Password, input.TrustedDevice.Value)
 	if err != nil {
 		// Special handling for 2FA pending case
 		if errors.Is(err, httpx.ErrTwoFactorPending) && session != nil {
 			// Return success with temporary session and 2FA pending flag
-			response := &SignInResponse{
-				SetCookies: []http.Cookie{
-					v.newSessionCookie(
-						session.Token,
-						int(time.Until(session.ExpiresAt).Seconds()),
-						session.ExpiresAt,
-					),
-					v.newRefreshCookie(
-						session.RefreshToken,
-						int(time.Until(session.RefreshExpiresAt).Seconds()),
-						session.RefreshExpiresAt,
-					),
-				},
-			}
+			return v.newSignInResponse(session, true), nil
+		}
+
+		v.Logger.Error("Failed to sign in", "error", err)
+		return nil, err
+	}
+
+	return v.newSignInResponse(session, false), nil
+}
 
-			response.Body.IsTwoFactorPending = true
+// RequestMagicLinkRequest is the request body for the request magic link endpoint.
+type RequestMagicLinkRequest struct {
+	Body struct {
+		CfTurnstileToken httpx.TurnstileToken `json:"cfTurnstileToken" required:"true" doc:"The Cloudflare Turnstile token" example:"XXX.DUMMY.TOKEN"`
+		Email            string               `json:"email" required:"true" doc:"The user's email address" format:"email" example:"john_doe@example.com"`
+	}
+}
+
+// RequestMagicLinkResponse is the response body for the request magic link endpoint.
+type RequestMagicLinkResponse struct{}
+
+// RequestMagicLink emails a sign-in link to the user.
+func (v *V1) RequestMagicLink(ctx context.Context, input *RequestMagicLinkRequest) (*RequestMagicLinkResponse, error) {
+	err := v.identity.Session.RequestMagicLink(ctx, input.Body.Email)
+	if err != nil {
+		v.Logger.Error("Failed to request magic link", "error", err)
+		return nil, err
+	}
+
+	return &RequestMagicLinkResponse{}, nil
+}
+
+// SignInWithMagicLinkRequest is the request body for the sign in with magic link endpoint.
+type SignInWithMagicLinkRequest struct {
+	TrustedDevice http.Cookie `cookie:"trusted_device" doc:"The trusted device cookie, to skip two-factor authentication"`
+	Body          struct {
+		Token string `json:"token" format:"uuid" required:"true" doc:"The sign-in link token"`
+	}
+}
 
-			return response, nil
+// SignInWithMagicLink is the handler for the sign in with magic link endpoint.
+func (v *V1) SignInWithMagicLink(ctx context.Context, input *SignInWithMagicLinkRequest) (*SignInResponse, error) {
+	session, err := v.identity.Session.CreateWithMagicLink(ctx, input.Body.Token, input.TrustedDevice.Value)
+	if err != nil {
+		if errors.Is(err, httpx.ErrTwoFactorPending) && session != nil {
+			return v.newSignInResponse(session, true), nil
 		}
 
-		v.Logger.Error("Failed to sign in", "error", err)
+		v.Logger.Error("Failed to sign in with magic link", "error", err)
 		return nil, err
 	}
+
+	return v.newSignInResponse(session, false), nil
+}
+
+// newSignInResponse sets the session cookies of a newly created session.
+func (v *V1) newSignInResponse(session *model.Session, isTwoFactorPending bool) *SignInResponse {
 	response := &SignInResponse{
 		SetCookies: []http.Cookie{
 			v.newSessionCookie(
@@ -246,7 +304,9 @@ func (v *V1) SignIn(ctx context.Context, input *SignInRequest) (*SignInResponse,
 			),
 		},
 	}
-	return response, nil
+	response.Body.IsTwoFactorPending = isTwoFactorPending
+
+	return response
 }
 
 // SignOutRequest is the request body for the sign out endpoint.
@@ -305,7 +365,7 @@ func (v *V1) GetAllSessions(ctx context.Context, input *GetAllSessionsRequest) (
 			Country:      s.Country,
 			UserAgent:    s.UserAgent,
 			CreatedAt:    s.CreatedAt,
-			LastActiveAt: s.UpdatedAt,
+			LastActiveAt: s.LastActiveAt,
 		})
 	}
 	return response, nil
@@ -394,6 +454,41 @@ func (v *V1) RefreshSession(ctx context.Context, input *RefreshSessionRequest) (
 	return response, nil
 }
 
+// RevokeSignInRequest is the request body for the revoke sign-in endpoint.
+type RevokeSignInRequest struct {
+	Body struct {
+		Token string `json:"token" format:"uuid" required:"true" doc:"The token from the new sign-in email"`
+	}
+}
+
+// RevokeSignInResponse is the response body for the revoke sign-in endpoint.
+type RevokeSignInResponse struct {
+	Body struct {
+		ResetToken string `json:"resetToken" doc:"The password reset token to choose a new password"`
+	}
+
+	SetCookies []http.Cookie `header:"Set-Cookie"`
+}
+
+// RevokeSignIn is the handler for the "this wasn't me" link of a new sign-in email.
+func (v *V1) RevokeSignIn(ctx context.Context, input *RevokeSignInRequest) (*RevokeSignInResponse, error) {
+	resetToken, err := v.identity.Session.RevokeSignIn(ctx, input.Body.Token)
+	if err != nil {
+		v.Logger.Error("Failed to revoke sign-in", "error", err)
+		return nil, err
+	}
+
+	response := &RevokeSignInResponse{
+		SetCookies: []http.Cookie{
+			v.newSessionCookie("", -1, time.Time{}),
+			v.newRefreshCookie("", -1, time.Time{}),
+		},
+	}
+	response.Body.ResetToken = resetToken
+
+	return response, nil
+}
+
 // newRefreshCookie creates a new refresh token cookie with standard configuration
 func (v *V1) newRefreshCookie(value string, maxAge int, expiresAt time.Time) http.Cookie {
 	return http.Cookie{
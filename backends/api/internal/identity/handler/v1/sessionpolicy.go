@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+)
+
+// SessionPolicy is the session limits of the members of the active entity.
+// Limits that are left out don't apply.
+type SessionPolicy struct {
+	IdleTimeoutMinutes    *int `json:"idleTimeoutMinutes,omitempty" required:"false" minimum:"5" maximum:"43200" doc:"The minutes without activity after which members must sign in again"`
+	MaxSessionMinutes     *int `json:"maxSessionMinutes,omitempty" required:"false" minimum:"5" maximum:"43200" doc:"The minutes after signing in after which members must sign in again"`
+	MaxConcurrentSessions *int `json:"maxConcurrentSessions,omitempty" required:"false" minimum:"1" maximum:"100" doc:"The most sessions a member can have at once, ending the least recently active ones"`
+}
+
+func newSessionPolicy(policy *model.SessionPolicy) SessionPolicy {
+	return SessionPolicy{
+		IdleTimeoutMinutes:    policy.IdleTimeoutMinutes,
+		MaxSessionMinutes:     policy.MaxSessionMinutes,
+		MaxConcurrentSessions: policy.MaxConcurrentSessions,
+	}
+}
+
+// GetSessionPolicyRequest is the request body for the get session policy endpoint.
+type GetSessionPolicyRequest struct{}
+
+// GetSessionPolicyResponse is the response body for the get session policy endpoint.
+type GetSessionPolicyResponse struct {
+	Body SessionPolicy
+}
+
+// GetSessionPolicy returns the session policy of the active entity.
+func (v *V1) GetSessionPolicy(ctx context.Context, input *GetSessionPolicyRequest) (*GetSessionPolicyResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	policy, err := v.identity.SessionPolicy.Get(ctx, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to get session policy", "error", err)
+		return nil, err
+	}
+
+	return &GetSessionPolicyResponse{Body: newSessionPolicy(policy)}, nil
+}
+
+// UpdateSessionPolicyRequest is the request body for the update session policy endpoint.
+type UpdateSessionPolicyRequest struct {
+	Body SessionPolicy
+}
+
+// UpdateSessionPolicyResponse is the response body for the update session policy endpoint.
+type UpdateSessionPolicyResponse struct {
+	Body SessionPolicy
+}
+
+// UpdateSessionPolicy replaces the session policy of the active entity. The
+// strictest policy of the entities of a user applies to their next session.
+func (v *V1) UpdateSessionPolicy(ctx context.Context, input *UpdateSessionPolicyRequest) (*UpdateSessionPolicyResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	policy, err := v.identity.SessionPolicy.Update(ctx, auth.EntityID, auth.UserID, &model.SessionPolicy{
+		IdleTimeoutMinutes:    input.Body.IdleTimeoutMinutes,
+		MaxSessionMinutes:     input.Body.MaxSessionMinutes,
+		MaxConcurrentSessions: input.Body.MaxConcurrentSessions,
+	})
+	if err != nil {
+		v.Logger.Error("Failed to update session policy", "error", err)
+		return nil, err
+	}
+
+	return &UpdateSessionPolicyResponse{Body: newSessionPolicy(policy)}, nil
+}
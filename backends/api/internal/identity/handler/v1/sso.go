@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SSOConnection is the single sign-on connection of an entity.
+type SSOConnection struct {
+	ID             string     `json:"id" doc:"The connection ID"`
+	EntityID       string     `json:"entityId" doc:"The entity's ID"`
+	Issuer         string     `json:"issuer" doc:"The OpenID Connect issuer URL"`
+	ClientID       string     `json:"clientId" doc:"The OAuth client ID"`
+	AllowedDomains []string   `json:"allowedDomains" doc:"The email domains allowed to sign in"`
+	DefaultRole    types.Role `json:"defaultRole" doc:"The role of members provisioned on first sign-in"`
+	IsEnforced     bool       `json:"isEnforced" doc:"Whether members can only sign in through single sign-on"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+func newSSOConnection(connection *model.SSOConnection) SSOConnection {
+	return SSOConnection{
+		ID:             connection.ID,
+		EntityID:       connection.EntityID,
+		Issuer:         connection.Issuer,
+		ClientID:       connection.ClientID,
+		AllowedDomains: connection.AllowedDomains,
+		DefaultRole:    connection.DefaultRole,
+		IsEnforced:     connection.IsEnforced,
+		CreatedAt:      connection.CreatedAt,
+		UpdatedAt:      connection.UpdatedAt,
+	}
+}
+
+// StartSSORequest is the request body for the start SSO endpoint.
+type StartSSORequest struct {
+	Body struct {
+		Entity string `json:"entity" required:"true" doc:"The ID or slug of the entity to sign in to" example:"acme"`
+	}
+}
+
+// StartSSOResponse is the response body for the start SSO endpoint.
+type StartSSOResponse struct {
+	Body struct {
+		URL string `json:"url" doc:"The identity provider URL to redirect the user to"`
+	}
+
+	SetCookies []http.Cookie `header:"Set-Cookie"`
+}
+
+// StartSSO begins a single sign-on through the entity's identity provider.
+func (v *V1) StartSSO(ctx context.Context, input *StartSSORequest) (*StartSSOResponse, error) {
+	url, state, err := v.identity.Session.StartSSO(ctx, input.Body.Entity)
+	if err != nil {
+		v.Logger.Error("Failed to start single sign-on", "error", err)
+		return nil, err
+	}
+
+	// The state cookie ties the callback to the browser starting the sign-on
+	expiresAt := time.Now().Add(model.SSOStateDuration)
+	response := &StartSSOResponse{
+		SetCookies: []http.Cookie{
+			v.newSSOStateCookie(state, int(model.SSOStateDuration.Seconds()), expiresAt),
+		},
+	}
+	response.Body.URL = url
+
+	return response, nil
+}
+
+// SSOCallbackRequest is the request body for the SSO callback endpoint.
+type SSOCallbackRequest struct {
+	SSOState      http.Cookie `cookie:"sso_state" doc:"The state cookie set when the single sign-on started"`
+	TrustedDevice http.Cookie `cookie:"trusted_device" doc:"The trusted device cookie, to skip two-factor authentication"`
+	Body          struct {
+		Code  string `json:"code" required:"true" doc:"The authorization code returned by the identity provider"`
+		State string `json:"state" format:"uuid" required:"true" doc:"The state returned by the identity provider"`
+	}
+}
+
+// SSOCallback completes a single sign-on started by the same browser and
+// creates a new session.
+func (v *V1) SSOCallback(ctx context.Context, input *SSOCallbackRequest) (*SignInResponse, error) {
+	if input.SSOState.Value == "" || subtle.ConstantTimeCompare([]byte(input.SSOState.Value), []byte(input.Body.State)) != 1 {
+		return nil, httpx.ErrInvalidOrExpiredToken
+	}
+
+	session, err := v.identity.Session.CreateWithSSO(ctx, input.Body.State, input.Body.Code, input.TrustedDevice.Value)
+	if err != nil {
+		if errors.Is(err, httpx.ErrTwoFactorPending) && session != nil {
+			response := v.newSignInResponse(session, true)
+			response.SetCookies = append(response.SetCookies, v.newSSOStateCookie("", -1, time.Time{}))
+			return response, nil
+		}
+
+		v.Logger.Error("Failed to complete single sign-on", "error", err)
+		return nil, err
+	}
+
+	response := v.newSignInResponse(session, false)
+	response.SetCookies = append(response.SetCookies, v.newSSOStateCookie("", -1, time.Time{}))
+
+	return response, nil
+}
+
+// newSSOStateCookie creates a new single sign-on state cookie with standard configuration
+func (v *V1) newSSOStateCookie(value string, maxAge int, expiresAt time.Time) http.Cookie {
+	return http.Cookie{
+		Name:     "sso_state",
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(v.Config.App.BaseURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   maxAge,
+		Expires:  expiresAt,
+	}
+}
+
+// GetSSOConnectionRequest is the request body for the get SSO connection endpoint.
+type GetSSOConnectionRequest struct{}
+
+// GetSSOConnectionResponse is the response body for the get SSO connection endpoint.
+type GetSSOConnectionResponse struct {
+	Body SSOConnection
+}
+
+// GetSSOConnection returns the single sign-on connection of the active entity.
+func (v *V1) GetSSOConnection(ctx context.Context, input *GetSSOConnectionRequest) (*GetSSOConnectionResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	connection, err := v.identity.SSOConnection.Get(ctx, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to get SSO connection", "error", err)
+		return nil, err
+	}
+
+	return &GetSSOConnectionResponse{Body: newSSOConnection(connection)}, nil
+}
+
+// UpdateSSOConnectionRequest is the request body for the update SSO connection endpoint.
+type UpdateSSOConnectionRequest struct {
+	Body struct {
+		Issuer         string     `json:"issuer" required:"true" format:"uri" doc:"The OpenID Connect issuer URL" example:"https://login.example.com"`
+		ClientID       string     `json:"clientId" required:"true" doc:"The OAuth client ID"`
+		ClientSecret   string     `json:"clientSecret,omitempty" required:"false" doc:"The OAuth client secret. Leave empty to keep the current secret."`
+		AllowedDomains []string   `json:"allowedDomains" required:"true" minItems:"1" uniqueItems:"true" doc:"The email domains allowed to sign in, which the entity must have verified" example:"[\"example.com\"]"`
+		DefaultRole    types.Role `json:"defaultRole" required:"true" enum:"admin,viewer" doc:"The role of members provisioned on first sign-in"`
+		IsEnforced     bool       `json:"isEnforced" doc:"Whether members can only sign in through single sign-on"`
+	}
+}
+
+// UpdateSSOConnectionResponse is the response body for the update SSO connection endpoint.
+type UpdateSSOConnectionResponse struct {
+	Body SSOConnection
+}
+
+// UpdateSSOConnection creates or replaces the single sign-on connection of the active entity.
+func (v *V1) UpdateSSOConnection(ctx context.Context, input *UpdateSSOConnectionRequest) (*UpdateSSOConnectionResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	connection, err := v.identity.SSOConnection.Upsert(ctx, auth.UserID, &model.SSOConnection{
+		EntityID:       auth.EntityID,
+		Issuer:         input.Body.Issuer,
+		ClientID:       input.Body.ClientID,
+		AllowedDomains: input.Body.AllowedDomains,
+		DefaultRole:    input.Body.DefaultRole,
+		IsEnforced:     input.Body.IsEnforced,
+	}, input.Body.ClientSecret)
+	if err != nil {
+		v.Logger.Error("Failed to update SSO connection", "error", err)
+		return nil, err
+	}
+
+	return &UpdateSSOConnectionResponse{Body: newSSOConnection(connection)}, nil
+}
+
+// DeleteSSOConnectionRequest is the request body for the delete SSO connection endpoint.
+type DeleteSSOConnectionRequest struct{}
+
+// DeleteSSOConnectionResponse is the response body for the delete SSO connection endpoint.
+type DeleteSSOConnectionResponse struct{}
+
+// DeleteSSOConnection removes the single sign-on connection of the active entity.
+func (v *V1) DeleteSSOConnection(ctx context.Context, input *DeleteSSOConnectionRequest) (*DeleteSSOConnectionResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	err := v.identity.SSOConnection.Delete(ctx, auth.UserID, auth.EntityID)
+	if err != nil {
+		v.Logger.Error("Failed to delete SSO connection", "error", err)
+		return nil, err
+	}
+
+	return &DeleteSSOConnectionResponse{}, nil
+}
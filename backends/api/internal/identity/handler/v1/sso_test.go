@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/service"
+	"autopilot/backends/api/internal/identity/service/mocks"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/core"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSOCallback(t *testing.T) {
+	t.Parallel()
+
+	newV1 := func(sessioner service.Sessioner) *V1 {
+		return &V1{
+			Container: &app.Container{
+				Config: &app.Config{},
+				Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard}),
+			},
+			identity: &service.Manager{Session: sessioner},
+		}
+	}
+
+	newInput := func(cookie, state string) *SSOCallbackRequest {
+		input := &SSOCallbackRequest{SSOState: http.Cookie{Name: "sso_state", Value: cookie}}
+		input.Body.Code = "code"
+		input.Body.State = state
+
+		return input
+	}
+
+	t.Run("should refuse a state not started by the browser", func(t *testing.T) {
+		t.Parallel()
+		v := newV1(mocks.NewMockSessioner(t))
+
+		_, err := v.SSOCallback(context.Background(), newInput("", "state-1"))
+		assert.ErrorIs(t, err, httpx.ErrInvalidOrExpiredToken)
+
+		_, err = v.SSOCallback(context.Background(), newInput("state-2", "state-1"))
+		assert.ErrorIs(t, err, httpx.ErrInvalidOrExpiredToken)
+	})
+
+	t.Run("should complete the sign-on and clear the state cookie", func(t *testing.T) {
+		t.Parallel()
+		sessioner := mocks.NewMockSessioner(t)
+		sessioner.EXPECT().CreateWithSSO(context.Background(), "state-1", "code", "").Return(&model.Session{
+			Token:            "token",
+			ExpiresAt:        time.Now().Add(time.Hour),
+			RefreshToken:     "refresh",
+			RefreshExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		v := newV1(sessioner)
+
+		response, err := v.SSOCallback(context.Background(), newInput("state-1", "state-1"))
+		require.NoError(t, err)
+
+		var cleared bool
+		for _, cookie := range response.SetCookies {
+			if cookie.Name == "sso_state" {
+				cleared = cookie.MaxAge < 0
+			}
+		}
+		assert.True(t, cleared)
+	})
+}
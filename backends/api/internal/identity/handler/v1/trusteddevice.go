@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TrustedDevice is a device that skips two-factor authentication.
+type TrustedDevice struct {
+	ID          string     `json:"id" doc:"The trusted device ID"`
+	DeviceClass string     `json:"deviceClass" doc:"The class of device, such as desktop or mobile"`
+	IPAddress   *string    `json:"ipAddress" doc:"The IP address the device was trusted from"`
+	Country     *string    `json:"country" doc:"The country the device was trusted from"`
+	UserAgent   *string    `json:"userAgent" doc:"The user agent the device was trusted from"`
+	ExpiresAt   time.Time  `json:"expiresAt" doc:"When the trust expires"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty" doc:"When the device last skipped two-factor authentication"`
+	CreatedAt   time.Time  `json:"createdAt" doc:"When the device was trusted"`
+}
+
+// ListTrustedDevicesRequest is the request body for the list trusted devices endpoint.
+type ListTrustedDevicesRequest struct{}
+
+// ListTrustedDevicesResponse is the response body for the list trusted devices endpoint.
+type ListTrustedDevicesResponse struct {
+	Body struct {
+		Devices []TrustedDevice `json:"devices" doc:"The devices that skip two-factor authentication"`
+	}
+}
+
+// ListTrustedDevices is the handler for the list trusted devices endpoint.
+func (v *V1) ListTrustedDevices(ctx context.Context, input *ListTrustedDevicesRequest) (*ListTrustedDevicesResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	devices, err := v.identity.TrustedDevice.List(ctx, auth.UserID)
+	if err != nil {
+		v.Logger.Error("Failed to list trusted devices", "error", err)
+		return nil, err
+	}
+
+	response := &ListTrustedDevicesResponse{}
+	response.Body.Devices = make([]TrustedDevice, 0, len(devices))
+	for _, d := range devices {
+		response.Body.Devices = append(response.Body.Devices, TrustedDevice{
+			ID:          d.ID,
+			DeviceClass: d.DeviceClass,
+			IPAddress:   d.IPAddress,
+			Country:     d.Country,
+			UserAgent:   d.UserAgent,
+			ExpiresAt:   d.ExpiresAt,
+			LastUsedAt:  d.LastUsedAt,
+			CreatedAt:   d.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+// DeleteTrustedDeviceRequest is the request body for the delete trusted device endpoint.
+type DeleteTrustedDeviceRequest struct {
+	ID string `path:"id" doc:"The trusted device ID"`
+}
+
+// DeleteTrustedDeviceResponse is the response body for the delete trusted device endpoint.
+type DeleteTrustedDeviceResponse struct{}
+
+// DeleteTrustedDevice is the handler for the delete trusted device endpoint.
+func (v *V1) DeleteTrustedDevice(ctx context.Context, input *DeleteTrustedDeviceRequest) (*DeleteTrustedDeviceResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	if err := v.identity.TrustedDevice.Revoke(ctx, auth.UserID, input.ID); err != nil {
+		v.Logger.Error("Failed to revoke trusted device", "trusted_device_id", input.ID, "error", err)
+		return nil, err
+	}
+
+	return &DeleteTrustedDeviceResponse{}, nil
+}
+
+// DeleteAllTrustedDevicesRequest is the request body for the delete all trusted devices endpoint.
+type DeleteAllTrustedDevicesRequest struct{}
+
+// DeleteAllTrustedDevicesResponse is the response body for the delete all trusted devices endpoint.
+type DeleteAllTrustedDevicesResponse struct{}
+
+// DeleteAllTrustedDevices is the handler for the delete all trusted devices endpoint.
+func (v *V1) DeleteAllTrustedDevices(ctx context.Context, input *DeleteAllTrustedDevicesRequest) (*DeleteAllTrustedDevicesResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	if err := v.identity.TrustedDevice.RevokeAll(ctx, auth.UserID); err != nil {
+		v.Logger.Error("Failed to revoke trusted devices", "error", err)
+		return nil, err
+	}
+
+	return &DeleteAllTrustedDevicesResponse{}, nil
+}
+
+// newTrustedDeviceCookie creates a new trusted device cookie with standard configuration
+func (v *V1) newTrustedDeviceCookie(value string, maxAge int, expiresAt time.Time) http.Cookie {
+	return http.Cookie{
+		Name:     "trusted_device",
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(v.Config.App.BaseURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   maxAge,
+		Expires:  expiresAt,
+	}
+}
@@ -118,7 +118,8 @@ func (v *V1) SetupTwoFactor(ctx context.Context, input *SetupTwoFactorRequest) (
 type VerifyTwoFactorRequest struct {
 	Session http.Cookie `cookie:"session" doc:"The session cookie"`
 	Body    struct {
-		Code string `json:"code" required:"true" doc:"The two-factor authentication code" example:"123456"`
+		Code        string `json:"code" required:"true" doc:"The two-factor authentication code" example:"123456"`
+		TrustDevice bool   `json:"trustDevice,omitempty" doc:"Whether to skip two-factor authentication on this device for 30 days"`
 	}
 }
 
@@ -142,6 +143,18 @@ func (v *V1) VerifyTwoFactor(ctx context.Context, input *VerifyTwoFactorRequest)
 		return nil, err
 	}
 
+	// Trusting the device is best effort, the sign-in succeeds regardless
+	var trustedDeviceCookie *http.Cookie
+	if input.Body.TrustDevice {
+		device, value, err := v.identity.TrustedDevice.Trust(ctx, session.UserID)
+		if err != nil {
+			v.Logger.Error("Failed to trust device", "error", err)
+		} else {
+			cookie := v.newTrustedDeviceCookie(value, int(time.Until(device.ExpiresAt).Seconds()), device.ExpiresAt)
+			trustedDeviceCookie = &cookie
+		}
+	}
+
 	// Update session to mark 2FA as completed
 	if err := v.identity.Session.UpdateTwoFactorStatus(ctx, session.Token, false); err != nil {
 		v.Logger.Error("Failed to update session two-factor status", "error", err)
@@ -173,5 +186,9 @@ func (v *V1) VerifyTwoFactor(ctx context.Context, input *VerifyTwoFactorRequest)
 			),
 		},
 	}
+	if trustedDeviceCookie != nil {
+		response.SetCookies = append(response.SetCookies, *trustedDeviceCookie)
+	}
+
 	return response, nil
 }
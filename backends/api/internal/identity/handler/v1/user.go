@@ -3,18 +3,9 @@ package v1
 import (
 	"autopilot/backends/api/internal/identity/model"
 	"autopilot/backends/api/pkg/httpx"
-	"autopilot/backends/internal/core"
-	"bytes"
 	"context"
-	"crypto/sha256"
-	"encoding/base64"
-	"image"
+	"net/http"
 	"time"
-
-	_ "image/jpeg"
-	_ "image/png"
-
-	_ "golang.org/x/image/webp"
 )
 
 // User is object representing a user.
@@ -28,8 +19,9 @@ type User struct {
 	LastActiveAt   *time.Time `json:"lastActiveAt,omitempty"`
 	LastLoggedInAt *time.Time `json:"lastLoggedInAt,omitempty"`
 	// LockedAt            time.Time `json:"lockedAt,omitempty"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	DeletionScheduledAt *time.Time `json:"deletionScheduledAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
 }
 
 // GetUserRequest is the request body for the get user endpoint.
@@ -59,15 +51,16 @@ func (v *V1) GetUser(ctx context.Context, input *GetUserRequest) (*GetUserRespon
 	}
 	return &GetUserResponse{
 		Body: User{
-			ID:              user.ID,
-			Name:            user.Name,
-			Email:           user.Email,
-			EmailVerifiedAt: user.EmailVerifiedAt,
-			Image:           user.Image,
-			LastActiveAt:    user.LastActiveAt,
-			LastLoggedInAt:  user.LastLoggedInAt,
-			CreatedAt:       user.CreatedAt,
-			UpdatedAt:       user.UpdatedAt,
+			ID:                  user.ID,
+			Name:                user.Name,
+			Email:               user.Email,
+			EmailVerifiedAt:     user.EmailVerifiedAt,
+			Image:               user.Image,
+			LastActiveAt:        user.LastActiveAt,
+			LastLoggedInAt:      user.LastLoggedInAt,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+			CreatedAt:           user.CreatedAt,
+			UpdatedAt:           user.UpdatedAt,
 		},
 	}, nil
 }
@@ -105,30 +98,31 @@ func (v *V1) UpdateUser(ctx context.Context, input *UpdateUserRequest) (*UpdateU
 	}
 	return &UpdateUserResponse{
 		Body: User{
-			ID:              user.ID,
-			Name:            user.Name,
-			Email:           user.Email,
-			EmailVerifiedAt: user.EmailVerifiedAt,
-			Image:           user.Image,
-			LastActiveAt:    user.LastActiveAt,
-			LastLoggedInAt:  user.LastLoggedInAt,
-			CreatedAt:       user.CreatedAt,
-			UpdatedAt:       user.UpdatedAt,
+			ID:                  user.ID,
+			Name:                user.Name,
+			Email:               user.Email,
+			EmailVerifiedAt:     user.EmailVerifiedAt,
+			Image:               user.Image,
+			LastActiveAt:        user.LastActiveAt,
+			LastLoggedInAt:      user.LastLoggedInAt,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+			CreatedAt:           user.CreatedAt,
+			UpdatedAt:           user.UpdatedAt,
 		},
 	}, nil
 }
 
 // UpdateUserImageRequest is the request body for the update user image endpoint.
 type UpdateUserImageRequest struct {
-	ID       string `path:"id" required:"true" doc:"The ID of the user. Use @me to refer to the current user." example:"@me"`
-	FileName string `header:"X-File-Name" required:"true" doc:"The original file name."`
-	RawBody  []byte `contentType:"image/*" doc:"Supports png, jpeg and webp file types."`
+	ID      string `path:"id" required:"true" doc:"The ID of the user. Use @me to refer to the current user." example:"@me"`
+	RawBody []byte `contentType:"image/*" doc:"Supports png, jpeg and webp file types."`
 }
 
 // UpdateUserImageResponse is the response body for the update user image endpoint.
 type UpdateUserImageResponse struct{}
 
-// UpdateUserImage is the handler for the update user image endpoint.
+// UpdateUserImage is the handler for the update user image endpoint. The image
+// is processed in the background, and replaces the user's image once ready.
 func (v *V1) UpdateUserImage(ctx context.Context, input *UpdateUserImageRequest) (*UpdateUserImageResponse, error) {
 	auth := httpx.GetAuthInfo(ctx)
 	if input.ID == "@me" || input.ID == "%40me" {
@@ -138,33 +132,8 @@ func (v *V1) UpdateUserImage(ctx context.Context, input *UpdateUserImageRequest)
 		return nil, httpx.ErrUserNotFound
 	}
 
-	key, format, err := validateImage(input.RawBody)
-	if err != nil {
-		return nil, err
-	}
-
-	_, err = v.Storage.Identity.Upload(ctx, key, bytes.NewReader(input.RawBody), &core.ObjectMetadata{
-		Key:            key,
-		Size:           int64(len(input.RawBody)),
-		ContentType:    "image/" + format,
-		LastModified:   time.Now(),
-		CustomMetadata: map[string]string{"name": input.FileName},
-	})
-	if err != nil {
-		v.Logger.Error("error uploading image to storage", "error", err)
-		return nil, httpx.ErrUnknown.WithInternal(err)
-	}
-
-	info, err := v.Storage.Identity.GenerateDownloadURL(ctx, key, 0)
-	if err != nil {
-		v.Logger.Error("error generating link to storage object", "error", err)
-		return nil, httpx.ErrUnknown.WithInternal(err)
-	}
-
-	if _, err := v.identity.User.Update(ctx, &model.User{
-		ID:    input.ID,
-		Image: &info.URL,
-	}); err != nil {
+	if err := v.identity.User.UpdateImage(ctx, input.ID, input.RawBody); err != nil {
+		v.Logger.Error("Failed to update user image", "error", err)
 		return nil, err
 	}
 
@@ -263,6 +232,58 @@ func (v *V1) VerifyEmail(ctx context.Context, input *VerifyEmailRequest) (*Verif
 	return &VerifyEmailResponse{}, nil
 }
 
+// ResendVerificationRequest is the request body for the resend verification endpoint.
+type ResendVerificationRequest struct {
+	Body struct {
+		Email string `json:"email" required:"true" format:"email" doc:"The user's email address" example:"user@example.com"`
+	}
+}
+
+// ResendVerificationResponse is the response body for the resend verification endpoint.
+type ResendVerificationResponse struct{}
+
+// ResendVerification sends a new verification email. The response is the same
+// whether or not the email address belongs to an unverified user.
+func (v *V1) ResendVerification(ctx context.Context, input *ResendVerificationRequest) (*ResendVerificationResponse, error) {
+	err := v.identity.User.ResendVerification(ctx, input.Body.Email)
+	if err != nil {
+		v.Logger.Error("Failed to resend verification", "error", err)
+		return nil, err
+	}
+
+	return &ResendVerificationResponse{}, nil
+}
+
+// VerificationStatusRequest is the request body for the verification status endpoint.
+type VerificationStatusRequest struct {
+	Token string `query:"token" format:"uuid" required:"true" doc:"The email verification token"`
+}
+
+// VerificationStatusResponse is the response body for the verification status endpoint.
+type VerificationStatusResponse struct {
+	Body struct {
+		Valid     bool       `json:"valid" doc:"Whether the token can still be used to verify the email address"`
+		ExpiresAt *time.Time `json:"expiresAt,omitempty" doc:"When the token expires"`
+	}
+}
+
+// VerificationStatus is the handler for the verification status endpoint.
+func (v *V1) VerificationStatus(ctx context.Context, input *VerificationStatusRequest) (*VerificationStatusResponse, error) {
+	verification, err := v.identity.User.GetEmailVerification(ctx, input.Token)
+	if err != nil {
+		v.Logger.Error("Failed to get verification status", "error", err)
+		return nil, err
+	}
+
+	response := &VerificationStatusResponse{}
+	if verification != nil {
+		response.Body.Valid = true
+		response.Body.ExpiresAt = &verification.ExpiresAt
+	}
+
+	return response, nil
+}
+
 // VerifyPasswordRequest is the request body for the verify password endpoint.
 type VerifyPasswordRequest struct {
 	Body struct {
@@ -319,17 +340,188 @@ func (v *V1) UpdatePassword(ctx context.Context, input *UpdatePasswordRequest) (
 	return &UpdatePasswordResponse{}, nil
 }
 
-func validateImage(img []byte) (key string, format string, err error) {
-	_, format, err = image.Decode(bytes.NewBuffer(img))
+// ChangeEmailRequest is the request body for the change email endpoint.
+type ChangeEmailRequest struct {
+	Body struct {
+		NewEmail string `json:"newEmail" required:"true" format:"email" doc:"The new email address" example:"new@example.com"`
+		Password string `json:"password" required:"true" doc:"The current password" example:"current-password"`
+	}
+}
+
+// ChangeEmailResponse is the response body for the change email endpoint.
+type ChangeEmailResponse struct{}
+
+// ChangeEmail sends a confirmation link to the new email address.
+func (v *V1) ChangeEmail(ctx context.Context, input *ChangeEmailRequest) (*ChangeEmailResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	err := v.identity.User.RequestEmailChange(ctx, auth.UserID, input.Body.Password, input.Body.NewEmail)
 	if err != nil {
-		return "", "", httpx.ErrInvalidImageFormat
+		v.Logger.Error("Failed to request email change", "error", err)
+		return nil, err
 	}
-	switch format {
-	case "png", "jpeg", "webp":
-	default:
-		return "", "", httpx.ErrInvalidImageFormat
+
+	return &ChangeEmailResponse{}, nil
+}
+
+// ConfirmEmailChangeRequest is the request body for the confirm email change endpoint.
+type ConfirmEmailChangeRequest struct {
+	Session http.Cookie `cookie:"session" doc:"The session cookie, which stays valid after the change"`
+	Body    struct {
+		Token string `json:"token" format:"uuid" required:"true" doc:"The email change token"`
 	}
-	hash := sha256.Sum256(img)
-	key = base64.RawURLEncoding.EncodeToString(hash[:])
-	return key, format, nil
+}
+
+// ConfirmEmailChangeResponse is the response body for the confirm email change endpoint.
+type ConfirmEmailChangeResponse struct{}
+
+// ConfirmEmailChange completes the email change.
+func (v *V1) ConfirmEmailChange(ctx context.Context, input *ConfirmEmailChangeRequest) (*ConfirmEmailChangeResponse, error) {
+	err := v.identity.User.ConfirmEmailChange(ctx, input.Body.Token, input.Session.Value)
+	if err != nil {
+		v.Logger.Error("Failed to confirm email change", "error", err)
+		return nil, err
+	}
+
+	return &ConfirmEmailChangeResponse{}, nil
+}
+
+// RevertEmailChangeRequest is the request body for the revert email change endpoint.
+type RevertEmailChangeRequest struct {
+	Body struct {
+		Token string `json:"token" format:"uuid" required:"true" doc:"The email change revert token"`
+	}
+}
+
+// RevertEmailChangeResponse is the response body for the revert email change endpoint.
+type RevertEmailChangeResponse struct {
+	Body struct {
+		ResetToken string `json:"resetToken" doc:"The password reset token to choose a new password"`
+	}
+
+	SetCookies []http.Cookie `header:"Set-Cookie"`
+}
+
+// RevertEmailChange restores the previous email address.
+func (v *V1) RevertEmailChange(ctx context.Context, input *RevertEmailChangeRequest) (*RevertEmailChangeResponse, error) {
+	resetToken, err := v.identity.User.RevertEmailChange(ctx, input.Body.Token)
+	if err != nil {
+		v.Logger.Error("Failed to revert email change", "error", err)
+		return nil, err
+	}
+
+	response := &RevertEmailChangeResponse{
+		SetCookies: []http.Cookie{
+			v.newSessionCookie("", -1, time.Time{}),
+			v.newRefreshCookie("", -1, time.Time{}),
+		},
+	}
+	response.Body.ResetToken = resetToken
+
+	return response, nil
+}
+
+// UnlockAccountRequest is the request body for the unlock account endpoint.
+type UnlockAccountRequest struct {
+	Body struct {
+		Token string `json:"token" format:"uuid" required:"true" doc:"The token from the account locked email"`
+	}
+}
+
+// UnlockAccountResponse is the response body for the unlock account endpoint.
+type UnlockAccountResponse struct{}
+
+// UnlockAccount is the handler for the unlock link of an account locked email.
+func (v *V1) UnlockAccount(ctx context.Context, input *UnlockAccountRequest) (*UnlockAccountResponse, error) {
+	err := v.identity.User.Unlock(ctx, input.Body.Token)
+	if err != nil {
+		v.Logger.Error("Failed to unlock account", "error", err)
+		return nil, err
+	}
+
+	return &UnlockAccountResponse{}, nil
+}
+
+// UnlockMemberRequest is the request body for the unlock member endpoint.
+type UnlockMemberRequest struct {
+	ID string `path:"id" format:"uuid" required:"true" doc:"The ID of the member's user"`
+}
+
+// UnlockMemberResponse is the response body for the unlock member endpoint.
+type UnlockMemberResponse struct{}
+
+// UnlockMember unlocks the account of a member of the active entity.
+func (v *V1) UnlockMember(ctx context.Context, input *UnlockMemberRequest) (*UnlockMemberResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	err := v.identity.User.UnlockMember(ctx, auth.EntityID, auth.UserID, input.ID)
+	if err != nil {
+		v.Logger.Error("Failed to unlock member", "error", err)
+		return nil, err
+	}
+
+	return &UnlockMemberResponse{}, nil
+}
+
+// ExportDataRequest is the request body for the export data endpoint.
+type ExportDataRequest struct{}
+
+// ExportDataResponse is the response body for the export data endpoint.
+type ExportDataResponse struct{}
+
+// ExportData queues an export of the user's personal data.
+func (v *V1) ExportData(ctx context.Context, input *ExportDataRequest) (*ExportDataResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	err := v.identity.User.RequestDataExport(ctx, auth.UserID)
+	if err != nil {
+		v.Logger.Error("Failed to request data export", "error", err)
+		return nil, err
+	}
+
+	return &ExportDataResponse{}, nil
+}
+
+// DeleteAccountRequest is the request body for the delete account endpoint.
+type DeleteAccountRequest struct {
+	Body struct {
+		Password string `json:"password" required:"true" doc:"The current password" example:"current-password"`
+	}
+}
+
+// DeleteAccountResponse is the response body for the delete account endpoint.
+type DeleteAccountResponse struct {
+	Body struct {
+		DeletionScheduledAt time.Time `json:"deletionScheduledAt" doc:"When the account will be deleted"`
+	}
+}
+
+// DeleteAccount schedules the deletion of the user's account.
+func (v *V1) DeleteAccount(ctx context.Context, input *DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	user, err := v.identity.User.ScheduleDeletion(ctx, auth.UserID, input.Body.Password)
+	if err != nil {
+		v.Logger.Error("Failed to schedule account deletion", "error", err)
+		return nil, err
+	}
+
+	response := &DeleteAccountResponse{}
+	response.Body.DeletionScheduledAt = *user.DeletionScheduledAt
+
+	return response, nil
+}
+
+// CancelAccountDeletionRequest is the request body for the cancel account deletion endpoint.
+type CancelAccountDeletionRequest struct{}
+
+// CancelAccountDeletionResponse is the response body for the cancel account deletion endpoint.
+type CancelAccountDeletionResponse struct{}
+
+// CancelAccountDeletion cancels the scheduled deletion of the user's account.
+func (v *V1) CancelAccountDeletion(ctx context.Context, input *CancelAccountDeletionRequest) (*CancelAccountDeletionResponse, error) {
+	auth := httpx.GetAuthInfo(ctx)
+	err := v.identity.User.CancelDeletion(ctx, auth.UserID)
+	if err != nil {
+		v.Logger.Error("Failed to cancel account deletion", "error", err)
+		return nil, err
+	}
+
+	return &CancelAccountDeletionResponse{}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"autopilot/backends/api/internal/identity/service"
 	"autopilot/backends/api/pkg/app"
 	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
 	"fmt"
 	"net/http"
 
@@ -21,14 +22,19 @@ var TagIdentity = huma.Tag{
 	Description: `Identity & Access Management`,
 }
 
+var TagSCIM = huma.Tag{
+	Name:        "SCIM",
+	Description: `SCIM 2.0 user and group provisioning`,
+}
+
 func BasePath(path string) string {
 	return fmt.Sprintf("/v1%s", path)
 }
 
 // AddRoutes adds the v1 API docs/routes to the http server
-func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Manager, auth httpx.Authenticator) error {
-	api := httpx.InitHandler(humaAPI, container.Mode, auth)
-	api.AddTags(&TagIdentity)
+func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Manager, auth httpx.Authenticator, rateLimiter httpx.RateLimiter) error {
+	api := httpx.InitHandler(humaAPI, container.Mode, auth, rateLimiter)
+	api.AddTags(&TagIdentity, &TagSCIM)
 
 	v1 := &V1{
 		Container: container,
@@ -56,15 +62,24 @@ func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Man
 	}, v1.UpdateUser, api.WithUserSession())
 
 	httpx.Register(api, huma.Operation{
-		Method:       http.MethodPost,
-		OperationID:  "update-user-image",
-		Path:         BasePath("/users/{id}/image"),
-		Summary:      "Update user profile image",
-		Tags:         []string{TagIdentity.Name},
-		MaxBodyBytes: 5 * 1024 * 1024, // 5 MiB max
-
+		Method:        http.MethodPost,
+		OperationID:   "update-user-image",
+		Path:          BasePath("/users/{id}/image"),
+		Summary:       "Update user profile image",
+		Tags:          []string{TagIdentity.Name},
+		MaxBodyBytes:  5 * 1024 * 1024, // 5 MiB max
+		DefaultStatus: http.StatusAccepted,
 	}, v1.UpdateUserImage, api.WithUserSession())
 
+	httpx.Register(api, huma.Operation{
+		Method:        http.MethodGet,
+		OperationID:   "get-image",
+		Path:          BasePath("/identity/images/{id}"),
+		Summary:       "Redirect to a user image or entity logo",
+		Tags:          []string{TagIdentity.Name},
+		DefaultStatus: http.StatusFound,
+	}, v1.GetImage, api.WithUnauthenticated())
+
 	// Identity Routes
 
 	// Allow me endpoint with API key
@@ -84,7 +99,42 @@ func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Man
 		Path:        BasePath("/identity/sign-in"),
 		Summary:     "Authenticate and create a new session",
 		Tags:        []string{TagIdentity.Name},
-	}, v1.SignIn, api.WithUnauthenticated())
+	}, v1.SignIn, api.WithUnauthenticated(),
+		httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic),
+		httpx.WithRateLimitPolicy(httpx.RateLimitPolicySignIn),
+	)
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "request-magic-link",
+		Path:        BasePath("/identity/magic-link"),
+		Summary:     "Email a single-use sign-in link",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.RequestMagicLink, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "sign-in-with-magic-link",
+		Path:        BasePath("/identity/magic-link/sign-in"),
+		Summary:     "Authenticate with a sign-in link and create a new session",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.SignInWithMagicLink, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "start-sso",
+		Path:        BasePath("/identity/sso/authorize"),
+		Summary:     "Start a single sign-on through the entity's identity provider",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.StartSSO, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "sso-callback",
+		Path:        BasePath("/identity/sso/callback"),
+		Summary:     "Complete a single sign-on and create a new session",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.SSOCallback, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
 
 	httpx.Register(api, huma.Operation{
 		Method:      http.MethodPost,
@@ -92,7 +142,7 @@ func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Man
 		Path:        BasePath("/identity/sign-up"),
 		Summary:     "Create a new user account",
 		Tags:        []string{TagIdentity.Name},
-	}, v1.SignUp, api.WithUnauthenticated())
+	}, v1.SignUp, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
 
 	httpx.Register(api, huma.Operation{
 		Method:      http.MethodPost,
@@ -100,7 +150,7 @@ func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Man
 		Path:        BasePath("/identity/forgot-password"),
 		Summary:     "Initiate password reset process",
 		Tags:        []string{TagIdentity.Name},
-	}, v1.ForgotPassword, api.WithUnauthenticated())
+	}, v1.ForgotPassword, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
 
 	httpx.Register(api, huma.Operation{
 		Method:      http.MethodPost,
@@ -108,7 +158,7 @@ func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Man
 		Path:        BasePath("/identity/reset-password"),
 		Summary:     "Complete password reset process",
 		Tags:        []string{TagIdentity.Name},
-	}, v1.ResetPassword, api.WithUnauthenticated())
+	}, v1.ResetPassword, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
 
 	httpx.Register(api, huma.Operation{
 		Method:      http.MethodPost,
@@ -116,7 +166,55 @@ func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Man
 		Path:        BasePath("/identity/verify-email"),
 		Summary:     "Confirm user email address",
 		Tags:        []string{TagIdentity.Name},
-	}, v1.VerifyEmail, api.WithUnauthenticated())
+	}, v1.VerifyEmail, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "resend-verification",
+		Path:        BasePath("/identity/resend-verification"),
+		Summary:     "Resend the email address verification",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ResendVerification, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "verification-status",
+		Path:        BasePath("/identity/verification-status"),
+		Summary:     "Check whether an email verification token is still valid",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.VerificationStatus, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "revoke-sign-in",
+		Path:        BasePath("/identity/revoke-sign-in"),
+		Summary:     "Revoke all sessions and force a password reset after an unrecognised sign-in",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.RevokeSignIn, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "unlock-account",
+		Path:        BasePath("/identity/unlock-account"),
+		Summary:     "Unlock an account locked after too many failed sign-in attempts",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.UnlockAccount, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "confirm-email-change",
+		Path:        BasePath("/identity/confirm-email-change"),
+		Summary:     "Confirm the new email address of an email change",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ConfirmEmailChange, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "revert-email-change",
+		Path:        BasePath("/identity/revert-email-change"),
+		Summary:     "Restore the previous email address after an email change",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.RevertEmailChange, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
 
 	// Private identity endpoints with rate limits
 
@@ -128,6 +226,62 @@ func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Man
 		Tags:        []string{TagIdentity.Name},
 	}, v1.UpdatePassword, api.WithUserSession())
 
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "change-email",
+		Path:        BasePath("/identity/change-email"),
+		Summary:     "Request a change of the user email address",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ChangeEmail, api.WithUserSession())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "export-data",
+		Path:        BasePath("/identity/export-data"),
+		Summary:     "Request an export of the user's personal data",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ExportData, api.WithUserSession())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "delete-account",
+		Path:        BasePath("/identity/delete-account"),
+		Summary:     "Schedule the deletion of the user account",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.DeleteAccount, api.WithUserSession())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		OperationID: "cancel-account-deletion",
+		Path:        BasePath("/identity/delete-account"),
+		Summary:     "Cancel the scheduled deletion of the user account",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.CancelAccountDeletion, api.WithUserSession())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "get-sso-connection",
+		Path:        BasePath("/identity/sso-connection"),
+		Summary:     "Get the single sign-on connection of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.GetSSOConnection, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionRead))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		OperationID: "update-sso-connection",
+		Path:        BasePath("/identity/sso-connection"),
+		Summary:     "Create or replace the single sign-on connection of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.UpdateSSOConnection, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionManage))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		OperationID: "delete-sso-connection",
+		Path:        BasePath("/identity/sso-connection"),
+		Summary:     "Delete the single sign-on connection of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.DeleteSSOConnection, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionManage))
+
 	httpx.Register(api, huma.Operation{
 		Method:      http.MethodPost,
 		OperationID: "setup-two-factor",
@@ -158,7 +312,31 @@ func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Man
 		Path:        BasePath("/identity/verify-two-factor"),
 		Summary:     "Verify two-factor authentication code during sign-in",
 		Tags:        []string{TagIdentity.Name},
-	}, v1.VerifyTwoFactor, api.WithUnauthenticated())
+	}, v1.VerifyTwoFactor, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "list-trusted-devices",
+		Path:        BasePath("/identity/trusted-devices"),
+		Summary:     "List the devices that skip two-factor authentication",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ListTrustedDevices, api.WithUserSession())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		OperationID: "delete-all-trusted-devices",
+		Path:        BasePath("/identity/trusted-devices"),
+		Summary:     "Stop trusting every device",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.DeleteAllTrustedDevices, api.WithUserSession())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		OperationID: "delete-trusted-device",
+		Path:        BasePath("/identity/trusted-devices/{id}"),
+		Summary:     "Stop trusting a device",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.DeleteTrustedDevice, api.WithUserSession())
 
 	httpx.Register(api, huma.Operation{
 		Method:      http.MethodPost,
@@ -217,5 +395,369 @@ func AddRoutes(container *app.Container, humaAPI huma.API, identity *service.Man
 		Tags:        []string{TagIdentity.Name},
 	}, v1.RegenerateQRCode, api.WithUserSession())
 
+	// Audit log routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "list-audit-logs",
+		Path:        BasePath("/audit-logs"),
+		Summary:     "List the audit log of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ListAuditLogs, api.WithUserSession(), api.WithPermission(types.ResourceAuditLog, types.ActionRead))
+
+	httpx.Register(api, huma.Operation{
+		Method:        http.MethodPost,
+		OperationID:   "export-audit-logs",
+		Path:          BasePath("/audit-logs/export"),
+		Summary:       "Export the audit log of the active entity",
+		Tags:          []string{TagIdentity.Name},
+		DefaultStatus: http.StatusAccepted,
+	}, v1.ExportAuditLogs, api.WithUserSession(), api.WithPermission(types.ResourceAuditLog, types.ActionExport))
+
+	// Compliance routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "list-compliance-records",
+		Path:        BasePath("/compliance-records"),
+		Summary:     "List the compliance records of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ListComplianceRecords, api.WithUserSession(), api.WithPermission(types.ResourceCompliance, types.ActionRead))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		OperationID: "submit-kyb",
+		Path:        BasePath("/compliance-records/kyb"),
+		Summary:     "Submit the KYB information of the active entity for review",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.SubmitKYB, api.WithUserSession(), api.WithPermission(types.ResourceCompliance, types.ActionUpdate))
+
+	httpx.Register(api, huma.Operation{
+		Method:        http.MethodPost,
+		OperationID:   "create-compliance-document",
+		Path:          BasePath("/compliance-records/{type}/documents"),
+		Summary:       "Register a compliance document of the active entity for upload",
+		Tags:          []string{TagIdentity.Name},
+		DefaultStatus: http.StatusCreated,
+	}, v1.CreateComplianceDocument, api.WithUserSession(), api.WithPermission(types.ResourceCompliance, types.ActionUpdate))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		OperationID: "delete-compliance-document",
+		Path:        BasePath("/compliance-documents/{id}"),
+		Summary:     "Remove a compliance document of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.DeleteComplianceDocument, api.WithUserSession(), api.WithPermission(types.ResourceCompliance, types.ActionUpdate))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "list-compliance-reviews",
+		Path:        BasePath("/compliance-reviews"),
+		Summary:     "List the compliance records to review from the active platform entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ListComplianceReviews, api.WithUserSession(), api.WithPermission(types.ResourceCompliance, types.ActionVerify))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "get-compliance-review",
+		Path:        BasePath("/compliance-reviews/{id}"),
+		Summary:     "Get a compliance record to review with its documents",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.GetComplianceReview, api.WithUserSession(), api.WithPermission(types.ResourceCompliance, types.ActionVerify))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "review-compliance-record",
+		Path:        BasePath("/compliance-reviews/{id}"),
+		Summary:     "Move a compliance record to a new review status",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ReviewComplianceRecord, api.WithUserSession(), api.WithPermission(types.ResourceCompliance, types.ActionVerify))
+
+	// Impersonation routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "start-impersonation",
+		Path:        BasePath("/identity/impersonation"),
+		Summary:     "Start a read-only impersonation of a user below the active platform entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.StartImpersonation, api.WithUserSession(), api.WithPermission(types.ResourceImpersonation, types.ActionCreate))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		OperationID: "stop-impersonation",
+		Path:        BasePath("/identity/impersonation"),
+		Summary:     "Stop impersonating a user and switch back to the impersonator's session",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.StopImpersonation, api.WithUserSession(), httpx.WithImpersonation())
+
+	// Member routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "unlock-member",
+		Path:        BasePath("/identity/members/{id}/unlock"),
+		Summary:     "Unlock the account of a member of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.UnlockMember, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionManage))
+
+	// IP allowlist routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "get-ip-allowlist",
+		Path:        BasePath("/identity/ip-allowlist"),
+		Summary:     "Get the IP allowlist of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.GetIPAllowlist, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionRead))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		OperationID: "update-ip-allowlist",
+		Path:        BasePath("/identity/ip-allowlist"),
+		Summary:     "Replace the IP allowlist of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.UpdateIPAllowlist, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionUpdate))
+
+	// Branding routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "get-branding",
+		Path:        BasePath("/identity/branding"),
+		Summary:     "Get the branding of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.GetBranding, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionRead))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		OperationID: "update-branding",
+		Path:        BasePath("/identity/branding"),
+		Summary:     "Update the brand colour and support email of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.UpdateBranding, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionUpdate))
+
+	httpx.Register(api, huma.Operation{
+		Method:        http.MethodPost,
+		OperationID:   "update-entity-logo",
+		Path:          BasePath("/identity/branding/logo"),
+		Summary:       "Update the logo of the active entity",
+		Tags:          []string{TagIdentity.Name},
+		MaxBodyBytes:  5 * 1024 * 1024, // 5 MiB max
+		DefaultStatus: http.StatusAccepted,
+	}, v1.UpdateEntityLogo, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionUpdate))
+
+	// Entity domain routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "list-entity-domains",
+		Path:        BasePath("/identity/domains"),
+		Summary:     "List the domains claimed by the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ListEntityDomains, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionRead))
+
+	httpx.Register(api, huma.Operation{
+		Method:        http.MethodPost,
+		OperationID:   "create-entity-domain",
+		Path:          BasePath("/identity/domains"),
+		Summary:       "Claim a domain for the active entity",
+		Tags:          []string{TagIdentity.Name},
+		DefaultStatus: http.StatusCreated,
+	}, v1.CreateEntityDomain, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionUpdate))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		OperationID: "update-entity-domain",
+		Path:        BasePath("/identity/domains/{id}"),
+		Summary:     "Update the automatic joining settings of a domain of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.UpdateEntityDomain, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionUpdate))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		OperationID: "delete-entity-domain",
+		Path:        BasePath("/identity/domains/{id}"),
+		Summary:     "Remove a domain from the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.DeleteEntityDomain, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionUpdate))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "verify-entity-domain",
+		Path:        BasePath("/identity/domains/{id}/verify"),
+		Summary:     "Verify a domain of the active entity through its DNS TXT record",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.VerifyEntityDomain, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionUpdate))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "list-joinable-entities",
+		Path:        BasePath("/identity/joinable-entities"),
+		Summary:     "List the entities the user can join through the domain of their email address",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ListJoinableEntities, api.WithUserSession())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPost,
+		OperationID: "join-entity",
+		Path:        BasePath("/identity/joinable-entities/{id}/join"),
+		Summary:     "Join an entity through the domain of the user's email address",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.JoinEntity, api.WithUserSession())
+
+	// Password policy routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "get-password-policy",
+		Path:        BasePath("/identity/password-policy"),
+		Summary:     "Get the password policy of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.GetPasswordPolicy, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionRead))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		OperationID: "update-password-policy",
+		Path:        BasePath("/identity/password-policy"),
+		Summary:     "Replace the password policy of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.UpdatePasswordPolicy, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionUpdate))
+
+	// Session policy routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "get-session-policy",
+		Path:        BasePath("/identity/session-policy"),
+		Summary:     "Get the session policy of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.GetSessionPolicy, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionRead))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		OperationID: "update-session-policy",
+		Path:        BasePath("/identity/session-policy"),
+		Summary:     "Replace the session policy of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.UpdateSessionPolicy, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionUpdate))
+
+	// SCIM token routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "list-scim-tokens",
+		Path:        BasePath("/identity/scim-tokens"),
+		Summary:     "List the SCIM tokens of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.ListSCIMTokens, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionManage))
+
+	httpx.Register(api, huma.Operation{
+		Method:        http.MethodPost,
+		OperationID:   "create-scim-token",
+		Path:          BasePath("/identity/scim-tokens"),
+		Summary:       "Create a SCIM token for the active entity",
+		Tags:          []string{TagIdentity.Name},
+		DefaultStatus: http.StatusCreated,
+	}, v1.CreateSCIMToken, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionManage))
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodDelete,
+		OperationID: "delete-scim-token",
+		Path:        BasePath("/identity/scim-tokens/{id}"),
+		Summary:     "Revoke a SCIM token of the active entity",
+		Tags:        []string{TagIdentity.Name},
+	}, v1.DeleteSCIMToken, api.WithUserSession(), api.WithPermission(types.ResourceEntity, types.ActionManage))
+
+	// SCIM routes
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "get-scim-service-provider-config",
+		Path:        SCIMPath("/ServiceProviderConfig"),
+		Summary:     "Describe the supported SCIM features",
+		Tags:        []string{TagSCIM.Name},
+	}, v1.GetSCIMServiceProviderConfig, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "list-scim-users",
+		Path:        SCIMPath("/Users"),
+		Summary:     "List the users of the entity",
+		Tags:        []string{TagSCIM.Name},
+	}, v1.ListSCIMUsers, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:        http.MethodPost,
+		OperationID:   "create-scim-user",
+		Path:          SCIMPath("/Users"),
+		Summary:       "Provision a user into the entity",
+		Tags:          []string{TagSCIM.Name},
+		DefaultStatus: http.StatusCreated,
+	}, v1.CreateSCIMUser, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "get-scim-user",
+		Path:        SCIMPath("/Users/{id}"),
+		Summary:     "Get a user of the entity",
+		Tags:        []string{TagSCIM.Name},
+	}, v1.GetSCIMUser, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		OperationID: "replace-scim-user",
+		Path:        SCIMPath("/Users/{id}"),
+		Summary:     "Replace a user of the entity",
+		Tags:        []string{TagSCIM.Name},
+	}, v1.ReplaceSCIMUser, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPatch,
+		OperationID: "patch-scim-user",
+		Path:        SCIMPath("/Users/{id}"),
+		Summary:     "Update or deactivate a user of the entity",
+		Tags:        []string{TagSCIM.Name},
+	}, v1.PatchSCIMUser, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:        http.MethodDelete,
+		OperationID:   "delete-scim-user",
+		Path:          SCIMPath("/Users/{id}"),
+		Summary:       "Deprovision a user from the entity",
+		Tags:          []string{TagSCIM.Name},
+		DefaultStatus: http.StatusNoContent,
+	}, v1.DeleteSCIMUser, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "list-scim-groups",
+		Path:        SCIMPath("/Groups"),
+		Summary:     "List the groups of the entity",
+		Tags:        []string{TagSCIM.Name},
+	}, v1.ListSCIMGroups, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:        http.MethodPost,
+		OperationID:   "create-scim-group",
+		Path:          SCIMPath("/Groups"),
+		Summary:       "Link a directory group to a role of the entity",
+		Tags:          []string{TagSCIM.Name},
+		DefaultStatus: http.StatusCreated,
+	}, v1.CreateSCIMGroup, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodGet,
+		OperationID: "get-scim-group",
+		Path:        SCIMPath("/Groups/{id}"),
+		Summary:     "Get a group of the entity",
+		Tags:        []string{TagSCIM.Name},
+	}, v1.GetSCIMGroup, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPut,
+		OperationID: "replace-scim-group",
+		Path:        SCIMPath("/Groups/{id}"),
+		Summary:     "Replace the members of a group of the entity",
+		Tags:        []string{TagSCIM.Name},
+	}, v1.ReplaceSCIMGroup, api.WithSCIMToken())
+
+	httpx.Register(api, huma.Operation{
+		Method:      http.MethodPatch,
+		OperationID: "patch-scim-group",
+		Path:        SCIMPath("/Groups/{id}"),
+		Summary:     "Add or remove members of a group of the entity",
+		Tags:        []string{TagSCIM.Name},
+	}, v1.PatchSCIMGroup, api.WithSCIMToken())
+
 	return nil
 }
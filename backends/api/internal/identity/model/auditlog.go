@@ -2,18 +2,169 @@ package model
 
 import (
 	"autopilot/backends/internal/types"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"time"
 )
 
+const (
+	// AuditLogExportLinkDuration is how long the audit log export download link is valid
+	AuditLogExportLinkDuration = 7 * 24 * time.Hour
+
+	// AuditLogExportMaxRange is the longest time range a single export may cover
+	AuditLogExportMaxRange = 366 * 24 * time.Hour
+
+	// AuditLogGenesisHash is the previous hash of the first entry of a chain
+	AuditLogGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	// AuditLogGlobalChain is the chain of the entries not taken in an entity
+	AuditLogGlobalChain = "00000000-0000-0000-0000-000000000000"
+)
+
+// AuditLogExportFormat is the file format of an audit log export
+type AuditLogExportFormat string
+
+const (
+	AuditLogExportFormatCSV    AuditLogExportFormat = "csv"
+	AuditLogExportFormatNDJSON AuditLogExportFormat = "ndjson"
+)
+
+// ContentType returns the MIME type of the export format
+func (f AuditLogExportFormat) ContentType() string {
+	if f == AuditLogExportFormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// AuditLogActorType is the kind of actor that took an audited action
+type AuditLogActorType string
+
+const (
+	AuditLogActorTypeAPIKey AuditLogActorType = "api_key" // An entity credential, such as a SCIM token
+	AuditLogActorTypeSystem AuditLogActorType = "system"  // A background job or an unauthenticated request
+	AuditLogActorTypeUser   AuditLogActorType = "user"
+)
+
 // AuditLog represents an audit log entry
 type AuditLog struct {
-	ID           string         `db:"id"`
-	Action       types.Action   `db:"action"`
-	ResourceID   string         `db:"resource_id"`
-	ResourceType types.Resource `db:"resource_type"`
-	IPAddress    *string        `db:"ip_address"`
-	Metadata     []byte         `db:"metadata"`
-	UserAgent    *string        `db:"user_agent"`
-	UserID       string         `db:"user_id"`
-	CreatedAt    time.Time      `db:"created_at"`
+	ID           string             `db:"id"`
+	Action       types.Action       `db:"action"`
+	ActorID      *string            `db:"actor_id"`   // The API key that acted, the user is in UserID
+	ActorType    *AuditLogActorType `db:"actor_type"` // Empty for entries written before actors were recorded
+	Changes      []byte             `db:"changes"`    // The before and after values of the changed fields
+	EntityID     *string            `db:"entity_id"`  // The entity the action was taken in, if any
+	ResourceID   string             `db:"resource_id"`
+	ResourceType types.Resource     `db:"resource_type"`
+	IPAddress    *string            `db:"ip_address"`
+	Metadata     []byte             `db:"metadata"`
+	UserAgent    *string            `db:"user_agent"`
+	UserID       *string            `db:"user_id"` // Cleared when the user is deleted
+	CreatedAt    time.Time          `db:"created_at"`
+
+	// Hash chain, empty for entries written before chaining was introduced
+	Sequence     *int64     `db:"sequence"` // The position in the chain, starting at 1
+	PreviousHash *string    `db:"previous_hash"`
+	PersonalHash *string    `db:"personal_hash"` // Hash of the personal data, which anonymization removes
+	Hash         *string    `db:"hash"`
+	AnonymizedAt *time.Time `db:"anonymized_at"`
+}
+
+// Chain returns the ID of the hash chain the entry belongs to
+func (l *AuditLog) Chain() string {
+	if l.EntityID == nil {
+		return AuditLogGlobalChain
+	}
+	return *l.EntityID
+}
+
+// ComputePersonalHash hashes the personal data of the entry. The metadata and
+// changes are re-encoded first, as the database doesn't preserve their
+// formatting. Fields added after chaining was introduced are left out when
+// empty, so earlier entries keep their hash.
+func (l *AuditLog) ComputePersonalHash() (string, error) {
+	var metadata, changes any
+	if len(l.Metadata) > 0 {
+		if err := json.Unmarshal(l.Metadata, &metadata); err != nil {
+			return "", err
+		}
+	}
+	if len(l.Changes) > 0 {
+		if err := json.Unmarshal(l.Changes, &changes); err != nil {
+			return "", err
+		}
+	}
+
+	return hashJSON(struct {
+		UserID    *string `json:"user_id"`
+		IPAddress *string `json:"ip_address"`
+		UserAgent *string `json:"user_agent"`
+		Metadata  any     `json:"metadata"`
+		Changes   any     `json:"changes,omitempty"`
+	}{l.UserID, l.IPAddress, l.UserAgent, metadata, changes})
+}
+
+// ComputeContentHash hashes the contents of the entry, with the personal data
+// represented by its hash.
+func (l *AuditLog) ComputeContentHash(personalHash string) (string, error) {
+	return hashJSON(struct {
+		ID           string             `json:"id"`
+		EntityID     *string            `json:"entity_id"`
+		Action       types.Action       `json:"action"`
+		ResourceType types.Resource     `json:"resource_type"`
+		ResourceID   string             `json:"resource_id"`
+		CreatedAt    string             `json:"created_at"`
+		PersonalHash string             `json:"personal_hash"`
+		ActorType    *AuditLogActorType `json:"actor_type,omitempty"`
+		ActorID      *string            `json:"actor_id,omitempty"`
+	}{
+		l.ID, l.EntityID, l.Action, l.ResourceType, l.ResourceID, l.CreatedAt.UTC().Format(time.RFC3339Nano), personalHash,
+		l.ActorType, l.ActorID,
+	})
+}
+
+// ChainAuditLogHash links the content hash of an entry to the hash of the
+// previous entry. The store computes the same hash in SQL when appending.
+func ChainAuditLogHash(previousHash, contentHash string) string {
+	sum := sha256.Sum256([]byte(previousHash + contentHash))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashJSON returns the hex encoded SHA-256 hash of the JSON encoding of v
+func hashJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// AuditLogChainHead is the latest entry of an audit log hash chain
+type AuditLogChainHead struct {
+	Chain     string    `db:"chain_id"`
+	Sequence  int64     `db:"sequence"`
+	Hash      string    `db:"hash"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// AuditLogFilter narrows down the audit log entries listed or exported. Zero
+// values match everything.
+type AuditLogFilter struct {
+	UserID       string
+	ResourceType types.Resource
+	Action       types.Action
+	From         *time.Time // Inclusive
+	To           *time.Time // Exclusive
+}
+
+// AuditLogPage selects a page of audit log entries by cursor. The cursors are
+// entry IDs, which are time ordered, and only one of After or Before is set.
+type AuditLogPage struct {
+	After     string
+	Before    string
+	Ascending bool
+	Limit     int
 }
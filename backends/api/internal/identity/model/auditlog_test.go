@@ -0,0 +1,72 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogHash(t *testing.T) {
+	t.Parallel()
+
+	userID, ip := "0194844f-5b1e-7a52-9d4c-1b0c4b7d6a01", "203.0.113.7"
+	newLog := func(metadata string) *AuditLog {
+		return &AuditLog{
+			ID:           "01948450-988e-7976-a454-000000000001",
+			Action:       "update",
+			ResourceID:   "resource",
+			ResourceType: "user",
+			IPAddress:    &ip,
+			Metadata:     []byte(metadata),
+			UserID:       &userID,
+			CreatedAt:    time.Date(2026, 10, 18, 13, 0, 0, 123456000, time.FixedZone("", 3600)),
+		}
+	}
+
+	t.Run("should ignore the formatting of the metadata", func(t *testing.T) {
+		t.Parallel()
+
+		stored, err := newLog(`{"name": "Ada",   "email": "ada@example.com"}`).ComputePersonalHash()
+		require.NoError(t, err)
+		written, err := newLog(`{"email":"ada@example.com","name":"Ada"}`).ComputePersonalHash()
+		require.NoError(t, err)
+		assert.Equal(t, written, stored)
+	})
+
+	t.Run("should change when the personal data changes", func(t *testing.T) {
+		t.Parallel()
+
+		log := newLog(`{"name":"Ada"}`)
+		before, err := log.ComputePersonalHash()
+		require.NoError(t, err)
+
+		log.IPAddress = nil
+		after, err := log.ComputePersonalHash()
+		require.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("should hash the creation time in UTC", func(t *testing.T) {
+		t.Parallel()
+
+		log := newLog(`{}`)
+		local, err := log.ComputeContentHash("personal")
+		require.NoError(t, err)
+
+		log.CreatedAt = log.CreatedAt.UTC()
+		utc, err := log.ComputeContentHash("personal")
+		require.NoError(t, err)
+		assert.Equal(t, utc, local)
+	})
+
+	t.Run("should chain the hex encoded hashes as text", func(t *testing.T) {
+		t.Parallel()
+
+		sum := sha256.Sum256([]byte(AuditLogGenesisHash + "content"))
+		assert.Equal(t, hex.EncodeToString(sum[:]), ChainAuditLogHash(AuditLogGenesisHash, "content"))
+	})
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+type (
+	ComplianceStatus      string
+	ComplianceSubjectType string
+	ComplianceType        string
+)
+
+// ComplianceStatus constants
+const (
+	ComplianceStatusPending    ComplianceStatus = "pending"
+	ComplianceStatusInProgress ComplianceStatus = "in_progress"
+	ComplianceStatusApproved   ComplianceStatus = "approved"
+	ComplianceStatusRejected   ComplianceStatus = "rejected"
+)
+
+// ComplianceSubjectType constants
+const (
+	ComplianceSubjectEntity ComplianceSubjectType = "entity"
+)
+
+// ComplianceType constants
+const (
+	ComplianceTypeAML ComplianceType = "aml" // Anti-money laundering screening
+	ComplianceTypeKYB ComplianceType = "kyb" // Know your business
+	ComplianceTypeKYC ComplianceType = "kyc" // Know your customer
+)
+
+// ComplianceDocumentUploadDuration is how long the upload URL of a compliance document is valid
+const ComplianceDocumentUploadDuration = 15 * time.Minute
+
+// ComplianceDocumentDownloadDuration is how long the download URL of a compliance document is valid
+const ComplianceDocumentDownloadDuration = 15 * time.Minute
+
+// complianceTransitions are the statuses a reviewer can move a submitted record to
+var complianceTransitions = map[ComplianceStatus][]ComplianceStatus{
+	ComplianceStatusPending:    {ComplianceStatusInProgress, ComplianceStatusApproved, ComplianceStatusRejected},
+	ComplianceStatusInProgress: {ComplianceStatusApproved, ComplianceStatusRejected},
+}
+
+// ComplianceRecord represents a KYB, KYC or AML check of a subject
+type ComplianceRecord struct {
+	ID          string                `db:"id"`
+	SubjectType ComplianceSubjectType `db:"entity_type"`
+	SubjectID   string                `db:"entity_id"`
+	Type        ComplianceType        `db:"type"`
+	Status      ComplianceStatus      `db:"status"`
+	Metadata    []byte                `db:"metadata"` // The information submitted for review
+	Reason      *string               `db:"reason"`
+	SubmittedAt *time.Time            `db:"submitted_at"` // Empty while a draft
+	VerifiedAt  *time.Time            `db:"verified_at"`
+	VerifiedBy  *string               `db:"verified_by"`
+	CreatedAt   time.Time             `db:"created_at"`
+	UpdatedAt   time.Time             `db:"updated_at"`
+
+	Documents []*ComplianceDocument `db:"-"`
+}
+
+// IsEditable checks if the subject can change the record, which is only
+// possible until a reviewer picks it up or after it was rejected
+func (r *ComplianceRecord) IsEditable() bool {
+	if r.Status == ComplianceStatusRejected {
+		return true
+	}
+	return r.Status == ComplianceStatusPending && r.SubmittedAt == nil
+}
+
+// CanTransition checks if a reviewer can move the record to the status
+func (r *ComplianceRecord) CanTransition(status ComplianceStatus) bool {
+	return r.SubmittedAt != nil && slices.Contains(complianceTransitions[r.Status], status)
+}
+
+// ComplianceDocument represents a document uploaded for a compliance record
+type ComplianceDocument struct {
+	ID          string    `db:"id"`
+	RecordID    string    `db:"record_id"`
+	Key         string    `db:"key"` // The object key in identity storage
+	Name        string    `db:"name"`
+	ContentType string    `db:"content_type"`
+	CreatedBy   *string   `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// RequiredComplianceTypes returns the records that must be approved before an
+// entity of the type can become active
+func RequiredComplianceTypes(entityType EntityType) []ComplianceType {
+	if entityType == EntityTypePlatform {
+		return nil
+	}
+	return []ComplianceType{ComplianceTypeKYB}
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplianceRecordCanTransition(t *testing.T) {
+	t.Parallel()
+
+	submittedAt := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		status      ComplianceStatus
+		submittedAt *time.Time
+		to          ComplianceStatus
+		want        bool
+	}{
+		{
+			name:        "should start the review of a submitted record",
+			status:      ComplianceStatusPending,
+			submittedAt: &submittedAt,
+			to:          ComplianceStatusInProgress,
+			want:        true,
+		},
+		{
+			name:        "should approve a record under review",
+			status:      ComplianceStatusInProgress,
+			submittedAt: &submittedAt,
+			to:          ComplianceStatusApproved,
+			want:        true,
+		},
+		{
+			name:   "should not review a draft",
+			status: ComplianceStatusPending,
+			to:     ComplianceStatusApproved,
+		},
+		{
+			name:        "should not reopen a decided record",
+			status:      ComplianceStatusApproved,
+			submittedAt: &submittedAt,
+			to:          ComplianceStatusRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			record := &ComplianceRecord{Status: tt.status, SubmittedAt: tt.submittedAt}
+			assert.Equal(t, tt.want, record.CanTransition(tt.to))
+		})
+	}
+}
+
+func TestComplianceRecordIsEditable(t *testing.T) {
+	t.Parallel()
+
+	submittedAt := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	assert.True(t, (&ComplianceRecord{Status: ComplianceStatusPending}).IsEditable())
+	assert.True(t, (&ComplianceRecord{Status: ComplianceStatusRejected, SubmittedAt: &submittedAt}).IsEditable())
+	assert.False(t, (&ComplianceRecord{Status: ComplianceStatusPending, SubmittedAt: &submittedAt}).IsEditable())
+	assert.False(t, (&ComplianceRecord{Status: ComplianceStatusApproved, SubmittedAt: &submittedAt}).IsEditable())
+}
@@ -26,16 +26,18 @@ const (
 
 // Entity represents a entity in the system
 type Entity struct {
-	ID        string       `db:"id"`
-	Domain    *string      `db:"domain"`
-	Logo      *string      `db:"logo"`
-	Name      string       `db:"name"`
-	ParentID  *string      `db:"parent_id"`
-	Slug      string       `db:"slug"`
-	Status    EntityStatus `db:"status"`
-	Type      EntityType   `db:"type"`
-	CreatedAt time.Time    `db:"created_at"`
-	UpdatedAt time.Time    `db:"updated_at"`
+	ID           string       `db:"id"`
+	Domain       *string      `db:"domain"`
+	Logo         *string      `db:"logo"`
+	Name         string       `db:"name"`
+	ParentID     *string      `db:"parent_id"`
+	Slug         string       `db:"slug"`
+	Status       EntityStatus `db:"status"`
+	Type         EntityType   `db:"type"`
+	CreatedAt    time.Time    `db:"created_at"`
+	UpdatedAt    time.Time    `db:"updated_at"`
+	BrandColor   *string      `db:"brand_color"` // Lowercase hex colour, such as #0070f3
+	SupportEmail *string      `db:"support_email"`
 }
 
 // IsActive checks if the entity is active
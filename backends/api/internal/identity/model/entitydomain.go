@@ -0,0 +1,66 @@
+package model
+
+import (
+	"autopilot/backends/internal/types"
+	"strings"
+	"time"
+)
+
+const (
+	// EntityDomainRecordPrefix is prepended to a domain to name the DNS TXT
+	// record proving control of it
+	EntityDomainRecordPrefix = "_autopilot-verification."
+
+	// EntityDomainTokenPrefix is prepended to the verification token in the
+	// value of the DNS TXT record
+	EntityDomainTokenPrefix = "autopilot-verification="
+)
+
+// EntityDomain represents a domain claimed by an entity. Once verified, no
+// other entity can claim it, and users with an email address at the domain
+// can join the entity if it allows automatic joining.
+type EntityDomain struct {
+	ID                string     `db:"id"`
+	EntityID          string     `db:"entity_id"`
+	Domain            string     `db:"domain"` // Lowercase
+	VerificationToken string     `db:"verification_token"`
+	VerifiedAt        *time.Time `db:"verified_at"`
+	AutoJoin          bool       `db:"auto_join"`    // Whether users at the domain can join the entity
+	DefaultRole       types.Role `db:"default_role"` // Role of users joining through the domain
+	CreatedBy         *string    `db:"created_by"`
+	CreatedAt         time.Time  `db:"created_at"`
+	UpdatedAt         time.Time  `db:"updated_at"`
+}
+
+// IsVerified checks if the entity has proven control of the domain
+func (d *EntityDomain) IsVerified() bool {
+	return d.VerifiedAt != nil
+}
+
+// RecordName returns the name of the DNS TXT record proving control of the domain
+func (d *EntityDomain) RecordName() string {
+	return EntityDomainRecordPrefix + d.Domain
+}
+
+// RecordValue returns the value of the DNS TXT record proving control of the domain
+func (d *EntityDomain) RecordValue() string {
+	return EntityDomainTokenPrefix + d.VerificationToken
+}
+
+// EmailDomain returns the lowercase domain of an email address, or an empty
+// string if it has none
+func EmailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+
+	return strings.ToLower(email[at+1:])
+}
+
+// JoinableEntity is an entity a user can join through the verified domain of
+// their email address
+type JoinableEntity struct {
+	Entity *Entity
+	Domain *EntityDomain
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// FailedSignIn tracks the failed sign-in attempts against an email address
+// without an account. Such addresses are locked out like accounts, so the
+// responses don't reveal whether an account exists.
+type FailedSignIn struct {
+	EmailHash      string     `db:"email_hash"`
+	FailedAttempts int        `db:"failed_attempts"`
+	LockoutCount   int        `db:"lockout_count"`
+	LockedUntil    *time.Time `db:"locked_until"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// HashSignInEmail hashes a normalized email address, so addresses typed in
+// by mistake aren't stored.
+func HashSignInEmail(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsLocked checks if the address is locked out of signing in at the given time
+func (f *FailedSignIn) IsLocked(at time.Time) bool {
+	return f.LockedUntil != nil && at.Before(*f.LockedUntil)
+}
+
+// RecordFailedAttempt counts a failed sign-in attempt at the given time, with
+// the same lockout tiers as User.RecordFailedLogin.
+func (f *FailedSignIn) RecordFailedAttempt(at time.Time) {
+	f.FailedAttempts++
+	f.UpdatedAt = at
+	if f.FailedAttempts < MaxFailedLoginAttempts {
+		return
+	}
+
+	f.FailedAttempts = 0
+	f.LockoutCount++
+	lockedUntil := at.Add(AccountLockoutDuration(f.LockoutCount))
+	f.LockedUntil = &lockedUntil
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ImageLinkDuration is how long the links image URLs redirect to are valid
+const ImageLinkDuration = time.Hour
+
+// ImageUploadKey returns the storage key of an uploaded image waiting to be
+// processed. Images are identified by the hash of their upload.
+func ImageUploadKey(id string) string {
+	return "uploads/images/" + id
+}
+
+// ImageVariantKey returns the storage key of a variant of a processed image
+func ImageVariantKey(id string, size int) string {
+	return fmt.Sprintf("images/%s/%d.png", id, size)
+}
+
+// ImageURL returns the stable URL of a processed image, which redirects to
+// its variants
+func ImageURL(baseURL, id string) string {
+	return strings.TrimRight(baseURL, "/") + "/v1/identity/images/" + id
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// IPAllowlistMaxEntries is the most address ranges an entity can allow
+const IPAllowlistMaxEntries = 100
+
+// IPAllowlistEntry is an address range an entity allows access from
+type IPAllowlistEntry struct {
+	ID          string    `db:"id"`
+	EntityID    string    `db:"entity_id"`
+	CIDR        string    `db:"cidr"`
+	Description *string   `db:"description"`
+	CreatedBy   *string   `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// IPAllowlist is the list of address ranges an entity allows access from. An
+// empty list allows any address.
+type IPAllowlist []*IPAllowlistEntry
+
+// Allows checks if the list allows the IP address. Unparsable addresses are
+// only allowed by an empty list.
+func (l IPAllowlist) Allows(ip string) bool {
+	if len(l) == 0 {
+		return true
+	}
+
+	addr, ok := parseClientIP(ip)
+	if !ok {
+		return false
+	}
+
+	for _, entry := range l {
+		prefix, err := netip.ParsePrefix(entry.CIDR)
+		if err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseIPRange parses an address range in CIDR notation, or a single address,
+// and returns it in canonical CIDR notation with the host bits cleared.
+func ParseIPRange(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if addr, err := netip.ParseAddr(s); err == nil {
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()).String(), true
+	}
+
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return "", false
+	}
+
+	if prefix.Addr().Is4In6() {
+		prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		if !prefix.IsValid() {
+			return "", false
+		}
+	}
+
+	return prefix.Masked().String(), true
+}
+
+// parseClientIP parses the client IP address of a request, which may carry a port
+func parseClientIP(ip string) (netip.Addr, bool) {
+	ip = strings.TrimSpace(ip)
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+
+	return addr.Unmap(), true
+}
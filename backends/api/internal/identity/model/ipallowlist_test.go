@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPAllowlistAllows(t *testing.T) {
+	t.Parallel()
+
+	allowlist := IPAllowlist{{CIDR: "203.0.113.0/24"}, {CIDR: "2001:db8::/32"}}
+	tests := []struct {
+		name      string
+		allowlist IPAllowlist
+		ip        string
+		want      bool
+	}{
+		{name: "should allow any address without entries", ip: "198.51.100.7", want: true},
+		{name: "should allow an address in a range", allowlist: allowlist, ip: "203.0.113.42", want: true},
+		{name: "should allow an address with a port", allowlist: allowlist, ip: "203.0.113.42:51234", want: true},
+		{name: "should allow an IPv4-mapped IPv6 address", allowlist: allowlist, ip: "::ffff:203.0.113.42", want: true},
+		{name: "should allow an IPv6 address in a range", allowlist: allowlist, ip: "2001:db8::1", want: true},
+		{name: "should deny an address outside the ranges", allowlist: allowlist, ip: "198.51.100.7"},
+		{name: "should deny an unparsable address", allowlist: allowlist, ip: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.allowlist.Allows(tt.ip))
+		})
+	}
+}
+
+func TestParseIPRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{name: "should keep a canonical range", input: "10.0.0.0/8", want: "10.0.0.0/8", wantOK: true},
+		{name: "should clear the host bits", input: " 203.0.113.9/24 ", want: "203.0.113.0/24", wantOK: true},
+		{name: "should turn an address into a single address range", input: "2001:db8::1", want: "2001:db8::1/128", wantOK: true},
+		{name: "should unmap an IPv4-mapped range", input: "::ffff:203.0.113.0/120", want: "203.0.113.0/24", wantOK: true},
+		{name: "should reject an invalid range", input: "10.0.0.0/33"},
+		{name: "should reject a hostname", input: "example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ParseIPRange(tt.input)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
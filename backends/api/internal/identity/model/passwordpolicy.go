@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+)
+
+// PasswordHistoryMaxSize is the most previous passwords a policy can ban reusing
+const PasswordHistoryMaxSize = 10
+
+// PasswordPolicy represents the password requirements of the members of an
+// entity, on top of the character classes and length every password needs
+// and the ban on breached passwords.
+type PasswordPolicy struct {
+	EntityID         string    `db:"entity_id"`
+	MinStrengthScore int       `db:"min_strength_score"` // From 0 to password.MaxScore
+	HistorySize      int       `db:"history_size"`       // Number of previous passwords that can't be reused
+	UpdatedBy        *string   `db:"updated_by"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+// StrictestPasswordPolicy combines the policies of the entities a user belongs
+// to into the strictest requirements of each. Without policies, only the
+// requirements every password needs apply.
+func StrictestPasswordPolicy(policies ...*PasswordPolicy) *PasswordPolicy {
+	strictest := &PasswordPolicy{}
+	for _, policy := range policies {
+		strictest.MinStrengthScore = max(strictest.MinStrengthScore, policy.MinStrengthScore)
+		strictest.HistorySize = max(strictest.HistorySize, policy.HistorySize)
+	}
+	return strictest
+}
+
+// PasswordHistoryEntry is the hash of a password a user has set
+type PasswordHistoryEntry struct {
+	ID           string    `db:"id"`
+	UserID       string    `db:"user_id"`
+	PasswordHash string    `db:"password_hash"`
+	CreatedAt    time.Time `db:"created_at"`
+}
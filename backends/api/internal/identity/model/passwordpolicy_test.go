@@ -0,0 +1,17 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictestPasswordPolicy(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, &PasswordPolicy{}, StrictestPasswordPolicy())
+	assert.Equal(t, &PasswordPolicy{MinStrengthScore: 3, HistorySize: 5}, StrictestPasswordPolicy(
+		&PasswordPolicy{EntityID: "a", MinStrengthScore: 3, HistorySize: 2},
+		&PasswordPolicy{EntityID: "b", MinStrengthScore: 1, HistorySize: 5},
+	))
+}
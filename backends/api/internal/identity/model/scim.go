@@ -0,0 +1,68 @@
+package model
+
+import (
+	"autopilot/backends/internal/types"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"time"
+)
+
+// SCIMTokenPrefix is the prefix of SCIM bearer tokens, making leaked tokens easy to recognise
+const SCIMTokenPrefix = "scim_"
+
+// SCIMGroupRoles are the roles exposed to SCIM clients as groups. Owners are
+// managed in the dashboard only, so a directory can never grant full access.
+var SCIMGroupRoles = []types.Role{types.RoleAdmin, types.RoleViewer}
+
+// SCIMToken represents a bearer token of an entity's SCIM provisioning client
+type SCIMToken struct {
+	ID         string     `db:"id"`
+	EntityID   string     `db:"entity_id"`
+	Name       string     `db:"name"`
+	TokenHash  string     `db:"token_hash"` // SHA-256 hash of the token
+	CreatedBy  *string    `db:"created_by"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// HashSCIMToken returns the hash a SCIM token is stored and looked up by
+func HashSCIMToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// SCIMUser represents a user as seen by the SCIM client of an entity. The user
+// is active while they have a membership in the entity.
+type SCIMUser struct {
+	ID         string     `db:"id"` // The user's ID
+	Name       string     `db:"name"`
+	Email      string     `db:"email"`
+	ExternalID *string    `db:"external_id"`  // The ID of the user in the directory
+	Role       types.Role `db:"role"`         // The membership role, empty if inactive
+	IsCreated  bool       `db:"created_user"` // Whether the SCIM client created the user, rather than linking an existing one
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+// IsActive checks if the user has a membership in the entity
+func (u *SCIMUser) IsActive() bool {
+	return u.Role != types.RoleNone
+}
+
+// SCIMUserFilter narrows down the users listed to a SCIM client
+type SCIMUserFilter struct {
+	Email      string
+	ExternalID string
+}
+
+// SCIMGroup represents a role of an entity exposed to SCIM clients as a group
+type SCIMGroup struct {
+	Role      types.Role
+	MemberIDs []string // The IDs of the users with the role
+}
+
+// IsSCIMGroupRole checks if the role is exposed to SCIM clients as a group
+func IsSCIMGroupRole(role types.Role) bool {
+	return slices.Contains(SCIMGroupRoles, role)
+}
@@ -2,9 +2,27 @@ package model
 
 import (
 	"autopilot/backends/internal/types"
+	"strings"
 	"time"
 )
 
+const (
+	// DeviceClassBot represents automated clients such as crawlers and scripts
+	DeviceClassBot = "bot"
+
+	// DeviceClassDesktop represents desktop and laptop browsers
+	DeviceClassDesktop = "desktop"
+
+	// DeviceClassMobile represents mobile phone browsers and apps
+	DeviceClassMobile = "mobile"
+
+	// DeviceClassTablet represents tablet browsers and apps
+	DeviceClassTablet = "tablet"
+
+	// DeviceClassUnknown represents a missing or unrecognised user agent
+	DeviceClassUnknown = "unknown"
+)
+
 // Session represents a user session
 type Session struct {
 	ID                 string        `db:"id"`
@@ -20,6 +38,14 @@ type Session struct {
 	UserAgent          *string       `db:"user_agent"`
 	CreatedAt          time.Time     `db:"created_at"`
 	UpdatedAt          time.Time     `db:"updated_at"`
+
+	// Impersonation sessions let a platform member see what the user sees
+	ImpersonatorID       *string `db:"impersonator_id"`
+	ImpersonatorEntityID *string `db:"impersonator_entity_id"`
+
+	LastActiveAt       time.Time `db:"last_active_at"`       // Updated at most every SessionActivityInterval
+	AuthenticatedAt    time.Time `db:"authenticated_at"`     // When the user signed in, kept across refreshes
+	IdleTimeoutSeconds *int      `db:"idle_timeout_seconds"` // From the session policy when the session was created
 }
 
 // HasPermission checks if the session's active member has the given permission
@@ -41,6 +67,51 @@ func (s *Session) Role(entityID string) types.Role {
 	return types.RoleNone
 }
 
+// DeviceClass returns the class of device the session was created from
+func (s *Session) DeviceClass() string {
+	if s.UserAgent == nil {
+		return DeviceClassUnknown
+	}
+	return DeviceClass(*s.UserAgent)
+}
+
+// DeviceClass classifies a user agent into a coarse device class. The result
+// is intentionally coarse so that browser and OS upgrades are not treated as a
+// new device.
+func DeviceClass(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return DeviceClassUnknown
+	case strings.Contains(ua, "bot"), strings.Contains(ua, "crawler"), strings.Contains(ua, "spider"),
+		strings.Contains(ua, "curl/"), strings.Contains(ua, "wget/"):
+		return DeviceClassBot
+	case strings.Contains(ua, "ipad"), strings.Contains(ua, "tablet"),
+		strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return DeviceClassTablet
+	case strings.Contains(ua, "mobi"), strings.Contains(ua, "iphone"), strings.Contains(ua, "ipod"):
+		return DeviceClassMobile
+	case strings.Contains(ua, "windows"), strings.Contains(ua, "macintosh"), strings.Contains(ua, "linux"),
+		strings.Contains(ua, "cros"):
+		return DeviceClassDesktop
+	}
+	return DeviceClassUnknown
+}
+
+// IsImpersonation checks if the session impersonates its user
+func (s *Session) IsImpersonation() bool {
+	return s.ImpersonatorID != nil
+}
+
+// IsIdle checks if the session has gone without activity for longer than its
+// idle timeout at the given time
+func (s *Session) IsIdle(at time.Time) bool {
+	if s.IdleTimeoutSeconds == nil {
+		return false
+	}
+	return at.Sub(s.LastActiveAt) > time.Duration(*s.IdleTimeoutSeconds)*time.Second
+}
+
 // IsExpired checks if the session has expired
 func (s *Session) IsExpired() bool {
 	return time.Now().After(s.ExpiresAt)
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceClass(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{
+			name: "should classify an empty user agent as unknown",
+			want: DeviceClassUnknown,
+		},
+		{
+			name:      "should classify a desktop browser on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36",
+			want:      DeviceClassDesktop,
+		},
+		{
+			name:      "should classify a desktop browser on macOS",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_6) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.0 Safari/605.1.15",
+			want:      DeviceClassDesktop,
+		},
+		{
+			name:      "should classify a desktop browser on Linux",
+			userAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0",
+			want:      DeviceClassDesktop,
+		},
+		{
+			name:      "should classify a Chromebook as a desktop",
+			userAgent: "Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36",
+			want:      DeviceClassDesktop,
+		},
+		{
+			name:      "should classify an iPhone as a mobile",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 18_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.0 Mobile/15E148 Safari/604.1",
+			want:      DeviceClassMobile,
+		},
+		{
+			name:      "should classify an Android phone as a mobile",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Mobile Safari/537.36",
+			want:      DeviceClassMobile,
+		},
+		{
+			name:      "should classify an iPad as a tablet",
+			userAgent: "Mozilla/5.0 (iPad; CPU OS 18_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.0 Mobile/15E148 Safari/604.1",
+			want:      DeviceClassTablet,
+		},
+		{
+			name:      "should classify an Android device without mobile as a tablet",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; SM-X910) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36",
+			want:      DeviceClassTablet,
+		},
+		{
+			name:      "should classify a crawler as a bot",
+			userAgent: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want:      DeviceClassBot,
+		},
+		{
+			name:      "should classify curl as a bot",
+			userAgent: "curl/8.7.1",
+			want:      DeviceClassBot,
+		},
+		{
+			name:      "should classify an unrecognised user agent as unknown",
+			userAgent: "okhttp/4.12.0",
+			want:      DeviceClassUnknown,
+		},
+		{
+			name:      "should ignore browser and OS versions",
+			userAgent: "Mozilla/5.0 (Windows NT 6.1) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/49.0.2623.112 Safari/537.36",
+			want:      DeviceClassDesktop,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, DeviceClass(tt.userAgent))
+		})
+	}
+}
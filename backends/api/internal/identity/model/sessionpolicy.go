@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+)
+
+// SessionPolicy represents the session limits of the members of an entity.
+// Limits that aren't set don't apply.
+type SessionPolicy struct {
+	EntityID              string    `db:"entity_id"`
+	IdleTimeoutMinutes    *int      `db:"idle_timeout_minutes"`    // Minutes without activity before a session ends
+	MaxSessionMinutes     *int      `db:"max_session_minutes"`     // Minutes from signing in until the user must sign in again
+	MaxConcurrentSessions *int      `db:"max_concurrent_sessions"` // Most sessions a member can have at once
+	UpdatedBy             *string   `db:"updated_by"`
+	CreatedAt             time.Time `db:"created_at"`
+	UpdatedAt             time.Time `db:"updated_at"`
+}
+
+// StrictestSessionPolicy combines the policies of the entities a user belongs
+// to into the strictest limit of each.
+func StrictestSessionPolicy(policies ...*SessionPolicy) *SessionPolicy {
+	strictest := &SessionPolicy{}
+	for _, policy := range policies {
+		strictest.IdleTimeoutMinutes = minLimit(strictest.IdleTimeoutMinutes, policy.IdleTimeoutMinutes)
+		strictest.MaxSessionMinutes = minLimit(strictest.MaxSessionMinutes, policy.MaxSessionMinutes)
+		strictest.MaxConcurrentSessions = minLimit(strictest.MaxConcurrentSessions, policy.MaxConcurrentSessions)
+	}
+	return strictest
+}
+
+// IdleTimeout returns how long a session lasts without activity, or 0 without a limit
+func (p *SessionPolicy) IdleTimeout() time.Duration {
+	if p.IdleTimeoutMinutes == nil {
+		return 0
+	}
+	return time.Duration(*p.IdleTimeoutMinutes) * time.Minute
+}
+
+// MaxSessionLength returns how long after signing in a session ends, or 0 without a limit
+func (p *SessionPolicy) MaxSessionLength() time.Duration {
+	if p.MaxSessionMinutes == nil {
+		return 0
+	}
+	return time.Duration(*p.MaxSessionMinutes) * time.Minute
+}
+
+// Apply sets the limits of the policy on a new session. The session and its
+// refresh token end no later than the maximum session length after the user
+// signed in.
+func (p *SessionPolicy) Apply(session *Session) {
+	session.IdleTimeoutSeconds = nil
+	if idleTimeout := p.IdleTimeout(); idleTimeout > 0 {
+		seconds := int(idleTimeout / time.Second)
+		session.IdleTimeoutSeconds = &seconds
+	}
+
+	if maxLength := p.MaxSessionLength(); maxLength > 0 {
+		end := session.AuthenticatedAt.Add(maxLength)
+		if session.ExpiresAt.After(end) {
+			session.ExpiresAt = end
+		}
+		if session.RefreshExpiresAt.After(end) {
+			session.RefreshExpiresAt = end
+		}
+	}
+}
+
+// minLimit returns the lower of two optional limits
+func minLimit(a, b *int) *int {
+	switch {
+	case a == nil:
+		return b
+	case b == nil || *a <= *b:
+		return a
+	default:
+		return b
+	}
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictestSessionPolicy(t *testing.T) {
+	t.Parallel()
+
+	limit := func(n int) *int { return &n }
+
+	assert.Equal(t, &SessionPolicy{}, StrictestSessionPolicy())
+	assert.Equal(t, &SessionPolicy{
+		IdleTimeoutMinutes:    limit(15),
+		MaxSessionMinutes:     limit(480),
+		MaxConcurrentSessions: limit(3),
+	}, StrictestSessionPolicy(
+		&SessionPolicy{EntityID: "a", IdleTimeoutMinutes: limit(60), MaxConcurrentSessions: limit(3)},
+		&SessionPolicy{EntityID: "b", IdleTimeoutMinutes: limit(15), MaxSessionMinutes: limit(480)},
+		&SessionPolicy{EntityID: "c"},
+	))
+}
+
+func TestSessionPolicyApply(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	limit := func(n int) *int { return &n }
+
+	tests := []struct {
+		name              string
+		policy            *SessionPolicy
+		authenticatedAt   time.Time
+		wantExpiresAt     time.Time
+		wantRefreshExpiry time.Time
+		wantIdleSeconds   *int
+	}{
+		{
+			name:              "should leave a session without limits untouched",
+			policy:            &SessionPolicy{},
+			authenticatedAt:   now,
+			wantExpiresAt:     now.Add(24 * time.Hour),
+			wantRefreshExpiry: now.Add(30 * 24 * time.Hour),
+		},
+		{
+			name:              "should set the idle timeout",
+			policy:            &SessionPolicy{IdleTimeoutMinutes: limit(30)},
+			authenticatedAt:   now,
+			wantExpiresAt:     now.Add(24 * time.Hour),
+			wantRefreshExpiry: now.Add(30 * 24 * time.Hour),
+			wantIdleSeconds:   limit(1800),
+		},
+		{
+			name:              "should end the session and refresh token at the maximum session length",
+			policy:            &SessionPolicy{MaxSessionMinutes: limit(480)},
+			authenticatedAt:   now,
+			wantExpiresAt:     now.Add(8 * time.Hour),
+			wantRefreshExpiry: now.Add(8 * time.Hour),
+		},
+		{
+			name:              "should count the maximum session length from signing in",
+			policy:            &SessionPolicy{MaxSessionMinutes: limit(48 * 60)},
+			authenticatedAt:   now.Add(-40 * time.Hour),
+			wantExpiresAt:     now.Add(8 * time.Hour),
+			wantRefreshExpiry: now.Add(8 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			session := &Session{
+				AuthenticatedAt:  tt.authenticatedAt,
+				ExpiresAt:        now.Add(24 * time.Hour),
+				RefreshExpiresAt: now.Add(30 * 24 * time.Hour),
+			}
+			tt.policy.Apply(session)
+
+			assert.Equal(t, tt.wantExpiresAt, session.ExpiresAt)
+			assert.Equal(t, tt.wantRefreshExpiry, session.RefreshExpiresAt)
+			assert.Equal(t, tt.wantIdleSeconds, session.IdleTimeoutSeconds)
+		})
+	}
+}
+
+func TestSessionIsIdle(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	timeout := 900
+
+	assert.False(t, (&Session{LastActiveAt: now.Add(-time.Hour)}).IsIdle(now), "sessions without an idle timeout never idle out")
+	assert.False(t, (&Session{LastActiveAt: now.Add(-10 * time.Minute), IdleTimeoutSeconds: &timeout}).IsIdle(now))
+	assert.True(t, (&Session{LastActiveAt: now.Add(-20 * time.Minute), IdleTimeoutSeconds: &timeout}).IsIdle(now))
+}
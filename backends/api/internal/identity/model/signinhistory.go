@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// SignInHistory is a country and device class a user signed in from. It
+// outlives the sessions, so that a sign-in is only new when the user has
+// never signed in from there before.
+type SignInHistory struct {
+	UserID      string    `db:"user_id"`
+	Country     string    `db:"country"`
+	DeviceClass string    `db:"device_class"`
+	FirstSeenAt time.Time `db:"first_seen_at"`
+	LastSeenAt  time.Time `db:"last_seen_at"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"autopilot/backends/internal/types"
+	"strings"
+	"time"
+)
+
+// SSOConnection represents the OpenID Connect single sign-on connection of an entity
+type SSOConnection struct {
+	ID             string     `db:"id"`
+	EntityID       string     `db:"entity_id"`
+	Issuer         string     `db:"issuer"`
+	ClientID       string     `db:"client_id"`
+	ClientSecret   string     `db:"client_secret"`   // Encrypted with the identity encryption key
+	AllowedDomains []string   `db:"allowed_domains"` // JSONB array of email domains
+	DefaultRole    types.Role `db:"default_role"`    // Role of just-in-time provisioned members
+	IsEnforced     bool       `db:"is_enforced"`     // Whether members can only sign in through SSO
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// AllowsEmail checks if the domain of the email address is allowed to sign in
+// through the connection
+func (c *SSOConnection) AllowsEmail(email string) bool {
+	domain := EmailDomain(email)
+	if domain == "" {
+		return false
+	}
+
+	for _, allowed := range c.AllowedDomains {
+		if strings.EqualFold(allowed, domain) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// TrustedDeviceDuration is how long a device skips two-factor authentication
+// after the user chose to trust it
+const TrustedDeviceDuration = 30 * 24 * time.Hour
+
+// TrustedDevice is a browser a user chose to trust while verifying their
+// second factor. It is bound to the class of device it was trusted from.
+type TrustedDevice struct {
+	ID          string     `db:"id"`
+	UserID      string     `db:"user_id"`
+	DeviceClass string     `db:"device_class"`
+	IPAddress   *string    `db:"ip_address"`
+	Country     *string    `db:"country"`
+	UserAgent   *string    `db:"user_agent"`
+	ExpiresAt   time.Time  `db:"expires_at"`
+	LastUsedAt  *time.Time `db:"last_used_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// IsExpired checks if the trust has expired at the given time
+func (d *TrustedDevice) IsExpired(at time.Time) bool {
+	return !at.Before(d.ExpiresAt)
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"sync"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -8,6 +9,22 @@ import (
 
 const (
 	PasswordHashBcryptCost = 12
+
+	// AccountLockoutBaseDuration is how long the first lockout of an account
+	// lasts, every further lockout doubles it
+	AccountLockoutBaseDuration = 15 * time.Minute
+
+	// AccountLockoutMaxDuration is the longest an account is locked for
+	AccountLockoutMaxDuration = 24 * time.Hour
+
+	// MaxFailedLoginAttempts is the number of failed sign-in attempts that locks an account
+	MaxFailedLoginAttempts = 5
+
+	// AccountDeletionGracePeriod is how long a scheduled deletion can be cancelled
+	AccountDeletionGracePeriod = 30 * 24 * time.Hour
+
+	// DataExportLinkDuration is how long the data export download link is valid
+	DataExportLinkDuration = 7 * 24 * time.Hour
 )
 
 // User represents a user in the system
@@ -21,8 +38,11 @@ type User struct {
 	LastActiveAt        *time.Time `db:"last_active_at"`
 	LastLoggedInAt      *time.Time `db:"last_logged_in_at"`
 	LockedAt            *time.Time `db:"locked_at"`
+	LockedUntil         *time.Time `db:"locked_until"`
+	LockoutCount        int        `db:"lockout_count"`
 	PasswordChangedAt   *time.Time `db:"password_changed_at"`
 	PasswordHash        *string    `db:"password_hash"`
+	DeletionScheduledAt *time.Time `db:"deletion_scheduled_at"`
 	CreatedAt           time.Time  `db:"created_at"`
 	UpdatedAt           time.Time  `db:"updated_at"`
 }
@@ -37,17 +57,81 @@ func (u *User) HasPassword() bool {
 	return u.PasswordHash != nil
 }
 
+// IsDeletionScheduled checks if the user account is scheduled for deletion
+func (u *User) IsDeletionScheduled() bool {
+	return u.DeletionScheduledAt != nil
+}
+
 // IsEmailVerified checks if the user's email is verified
 func (u *User) IsEmailVerified() bool {
 	return u.EmailVerifiedAt != nil
 }
 
-// IsLocked checks if the user account is locked at the given time
+// IsLocked checks if the user account is locked out of signing in at the given time
 func (u *User) IsLocked(at time.Time) bool {
-	return u.LockedAt != nil && u.LockedAt.After(at)
+	return u.LockedUntil != nil && at.Before(*u.LockedUntil)
 }
 
+// RecordFailedLogin counts a failed sign-in attempt at the given time. After
+// MaxFailedLoginAttempts the account is locked for the next lockout tier and
+// the attempts start over. It reports whether the attempt locked the account.
+func (u *User) RecordFailedLogin(at time.Time) bool {
+	u.FailedLoginAttempts++
+	u.UpdatedAt = at
+	if u.FailedLoginAttempts < MaxFailedLoginAttempts {
+		return false
+	}
+
+	u.FailedLoginAttempts = 0
+	u.LockoutCount++
+	lockedUntil := at.Add(AccountLockoutDuration(u.LockoutCount))
+	u.LockedAt = &at
+	u.LockedUntil = &lockedUntil
+	return true
+}
+
+// Unlock clears the lock and the failed sign-in attempts of the account, so
+// the next lockout starts from the first tier again.
+func (u *User) Unlock(at time.Time) {
+	u.FailedLoginAttempts = 0
+	u.LockoutCount = 0
+	u.LockedAt = nil
+	u.LockedUntil = nil
+	u.UpdatedAt = at
+}
+
+// dummyPasswordHash is compared against when there's no password to verify,
+// generated on first use at the cost of the real hashes
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("dummy password"), PasswordHashBcryptCost)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
 // VerifyPassword verifies the user's password
 func (u *User) VerifyPassword(password string) bool {
+	if u.PasswordHash == nil {
+		VerifyDummyPassword(password)
+		return false
+	}
 	return bcrypt.CompareHashAndPassword([]byte(*u.PasswordHash), []byte(password)) == nil
 }
+
+// VerifyDummyPassword takes as long as verifying a password, so that signing
+// in doesn't reveal whether an account exists or has a password
+func VerifyDummyPassword(password string) {
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+}
+
+// AccountLockoutDuration returns how long the nth consecutive lockout of an
+// account lasts, doubling from AccountLockoutBaseDuration up to
+// AccountLockoutMaxDuration.
+func AccountLockoutDuration(lockoutCount int) time.Duration {
+	duration := AccountLockoutBaseDuration
+	for i := 1; i < lockoutCount && duration < AccountLockoutMaxDuration; i++ {
+		duration *= 2
+	}
+	return min(duration, AccountLockoutMaxDuration)
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountLockoutDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		lockoutCount int
+		want         time.Duration
+	}{
+		{name: "should lock for the base duration the first time", lockoutCount: 1, want: 15 * time.Minute},
+		{name: "should double the duration for every further lockout", lockoutCount: 3, want: time.Hour},
+		{name: "should cap the duration", lockoutCount: 8, want: AccountLockoutMaxDuration},
+		{name: "should cap the duration without overflowing", lockoutCount: 100, want: AccountLockoutMaxDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, AccountLockoutDuration(tt.lockoutCount))
+		})
+	}
+}
+
+func TestUserRecordFailedLogin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should lock the account after the maximum attempts", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 10, 18, 17, 0, 0, 0, time.UTC)
+		user := &User{}
+		for range MaxFailedLoginAttempts - 1 {
+			assert.False(t, user.RecordFailedLogin(now))
+		}
+		assert.False(t, user.IsLocked(now))
+
+		assert.True(t, user.RecordFailedLogin(now))
+		assert.True(t, user.IsLocked(now))
+		assert.False(t, user.IsLocked(now.Add(AccountLockoutBaseDuration)))
+		assert.Equal(t, 0, user.FailedLoginAttempts)
+		assert.Equal(t, 1, user.LockoutCount)
+		assert.Equal(t, &now, user.LockedAt)
+	})
+
+	t.Run("should lock for the next tier after a lockout expires", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 10, 18, 17, 0, 0, 0, time.UTC)
+		user := &User{LockoutCount: 1, FailedLoginAttempts: MaxFailedLoginAttempts - 1}
+		assert.True(t, user.RecordFailedLogin(now))
+		assert.Equal(t, now.Add(2*AccountLockoutBaseDuration), *user.LockedUntil)
+	})
+
+	t.Run("should start over from the first tier once unlocked", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 10, 18, 17, 0, 0, 0, time.UTC)
+		lockedUntil := now.Add(time.Hour)
+		user := &User{LockoutCount: 3, FailedLoginAttempts: 2, LockedAt: &now, LockedUntil: &lockedUntil}
+		user.Unlock(now)
+		assert.False(t, user.IsLocked(now))
+		assert.Equal(t, User{UpdatedAt: now}, *user)
+	})
+}
+
+func TestFailedSignInRecordFailedAttempt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 17, 0, 0, 0, time.UTC)
+	failedSignIn := &FailedSignIn{LockoutCount: 1}
+	for range MaxFailedLoginAttempts {
+		assert.False(t, failedSignIn.IsLocked(now))
+		failedSignIn.RecordFailedAttempt(now)
+	}
+
+	assert.True(t, failedSignIn.IsLocked(now))
+	assert.Equal(t, now.Add(AccountLockoutDuration(2)), *failedSignIn.LockedUntil)
+	assert.Equal(t, HashSignInEmail("Ada@Example.com "), HashSignInEmail("ada@example.com"))
+}
@@ -1,19 +1,61 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 const (
+	// VerificationContextAccountUnlock represents the unlock link sent when an account is locked
+	VerificationContextAccountUnlock = "account_unlock"
+
+	// VerificationContextEmailChange represents the confirmation of a new email address
+	VerificationContextEmailChange = "email_change"
+
+	// VerificationContextEmailChangeRevert represents the revert link sent to the previous email address
+	VerificationContextEmailChangeRevert = "email_change_revert"
+
 	// VerificationContextEmailVerification represents email verification context
 	VerificationContextEmailVerification = "email_verification"
 
+	// VerificationContextMagicLink represents a passwordless sign-in link
+	VerificationContextMagicLink = "magic_link"
+
 	// VerificationContextPasswordReset represents password reset context
 	VerificationContextPasswordReset = "password_reset"
 
+	// VerificationContextSSOState represents the state of a pending single sign-on
+	VerificationContextSSOState = "sso_state"
+
+	// VerificationContextSignInRevoke represents the "this wasn't me" link sent on a new sign-in
+	VerificationContextSignInRevoke = "sign_in_revoke"
+
+	// AccountUnlockDuration is the duration for which account unlock links are valid
+	AccountUnlockDuration = AccountLockoutMaxDuration
+
+	// EmailChangeDuration is the duration for which email change confirmation links are valid
+	EmailChangeDuration = 24 * time.Hour
+
+	// EmailChangeRevertDuration is the duration for which email change revert links are valid
+	EmailChangeRevertDuration = 7 * 24 * time.Hour
+
 	// EmailVerificationDuration is the duration for which email verification links are valid
 	EmailVerificationDuration = 24 * time.Hour
 
+	// EmailVerificationResendInterval is the shortest time between two verification emails to a user
+	EmailVerificationResendInterval = time.Minute
+
+	// MagicLinkDuration is the duration for which passwordless sign-in links are valid
+	MagicLinkDuration = 15 * time.Minute
+
 	// PasswordResetDuration is the duration for which password reset links are valid
 	PasswordResetDuration = 1 * time.Hour
+
+	// SSOStateDuration is the duration for which a single sign-on has to be completed
+	SSOStateDuration = 10 * time.Minute
+
+	// SignInRevokeDuration is the duration for which "this wasn't me" links are valid
+	SignInRevokeDuration = 7 * 24 * time.Hour
 )
 
 // Verification represents an email or other verification process
@@ -30,3 +72,31 @@ type Verification struct {
 func (v *Verification) IsExpired() bool {
 	return time.Now().After(v.ExpiresAt)
 }
+
+// NewUserScopedValue joins a user ID and a value into a verification value so
+// that the verification can only be applied to that user.
+func NewUserScopedValue(userID, value string) string {
+	return userID + ":" + value
+}
+
+// UserScopedValue splits a value created by NewUserScopedValue into the user ID
+// and the original value.
+func (v *Verification) UserScopedValue() (userID string, value string, ok bool) {
+	return strings.Cut(v.Value, ":")
+}
+
+// NewSSOStateValue joins the connection ID, PKCE code verifier and nonce of a
+// pending single sign-on into a verification value.
+func NewSSOStateValue(connectionID, codeVerifier, nonce string) string {
+	return connectionID + ":" + codeVerifier + ":" + nonce
+}
+
+// SSOState splits a value created by NewSSOStateValue.
+func (v *Verification) SSOState() (connectionID, codeVerifier, nonce string, ok bool) {
+	parts := strings.Split(v.Value, ":")
+	if len(parts) != 3 {
+		return "", "", "", false
+	}
+
+	return parts[0], parts[1], parts[2], true
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"autopilot/backends/api/pkg/app"
+	"context"
+	"fmt"
+
+	"github.com/riverqueue/river"
+)
+
+// AccountDeleterArgs is the arguments for the account deleter
+type AccountDeleterArgs struct{}
+
+// Kind returns the kind of the worker
+func (AccountDeleterArgs) Kind() string {
+	return "account_deleter"
+}
+
+// AccountDeleter is a worker that deletes accounts whose grace period has ended
+type AccountDeleter struct {
+	*app.Container
+	service *Manager
+	river.WorkerDefaults[AccountDeleterArgs]
+}
+
+// Work is the worker function that deletes the scheduled accounts
+func (w *AccountDeleter) Work(ctx context.Context, job *river.Job[AccountDeleterArgs]) error {
+	w.Logger.Info("Starting scheduled account deletion")
+
+	if err := w.service.User.DeleteScheduled(ctx); err != nil {
+		w.Logger.Error("Failed to delete scheduled accounts", "error", err)
+		return fmt.Errorf("deleting scheduled accounts: %w", err)
+	}
+
+	w.Logger.Info("Successfully deleted scheduled accounts")
+	return nil
+}
@@ -0,0 +1,596 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/api/pkg/middleware"
+	"autopilot/backends/internal/core"
+	"autopilot/backends/internal/types"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// auditLogExportBatchSize is the number of entries read at a time while exporting
+	auditLogExportBatchSize = 1000
+
+	// auditLogVerifyBatchSize is the number of entries read at a time while verifying a chain
+	auditLogVerifyBatchSize = 1000
+
+	// auditLogCheckpointPrefix is the storage prefix of the signed checkpoints, one per day
+	auditLogCheckpointPrefix = "audit-logs/checkpoints/"
+)
+
+// AuditLoger is an interface that wraps the AuditLog methods
+type AuditLoger interface {
+	Checkpoint(ctx context.Context) error
+	Export(ctx context.Context, args AuditLogExportArgs) error
+	List(ctx context.Context, entityID string, filter model.AuditLogFilter, params httpx.CursorPaginationParams) (*AuditLogList, error)
+	RequestExport(ctx context.Context, entityID, userID string, filter model.AuditLogFilter, format model.AuditLogExportFormat) error
+	Verify(ctx context.Context, chain string) ([]*AuditLogVerification, error)
+}
+
+// AuditLogList is a page of audit log entries. A cursor is empty if there is
+// no page in its direction.
+type AuditLogList struct {
+	Logs           []*model.AuditLog
+	NextCursor     string
+	PreviousCursor string
+}
+
+// AuditLogVerification is the result of verifying an audit log hash chain.
+type AuditLogVerification struct {
+	Chain        string
+	Entries      int64      // The number of entries verified
+	BrokenAt     int64      // The sequence of the first broken link, 0 if the chain is intact
+	Reason       string     // Why the link is broken
+	CheckpointAt *time.Time // When the checkpoint the chain was checked against was taken, if any
+}
+
+// IsIntact checks if every link of the chain holds
+func (v *AuditLogVerification) IsIntact() bool {
+	return v.BrokenAt == 0
+}
+
+// auditLogCheckpoint records the heads of all audit log chains at a point in
+// time. It is signed and kept in object storage, out of reach of anyone able to
+// rewrite the database, so a chain rewritten from scratch is still detected.
+type auditLogCheckpoint struct {
+	CreatedAt time.Time                `json:"created_at"`
+	Heads     []auditLogCheckpointHead `json:"heads"`
+}
+
+type auditLogCheckpointHead struct {
+	Chain    string `json:"chain"`
+	Sequence int64  `json:"sequence"`
+	Hash     string `json:"hash"`
+}
+
+// signedAuditLogCheckpoint is a checkpoint as stored in object storage
+type signedAuditLogCheckpoint struct {
+	Checkpoint json.RawMessage `json:"checkpoint"`
+	Signature  []byte          `json:"signature"` // Ed25519 signature of the checkpoint
+}
+
+// AuditLog is the service for audit log operations.
+type AuditLog struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewAuditLog creates a new AuditLog service.
+func NewAuditLog(container *app.Container, store *store.Manager) AuditLoger {
+	return &AuditLog{
+		Container: container,
+		store:     store,
+	}
+}
+
+// List lists a page of the audit log entries of an entity.
+func (s *AuditLog) List(ctx context.Context, entityID string, filter model.AuditLogFilter, params httpx.CursorPaginationParams) (*AuditLogList, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, httpx.ErrInvalidTimeRange
+	}
+
+	for _, cursor := range []string{params.After, params.Before} {
+		if cursor == "" {
+			continue
+		}
+		if _, err := uuid.Parse(cursor); err != nil {
+			return nil, httpx.ErrInvalidCursor
+		}
+	}
+
+	// One extra entry is read to tell if there is another page
+	logs, err := s.store.AuditLog.List(ctx, entityID, filter, model.AuditLogPage{
+		After:     params.After,
+		Before:    params.Before,
+		Ascending: params.Direction == httpx.SortAsc,
+		Limit:     params.PageSize + 1,
+	})
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	backwards := params.Before != ""
+	more := len(logs) > params.PageSize
+	if more && backwards {
+		logs = logs[1:]
+	} else if more {
+		logs = logs[:params.PageSize]
+	}
+
+	list := &AuditLogList{Logs: logs}
+	if len(logs) == 0 {
+		return list, nil
+	}
+
+	// Coming from a cursor means there is a page on the other side of it
+	if more || backwards {
+		list.NextCursor = logs[len(logs)-1].ID
+	}
+	if (more && backwards) || params.After != "" {
+		list.PreviousCursor = logs[0].ID
+	}
+
+	return list, nil
+}
+
+// RequestExport queues an export of the audit log entries of an entity. The
+// requester is emailed a download link once the export is ready.
+func (s *AuditLog) RequestExport(ctx context.Context, entityID, userID string, filter model.AuditLogFilter, format model.AuditLogExportFormat) error {
+	if filter.From == nil || filter.To == nil || !filter.From.Before(*filter.To) ||
+		filter.To.Sub(*filter.From) > model.AuditLogExportMaxRange {
+		return httpx.ErrInvalidTimeRange
+	}
+
+	if _, err := s.Worker.Insert(ctx, AuditLogExportArgs{
+		Action:       filter.Action,
+		EntityID:     entityID,
+		Format:       format,
+		From:         *filter.From,
+		Locale:       middleware.GetLocale(ctx),
+		RequestedBy:  userID,
+		ResourceType: filter.ResourceType,
+		To:           *filter.To,
+		UserID:       filter.UserID,
+	}, nil); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"action":        filter.Action,
+		"format":        format,
+		"from":          filter.From,
+		"resource_type": filter.ResourceType,
+		"to":            filter.To,
+		"user_id":       filter.UserID,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceAuditLog, types.ActionExport, entityID, userID, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Export writes the audit log entries selected by the arguments to a file in
+// storage and emails a signed download link to the requester.
+func (s *AuditLog) Export(ctx context.Context, args AuditLogExportArgs) error {
+	user, err := s.store.User.GetByID(ctx, args.RequestedBy)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return httpx.ErrUserNotFound
+	}
+
+	entity, err := s.store.Entity.GetByID(ctx, args.EntityID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if entity == nil {
+		return httpx.ErrEntityNotFound
+	}
+
+	// Exports can be large, so they are spooled to disk rather than memory
+	file, err := os.CreateTemp("", "audit-log-export-*")
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	filter := model.AuditLogFilter{
+		UserID:       args.UserID,
+		ResourceType: args.ResourceType,
+		Action:       args.Action,
+		From:         &args.From,
+		To:           &args.To,
+	}
+	if err := s.write(ctx, file, args.EntityID, filter, args.Format); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	token, err := generateSecureToken(16)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	key := fmt.Sprintf("audit-logs/%s/%s.%s", entity.ID, strings.TrimRight(token, "="), args.Format)
+	if _, err := s.Storage.Identity.Upload(ctx, key, file, &core.ObjectMetadata{
+		ContentType: args.Format.ContentType(),
+	}); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	download, err := s.Storage.Identity.GenerateDownloadURL(ctx, key, model.AuditLogExportLinkDuration)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	// Jobs run outside of a request, so the locale of the requester is restored
+	ctx = context.WithValue(ctx, middleware.LocaleKey, args.Locale)
+	// The export is sent on behalf of the entity, so it carries its branding
+	queueMail(ctx, s.Container, "audit_log_export", user.Email, fmt.Sprintf("Your %s audit log export is ready", s.Config.App.Name), brandMail(map[string]any{
+		"DownloadURL": download.URL,
+		"Duration":    model.AuditLogExportLinkDuration.Hours() / 24,
+		"EntityName":  entity.Name,
+		"Name":        user.Name,
+	}, entity))
+
+	return nil
+}
+
+// Checkpoint signs the current heads of all audit log chains and stores them
+// in object storage under the current date, replacing any earlier checkpoint of
+// the same day.
+func (s *AuditLog) Checkpoint(ctx context.Context) error {
+	key, err := s.signingKey()
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	heads, err := s.store.AuditLog.ListChainHeads(ctx)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	checkpoint := auditLogCheckpoint{
+		CreatedAt: time.Now().UTC(),
+		Heads:     make([]auditLogCheckpointHead, 0, len(heads)),
+	}
+	for _, head := range heads {
+		checkpoint.Heads = append(checkpoint.Heads, auditLogCheckpointHead{
+			Chain:    head.Chain,
+			Sequence: head.Sequence,
+			Hash:     head.Hash,
+		})
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	signed, err := json.Marshal(signedAuditLogCheckpoint{
+		Checkpoint: data,
+		Signature:  ed25519.Sign(key, data),
+	})
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	name := auditLogCheckpointPrefix + checkpoint.CreatedAt.Format(time.DateOnly) + ".json"
+	if _, err := s.Storage.Identity.Upload(ctx, name, bytes.NewReader(signed), &core.ObjectMetadata{
+		ContentType: "application/json",
+	}); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	s.Logger.Info("Stored audit log checkpoint", "key", name, "chains", len(heads))
+	return nil
+}
+
+// Verify walks an audit log chain, or every chain if chain is empty, and
+// reports the first broken link of each. Chains are also checked against the
+// latest signed checkpoint, which catches a chain that was rewritten in full.
+func (s *AuditLog) Verify(ctx context.Context, chain string) ([]*AuditLogVerification, error) {
+	checkpoint, err := s.latestCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var chains []string
+	if chain != "" {
+		chains = []string{chain}
+	} else {
+		heads, err := s.store.AuditLog.ListChainHeads(ctx)
+		if err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+		for _, head := range heads {
+			chains = append(chains, head.Chain)
+		}
+	}
+
+	verifications := make([]*AuditLogVerification, 0, len(chains))
+	for _, chain := range chains {
+		verification, err := s.verifyChain(ctx, chain, checkpoint)
+		if err != nil {
+			return nil, err
+		}
+		verifications = append(verifications, verification)
+	}
+
+	return verifications, nil
+}
+
+// verifyChain verifies the links of a chain up to its current head.
+func (s *AuditLog) verifyChain(ctx context.Context, chain string, checkpoint *auditLogCheckpoint) (*AuditLogVerification, error) {
+	verification := &AuditLogVerification{Chain: chain}
+	broken := func(sequence int64, reason string) (*AuditLogVerification, error) {
+		verification.BrokenAt = sequence
+		verification.Reason = reason
+		return verification, nil
+	}
+
+	var checkpointed *auditLogCheckpointHead
+	if checkpoint != nil {
+		verification.CheckpointAt = &checkpoint.CreatedAt
+		for i := range checkpoint.Heads {
+			if checkpoint.Heads[i].Chain == chain {
+				checkpointed = &checkpoint.Heads[i]
+			}
+		}
+	}
+
+	// The head is read first, so entries appended while walking are ignored
+	head, err := s.store.AuditLog.GetChainHead(ctx, chain)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if head == nil {
+		if checkpointed != nil {
+			return broken(1, "the chain recorded in the checkpoint is missing")
+		}
+		return verification, nil
+	}
+
+	sequence, previous := int64(0), model.AuditLogGenesisHash
+	for sequence < head.Sequence {
+		logs, err := s.store.AuditLog.ListChain(ctx, chain, sequence, auditLogVerifyBatchSize)
+		if err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+		if len(logs) == 0 {
+			break
+		}
+
+		for _, log := range logs {
+			if *log.Sequence > head.Sequence {
+				break
+			}
+
+			sequence++
+			if *log.Sequence != sequence {
+				return broken(sequence, "the entry is missing")
+			}
+			if deref(log.PreviousHash) != previous {
+				return broken(sequence, "the previous hash doesn't match the previous entry")
+			}
+
+			// The personal data of anonymized entries is gone, so its hash is taken as is
+			personalHash := deref(log.PersonalHash)
+			if log.AnonymizedAt == nil {
+				computed, err := log.ComputePersonalHash()
+				if err != nil || computed != personalHash {
+					return broken(sequence, "the personal data of the entry was changed")
+				}
+			}
+
+			contentHash, err := log.ComputeContentHash(personalHash)
+			if err != nil || model.ChainAuditLogHash(previous, contentHash) != deref(log.Hash) {
+				return broken(sequence, "the contents of the entry were changed")
+			}
+
+			if checkpointed != nil && checkpointed.Sequence == sequence && checkpointed.Hash != deref(log.Hash) {
+				return broken(sequence, "the hash doesn't match the checkpoint")
+			}
+
+			previous = deref(log.Hash)
+			verification.Entries++
+		}
+	}
+
+	if sequence < head.Sequence {
+		return broken(sequence+1, "the entry is missing")
+	}
+	if previous != head.Hash {
+		return broken(head.Sequence, "the hash doesn't match the chain head")
+	}
+	if checkpointed != nil && checkpointed.Sequence > head.Sequence {
+		return broken(head.Sequence+1, "entries recorded in the checkpoint are missing")
+	}
+
+	return verification, nil
+}
+
+// latestCheckpoint retrieves the most recent checkpoint, returning nil if none
+// was taken yet. A checkpoint with an invalid signature is an error, as it
+// can't be trusted to verify anything.
+func (s *AuditLog) latestCheckpoint(ctx context.Context) (*auditLogCheckpoint, error) {
+	objects, err := s.Storage.Identity.List(ctx, auditLogCheckpointPrefix)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if len(objects) == 0 {
+		return nil, nil
+	}
+
+	// Checkpoints are named by date, so the latest sorts last
+	latest := slices.MaxFunc(objects, func(a, b *core.ObjectMetadata) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	reader, _, err := s.Storage.Identity.Download(ctx, latest.Key)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	defer reader.Close()
+
+	var signed signedAuditLogCheckpoint
+	if err := json.NewDecoder(reader).Decode(&signed); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	key, err := s.signingKey()
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if !ed25519.Verify(key.Public().(ed25519.PublicKey), signed.Checkpoint, signed.Signature) {
+		return nil, httpx.ErrUnknown.WithInternal(fmt.Errorf("invalid signature of audit log checkpoint %s", latest.Key))
+	}
+
+	var checkpoint auditLogCheckpoint
+	if err := json.Unmarshal(signed.Checkpoint, &checkpoint); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return &checkpoint, nil
+}
+
+// signingKey returns the key signing the audit log checkpoints
+func (s *AuditLog) signingKey() (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(s.Config.Identity.AuditLogSigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding audit log signing key: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("audit log signing key must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// auditLogRecord is an audit log entry as written to exports
+type auditLogRecord struct {
+	ID           string                   `json:"id"`
+	CreatedAt    time.Time                `json:"created_at"`
+	ActorType    *model.AuditLogActorType `json:"actor_type"`
+	ActorID      *string                  `json:"actor_id"`
+	UserID       *string                  `json:"user_id"`
+	Action       types.Action             `json:"action"`
+	ResourceType types.Resource           `json:"resource_type"`
+	ResourceID   string                   `json:"resource_id"`
+	IPAddress    *string                  `json:"ip_address"`
+	UserAgent    *string                  `json:"user_agent"`
+	Changes      json.RawMessage          `json:"changes"`
+	Metadata     json.RawMessage          `json:"metadata"`
+}
+
+// auditLogCSVHeader is the header row of CSV exports, matching the fields of auditLogRecord
+var auditLogCSVHeader = []string{
+	"id", "created_at", "actor_type", "actor_id", "user_id", "action", "resource_type", "resource_id", "ip_address", "user_agent",
+	"changes", "metadata",
+}
+
+// write writes the entries matching the filter to w in the format, oldest first.
+func (s *AuditLog) write(ctx context.Context, w io.Writer, entityID string, filter model.AuditLogFilter, format model.AuditLogExportFormat) error {
+	csvWriter := csv.NewWriter(w)
+	encoder := json.NewEncoder(w)
+
+	if format == model.AuditLogExportFormatCSV {
+		if err := csvWriter.Write(auditLogCSVHeader); err != nil {
+			return err
+		}
+	}
+
+	page := model.AuditLogPage{Ascending: true, Limit: auditLogExportBatchSize}
+	for {
+		logs, err := s.store.AuditLog.List(ctx, entityID, filter, page)
+		if err != nil {
+			return err
+		}
+
+		for _, log := range logs {
+			record := auditLogRecord{
+				ID:           log.ID,
+				CreatedAt:    log.CreatedAt,
+				ActorType:    log.ActorType,
+				ActorID:      log.ActorID,
+				UserID:       log.UserID,
+				Action:       log.Action,
+				ResourceType: log.ResourceType,
+				ResourceID:   log.ResourceID,
+				IPAddress:    log.IPAddress,
+				UserAgent:    log.UserAgent,
+				Changes:      log.Changes,
+				Metadata:     log.Metadata,
+			}
+			if len(record.Changes) == 0 {
+				record.Changes = json.RawMessage("{}")
+			}
+			if len(record.Metadata) == 0 {
+				record.Metadata = json.RawMessage("{}")
+			}
+
+			if format == model.AuditLogExportFormatCSV {
+				err = csvWriter.Write([]string{
+					record.ID,
+					record.CreatedAt.UTC().Format(time.RFC3339Nano),
+					string(deref(record.ActorType)),
+					deref(record.ActorID),
+					deref(record.UserID),
+					string(record.Action),
+					string(record.ResourceType),
+					record.ResourceID,
+					deref(record.IPAddress),
+					deref(record.UserAgent),
+					string(record.Changes),
+					string(record.Metadata),
+				})
+			} else {
+				err = encoder.Encode(record)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(logs) < page.Limit {
+			break
+		}
+		page.After = logs[len(logs)-1].ID
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// deref returns the value of a pointer, or the zero value if nil
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuditLogs(ids ...int) []*model.AuditLog {
+	logs := make([]*model.AuditLog, 0, len(ids))
+	for _, id := range ids {
+		logs = append(logs, &model.AuditLog{ID: fmt.Sprintf("01948450-988e-7976-a454-%012d", id)})
+	}
+	return logs
+}
+
+func TestAuditLogList(t *testing.T) {
+	t.Parallel()
+
+	cursor := newAuditLogs(50)[0].ID
+	tests := []struct {
+		name         string
+		params       httpx.CursorPaginationParams
+		stored       []*model.AuditLog
+		wantLogs     []*model.AuditLog
+		wantNext     string
+		wantPrevious string
+	}{
+		{
+			name:     "should return a next cursor on the first page when there are more entries",
+			params:   httpx.CursorPaginationParams{PageSize: 2},
+			stored:   newAuditLogs(9, 8, 7),
+			wantLogs: newAuditLogs(9, 8),
+			wantNext: newAuditLogs(8)[0].ID,
+		},
+		{
+			name:     "should return no cursors when all entries fit",
+			params:   httpx.CursorPaginationParams{PageSize: 2},
+			stored:   newAuditLogs(9, 8),
+			wantLogs: newAuditLogs(9, 8),
+		},
+		{
+			name:         "should return both cursors in the middle when paging forwards",
+			params:       httpx.CursorPaginationParams{After: cursor, PageSize: 2},
+			stored:       newAuditLogs(6, 5, 4),
+			wantLogs:     newAuditLogs(6, 5),
+			wantNext:     newAuditLogs(5)[0].ID,
+			wantPrevious: newAuditLogs(6)[0].ID,
+		},
+		{
+			name:         "should drop the entry furthest from the cursor when paging backwards",
+			params:       httpx.CursorPaginationParams{Before: cursor, PageSize: 2},
+			stored:       newAuditLogs(3, 2, 1),
+			wantLogs:     newAuditLogs(2, 1),
+			wantNext:     newAuditLogs(1)[0].ID,
+			wantPrevious: newAuditLogs(2)[0].ID,
+		},
+		{
+			name:     "should return no previous cursor on the first page when paging backwards",
+			params:   httpx.CursorPaginationParams{Before: cursor, PageSize: 2},
+			stored:   newAuditLogs(2, 1),
+			wantLogs: newAuditLogs(2, 1),
+			wantNext: newAuditLogs(1)[0].ID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			auditLogStore := mocks.NewMockAuditLoger(t)
+			auditLogStore.EXPECT().
+				List(mock.Anything, "entity", model.AuditLogFilter{}, model.AuditLogPage{
+					After:  tt.params.After,
+					Before: tt.params.Before,
+					Limit:  tt.params.PageSize + 1,
+				}).
+				Return(tt.stored, nil)
+
+			s := &AuditLog{store: &store.Manager{AuditLog: auditLogStore}}
+			list, err := s.List(context.Background(), "entity", model.AuditLogFilter{}, tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLogs, list.Logs)
+			assert.Equal(t, tt.wantNext, list.NextCursor)
+			assert.Equal(t, tt.wantPrevious, list.PreviousCursor)
+		})
+	}
+
+	t.Run("should reject invalid cursors and time ranges", func(t *testing.T) {
+		t.Parallel()
+
+		s := &AuditLog{store: &store.Manager{AuditLog: mocks.NewMockAuditLoger(t)}}
+		_, err := s.List(context.Background(), "entity", model.AuditLogFilter{}, httpx.CursorPaginationParams{After: "nope", PageSize: 10})
+		assert.ErrorIs(t, err, httpx.ErrInvalidCursor)
+
+		now := time.Now()
+		_, err = s.List(context.Background(), "entity", model.AuditLogFilter{From: &now, To: &now}, httpx.CursorPaginationParams{PageSize: 10})
+		assert.ErrorIs(t, err, httpx.ErrInvalidTimeRange)
+	})
+}
+
+func TestDiffAuditLog(t *testing.T) {
+	t.Parallel()
+
+	changedAt := time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC)
+	sameAt := changedAt
+
+	tests := []struct {
+		name   string
+		before map[string]any
+		after  map[string]any
+		want   map[string]auditLogFieldChange
+	}{
+		{
+			name:   "should only record the changed fields",
+			before: map[string]any{"name": "Ada", "image": ""},
+			after:  map[string]any{"name": "Ada Lovelace", "image": ""},
+			want:   map[string]auditLogFieldChange{"name": {Before: "Ada", After: "Ada Lovelace"}},
+		},
+		{
+			name:   "should compare pointers by value",
+			before: map[string]any{"password_changed_at": &changedAt},
+			after:  map[string]any{"password_changed_at": &sameAt},
+			want:   map[string]auditLogFieldChange{},
+		},
+		{
+			name:   "should record fields missing from one side",
+			before: map[string]any{"role": "admin"},
+			after:  map[string]any{"status": "active"},
+			want: map[string]auditLogFieldChange{
+				"role":   {Before: "admin"},
+				"status": {After: "active"},
+			},
+		},
+		{
+			name: "should record nothing without values",
+			want: map[string]auditLogFieldChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, diffAuditLog(tt.before, tt.after))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"autopilot/backends/api/pkg/app"
+	"context"
+	"fmt"
+
+	"github.com/riverqueue/river"
+)
+
+// AuditLogCheckpointArgs is the arguments for the audit log checkpointer
+type AuditLogCheckpointArgs struct{}
+
+// Kind returns the kind of the worker
+func (AuditLogCheckpointArgs) Kind() string {
+	return "identity.audit_log_checkpoint"
+}
+
+// AuditLogCheckpointer is a worker that stores a signed checkpoint of the audit log chains
+type AuditLogCheckpointer struct {
+	*app.Container
+	service *Manager
+	river.WorkerDefaults[AuditLogCheckpointArgs]
+}
+
+// Work is the worker function that stores a signed checkpoint of the audit log chains
+func (w *AuditLogCheckpointer) Work(ctx context.Context, job *river.Job[AuditLogCheckpointArgs]) error {
+	if err := w.service.AuditLog.Checkpoint(ctx); err != nil {
+		w.Logger.Error("Failed to checkpoint audit logs", "error", err)
+		return fmt.Errorf("checkpointing audit logs: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/internal/types"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/riverqueue/river"
+)
+
+// AuditLogExportArgs is the arguments for the audit log exporter
+type AuditLogExportArgs struct {
+	Action       types.Action
+	EntityID     string
+	Format       model.AuditLogExportFormat
+	From         time.Time
+	Locale       string
+	RequestedBy  string // The user emailed the export
+	ResourceType types.Resource
+	To           time.Time
+	UserID       string // Only export the entries of this user, if set
+}
+
+// Kind returns the kind of the worker
+func (AuditLogExportArgs) Kind() string {
+	return "identity.audit_log_export"
+}
+
+// AuditLogExporter is a worker that exports the audit logs of an entity
+type AuditLogExporter struct {
+	*app.Container
+	service *Manager
+	river.WorkerDefaults[AuditLogExportArgs]
+}
+
+// Work is the worker function that exports the audit logs of an entity
+func (w *AuditLogExporter) Work(ctx context.Context, job *river.Job[AuditLogExportArgs]) error {
+	if err := w.service.AuditLog.Export(ctx, job.Args); err != nil {
+		w.Logger.Error("Failed to export audit logs", "entity_id", job.Args.EntityID, "error", err)
+		return fmt.Errorf("exporting audit logs: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/core"
+	"autopilot/backends/internal/types"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Compliancer is an interface that wraps the Compliance methods
+type Compliancer interface {
+	AddDocument(ctx context.Context, entityID, userID string, recordType model.ComplianceType, name, contentType string) (*model.ComplianceDocument, *core.UploadInfo, error)
+	DeleteDocument(ctx context.Context, entityID, userID, documentID string) error
+	GetReview(ctx context.Context, platformID, id string) (*model.ComplianceRecord, error)
+	List(ctx context.Context, entityID string) ([]*model.ComplianceRecord, error)
+	ListReviews(ctx context.Context, platformID string, status model.ComplianceStatus) ([]*model.ComplianceRecord, error)
+	Review(ctx context.Context, platformID, reviewerID, id string, status model.ComplianceStatus, reason string) (*model.ComplianceRecord, error)
+	SubmitKYB(ctx context.Context, entityID, userID string, information KYBInformation) (*model.ComplianceRecord, error)
+}
+
+// KYBInformation is the business information an entity submits for KYB
+type KYBInformation struct {
+	LegalName          string `json:"legal_name"`
+	RegistrationNumber string `json:"registration_number"`
+	Country            string `json:"country"` // ISO 3166-1 alpha-2 code
+	Address            string `json:"address"`
+	Website            string `json:"website,omitempty"`
+}
+
+// Compliance is the service for KYB, KYC and AML record operations. Entities
+// fill in their records, and members of a platform entity review the records
+// of the entities below it.
+type Compliance struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewCompliance creates a new Compliance service.
+func NewCompliance(container *app.Container, store *store.Manager) Compliancer {
+	return &Compliance{
+		Container: container,
+		store:     store,
+	}
+}
+
+// List lists the compliance records of an entity with their documents.
+func (s *Compliance) List(ctx context.Context, entityID string) ([]*model.ComplianceRecord, error) {
+	records, err := s.store.Compliance.ListBySubject(ctx, model.ComplianceSubjectEntity, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	for _, record := range records {
+		if record.Documents, err = s.store.Compliance.ListDocuments(ctx, record.ID); err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+	}
+
+	return records, nil
+}
+
+// AddDocument registers a document of a compliance record of an entity and
+// returns the URL to upload it to. The record is created as a draft if needed.
+func (s *Compliance) AddDocument(ctx context.Context, entityID, userID string, recordType model.ComplianceType, name, contentType string) (*model.ComplianceDocument, *core.UploadInfo, error) {
+	record, err := s.getOrCreate(ctx, entityID, recordType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !record.IsEditable() {
+		return nil, nil, httpx.ErrComplianceRecordLocked
+	}
+
+	id := uuid.NewString()
+	key := fmt.Sprintf("compliance/%s/%s/%s", entityID, record.ID, id)
+	upload, err := s.Storage.Identity.GenerateUploadURL(ctx, key, contentType, model.ComplianceDocumentUploadDuration)
+	if err != nil {
+		return nil, nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	document, err := s.store.Compliance.CreateDocument(ctx, &model.ComplianceDocument{
+		ID:          id,
+		RecordID:    record.ID,
+		Key:         key,
+		Name:        name,
+		ContentType: contentType,
+		CreatedBy:   &userID,
+	})
+	if err != nil {
+		return nil, nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"content_type": contentType,
+		"document_id":  document.ID,
+		"type":         record.Type,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceCompliance, types.ActionCreate, record.ID, userID, metadata); err != nil {
+		return nil, nil, err
+	}
+
+	return document, upload, nil
+}
+
+// DeleteDocument removes a document of a compliance record of an entity.
+func (s *Compliance) DeleteDocument(ctx context.Context, entityID, userID, documentID string) error {
+	document, err := s.store.Compliance.GetDocument(ctx, documentID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if document == nil {
+		return httpx.ErrComplianceDocumentNotFound
+	}
+
+	record, err := s.store.Compliance.GetByID(ctx, document.RecordID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if record == nil || record.SubjectType != model.ComplianceSubjectEntity || record.SubjectID != entityID {
+		return httpx.ErrComplianceDocumentNotFound
+	}
+
+	if !record.IsEditable() {
+		return httpx.ErrComplianceRecordLocked
+	}
+
+	if err := s.store.Compliance.DeleteDocument(ctx, document.ID); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	// The upload may never have happened, and an orphaned object is harmless
+	if err := s.Storage.Identity.Delete(ctx, document.Key); err != nil {
+		s.Logger.Warn("Failed to delete compliance document from storage", "key", document.Key, "error", err)
+	}
+
+	metadata := map[string]any{
+		"document_id": document.ID,
+		"type":        record.Type,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceCompliance, types.ActionDelete, record.ID, userID, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SubmitKYB submits the KYB record of an entity for review. Every document
+// registered for the record must have been uploaded by then.
+func (s *Compliance) SubmitKYB(ctx context.Context, entityID, userID string, information KYBInformation) (*model.ComplianceRecord, error) {
+	record, err := s.getOrCreate(ctx, entityID, model.ComplianceTypeKYB)
+	if err != nil {
+		return nil, err
+	}
+
+	if !record.IsEditable() {
+		return nil, httpx.ErrComplianceRecordLocked
+	}
+
+	record.Documents, err = s.store.Compliance.ListDocuments(ctx, record.ID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if len(record.Documents) == 0 {
+		return nil, httpx.ErrComplianceDocumentsMissing
+	}
+	for _, document := range record.Documents {
+		if _, err := s.Storage.Identity.GetMetadata(ctx, document.Key); err != nil {
+			return nil, httpx.ErrComplianceDocumentsMissing.WithInternal(err)
+		}
+	}
+
+	metadata, err := json.Marshal(information)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	now := time.Now()
+	before := map[string]any{"status": record.Status}
+	record.Status = model.ComplianceStatusPending
+	record.Metadata = metadata
+	record.Reason = nil
+	record.SubmittedAt = &now
+	record.VerifiedAt = nil
+	record.VerifiedBy = nil
+	if err := s.store.Compliance.Update(ctx, record); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	after := map[string]any{"status": record.Status}
+	auditMetadata := map[string]any{
+		"documents": len(record.Documents),
+		"type":      record.Type,
+	}
+	if err := auditLogChange(ctx, s.store, types.ResourceCompliance, types.ActionUpdate, record.ID, userID, before, after, auditMetadata); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// ListReviews lists the submitted records of the entities below a platform,
+// oldest submission first. An empty status lists every status.
+func (s *Compliance) ListReviews(ctx context.Context, platformID string, status model.ComplianceStatus) ([]*model.ComplianceRecord, error) {
+	if err := checkPlatform(ctx, s.store, platformID); err != nil {
+		return nil, err
+	}
+
+	records, err := s.store.Compliance.ListForReview(ctx, platformID, status)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return records, nil
+}
+
+// GetReview retrieves a record under review by a platform with its documents.
+func (s *Compliance) GetReview(ctx context.Context, platformID, id string) (*model.ComplianceRecord, error) {
+	record, err := s.reviewable(ctx, platformID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.Documents, err = s.store.Compliance.ListDocuments(ctx, record.ID); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return record, nil
+}
+
+// Review moves a record under review by a platform to a new status. A decision
+// records the reviewer, and approving the last required record of a pending
+// entity activates it.
+func (s *Compliance) Review(ctx context.Context, platformID, reviewerID, id string, status model.ComplianceStatus, reason string) (*model.ComplianceRecord, error) {
+	record, err := s.reviewable(ctx, platformID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !record.CanTransition(status) {
+		return nil, httpx.ErrInvalidComplianceStatus
+	}
+
+	if status == model.ComplianceStatusRejected && reason == "" {
+		return nil, httpx.ErrRequired
+	}
+
+	before := map[string]any{"status": record.Status}
+	record.Status = status
+	if reason != "" {
+		record.Reason = &reason
+	}
+	if status != model.ComplianceStatusInProgress {
+		now := time.Now()
+		record.VerifiedAt = &now
+		record.VerifiedBy = &reviewerID
+	}
+
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		txStore := store.NewManager(tx)
+		if err := txStore.Compliance.Update(ctx, record); err != nil {
+			return err
+		}
+
+		after := map[string]any{"status": record.Status}
+		metadata := map[string]any{
+			"entity_id": record.SubjectID,
+			"reason":    reason,
+			"type":      record.Type,
+		}
+		if err := auditLogChange(ctx, txStore, types.ResourceCompliance, types.ActionVerify, record.ID, reviewerID, before, after, metadata); err != nil {
+			return err
+		}
+
+		if status != model.ComplianceStatusApproved {
+			return nil
+		}
+
+		entity, err := txStore.Entity.GetByID(ctx, record.SubjectID)
+		if err != nil {
+			return err
+		}
+		if entity == nil || entity.Status != model.EntityStatusPending {
+			return nil
+		}
+
+		compliant, err := isCompliant(ctx, txStore, entity)
+		if err != nil || !compliant {
+			return err
+		}
+
+		return updateEntityStatus(ctx, txStore, entity, model.EntityStatusActive, reviewerID)
+	})
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return record, nil
+}
+
+// getOrCreate retrieves the record of a type of an entity, creating a draft if
+// there is none yet.
+func (s *Compliance) getOrCreate(ctx context.Context, entityID string, recordType model.ComplianceType) (*model.ComplianceRecord, error) {
+	record, err := s.store.Compliance.GetBySubject(ctx, model.ComplianceSubjectEntity, entityID, recordType)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if record != nil {
+		return record, nil
+	}
+
+	record, err = s.store.Compliance.Create(ctx, &model.ComplianceRecord{
+		SubjectType: model.ComplianceSubjectEntity,
+		SubjectID:   entityID,
+		Type:        recordType,
+		Status:      model.ComplianceStatusPending,
+	})
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return record, nil
+}
+
+// reviewable retrieves a record a platform can review, which is the record of
+// an entity below the platform.
+func (s *Compliance) reviewable(ctx context.Context, platformID, id string) (*model.ComplianceRecord, error) {
+	if err := checkPlatform(ctx, s.store, platformID); err != nil {
+		return nil, err
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, httpx.ErrComplianceRecordNotFound
+	}
+
+	record, err := s.store.Compliance.GetByID(ctx, id)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if record == nil || record.SubjectType != model.ComplianceSubjectEntity || record.SubmittedAt == nil {
+		return nil, httpx.ErrComplianceRecordNotFound
+	}
+
+	below, err := s.store.Entity.IsDescendant(ctx, record.SubjectID, platformID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if !below {
+		return nil, httpx.ErrComplianceRecordNotFound
+	}
+
+	return record, nil
+}
+
+// checkPlatform returns ErrPlatformEntityRequired unless the entity is a platform.
+func checkPlatform(ctx context.Context, store *store.Manager, entityID string) error {
+	entity, err := store.Entity.GetByID(ctx, entityID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if entity == nil || entity.Type != model.EntityTypePlatform {
+		return httpx.ErrPlatformEntityRequired
+	}
+
+	return nil
+}
+
+// isCompliant checks if every compliance record required of an entity is approved.
+func isCompliant(ctx context.Context, store *store.Manager, entity *model.Entity) (bool, error) {
+	records, err := store.Compliance.ListBySubject(ctx, model.ComplianceSubjectEntity, entity.ID)
+	if err != nil {
+		return false, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	approved := map[model.ComplianceType]bool{}
+	for _, record := range records {
+		approved[record.Type] = record.Status == model.ComplianceStatusApproved
+	}
+
+	for _, required := range model.RequiredComplianceTypes(entity.Type) {
+		if !approved[required] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
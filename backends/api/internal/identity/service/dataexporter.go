@@ -0,0 +1,37 @@
+package service
+
+import (
+	"autopilot/backends/api/pkg/app"
+	"context"
+	"fmt"
+
+	"github.com/riverqueue/river"
+)
+
+// DataExportArgs is the arguments for the data exporter
+type DataExportArgs struct {
+	Locale string
+	UserID string
+}
+
+// Kind returns the kind of the worker
+func (DataExportArgs) Kind() string {
+	return "identity.data_export"
+}
+
+// DataExporter is a worker that exports the personal data of a user
+type DataExporter struct {
+	*app.Container
+	service *Manager
+	river.WorkerDefaults[DataExportArgs]
+}
+
+// Work is the worker function that exports the personal data of a user
+func (w *DataExporter) Work(ctx context.Context, job *river.Job[DataExportArgs]) error {
+	if err := w.service.User.ExportData(ctx, job.Args.UserID, job.Args.Locale); err != nil {
+		w.Logger.Error("Failed to export user data", "user_id", job.Args.UserID, "error", err)
+		return fmt.Errorf("exporting user data: %w", err)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"autopilot/backends/api/pkg/httpx"
 	"autopilot/backends/internal/types"
 	"context"
+	"strings"
 )
 
 // Entityer defines the interface for entity operations
@@ -14,6 +15,10 @@ type Entityer interface {
 	Get(ctx context.Context, id string) (*model.Entity, error)
 	GetByID(ctx context.Context, id string) (*model.Entity, error)
 	GetBySlug(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error)
+	ProcessLogo(ctx context.Context, id, imageID string) error
+	UpdateBranding(ctx context.Context, userID, id string, brandColor, supportEmail *string) (*model.Entity, error)
+	UpdateLogo(ctx context.Context, userID, id string, data []byte) error
+	UpdateStatus(ctx context.Context, id string, status model.EntityStatus, userID string) (*model.Entity, error)
 }
 
 // Entity implements the Entityer interface
@@ -71,3 +76,127 @@ func (s *Entity) GetBySlug(ctx context.Context, mode types.OperationMode, slug s
 
 	return entity, nil
 }
+
+// UpdateBranding changes the brand colour and support email of an entity,
+// which emails sent on its behalf carry. Nil values clear the settings.
+func (s *Entity) UpdateBranding(ctx context.Context, userID, id string, brandColor, supportEmail *string) (*model.Entity, error) {
+	entity, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if brandColor != nil {
+		brandColor = &[]string{strings.ToLower(*brandColor)}[0]
+	}
+	if err := s.store.Entity.UpdateBranding(ctx, id, brandColor, supportEmail); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	before := map[string]any{"brand_color": deref(entity.BrandColor), "support_email": deref(entity.SupportEmail)}
+	after := map[string]any{"brand_color": deref(brandColor), "support_email": deref(supportEmail)}
+	if err := auditLogChange(ctx, s.store, types.ResourceEntity, types.ActionUpdate, id, userID, before, after, nil); err != nil {
+		return nil, err
+	}
+
+	entity.BrandColor = brandColor
+	entity.SupportEmail = supportEmail
+	return entity, nil
+}
+
+// UpdateLogo stores a new logo of an entity and queues its processing. The
+// logo of the entity is replaced once its variants are ready, and the change is
+// audited now, while the user making it is known.
+func (s *Entity) UpdateLogo(ctx context.Context, userID, id string, data []byte) error {
+	entity, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	imageID, err := uploadImage(ctx, s.Container, data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.Worker.Insert(ctx, ImageProcessArgs{
+		ImageID:    imageID,
+		Resource:   types.ResourceEntity,
+		ResourceID: id,
+	}, nil); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	before := map[string]any{"logo": deref(entity.Logo)}
+	after := map[string]any{"logo": model.ImageURL(s.Config.App.BaseURL, imageID)}
+	return auditLogChange(ctx, s.store, types.ResourceEntity, types.ActionUpdate, id, userID, before, after, nil)
+}
+
+// ProcessLogo processes an uploaded logo into its variants and sets the stable
+// URL of the image as the logo of the entity.
+func (s *Entity) ProcessLogo(ctx context.Context, id, imageID string) error {
+	entity, err := s.store.Entity.GetByID(ctx, id)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if entity == nil {
+		deleteImageUpload(ctx, s.Container, imageID)
+		return nil
+	}
+
+	if err := processImage(ctx, s.Container, imageID); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	logo := model.ImageURL(s.Config.App.BaseURL, imageID)
+	if err := s.store.Entity.UpdateLogo(ctx, id, &logo); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	deleteImageUpload(ctx, s.Container, imageID)
+	return nil
+}
+
+// UpdateStatus changes the status of an entity. An empty userID records a
+// change made by the system rather than a user. An entity only becomes active
+// once its required compliance records are approved.
+func (s *Entity) UpdateStatus(ctx context.Context, id string, status model.EntityStatus, userID string) (*model.Entity, error) {
+	entity, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if entity.Status == status {
+		return entity, nil
+	}
+
+	if status == model.EntityStatusActive {
+		compliant, err := isCompliant(ctx, s.store, entity)
+		if err != nil {
+			return nil, err
+		}
+		if !compliant {
+			return nil, httpx.ErrComplianceIncomplete
+		}
+	}
+
+	if err := updateEntityStatus(ctx, s.store, entity, status, userID); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// updateEntityStatus changes the status of an entity and audits the change.
+func updateEntityStatus(ctx context.Context, store *store.Manager, entity *model.Entity, status model.EntityStatus, userID string) error {
+	if err := store.Entity.UpdateStatus(ctx, entity.ID, status); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	before := map[string]any{"status": entity.Status}
+	after := map[string]any{"status": status}
+	if err := auditLogChange(ctx, store, types.ResourceEntity, types.ActionUpdate, entity.ID, userID, before, after, nil); err != nil {
+		return err
+	}
+
+	entity.Status = status
+	return nil
+}
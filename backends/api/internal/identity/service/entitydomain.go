@@ -0,0 +1,344 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// EntityDomainer is an interface that wraps the EntityDomain methods
+type EntityDomainer interface {
+	Create(ctx context.Context, userID string, domain *model.EntityDomain) (*model.EntityDomain, error)
+	Delete(ctx context.Context, entityID, userID, id string) error
+	Join(ctx context.Context, userID, entityID string) (*model.Membership, error)
+	List(ctx context.Context, entityID string) ([]*model.EntityDomain, error)
+	ListJoinable(ctx context.Context, userID string) ([]*model.JoinableEntity, error)
+	Update(ctx context.Context, userID string, domain *model.EntityDomain) (*model.EntityDomain, error)
+	Verify(ctx context.Context, entityID, userID, id string) (*model.EntityDomain, error)
+}
+
+// EntityDomain is the service for the domains entities claim. An entity
+// proves control of a domain by publishing a DNS TXT record with the
+// verification token of its claim. A verified domain can't be claimed by
+// other entities, and users with a verified email address at the domain can
+// join the entity with its default role if it allows automatic joining.
+type EntityDomain struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewEntityDomain creates a new EntityDomain service.
+func NewEntityDomain(container *app.Container, store *store.Manager) EntityDomainer {
+	return &EntityDomain{
+		Container: container,
+		store:     store,
+	}
+}
+
+// Create claims a domain for an entity and issues the token to verify it with.
+func (s *EntityDomain) Create(ctx context.Context, userID string, domain *model.EntityDomain) (*model.EntityDomain, error) {
+	name := normalizeDomain(domain.Domain)
+	if !strings.Contains(name, ".") {
+		return nil, httpx.ErrInvalidHostname
+	}
+
+	existing, err := s.store.EntityDomain.GetByDomain(ctx, domain.EntityID, name)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if existing != nil {
+		return nil, httpx.ErrDomainExists
+	}
+
+	if err := checkDomainsUnclaimed(ctx, s.store, domain.EntityID, name); err != nil {
+		return nil, err
+	}
+
+	token, err := generateSecureToken(32)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	created, err := s.store.EntityDomain.Create(ctx, &model.EntityDomain{
+		EntityID:          domain.EntityID,
+		Domain:            name,
+		VerificationToken: token,
+		AutoJoin:          domain.AutoJoin,
+		DefaultRole:       domain.DefaultRole,
+		CreatedBy:         &userID,
+	})
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"domain":       created.Domain,
+		"auto_join":    created.AutoJoin,
+		"default_role": created.DefaultRole,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceEntityDomain, types.ActionCreate, created.ID, userID, metadata); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Delete removes the claim of an entity on a domain, which lets other
+// entities claim it.
+func (s *EntityDomain) Delete(ctx context.Context, entityID, userID, id string) error {
+	domain, err := s.get(ctx, entityID, id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.store.EntityDomain.Delete(ctx, domain.ID, entityID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if !deleted {
+		return httpx.ErrDomainNotFound
+	}
+
+	return auditLog(ctx, s.store, types.ResourceEntityDomain, types.ActionDelete, domain.ID, userID, map[string]any{"domain": domain.Domain})
+}
+
+// Join makes a user a member of an entity through the verified domain of
+// their email address, with the default role of the domain.
+func (s *EntityDomain) Join(ctx context.Context, userID, entityID string) (*model.Membership, error) {
+	joinable, err := s.ListJoinable(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(joinable, func(j *model.JoinableEntity) bool { return j.Entity.ID == entityID })
+	if index < 0 {
+		return nil, httpx.ErrEntityNotFound
+	}
+	domain := joinable[index].Domain
+
+	var membership *model.Membership
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		m := s.store.Membership.WithQuerier(tx)
+
+		existing, err := m.GetByEntityIDAndUserID(ctx, entityID, userID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return httpx.ErrMemberExists
+		}
+
+		membership, err = m.Create(ctx, &model.Membership{
+			EntityID: &entityID,
+			Role:     domain.DefaultRole,
+			UserID:   userID,
+		})
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, httpx.ErrMemberExists) {
+			return nil, httpx.ErrMemberExists
+		}
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	invalidateCachedSessions(ctx, s.Container, userID)
+
+	// Joining changes the members of the entity rather than creating anything
+	metadata := map[string]any{
+		"entity_domain_id": domain.ID,
+		"membership_id":    membership.ID,
+		"reason":           "domain_join",
+		"role":             membership.Role,
+		"user_id":          userID,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceEntity, types.ActionUpdate, entityID, userID, metadata); err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// List lists the domains of an entity.
+func (s *EntityDomain) List(ctx context.Context, entityID string) ([]*model.EntityDomain, error) {
+	domains, err := s.store.EntityDomain.ListByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return domains, nil
+}
+
+// ListJoinable lists the entities a user can join through the verified domain
+// of their email address. Users must have verified their email address, as it
+// is what proves they belong to the domain.
+func (s *EntityDomain) ListJoinable(ctx context.Context, userID string) ([]*model.JoinableEntity, error) {
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return nil, httpx.ErrUserNotFound
+	}
+	if !user.IsEmailVerified() {
+		return []*model.JoinableEntity{}, nil
+	}
+
+	domain, err := s.store.EntityDomain.GetVerifiedByDomain(ctx, model.EmailDomain(user.Email))
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if domain == nil || !domain.AutoJoin {
+		return []*model.JoinableEntity{}, nil
+	}
+
+	membership, err := s.store.Membership.GetByEntityIDAndUserID(ctx, domain.EntityID, userID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if membership != nil {
+		return []*model.JoinableEntity{}, nil
+	}
+
+	entity, err := s.store.Entity.GetByID(ctx, domain.EntityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if entity == nil || !entity.IsActive() {
+		return []*model.JoinableEntity{}, nil
+	}
+
+	return []*model.JoinableEntity{{Entity: entity, Domain: domain}}, nil
+}
+
+// Update updates the automatic joining settings of a domain of an entity.
+func (s *EntityDomain) Update(ctx context.Context, userID string, domain *model.EntityDomain) (*model.EntityDomain, error) {
+	before, err := s.get(ctx, domain.EntityID, domain.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.EntityDomain.Update(ctx, domain)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if updated == nil {
+		return nil, httpx.ErrDomainNotFound
+	}
+
+	changeBefore := map[string]any{"auto_join": before.AutoJoin, "default_role": before.DefaultRole}
+	changeAfter := map[string]any{"auto_join": updated.AutoJoin, "default_role": updated.DefaultRole}
+	if err := auditLogChange(ctx, s.store, types.ResourceEntityDomain, types.ActionUpdate, updated.ID, userID, changeBefore, changeAfter, nil); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Verify checks the DNS TXT records of a domain for its verification token,
+// and marks the domain as verified if it is found.
+func (s *EntityDomain) Verify(ctx context.Context, entityID, userID, id string) (*model.EntityDomain, error) {
+	domain, err := s.get(ctx, entityID, id)
+	if err != nil {
+		return nil, err
+	}
+	if domain.IsVerified() {
+		return domain, nil
+	}
+
+	if err := checkDomainsUnclaimed(ctx, s.store, entityID, domain.Domain); err != nil {
+		return nil, err
+	}
+
+	records, err := s.Resolver.LookupTXT(ctx, domain.RecordName())
+	if err != nil {
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+	}
+	if !slices.Contains(records, domain.RecordValue()) {
+		return nil, httpx.ErrDomainVerificationFailed
+	}
+
+	verified, err := s.store.EntityDomain.Verify(ctx, domain.ID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if verified == nil {
+		return nil, httpx.ErrDomainClaimed
+	}
+
+	if err := auditLog(ctx, s.store, types.ResourceEntityDomain, types.ActionUpdate, verified.ID, userID, map[string]any{"domain": verified.Domain, "verified": true}); err != nil {
+		return nil, err
+	}
+
+	return verified, nil
+}
+
+// get retrieves a domain of an entity.
+func (s *EntityDomain) get(ctx context.Context, entityID, id string) (*model.EntityDomain, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, httpx.ErrDomainNotFound
+	}
+
+	domain, err := s.store.EntityDomain.Get(ctx, id, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if domain == nil {
+		return nil, httpx.ErrDomainNotFound
+	}
+
+	return domain, nil
+}
+
+// checkDomainsUnclaimed returns ErrDomainClaimed if an entity other than the
+// given one has verified any of the domains.
+func checkDomainsUnclaimed(ctx context.Context, store *store.Manager, entityID string, names ...string) error {
+	for _, name := range names {
+		verified, err := store.EntityDomain.GetVerifiedByDomain(ctx, normalizeDomain(name))
+		if err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+		if verified != nil && verified.EntityID != entityID {
+			return httpx.ErrDomainClaimed
+		}
+	}
+
+	return nil
+}
+
+// checkDomainsVerified returns ErrDomainClaimed if an entity other than the
+// given one has verified any of the domains, and ErrDomainNotVerified if the
+// entity hasn't verified them itself.
+func checkDomainsVerified(ctx context.Context, store *store.Manager, entityID string, names ...string) error {
+	for _, name := range names {
+		verified, err := store.EntityDomain.GetVerifiedByDomain(ctx, normalizeDomain(name))
+		if err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+		if verified == nil {
+			return httpx.ErrDomainNotVerified
+		}
+		if verified.EntityID != entityID {
+			return httpx.ErrDomainClaimed
+		}
+	}
+
+	return nil
+}
+
+// normalizeDomain returns a domain in lowercase without a trailing dot
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
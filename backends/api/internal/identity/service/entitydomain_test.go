@@ -0,0 +1,261 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"autopilot/backends/api/pkg/app"
+	appmocks "autopilot/backends/api/pkg/app/mocks"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEntityDomainVerify(t *testing.T) {
+	t.Parallel()
+
+	const id = "01948450-988e-7976-a454-000000000001"
+	pending := &model.EntityDomain{
+		ID:                id,
+		EntityID:          "entity",
+		Domain:            "example.com",
+		VerificationToken: "token",
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		records   []string
+		lookupErr error
+		claimedBy string
+		lost      bool
+		wantErr   error
+	}{
+		{
+			name:    "should verify a domain publishing the token",
+			records: []string{"v=spf1 -all", "autopilot-verification=token"},
+		},
+		{
+			name:    "should not verify a domain publishing another token",
+			records: []string{"autopilot-verification=other"},
+			wantErr: httpx.ErrDomainVerificationFailed,
+		},
+		{
+			name:      "should not verify a domain without the record",
+			lookupErr: &net.DNSError{Err: "no such host", IsNotFound: true},
+			wantErr:   httpx.ErrDomainVerificationFailed,
+		},
+		{
+			name:      "should fail on resolver errors",
+			lookupErr: errors.New("timeout"),
+			wantErr:   httpx.ErrUnknown,
+		},
+		{
+			name:      "should refuse a domain verified by another entity",
+			claimedBy: "other",
+			wantErr:   httpx.ErrDomainClaimed,
+		},
+		{
+			name:    "should refuse a domain verified by another entity in the meantime",
+			records: []string{"autopilot-verification=token"},
+			lost:    true,
+			wantErr: httpx.ErrDomainClaimed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			entityDomainStore := mocks.NewMockEntityDomainer(t)
+			entityDomainStore.EXPECT().Get(mock.Anything, id, "entity").Return(pending, nil)
+
+			var claimed *model.EntityDomain
+			if tt.claimedBy != "" {
+				claimed = &model.EntityDomain{EntityID: tt.claimedBy, Domain: "example.com", VerifiedAt: &now}
+			}
+			entityDomainStore.EXPECT().GetVerifiedByDomain(mock.Anything, "example.com").Return(claimed, nil)
+
+			resolver := appmocks.NewMockResolver(t)
+			if tt.claimedBy == "" {
+				resolver.EXPECT().LookupTXT(mock.Anything, "_autopilot-verification.example.com").Return(tt.records, tt.lookupErr)
+			}
+
+			auditLogStore := mocks.NewMockAuditLoger(t)
+			if tt.wantErr == nil || tt.lost {
+				verified := *pending
+				verified.VerifiedAt = &now
+				result := &verified
+				if tt.lost {
+					result = nil
+				}
+				entityDomainStore.EXPECT().Verify(mock.Anything, id).Return(result, nil)
+			}
+			if tt.wantErr == nil {
+				auditLogStore.EXPECT().Create(mock.Anything, mock.Anything).Return(&model.AuditLog{}, nil)
+			}
+
+			s := &EntityDomain{
+				Container: &app.Container{Config: &app.Config{}, Resolver: resolver},
+				store:     &store.Manager{AuditLog: auditLogStore, EntityDomain: entityDomainStore},
+			}
+			domain, err := s.Verify(context.Background(), "entity", "user", id)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, domain.IsVerified())
+		})
+	}
+}
+
+func TestEntityDomainListJoinable(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	verified := &model.EntityDomain{ID: "domain", EntityID: "entity", Domain: "example.com", VerifiedAt: &now, AutoJoin: true, DefaultRole: "viewer"}
+	closed := *verified
+	closed.AutoJoin = false
+
+	tests := []struct {
+		name          string
+		emailVerified bool
+		domain        *model.EntityDomain
+		member        bool
+		status        model.EntityStatus
+		want          bool
+	}{
+		{
+			name:          "should offer the entity of a verified domain",
+			emailVerified: true,
+			domain:        verified,
+			status:        model.EntityStatusActive,
+			want:          true,
+		},
+		{
+			name:   "should not offer anything before the email address is verified",
+			domain: verified,
+		},
+		{
+			name:          "should not offer an entity without automatic joining",
+			emailVerified: true,
+			domain:        &closed,
+		},
+		{
+			name:          "should not offer an entity the user is a member of",
+			emailVerified: true,
+			domain:        verified,
+			member:        true,
+		},
+		{
+			name:          "should not offer an inactive entity",
+			emailVerified: true,
+			domain:        verified,
+			status:        model.EntityStatusSuspended,
+		},
+		{
+			name:          "should not offer anything without a verified domain",
+			emailVerified: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			user := &model.User{ID: "user", Email: "jane@Example.com"}
+			if tt.emailVerified {
+				user.EmailVerifiedAt = &now
+			}
+			userStore := mocks.NewMockUserer(t)
+			userStore.EXPECT().GetByID(mock.Anything, "user").Return(user, nil)
+
+			entityDomainStore := mocks.NewMockEntityDomainer(t)
+			membershipStore := mocks.NewMockMembershiper(t)
+			entityStore := mocks.NewMockEntityer(t)
+			if tt.emailVerified {
+				entityDomainStore.EXPECT().GetVerifiedByDomain(mock.Anything, "example.com").Return(tt.domain, nil)
+			}
+			if tt.domain != nil && tt.domain.AutoJoin && tt.emailVerified {
+				var membership *model.Membership
+				if tt.member {
+					membership = &model.Membership{ID: "membership"}
+				}
+				membershipStore.EXPECT().GetByEntityIDAndUserID(mock.Anything, "entity", "user").Return(membership, nil)
+				if !tt.member {
+					entityStore.EXPECT().GetByID(mock.Anything, "entity").Return(&model.Entity{ID: "entity", Status: tt.status}, nil)
+				}
+			}
+
+			s := &EntityDomain{store: &store.Manager{Entity: entityStore, EntityDomain: entityDomainStore, Membership: membershipStore, User: userStore}}
+			joinable, err := s.ListJoinable(context.Background(), "user")
+			assert.NoError(t, err)
+			if !tt.want {
+				assert.Empty(t, joinable)
+				return
+			}
+			if assert.Len(t, joinable, 1) {
+				assert.Equal(t, "entity", joinable[0].Entity.ID)
+				assert.Equal(t, verified, joinable[0].Domain)
+			}
+		})
+	}
+}
+
+func TestCheckDomainsVerified(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	verified := map[string]*model.EntityDomain{
+		"example.com": {EntityID: "entity", Domain: "example.com", VerifiedAt: &now},
+		"other.com":   {EntityID: "other", Domain: "other.com", VerifiedAt: &now},
+	}
+
+	tests := []struct {
+		name    string
+		domains []string
+		wantErr error
+	}{
+		{
+			name:    "should allow domains verified by the entity",
+			domains: []string{"Example.com."},
+		},
+		{
+			name:    "should refuse a domain nobody verified",
+			domains: []string{"example.com", "unverified.com"},
+			wantErr: httpx.ErrDomainNotVerified,
+		},
+		{
+			name:    "should refuse a domain verified by another entity",
+			domains: []string{"other.com"},
+			wantErr: httpx.ErrDomainClaimed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			entityDomainStore := mocks.NewMockEntityDomainer(t)
+			entityDomainStore.EXPECT().GetVerifiedByDomain(mock.Anything, mock.Anything).RunAndReturn(
+				func(_ context.Context, domain string) (*model.EntityDomain, error) {
+					return verified[domain], nil
+				},
+			)
+
+			err := checkDomainsVerified(context.Background(), &store.Manager{EntityDomain: entityDomainStore}, "entity", tt.domains...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
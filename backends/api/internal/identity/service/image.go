@@ -0,0 +1,75 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/api/pkg/imaging"
+	"autopilot/backends/internal/core"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// uploadImage validates an uploaded image and stores it until it is processed
+// into its variants, returning the ID of the image.
+func uploadImage(ctx context.Context, container *app.Container, data []byte) (string, error) {
+	format, err := imaging.Validate(data)
+	if err != nil {
+		return "", httpx.ErrInvalidImageFormat
+	}
+
+	hash := sha256.Sum256(data)
+	id := base64.RawURLEncoding.EncodeToString(hash[:])
+
+	if _, err := container.Storage.Identity.Upload(ctx, model.ImageUploadKey(id), bytes.NewReader(data), &core.ObjectMetadata{
+		Size:        int64(len(data)),
+		ContentType: "image/" + format,
+	}); err != nil {
+		return "", httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return id, nil
+}
+
+// processImage stores the variants of an uploaded image. The upload is kept
+// until deleteImageUpload is called, so a failed job can be retried.
+func processImage(ctx context.Context, container *app.Container, id string) error {
+	reader, _, err := container.Storage.Identity.Download(ctx, model.ImageUploadKey(id))
+	if err != nil {
+		return fmt.Errorf("downloading image: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("reading image: %w", err)
+	}
+
+	variants, err := imaging.Process(data)
+	if err != nil {
+		return fmt.Errorf("processing image: %w", err)
+	}
+
+	for _, variant := range variants {
+		if _, err := container.Storage.Identity.Upload(ctx, model.ImageVariantKey(id, variant.Size), bytes.NewReader(variant.Data), &core.ObjectMetadata{
+			Size:        int64(len(variant.Data)),
+			ContentType: variant.ContentType,
+		}); err != nil {
+			return fmt.Errorf("uploading image variant: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteImageUpload deletes an uploaded image once it has been processed, as
+// it still holds the metadata of the original. Failures are logged only.
+func deleteImageUpload(ctx context.Context, container *app.Container, id string) {
+	if err := container.Storage.Identity.Delete(ctx, model.ImageUploadKey(id)); err != nil {
+		container.Logger.Warn("Failed to delete image upload", "image_id", id, "error", err)
+	}
+}
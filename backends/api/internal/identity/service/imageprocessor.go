@@ -0,0 +1,48 @@
+package service
+
+import (
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/internal/types"
+	"context"
+	"fmt"
+
+	"github.com/riverqueue/river"
+)
+
+// ImageProcessArgs is the arguments for the image processor
+type ImageProcessArgs struct {
+	ImageID    string
+	Resource   types.Resource // The kind of resource the image belongs to
+	ResourceID string
+}
+
+// Kind returns the kind of the worker
+func (ImageProcessArgs) Kind() string {
+	return "identity.image_process"
+}
+
+// ImageProcessor is a worker that processes uploaded images into their variants
+type ImageProcessor struct {
+	*app.Container
+	service *Manager
+	river.WorkerDefaults[ImageProcessArgs]
+}
+
+// Work is the worker function that processes an uploaded image
+func (w *ImageProcessor) Work(ctx context.Context, job *river.Job[ImageProcessArgs]) error {
+	var err error
+	switch job.Args.Resource {
+	case types.ResourceUser:
+		err = w.service.User.ProcessImage(ctx, job.Args.ResourceID, job.Args.ImageID)
+	case types.ResourceEntity:
+		err = w.service.Entity.ProcessLogo(ctx, job.Args.ResourceID, job.Args.ImageID)
+	default:
+		return fmt.Errorf("unsupported image resource: %s", job.Args.Resource)
+	}
+	if err != nil {
+		w.Logger.Error("Failed to process image", "image_id", job.Args.ImageID, "resource", job.Args.Resource, "error", err)
+		return fmt.Errorf("processing image: %w", err)
+	}
+
+	return nil
+}
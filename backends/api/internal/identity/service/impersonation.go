@@ -0,0 +1,205 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/api/pkg/middleware"
+	"autopilot/backends/internal/types"
+	"context"
+	"time"
+)
+
+// ImpersonationDuration is how long an impersonation session lasts. It can't
+// be refreshed.
+const ImpersonationDuration = time.Hour
+
+// Impersonationer is an interface that wraps the Impersonation methods
+type Impersonationer interface {
+	Record(ctx context.Context, method, path string, allowed bool) error
+	Start(ctx context.Context, entityID, actorID, userID, reason string) (*model.Session, error)
+	Stop(ctx context.Context, token, impersonatorToken string) (*model.Session, error)
+}
+
+// Impersonation is the service for platform members impersonating the users
+// of the entities below their platform, to see what they see. Impersonation
+// sessions are read-only, and everything done with them is recorded in the
+// audit log against the real actor.
+type Impersonation struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewImpersonation creates a new Impersonation service.
+func NewImpersonation(container *app.Container, store *store.Manager) Impersonationer {
+	return &Impersonation{
+		Container: container,
+		store:     store,
+	}
+}
+
+// Record records a request made while impersonating a user, and whether it
+// was allowed.
+func (s *Impersonation) Record(ctx context.Context, method, path string, allowed bool) error {
+	auth := httpx.GetAuthInfo(ctx)
+
+	action := types.ActionRequest
+	if !allowed {
+		action = types.ActionDeny
+	}
+
+	metadata := map[string]any{
+		"method": method,
+		"path":   path,
+	}
+	return auditLog(ctx, s.store, types.ResourceImpersonation, action, auth.UserID, auth.ImpersonatorID, metadata)
+}
+
+// Start creates an impersonation session of a user for a member of a platform
+// entity. Only users whose every membership is in an entity below the
+// platform can be impersonated.
+func (s *Impersonation) Start(ctx context.Context, entityID, actorID, userID, reason string) (*model.Session, error) {
+	if err := checkPlatform(ctx, s.store, entityID); err != nil {
+		return nil, err
+	}
+
+	if userID == actorID {
+		return nil, httpx.ErrImpersonationNotAllowed
+	}
+
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return nil, httpx.ErrUserNotFound
+	}
+
+	if err := s.checkImpersonable(ctx, entityID, user.ID); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := generateSecureToken(32)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	// The refresh token is never handed out, as impersonations can't be extended
+	refreshToken, err := generateSecureToken(32)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	now := time.Now()
+	session := &model.Session{
+		AuthenticatedAt:      now,
+		ExpiresAt:            now.Add(ImpersonationDuration),
+		RefreshExpiresAt:     now.Add(ImpersonationDuration),
+		RefreshToken:         refreshToken,
+		Token:                accessToken,
+		UserID:               user.ID,
+		ImpersonatorID:       &actorID,
+		ImpersonatorEntityID: &entityID,
+	}
+
+	if reqMetadata := middleware.GetRequestMetadata(ctx); reqMetadata != nil {
+		session.IPAddress = &reqMetadata.IPAddress
+		session.UserAgent = &reqMetadata.UserAgent
+		session.Country = &reqMetadata.Country
+	}
+
+	session, err = s.store.Session.Create(ctx, session)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"session_id": session.ID,
+		"reason":     reason,
+		"expires_at": session.ExpiresAt,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceImpersonation, types.ActionCreate, user.ID, actorID, metadata); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// Stop ends the impersonation session of a token. It returns the session of
+// the impersonator to switch back to, if impersonatorToken is still one.
+func (s *Impersonation) Stop(ctx context.Context, token, impersonatorToken string) (*model.Session, error) {
+	session, err := s.store.Session.GetByToken(ctx, token)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if session == nil || !session.IsImpersonation() {
+		return nil, httpx.ErrNotImpersonating
+	}
+
+	if err := s.store.Session.InvalidateByToken(ctx, token); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	invalidateCachedSession(ctx, s.Container, token)
+
+	metadata := map[string]any{"session_id": session.ID}
+	if err := auditLog(ctx, s.store, types.ResourceImpersonation, types.ActionDelete, session.UserID, *session.ImpersonatorID, metadata); err != nil {
+		return nil, err
+	}
+
+	if impersonatorToken == "" {
+		return nil, nil
+	}
+
+	impersonator, err := s.store.Session.GetByToken(ctx, impersonatorToken)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if impersonator == nil || impersonator.UserID != *session.ImpersonatorID ||
+		impersonator.IsTwoFactorPending || impersonator.IsExpired() {
+		return nil, nil
+	}
+
+	return impersonator, nil
+}
+
+// checkImpersonable checks if a user can be impersonated from a platform
+// entity. Users outside of the platform aren't found, while members of the
+// platform entity itself, or of entities outside of it, can't be impersonated
+// so that impersonation never reaches beyond the platform.
+func (s *Impersonation) checkImpersonable(ctx context.Context, platformID, userID string) error {
+	memberships, err := s.store.Membership.GetByUserID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	var below, outside int
+	for _, m := range memberships {
+		if m.EntityID == nil {
+			continue
+		}
+
+		if *m.EntityID == platformID {
+			return httpx.ErrImpersonationNotAllowed
+		}
+
+		isBelow, err := s.store.Entity.IsDescendant(ctx, *m.EntityID, platformID)
+		if err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+		if isBelow {
+			below++
+		} else {
+			outside++
+		}
+	}
+
+	switch {
+	case below == 0:
+		return httpx.ErrUserNotFound
+	case outside > 0:
+		return httpx.ErrImpersonationNotAllowed
+	}
+
+	return nil
+}
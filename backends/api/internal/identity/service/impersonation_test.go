@@ -0,0 +1,116 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckImpersonable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		entityIDs   []string
+		descendants map[string]bool
+		wantErr     error
+	}{
+		{
+			name:        "should allow a user whose every membership is below the platform",
+			entityIDs:   []string{"tenant", "sub-tenant"},
+			descendants: map[string]bool{"tenant": true, "sub-tenant": true},
+		},
+		{
+			name:      "should refuse a member of the platform entity",
+			entityIDs: []string{"platform"},
+			wantErr:   httpx.ErrImpersonationNotAllowed,
+		},
+		{
+			name:        "should refuse a user who is also a member outside of the platform",
+			entityIDs:   []string{"tenant", "other"},
+			descendants: map[string]bool{"tenant": true, "other": false},
+			wantErr:     httpx.ErrImpersonationNotAllowed,
+		},
+		{
+			name:        "should not find a user outside of the platform",
+			entityIDs:   []string{"other"},
+			descendants: map[string]bool{"other": false},
+			wantErr:     httpx.ErrUserNotFound,
+		},
+		{
+			name:    "should not find a user without memberships",
+			wantErr: httpx.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			memberships := make([]*model.Membership, 0, len(tt.entityIDs))
+			for _, entityID := range tt.entityIDs {
+				memberships = append(memberships, &model.Membership{EntityID: &entityID})
+			}
+
+			membershipStore := mocks.NewMockMembershiper(t)
+			membershipStore.EXPECT().GetByUserID(mock.Anything, "user").Return(memberships, nil)
+
+			entityStore := mocks.NewMockEntityer(t)
+			for entityID, below := range tt.descendants {
+				entityStore.EXPECT().IsDescendant(mock.Anything, entityID, "platform").Return(below, nil)
+			}
+
+			s := &Impersonation{store: &store.Manager{Entity: entityStore, Membership: membershipStore}}
+			err := s.checkImpersonable(context.Background(), "platform", "user")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuditLogWhileImpersonating(t *testing.T) {
+	t.Parallel()
+
+	auth := httpx.AuthInfo{
+		Authenticated:        true,
+		EntityID:             "tenant",
+		UserID:               "user",
+		EntityRole:           types.RoleOwner,
+		ImpersonatorID:       "support",
+		ImpersonatorEntityID: "platform",
+	}
+	ctx := context.WithValue(context.Background(), types.AuthKey, auth)
+
+	var created *model.AuditLog
+	auditLogStore := mocks.NewMockAuditLoger(t)
+	auditLogStore.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, log *model.AuditLog) (*model.AuditLog, error) {
+		created = log
+		return log, nil
+	})
+
+	metadata := map[string]any{"reason": "test"}
+	err := auditLog(ctx, &store.Manager{AuditLog: auditLogStore}, types.ResourceUser, types.ActionRead, "user", "user", metadata)
+	require.NoError(t, err)
+
+	require.NotNil(t, created.UserID)
+	assert.Equal(t, "support", *created.UserID, "the real actor should be recorded")
+	require.NotNil(t, created.EntityID)
+	assert.Equal(t, "platform", *created.EntityID, "the entry should belong to the impersonator's platform")
+
+	var recorded map[string]any
+	require.NoError(t, json.Unmarshal(created.Metadata, &recorded))
+	assert.Equal(t, map[string]any{"reason": "test", "impersonated_user_id": "user"}, recorded)
+	assert.Equal(t, map[string]any{"reason": "test"}, metadata, "the caller's metadata should be left untouched")
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/api/pkg/middleware"
+	"autopilot/backends/internal/core"
+	"autopilot/backends/internal/types"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// IPAllowlister is an interface that wraps the IPAllowlist methods
+type IPAllowlister interface {
+	Check(ctx context.Context, entityID, ip string) error
+	List(ctx context.Context, entityID string) (model.IPAllowlist, error)
+	Update(ctx context.Context, entityID, userID string, entries model.IPAllowlist) (model.IPAllowlist, error)
+}
+
+// IPAllowlist is the service for the address ranges entities restrict access
+// to. The allowlists are checked on every authenticated request, so they are
+// read through the identity cache like sessions.
+type IPAllowlist struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewIPAllowlist creates a new IPAllowlist service.
+func NewIPAllowlist(container *app.Container, store *store.Manager) IPAllowlister {
+	return &IPAllowlist{
+		Container: container,
+		store:     store,
+	}
+}
+
+// Check returns ErrIPNotAllowed if the allowlist of the entity doesn't allow
+// the IP address. Denied attempts are audit logged in the entity.
+func (s *IPAllowlist) Check(ctx context.Context, entityID, ip string) error {
+	allowlist, err := s.get(ctx, entityID)
+	if err != nil {
+		return err
+	}
+
+	if allowlist.Allows(ip) {
+		return nil
+	}
+
+	auth := httpx.GetAuthInfo(ctx)
+	if err := auditLog(ctx, s.store, types.ResourceIPAllowlist, types.ActionDeny, entityID, auth.UserID, nil); err != nil {
+		return err
+	}
+
+	return httpx.ErrIPNotAllowed
+}
+
+// List lists the allowlist of an entity.
+func (s *IPAllowlist) List(ctx context.Context, entityID string) (model.IPAllowlist, error) {
+	allowlist, err := s.store.IPAllowlist.ListByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return allowlist, nil
+}
+
+// Update replaces the allowlist of an entity. The ranges are normalized, and
+// the new list must allow the IP address of the request, so the user can't
+// lock themselves out. An empty list allows any address again.
+func (s *IPAllowlist) Update(ctx context.Context, entityID, userID string, entries model.IPAllowlist) (model.IPAllowlist, error) {
+	allowlist := make(model.IPAllowlist, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		cidr, ok := model.ParseIPRange(entry.CIDR)
+		if !ok {
+			return nil, httpx.ErrInvalidIPRange
+		}
+		if seen[cidr] {
+			continue
+		}
+		seen[cidr] = true
+
+		allowlist = append(allowlist, &model.IPAllowlistEntry{
+			EntityID:    entityID,
+			CIDR:        cidr,
+			Description: entry.Description,
+			CreatedBy:   &userID,
+		})
+	}
+
+	if len(allowlist) > model.IPAllowlistMaxEntries {
+		return nil, httpx.ErrTooLarge
+	}
+
+	var ip string
+	if reqMetadata := middleware.GetRequestMetadata(ctx); reqMetadata != nil {
+		ip = reqMetadata.IPAddress
+	}
+	if !allowlist.Allows(ip) {
+		return nil, httpx.ErrIPAllowlistLockout
+	}
+
+	before, err := s.store.IPAllowlist.ListByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		txStore := store.NewManager(tx)
+
+		if err := txStore.IPAllowlist.DeleteByEntityID(ctx, entityID); err != nil {
+			return err
+		}
+
+		for i, entry := range allowlist {
+			created, err := txStore.IPAllowlist.Create(ctx, entry)
+			if err != nil {
+				return err
+			}
+			allowlist[i] = created
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	s.invalidate(ctx, entityID)
+
+	changeBefore := map[string]any{"cidrs": ipAllowlistCIDRs(before)}
+	changeAfter := map[string]any{"cidrs": ipAllowlistCIDRs(allowlist)}
+	if err := auditLogChange(ctx, s.store, types.ResourceIPAllowlist, types.ActionUpdate, entityID, userID, changeBefore, changeAfter, nil); err != nil {
+		return nil, err
+	}
+
+	return allowlist, nil
+}
+
+// ipAllowlistCacheKey returns the key of the cached allowlist of an entity.
+func ipAllowlistCacheKey(entityID string) string {
+	return "ip_allowlist:" + entityID
+}
+
+// get reads the allowlist of an entity through the cache. Cache misses and
+// errors fall back to the database.
+func (s *IPAllowlist) get(ctx context.Context, entityID string) (model.IPAllowlist, error) {
+	load := func(ctx context.Context) (model.IPAllowlist, error) {
+		return s.store.IPAllowlist.ListByEntityID(ctx, entityID)
+	}
+
+	var allowlist model.IPAllowlist
+	var err error
+	if s.Cache.Identity != nil {
+		allowlist, err = core.CacheGetOrLoad(ctx, s.Cache.Identity, ipAllowlistCacheKey(entityID), SessionCacheTTL, load)
+	} else {
+		allowlist, err = load(ctx)
+	}
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return allowlist, nil
+}
+
+// invalidate removes the cached allowlist of an entity.
+func (s *IPAllowlist) invalidate(ctx context.Context, entityID string) {
+	if s.Cache.Identity == nil {
+		return
+	}
+
+	if err := s.Cache.Identity.Delete(ctx, ipAllowlistCacheKey(entityID)); err != nil {
+		s.Logger.Warn("Failed to invalidate cached IP allowlist", "error", err)
+	}
+}
+
+// ipAllowlistCIDRs returns the ranges of an allowlist, as recorded in audit logs.
+func ipAllowlistCIDRs(allowlist model.IPAllowlist) []string {
+	cidrs := make([]string, 0, len(allowlist))
+	for _, entry := range allowlist {
+		cidrs = append(cidrs, entry.CIDR)
+	}
+	return cidrs
+}
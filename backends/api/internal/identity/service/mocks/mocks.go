@@ -7,19 +7,21 @@ package mocks
 import (
 	"autopilot/backends/api/internal/identity/model"
 	"autopilot/backends/api/internal/identity/service"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/core"
 	"autopilot/backends/internal/types"
 	"context"
 
 	mock "github.com/stretchr/testify/mock"
 )
 
-// NewMockEntityer creates a new instance of MockEntityer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockAuditLoger creates a new instance of MockAuditLoger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockEntityer(t interface {
+func NewMockAuditLoger(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockEntityer {
-	mock := &MockEntityer{}
+}) *MockAuditLoger {
+	mock := &MockAuditLoger{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -27,136 +29,108 @@ func NewMockEntityer(t interface {
 	return mock
 }
 
-// MockEntityer is an autogenerated mock type for the Entityer type
-type MockEntityer struct {
+// MockAuditLoger is an autogenerated mock type for the AuditLoger type
+type MockAuditLoger struct {
 	mock.Mock
 }
 
-type MockEntityer_Expecter struct {
+type MockAuditLoger_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockEntityer) EXPECT() *MockEntityer_Expecter {
-	return &MockEntityer_Expecter{mock: &_m.Mock}
+func (_m *MockAuditLoger) EXPECT() *MockAuditLoger_Expecter {
+	return &MockAuditLoger_Expecter{mock: &_m.Mock}
 }
 
-// Get provides a mock function for the type MockEntityer
-func (_mock *MockEntityer) Get(ctx context.Context, id string) (*model.Entity, error) {
-	ret := _mock.Called(ctx, id)
+// Checkpoint provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) Checkpoint(ctx context.Context) error {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for Checkpoint")
 	}
 
-	var r0 *model.Entity
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Entity, error)); ok {
-		return returnFunc(ctx, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Entity); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Entity)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockEntityer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockEntityer_Get_Call struct {
+// MockAuditLoger_Checkpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Checkpoint'
+type MockAuditLoger_Checkpoint_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// Checkpoint is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockEntityer_Expecter) Get(ctx interface{}, id interface{}) *MockEntityer_Get_Call {
-	return &MockEntityer_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+func (_e *MockAuditLoger_Expecter) Checkpoint(ctx interface{}) *MockAuditLoger_Checkpoint_Call {
+	return &MockAuditLoger_Checkpoint_Call{Call: _e.mock.On("Checkpoint", ctx)}
 }
 
-func (_c *MockEntityer_Get_Call) Run(run func(ctx context.Context, id string)) *MockEntityer_Get_Call {
+func (_c *MockAuditLoger_Checkpoint_Call) Run(run func(ctx context.Context)) *MockAuditLoger_Checkpoint_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEntityer_Get_Call) Return(entity *model.Entity, err error) *MockEntityer_Get_Call {
-	_c.Call.Return(entity, err)
+func (_c *MockAuditLoger_Checkpoint_Call) Return(err error) *MockAuditLoger_Checkpoint_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockEntityer_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Entity, error)) *MockEntityer_Get_Call {
+func (_c *MockAuditLoger_Checkpoint_Call) RunAndReturn(run func(ctx context.Context) error) *MockAuditLoger_Checkpoint_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type MockEntityer
-func (_mock *MockEntityer) GetByID(ctx context.Context, id string) (*model.Entity, error) {
-	ret := _mock.Called(ctx, id)
+// Export provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) Export(ctx context.Context, args service.AuditLogExportArgs) error {
+	ret := _mock.Called(ctx, args)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for Export")
 	}
 
-	var r0 *model.Entity
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Entity, error)); ok {
-		return returnFunc(ctx, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Entity); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Entity)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, service.AuditLogExportArgs) error); ok {
+		r0 = returnFunc(ctx, args)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockEntityer_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockEntityer_GetByID_Call struct {
+// MockAuditLoger_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockAuditLoger_Export_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// Export is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockEntityer_Expecter) GetByID(ctx interface{}, id interface{}) *MockEntityer_GetByID_Call {
-	return &MockEntityer_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+//   - args service.AuditLogExportArgs
+func (_e *MockAuditLoger_Expecter) Export(ctx interface{}, args interface{}) *MockAuditLoger_Export_Call {
+	return &MockAuditLoger_Export_Call{Call: _e.mock.On("Export", ctx, args)}
 }
 
-func (_c *MockEntityer_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockEntityer_GetByID_Call {
+func (_c *MockAuditLoger_Export_Call) Run(run func(ctx context.Context, args service.AuditLogExportArgs)) *MockAuditLoger_Export_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 service.AuditLogExportArgs
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(service.AuditLogExportArgs)
 		}
 		run(
 			arg0,
@@ -166,158 +140,129 @@ func (_c *MockEntityer_GetByID_Call) Run(run func(ctx context.Context, id string
 	return _c
 }
 
-func (_c *MockEntityer_GetByID_Call) Return(entity *model.Entity, err error) *MockEntityer_GetByID_Call {
-	_c.Call.Return(entity, err)
+func (_c *MockAuditLoger_Export_Call) Return(err error) *MockAuditLoger_Export_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockEntityer_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Entity, error)) *MockEntityer_GetByID_Call {
+func (_c *MockAuditLoger_Export_Call) RunAndReturn(run func(ctx context.Context, args service.AuditLogExportArgs) error) *MockAuditLoger_Export_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBySlug provides a mock function for the type MockEntityer
-func (_mock *MockEntityer) GetBySlug(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error) {
-	ret := _mock.Called(ctx, mode, slug)
+// List provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) List(ctx context.Context, entityID string, filter model.AuditLogFilter, params httpx.CursorPaginationParams) (*service.AuditLogList, error) {
+	ret := _mock.Called(ctx, entityID, filter, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBySlug")
+		panic("no return value specified for List")
 	}
 
-	var r0 *model.Entity
+	var r0 *service.AuditLogList
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, types.OperationMode, string) (*model.Entity, error)); ok {
-		return returnFunc(ctx, mode, slug)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.AuditLogFilter, httpx.CursorPaginationParams) (*service.AuditLogList, error)); ok {
+		return returnFunc(ctx, entityID, filter, params)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, types.OperationMode, string) *model.Entity); ok {
-		r0 = returnFunc(ctx, mode, slug)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.AuditLogFilter, httpx.CursorPaginationParams) *service.AuditLogList); ok {
+		r0 = returnFunc(ctx, entityID, filter, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Entity)
+			r0 = ret.Get(0).(*service.AuditLogList)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, types.OperationMode, string) error); ok {
-		r1 = returnFunc(ctx, mode, slug)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.AuditLogFilter, httpx.CursorPaginationParams) error); ok {
+		r1 = returnFunc(ctx, entityID, filter, params)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockEntityer_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
-type MockEntityer_GetBySlug_Call struct {
+// MockAuditLoger_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockAuditLoger_List_Call struct {
 	*mock.Call
 }
 
-// GetBySlug is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - mode types.OperationMode
-//   - slug string
-func (_e *MockEntityer_Expecter) GetBySlug(ctx interface{}, mode interface{}, slug interface{}) *MockEntityer_GetBySlug_Call {
-	return &MockEntityer_GetBySlug_Call{Call: _e.mock.On("GetBySlug", ctx, mode, slug)}
+//   - entityID string
+//   - filter model.AuditLogFilter
+//   - params httpx.CursorPaginationParams
+func (_e *MockAuditLoger_Expecter) List(ctx interface{}, entityID interface{}, filter interface{}, params interface{}) *MockAuditLoger_List_Call {
+	return &MockAuditLoger_List_Call{Call: _e.mock.On("List", ctx, entityID, filter, params)}
 }
 
-func (_c *MockEntityer_GetBySlug_Call) Run(run func(ctx context.Context, mode types.OperationMode, slug string)) *MockEntityer_GetBySlug_Call {
+func (_c *MockAuditLoger_List_Call) Run(run func(ctx context.Context, entityID string, filter model.AuditLogFilter, params httpx.CursorPaginationParams)) *MockAuditLoger_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 types.OperationMode
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(types.OperationMode)
+			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 model.AuditLogFilter
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(model.AuditLogFilter)
+		}
+		var arg3 httpx.CursorPaginationParams
+		if args[3] != nil {
+			arg3 = args[3].(httpx.CursorPaginationParams)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEntityer_GetBySlug_Call) Return(entity *model.Entity, err error) *MockEntityer_GetBySlug_Call {
-	_c.Call.Return(entity, err)
+func (_c *MockAuditLoger_List_Call) Return(auditLogList *service.AuditLogList, err error) *MockAuditLoger_List_Call {
+	_c.Call.Return(auditLogList, err)
 	return _c
 }
 
-func (_c *MockEntityer_GetBySlug_Call) RunAndReturn(run func(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error)) *MockEntityer_GetBySlug_Call {
+func (_c *MockAuditLoger_List_Call) RunAndReturn(run func(ctx context.Context, entityID string, filter model.AuditLogFilter, params httpx.CursorPaginationParams) (*service.AuditLogList, error)) *MockAuditLoger_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMembershiper creates a new instance of MockMembershiper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockMembershiper(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockMembershiper {
-	mock := &MockMembershiper{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockMembershiper is an autogenerated mock type for the Membershiper type
-type MockMembershiper struct {
-	mock.Mock
-}
-
-type MockMembershiper_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockMembershiper) EXPECT() *MockMembershiper_Expecter {
-	return &MockMembershiper_Expecter{mock: &_m.Mock}
-}
-
-// GetByEntityID provides a mock function for the type MockMembershiper
-func (_mock *MockMembershiper) GetByEntityID(ctx context.Context, entityID string) ([]*model.Membership, error) {
-	ret := _mock.Called(ctx, entityID)
+// RequestExport provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) RequestExport(ctx context.Context, entityID string, userID string, filter model.AuditLogFilter, format model.AuditLogExportFormat) error {
+	ret := _mock.Called(ctx, entityID, userID, filter, format)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByEntityID")
+		panic("no return value specified for RequestExport")
 	}
 
-	var r0 []*model.Membership
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
-		return returnFunc(ctx, entityID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
-		r0 = returnFunc(ctx, entityID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Membership)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, entityID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, model.AuditLogFilter, model.AuditLogExportFormat) error); ok {
+		r0 = returnFunc(ctx, entityID, userID, filter, format)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMembershiper_GetByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEntityID'
-type MockMembershiper_GetByEntityID_Call struct {
+// MockAuditLoger_RequestExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestExport'
+type MockAuditLoger_RequestExport_Call struct {
 	*mock.Call
 }
 
-// GetByEntityID is a helper method to define mock.On call
+// RequestExport is a helper method to define mock.On call
 //   - ctx context.Context
 //   - entityID string
-func (_e *MockMembershiper_Expecter) GetByEntityID(ctx interface{}, entityID interface{}) *MockMembershiper_GetByEntityID_Call {
-	return &MockMembershiper_GetByEntityID_Call{Call: _e.mock.On("GetByEntityID", ctx, entityID)}
+//   - userID string
+//   - filter model.AuditLogFilter
+//   - format model.AuditLogExportFormat
+func (_e *MockAuditLoger_Expecter) RequestExport(ctx interface{}, entityID interface{}, userID interface{}, filter interface{}, format interface{}) *MockAuditLoger_RequestExport_Call {
+	return &MockAuditLoger_RequestExport_Call{Call: _e.mock.On("RequestExport", ctx, entityID, userID, filter, format)}
 }
 
-func (_c *MockMembershiper_GetByEntityID_Call) Run(run func(ctx context.Context, entityID string)) *MockMembershiper_GetByEntityID_Call {
+func (_c *MockAuditLoger_RequestExport_Call) Run(run func(ctx context.Context, entityID string, userID string, filter model.AuditLogFilter, format model.AuditLogExportFormat)) *MockAuditLoger_RequestExport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -327,65 +272,80 @@ func (_c *MockMembershiper_GetByEntityID_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 model.AuditLogFilter
+		if args[3] != nil {
+			arg3 = args[3].(model.AuditLogFilter)
+		}
+		var arg4 model.AuditLogExportFormat
+		if args[4] != nil {
+			arg4 = args[4].(model.AuditLogExportFormat)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMembershiper_GetByEntityID_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByEntityID_Call {
-	_c.Call.Return(memberships, err)
+func (_c *MockAuditLoger_RequestExport_Call) Return(err error) *MockAuditLoger_RequestExport_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMembershiper_GetByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.Membership, error)) *MockMembershiper_GetByEntityID_Call {
+func (_c *MockAuditLoger_RequestExport_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, filter model.AuditLogFilter, format model.AuditLogExportFormat) error) *MockAuditLoger_RequestExport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByUserID provides a mock function for the type MockMembershiper
-func (_mock *MockMembershiper) GetByUserID(ctx context.Context, userID string) ([]*model.Membership, error) {
-	ret := _mock.Called(ctx, userID)
+// Verify provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) Verify(ctx context.Context, chain string) ([]*service.AuditLogVerification, error) {
+	ret := _mock.Called(ctx, chain)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUserID")
+		panic("no return value specified for Verify")
 	}
 
-	var r0 []*model.Membership
+	var r0 []*service.AuditLogVerification
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*service.AuditLogVerification, error)); ok {
+		return returnFunc(ctx, chain)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*service.AuditLogVerification); ok {
+		r0 = returnFunc(ctx, chain)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Membership)
+			r0 = ret.Get(0).([]*service.AuditLogVerification)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+		r1 = returnFunc(ctx, chain)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMembershiper_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
-type MockMembershiper_GetByUserID_Call struct {
+// MockAuditLoger_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockAuditLoger_Verify_Call struct {
 	*mock.Call
 }
 
-// GetByUserID is a helper method to define mock.On call
+// Verify is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockMembershiper_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockMembershiper_GetByUserID_Call {
-	return &MockMembershiper_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+//   - chain string
+func (_e *MockAuditLoger_Expecter) Verify(ctx interface{}, chain interface{}) *MockAuditLoger_Verify_Call {
+	return &MockAuditLoger_Verify_Call{Call: _e.mock.On("Verify", ctx, chain)}
 }
 
-func (_c *MockMembershiper_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockMembershiper_GetByUserID_Call {
+func (_c *MockAuditLoger_Verify_Call) Run(run func(ctx context.Context, chain string)) *MockAuditLoger_Verify_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -403,57 +363,96 @@ func (_c *MockMembershiper_GetByUserID_Call) Run(run func(ctx context.Context, u
 	return _c
 }
 
-func (_c *MockMembershiper_GetByUserID_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByUserID_Call {
-	_c.Call.Return(memberships, err)
+func (_c *MockAuditLoger_Verify_Call) Return(auditLogVerifications []*service.AuditLogVerification, err error) *MockAuditLoger_Verify_Call {
+	_c.Call.Return(auditLogVerifications, err)
 	return _c
 }
 
-func (_c *MockMembershiper_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Membership, error)) *MockMembershiper_GetByUserID_Call {
+func (_c *MockAuditLoger_Verify_Call) RunAndReturn(run func(ctx context.Context, chain string) ([]*service.AuditLogVerification, error)) *MockAuditLoger_Verify_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByUserIDWithInheritance provides a mock function for the type MockMembershiper
-func (_mock *MockMembershiper) GetByUserIDWithInheritance(ctx context.Context, userID string) ([]*model.Membership, error) {
-	ret := _mock.Called(ctx, userID)
+// NewMockCompliancer creates a new instance of MockCompliancer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCompliancer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCompliancer {
+	mock := &MockCompliancer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCompliancer is an autogenerated mock type for the Compliancer type
+type MockCompliancer struct {
+	mock.Mock
+}
+
+type MockCompliancer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCompliancer) EXPECT() *MockCompliancer_Expecter {
+	return &MockCompliancer_Expecter{mock: &_m.Mock}
+}
+
+// AddDocument provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) AddDocument(ctx context.Context, entityID string, userID string, recordType model.ComplianceType, name string, contentType string) (*model.ComplianceDocument, *core.UploadInfo, error) {
+	ret := _mock.Called(ctx, entityID, userID, recordType, name, contentType)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUserIDWithInheritance")
+		panic("no return value specified for AddDocument")
 	}
 
-	var r0 []*model.Membership
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
-		return returnFunc(ctx, userID)
+	var r0 *model.ComplianceDocument
+	var r1 *core.UploadInfo
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, model.ComplianceType, string, string) (*model.ComplianceDocument, *core.UploadInfo, error)); ok {
+		return returnFunc(ctx, entityID, userID, recordType, name, contentType)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, model.ComplianceType, string, string) *model.ComplianceDocument); ok {
+		r0 = returnFunc(ctx, entityID, userID, recordType, name, contentType)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Membership)
+			r0 = ret.Get(0).(*model.ComplianceDocument)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, model.ComplianceType, string, string) *core.UploadInfo); ok {
+		r1 = returnFunc(ctx, entityID, userID, recordType, name, contentType)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*core.UploadInfo)
+		}
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, model.ComplianceType, string, string) error); ok {
+		r2 = returnFunc(ctx, entityID, userID, recordType, name, contentType)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockMembershiper_GetByUserIDWithInheritance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserIDWithInheritance'
-type MockMembershiper_GetByUserIDWithInheritance_Call struct {
+// MockCompliancer_AddDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDocument'
+type MockCompliancer_AddDocument_Call struct {
 	*mock.Call
 }
 
-// GetByUserIDWithInheritance is a helper method to define mock.On call
+// AddDocument is a helper method to define mock.On call
 //   - ctx context.Context
+//   - entityID string
 //   - userID string
-func (_e *MockMembershiper_Expecter) GetByUserIDWithInheritance(ctx interface{}, userID interface{}) *MockMembershiper_GetByUserIDWithInheritance_Call {
-	return &MockMembershiper_GetByUserIDWithInheritance_Call{Call: _e.mock.On("GetByUserIDWithInheritance", ctx, userID)}
+//   - recordType model.ComplianceType
+//   - name string
+//   - contentType string
+func (_e *MockCompliancer_Expecter) AddDocument(ctx interface{}, entityID interface{}, userID interface{}, recordType interface{}, name interface{}, contentType interface{}) *MockCompliancer_AddDocument_Call {
+	return &MockCompliancer_AddDocument_Call{Call: _e.mock.On("AddDocument", ctx, entityID, userID, recordType, name, contentType)}
 }
 
-func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) Run(run func(ctx context.Context, userID string)) *MockMembershiper_GetByUserIDWithInheritance_Call {
+func (_c *MockCompliancer_AddDocument_Call) Run(run func(ctx context.Context, entityID string, userID string, recordType model.ComplianceType, name string, contentType string)) *MockCompliancer_AddDocument_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -463,144 +462,155 @@ func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) Run(run func(ctx con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 model.ComplianceType
+		if args[3] != nil {
+			arg3 = args[3].(model.ComplianceType)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByUserIDWithInheritance_Call {
-	_c.Call.Return(memberships, err)
+func (_c *MockCompliancer_AddDocument_Call) Return(complianceDocument *model.ComplianceDocument, uploadInfo *core.UploadInfo, err error) *MockCompliancer_AddDocument_Call {
+	_c.Call.Return(complianceDocument, uploadInfo, err)
 	return _c
 }
 
-func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Membership, error)) *MockMembershiper_GetByUserIDWithInheritance_Call {
+func (_c *MockCompliancer_AddDocument_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, recordType model.ComplianceType, name string, contentType string) (*model.ComplianceDocument, *core.UploadInfo, error)) *MockCompliancer_AddDocument_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockSessioner creates a new instance of MockSessioner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockSessioner(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockSessioner {
-	mock := &MockSessioner{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockSessioner is an autogenerated mock type for the Sessioner type
-type MockSessioner struct {
-	mock.Mock
-}
-
-type MockSessioner_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockSessioner) EXPECT() *MockSessioner_Expecter {
-	return &MockSessioner_Expecter{mock: &_m.Mock}
-}
-
-// CleanUpExpired provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) CleanUpExpired(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// DeleteDocument provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) DeleteDocument(ctx context.Context, entityID string, userID string, documentID string) error {
+	ret := _mock.Called(ctx, entityID, userID, documentID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CleanUpExpired")
+		panic("no return value specified for DeleteDocument")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, entityID, userID, documentID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockSessioner_CleanUpExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanUpExpired'
-type MockSessioner_CleanUpExpired_Call struct {
+// MockCompliancer_DeleteDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDocument'
+type MockCompliancer_DeleteDocument_Call struct {
 	*mock.Call
 }
 
-// CleanUpExpired is a helper method to define mock.On call
+// DeleteDocument is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockSessioner_Expecter) CleanUpExpired(ctx interface{}) *MockSessioner_CleanUpExpired_Call {
-	return &MockSessioner_CleanUpExpired_Call{Call: _e.mock.On("CleanUpExpired", ctx)}
+//   - entityID string
+//   - userID string
+//   - documentID string
+func (_e *MockCompliancer_Expecter) DeleteDocument(ctx interface{}, entityID interface{}, userID interface{}, documentID interface{}) *MockCompliancer_DeleteDocument_Call {
+	return &MockCompliancer_DeleteDocument_Call{Call: _e.mock.On("DeleteDocument", ctx, entityID, userID, documentID)}
 }
 
-func (_c *MockSessioner_CleanUpExpired_Call) Run(run func(ctx context.Context)) *MockSessioner_CleanUpExpired_Call {
+func (_c *MockCompliancer_DeleteDocument_Call) Run(run func(ctx context.Context, entityID string, userID string, documentID string)) *MockCompliancer_DeleteDocument_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_CleanUpExpired_Call) Return(err error) *MockSessioner_CleanUpExpired_Call {
+func (_c *MockCompliancer_DeleteDocument_Call) Return(err error) *MockCompliancer_DeleteDocument_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSessioner_CleanUpExpired_Call) RunAndReturn(run func(ctx context.Context) error) *MockSessioner_CleanUpExpired_Call {
+func (_c *MockCompliancer_DeleteDocument_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, documentID string) error) *MockCompliancer_DeleteDocument_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Create provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) Create(ctx context.Context, email string, password string) (*model.Session, error) {
-	ret := _mock.Called(ctx, email, password)
+// GetReview provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) GetReview(ctx context.Context, platformID string, id string) (*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, platformID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for GetReview")
 	}
 
-	var r0 *model.Session
+	var r0 *model.ComplianceRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.Session, error)); ok {
-		return returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, platformID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.Session); ok {
-		r0 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, platformID, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Session)
+			r0 = ret.Get(0).(*model.ComplianceRecord)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, email, password)
+		r1 = returnFunc(ctx, platformID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSessioner_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockSessioner_Create_Call struct {
+// MockCompliancer_GetReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReview'
+type MockCompliancer_GetReview_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// GetReview is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-//   - password string
-func (_e *MockSessioner_Expecter) Create(ctx interface{}, email interface{}, password interface{}) *MockSessioner_Create_Call {
-	return &MockSessioner_Create_Call{Call: _e.mock.On("Create", ctx, email, password)}
+//   - platformID string
+//   - id string
+func (_e *MockCompliancer_Expecter) GetReview(ctx interface{}, platformID interface{}, id interface{}) *MockCompliancer_GetReview_Call {
+	return &MockCompliancer_GetReview_Call{Call: _e.mock.On("GetReview", ctx, platformID, id)}
 }
 
-func (_c *MockSessioner_Create_Call) Run(run func(ctx context.Context, email string, password string)) *MockSessioner_Create_Call {
+func (_c *MockCompliancer_GetReview_Call) Run(run func(ctx context.Context, platformID string, id string)) *MockCompliancer_GetReview_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -623,57 +633,57 @@ func (_c *MockSessioner_Create_Call) Run(run func(ctx context.Context, email str
 	return _c
 }
 
-func (_c *MockSessioner_Create_Call) Return(session *model.Session, err error) *MockSessioner_Create_Call {
-	_c.Call.Return(session, err)
+func (_c *MockCompliancer_GetReview_Call) Return(complianceRecord *model.ComplianceRecord, err error) *MockCompliancer_GetReview_Call {
+	_c.Call.Return(complianceRecord, err)
 	return _c
 }
 
-func (_c *MockSessioner_Create_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (*model.Session, error)) *MockSessioner_Create_Call {
+func (_c *MockCompliancer_GetReview_Call) RunAndReturn(run func(ctx context.Context, platformID string, id string) (*model.ComplianceRecord, error)) *MockCompliancer_GetReview_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByToken provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) GetByToken(ctx context.Context, token string) (*model.Session, error) {
-	ret := _mock.Called(ctx, token)
+// List provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) List(ctx context.Context, entityID string) ([]*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, entityID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByToken")
+		panic("no return value specified for List")
 	}
 
-	var r0 *model.Session
+	var r0 []*model.ComplianceRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
-		return returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, entityID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
-		r0 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, entityID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Session)
+			r0 = ret.Get(0).([]*model.ComplianceRecord)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, token)
+		r1 = returnFunc(ctx, entityID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSessioner_GetByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByToken'
-type MockSessioner_GetByToken_Call struct {
+// MockCompliancer_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockCompliancer_List_Call struct {
 	*mock.Call
 }
 
-// GetByToken is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-func (_e *MockSessioner_Expecter) GetByToken(ctx interface{}, token interface{}) *MockSessioner_GetByToken_Call {
-	return &MockSessioner_GetByToken_Call{Call: _e.mock.On("GetByToken", ctx, token)}
+//   - entityID string
+func (_e *MockCompliancer_Expecter) List(ctx interface{}, entityID interface{}) *MockCompliancer_List_Call {
+	return &MockCompliancer_List_Call{Call: _e.mock.On("List", ctx, entityID)}
 }
 
-func (_c *MockSessioner_GetByToken_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_GetByToken_Call {
+func (_c *MockCompliancer_List_Call) Run(run func(ctx context.Context, entityID string)) *MockCompliancer_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -691,57 +701,58 @@ func (_c *MockSessioner_GetByToken_Call) Run(run func(ctx context.Context, token
 	return _c
 }
 
-func (_c *MockSessioner_GetByToken_Call) Return(session *model.Session, err error) *MockSessioner_GetByToken_Call {
-	_c.Call.Return(session, err)
+func (_c *MockCompliancer_List_Call) Return(complianceRecords []*model.ComplianceRecord, err error) *MockCompliancer_List_Call {
+	_c.Call.Return(complianceRecords, err)
 	return _c
 }
 
-func (_c *MockSessioner_GetByToken_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.Session, error)) *MockSessioner_GetByToken_Call {
+func (_c *MockCompliancer_List_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.ComplianceRecord, error)) *MockCompliancer_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByTokenFull provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) GetByTokenFull(ctx context.Context, token string) (*model.Session, error) {
-	ret := _mock.Called(ctx, token)
+// ListReviews provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) ListReviews(ctx context.Context, platformID string, status model.ComplianceStatus) ([]*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, platformID, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByTokenFull")
+		panic("no return value specified for ListReviews")
 	}
 
-	var r0 *model.Session
+	var r0 []*model.ComplianceRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
-		return returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ComplianceStatus) ([]*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, platformID, status)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
-		r0 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ComplianceStatus) []*model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, platformID, status)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Session)
+			r0 = ret.Get(0).([]*model.ComplianceRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.ComplianceStatus) error); ok {
+		r1 = returnFunc(ctx, platformID, status)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSessioner_GetByTokenFull_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTokenFull'
-type MockSessioner_GetByTokenFull_Call struct {
+// MockCompliancer_ListReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReviews'
+type MockCompliancer_ListReviews_Call struct {
 	*mock.Call
 }
 
-// GetByTokenFull is a helper method to define mock.On call
+// ListReviews is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-func (_e *MockSessioner_Expecter) GetByTokenFull(ctx interface{}, token interface{}) *MockSessioner_GetByTokenFull_Call {
-	return &MockSessioner_GetByTokenFull_Call{Call: _e.mock.On("GetByTokenFull", ctx, token)}
+//   - platformID string
+//   - status model.ComplianceStatus
+func (_e *MockCompliancer_Expecter) ListReviews(ctx interface{}, platformID interface{}, status interface{}) *MockCompliancer_ListReviews_Call {
+	return &MockCompliancer_ListReviews_Call{Call: _e.mock.On("ListReviews", ctx, platformID, status)}
 }
 
-func (_c *MockSessioner_GetByTokenFull_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_GetByTokenFull_Call {
+func (_c *MockCompliancer_ListReviews_Call) Run(run func(ctx context.Context, platformID string, status model.ComplianceStatus)) *MockCompliancer_ListReviews_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -751,54 +762,74 @@ func (_c *MockSessioner_GetByTokenFull_Call) Run(run func(ctx context.Context, t
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 model.ComplianceStatus
+		if args[2] != nil {
+			arg2 = args[2].(model.ComplianceStatus)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_GetByTokenFull_Call) Return(session *model.Session, err error) *MockSessioner_GetByTokenFull_Call {
-	_c.Call.Return(session, err)
+func (_c *MockCompliancer_ListReviews_Call) Return(complianceRecords []*model.ComplianceRecord, err error) *MockCompliancer_ListReviews_Call {
+	_c.Call.Return(complianceRecords, err)
 	return _c
 }
 
-func (_c *MockSessioner_GetByTokenFull_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.Session, error)) *MockSessioner_GetByTokenFull_Call {
+func (_c *MockCompliancer_ListReviews_Call) RunAndReturn(run func(ctx context.Context, platformID string, status model.ComplianceStatus) ([]*model.ComplianceRecord, error)) *MockCompliancer_ListReviews_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Invalidate provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) Invalidate(ctx context.Context, token string) error {
-	ret := _mock.Called(ctx, token)
+// Review provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) Review(ctx context.Context, platformID string, reviewerID string, id string, status model.ComplianceStatus, reason string) (*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, platformID, reviewerID, id, status, reason)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Invalidate")
+		panic("no return value specified for Review")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, token)
+	var r0 *model.ComplianceRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, model.ComplianceStatus, string) (*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, platformID, reviewerID, id, status, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, model.ComplianceStatus, string) *model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, platformID, reviewerID, id, status, reason)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ComplianceRecord)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, model.ComplianceStatus, string) error); ok {
+		r1 = returnFunc(ctx, platformID, reviewerID, id, status, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockSessioner_Invalidate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invalidate'
-type MockSessioner_Invalidate_Call struct {
+// MockCompliancer_Review_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Review'
+type MockCompliancer_Review_Call struct {
 	*mock.Call
 }
 
-// Invalidate is a helper method to define mock.On call
+// Review is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-func (_e *MockSessioner_Expecter) Invalidate(ctx interface{}, token interface{}) *MockSessioner_Invalidate_Call {
-	return &MockSessioner_Invalidate_Call{Call: _e.mock.On("Invalidate", ctx, token)}
+//   - platformID string
+//   - reviewerID string
+//   - id string
+//   - status model.ComplianceStatus
+//   - reason string
+func (_e *MockCompliancer_Expecter) Review(ctx interface{}, platformID interface{}, reviewerID interface{}, id interface{}, status interface{}, reason interface{}) *MockCompliancer_Review_Call {
+	return &MockCompliancer_Review_Call{Call: _e.mock.On("Review", ctx, platformID, reviewerID, id, status, reason)}
 }
 
-func (_c *MockSessioner_Invalidate_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_Invalidate_Call {
+func (_c *MockCompliancer_Review_Call) Run(run func(ctx context.Context, platformID string, reviewerID string, id string, status model.ComplianceStatus, reason string)) *MockCompliancer_Review_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -808,55 +839,87 @@ func (_c *MockSessioner_Invalidate_Call) Run(run func(ctx context.Context, token
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 model.ComplianceStatus
+		if args[4] != nil {
+			arg4 = args[4].(model.ComplianceStatus)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_Invalidate_Call) Return(err error) *MockSessioner_Invalidate_Call {
-	_c.Call.Return(err)
+func (_c *MockCompliancer_Review_Call) Return(complianceRecord *model.ComplianceRecord, err error) *MockCompliancer_Review_Call {
+	_c.Call.Return(complianceRecord, err)
 	return _c
 }
 
-func (_c *MockSessioner_Invalidate_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockSessioner_Invalidate_Call {
+func (_c *MockCompliancer_Review_Call) RunAndReturn(run func(ctx context.Context, platformID string, reviewerID string, id string, status model.ComplianceStatus, reason string) (*model.ComplianceRecord, error)) *MockCompliancer_Review_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InvalidateAllSessions provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) InvalidateAllSessions(ctx context.Context, userID string, token string) error {
-	ret := _mock.Called(ctx, userID, token)
+// SubmitKYB provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) SubmitKYB(ctx context.Context, entityID string, userID string, information service.KYBInformation) (*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, entityID, userID, information)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InvalidateAllSessions")
+		panic("no return value specified for SubmitKYB")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, userID, token)
+	var r0 *model.ComplianceRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, service.KYBInformation) (*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, entityID, userID, information)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, service.KYBInformation) *model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, entityID, userID, information)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ComplianceRecord)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, service.KYBInformation) error); ok {
+		r1 = returnFunc(ctx, entityID, userID, information)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockSessioner_InvalidateAllSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateAllSessions'
-type MockSessioner_InvalidateAllSessions_Call struct {
+// MockCompliancer_SubmitKYB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubmitKYB'
+type MockCompliancer_SubmitKYB_Call struct {
 	*mock.Call
 }
 
-// InvalidateAllSessions is a helper method to define mock.On call
+// SubmitKYB is a helper method to define mock.On call
 //   - ctx context.Context
+//   - entityID string
 //   - userID string
-//   - token string
-func (_e *MockSessioner_Expecter) InvalidateAllSessions(ctx interface{}, userID interface{}, token interface{}) *MockSessioner_InvalidateAllSessions_Call {
-	return &MockSessioner_InvalidateAllSessions_Call{Call: _e.mock.On("InvalidateAllSessions", ctx, userID, token)}
+//   - information service.KYBInformation
+func (_e *MockCompliancer_Expecter) SubmitKYB(ctx interface{}, entityID interface{}, userID interface{}, information interface{}) *MockCompliancer_SubmitKYB_Call {
+	return &MockCompliancer_SubmitKYB_Call{Call: _e.mock.On("SubmitKYB", ctx, entityID, userID, information)}
 }
 
-func (_c *MockSessioner_InvalidateAllSessions_Call) Run(run func(ctx context.Context, userID string, token string)) *MockSessioner_InvalidateAllSessions_Call {
+func (_c *MockCompliancer_SubmitKYB_Call) Run(run func(ctx context.Context, entityID string, userID string, information service.KYBInformation)) *MockCompliancer_SubmitKYB_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -870,66 +933,5199 @@ func (_c *MockSessioner_InvalidateAllSessions_Call) Run(run func(ctx context.Con
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 service.KYBInformation
+		if args[3] != nil {
+			arg3 = args[3].(service.KYBInformation)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateAllSessions_Call) Return(err error) *MockSessioner_InvalidateAllSessions_Call {
-	_c.Call.Return(err)
+func (_c *MockCompliancer_SubmitKYB_Call) Return(complianceRecord *model.ComplianceRecord, err error) *MockCompliancer_SubmitKYB_Call {
+	_c.Call.Return(complianceRecord, err)
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateAllSessions_Call) RunAndReturn(run func(ctx context.Context, userID string, token string) error) *MockSessioner_InvalidateAllSessions_Call {
+func (_c *MockCompliancer_SubmitKYB_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, information service.KYBInformation) (*model.ComplianceRecord, error)) *MockCompliancer_SubmitKYB_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InvalidateByID provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) InvalidateByID(ctx context.Context, token string, sessionID string) error {
-	ret := _mock.Called(ctx, token, sessionID)
+// NewMockEntityer creates a new instance of MockEntityer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEntityer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEntityer {
+	mock := &MockEntityer{}
+	mock.Mock.Test(t)
 
-	if len(ret) == 0 {
-		panic("no return value specified for InvalidateByID")
-	}
+	t.Cleanup(func() { mock.AssertExpectations(t) })
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, token, sessionID)
-	} else {
-		r0 = ret.Error(0)
-	}
-	return r0
+	return mock
 }
 
-// MockSessioner_InvalidateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateByID'
-type MockSessioner_InvalidateByID_Call struct {
-	*mock.Call
+// MockEntityer is an autogenerated mock type for the Entityer type
+type MockEntityer struct {
+	mock.Mock
 }
 
-// InvalidateByID is a helper method to define mock.On call
-//   - ctx context.Context
-//   - token string
-//   - sessionID string
-func (_e *MockSessioner_Expecter) InvalidateByID(ctx interface{}, token interface{}, sessionID interface{}) *MockSessioner_InvalidateByID_Call {
-	return &MockSessioner_InvalidateByID_Call{Call: _e.mock.On("InvalidateByID", ctx, token, sessionID)}
+type MockEntityer_Expecter struct {
+	mock *mock.Mock
 }
 
-func (_c *MockSessioner_InvalidateByID_Call) Run(run func(ctx context.Context, token string, sessionID string)) *MockSessioner_InvalidateByID_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
+func (_m *MockEntityer) EXPECT() *MockEntityer_Expecter {
+	return &MockEntityer_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) Get(ctx context.Context, id string) (*model.Entity, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Entity, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Entity); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockEntityer_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEntityer_Expecter) Get(ctx interface{}, id interface{}) *MockEntityer_Get_Call {
+	return &MockEntityer_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockEntityer_Get_Call) Run(run func(ctx context.Context, id string)) *MockEntityer_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_Get_Call) Return(entity *model.Entity, err error) *MockEntityer_Get_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockEntityer_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Entity, error)) *MockEntityer_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) GetByID(ctx context.Context, id string) (*model.Entity, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Entity, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Entity); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockEntityer_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEntityer_Expecter) GetByID(ctx interface{}, id interface{}) *MockEntityer_GetByID_Call {
+	return &MockEntityer_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockEntityer_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockEntityer_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_GetByID_Call) Return(entity *model.Entity, err error) *MockEntityer_GetByID_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockEntityer_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Entity, error)) *MockEntityer_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySlug provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) GetBySlug(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error) {
+	ret := _mock.Called(ctx, mode, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 *model.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, types.OperationMode, string) (*model.Entity, error)); ok {
+		return returnFunc(ctx, mode, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, types.OperationMode, string) *model.Entity); ok {
+		r0 = returnFunc(ctx, mode, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, types.OperationMode, string) error); ok {
+		r1 = returnFunc(ctx, mode, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
+type MockEntityer_GetBySlug_Call struct {
+	*mock.Call
+}
+
+// GetBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mode types.OperationMode
+//   - slug string
+func (_e *MockEntityer_Expecter) GetBySlug(ctx interface{}, mode interface{}, slug interface{}) *MockEntityer_GetBySlug_Call {
+	return &MockEntityer_GetBySlug_Call{Call: _e.mock.On("GetBySlug", ctx, mode, slug)}
+}
+
+func (_c *MockEntityer_GetBySlug_Call) Run(run func(ctx context.Context, mode types.OperationMode, slug string)) *MockEntityer_GetBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 types.OperationMode
+		if args[1] != nil {
+			arg1 = args[1].(types.OperationMode)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_GetBySlug_Call) Return(entity *model.Entity, err error) *MockEntityer_GetBySlug_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockEntityer_GetBySlug_Call) RunAndReturn(run func(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error)) *MockEntityer_GetBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessLogo provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) ProcessLogo(ctx context.Context, id string, imageID string) error {
+	ret := _mock.Called(ctx, id, imageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessLogo")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, imageID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEntityer_ProcessLogo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessLogo'
+type MockEntityer_ProcessLogo_Call struct {
+	*mock.Call
+}
+
+// ProcessLogo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - imageID string
+func (_e *MockEntityer_Expecter) ProcessLogo(ctx interface{}, id interface{}, imageID interface{}) *MockEntityer_ProcessLogo_Call {
+	return &MockEntityer_ProcessLogo_Call{Call: _e.mock.On("ProcessLogo", ctx, id, imageID)}
+}
+
+func (_c *MockEntityer_ProcessLogo_Call) Run(run func(ctx context.Context, id string, imageID string)) *MockEntityer_ProcessLogo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_ProcessLogo_Call) Return(err error) *MockEntityer_ProcessLogo_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEntityer_ProcessLogo_Call) RunAndReturn(run func(ctx context.Context, id string, imageID string) error) *MockEntityer_ProcessLogo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBranding provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) UpdateBranding(ctx context.Context, userID string, id string, brandColor *string, supportEmail *string) (*model.Entity, error) {
+	ret := _mock.Called(ctx, userID, id, brandColor, supportEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBranding")
+	}
+
+	var r0 *model.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *string, *string) (*model.Entity, error)); ok {
+		return returnFunc(ctx, userID, id, brandColor, supportEmail)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *string, *string) *model.Entity); ok {
+		r0 = returnFunc(ctx, userID, id, brandColor, supportEmail)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *string, *string) error); ok {
+		r1 = returnFunc(ctx, userID, id, brandColor, supportEmail)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_UpdateBranding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBranding'
+type MockEntityer_UpdateBranding_Call struct {
+	*mock.Call
+}
+
+// UpdateBranding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - id string
+//   - brandColor *string
+//   - supportEmail *string
+func (_e *MockEntityer_Expecter) UpdateBranding(ctx interface{}, userID interface{}, id interface{}, brandColor interface{}, supportEmail interface{}) *MockEntityer_UpdateBranding_Call {
+	return &MockEntityer_UpdateBranding_Call{Call: _e.mock.On("UpdateBranding", ctx, userID, id, brandColor, supportEmail)}
+}
+
+func (_c *MockEntityer_UpdateBranding_Call) Run(run func(ctx context.Context, userID string, id string, brandColor *string, supportEmail *string)) *MockEntityer_UpdateBranding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *string
+		if args[3] != nil {
+			arg3 = args[3].(*string)
+		}
+		var arg4 *string
+		if args[4] != nil {
+			arg4 = args[4].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_UpdateBranding_Call) Return(entity *model.Entity, err error) *MockEntityer_UpdateBranding_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockEntityer_UpdateBranding_Call) RunAndReturn(run func(ctx context.Context, userID string, id string, brandColor *string, supportEmail *string) (*model.Entity, error)) *MockEntityer_UpdateBranding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLogo provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) UpdateLogo(ctx context.Context, userID string, id string, data []byte) error {
+	ret := _mock.Called(ctx, userID, id, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLogo")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = returnFunc(ctx, userID, id, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEntityer_UpdateLogo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLogo'
+type MockEntityer_UpdateLogo_Call struct {
+	*mock.Call
+}
+
+// UpdateLogo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - id string
+//   - data []byte
+func (_e *MockEntityer_Expecter) UpdateLogo(ctx interface{}, userID interface{}, id interface{}, data interface{}) *MockEntityer_UpdateLogo_Call {
+	return &MockEntityer_UpdateLogo_Call{Call: _e.mock.On("UpdateLogo", ctx, userID, id, data)}
+}
+
+func (_c *MockEntityer_UpdateLogo_Call) Run(run func(ctx context.Context, userID string, id string, data []byte)) *MockEntityer_UpdateLogo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_UpdateLogo_Call) Return(err error) *MockEntityer_UpdateLogo_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEntityer_UpdateLogo_Call) RunAndReturn(run func(ctx context.Context, userID string, id string, data []byte) error) *MockEntityer_UpdateLogo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) UpdateStatus(ctx context.Context, id string, status model.EntityStatus, userID string) (*model.Entity, error) {
+	ret := _mock.Called(ctx, id, status, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 *model.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.EntityStatus, string) (*model.Entity, error)); ok {
+		return returnFunc(ctx, id, status, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.EntityStatus, string) *model.Entity); ok {
+		r0 = returnFunc(ctx, id, status, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.EntityStatus, string) error); ok {
+		r1 = returnFunc(ctx, id, status, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type MockEntityer_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status model.EntityStatus
+//   - userID string
+func (_e *MockEntityer_Expecter) UpdateStatus(ctx interface{}, id interface{}, status interface{}, userID interface{}) *MockEntityer_UpdateStatus_Call {
+	return &MockEntityer_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, status, userID)}
+}
+
+func (_c *MockEntityer_UpdateStatus_Call) Run(run func(ctx context.Context, id string, status model.EntityStatus, userID string)) *MockEntityer_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.EntityStatus
+		if args[2] != nil {
+			arg2 = args[2].(model.EntityStatus)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_UpdateStatus_Call) Return(entity *model.Entity, err error) *MockEntityer_UpdateStatus_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockEntityer_UpdateStatus_Call) RunAndReturn(run func(ctx context.Context, id string, status model.EntityStatus, userID string) (*model.Entity, error)) *MockEntityer_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEntityDomainer creates a new instance of MockEntityDomainer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEntityDomainer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEntityDomainer {
+	mock := &MockEntityDomainer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEntityDomainer is an autogenerated mock type for the EntityDomainer type
+type MockEntityDomainer struct {
+	mock.Mock
+}
+
+type MockEntityDomainer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEntityDomainer) EXPECT() *MockEntityDomainer_Expecter {
+	return &MockEntityDomainer_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Create(ctx context.Context, userID string, domain *model.EntityDomain) (*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, userID, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.EntityDomain) (*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, userID, domain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.EntityDomain) *model.EntityDomain); ok {
+		r0 = returnFunc(ctx, userID, domain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *model.EntityDomain) error); ok {
+		r1 = returnFunc(ctx, userID, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockEntityDomainer_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - domain *model.EntityDomain
+func (_e *MockEntityDomainer_Expecter) Create(ctx interface{}, userID interface{}, domain interface{}) *MockEntityDomainer_Create_Call {
+	return &MockEntityDomainer_Create_Call{Call: _e.mock.On("Create", ctx, userID, domain)}
+}
+
+func (_c *MockEntityDomainer_Create_Call) Run(run func(ctx context.Context, userID string, domain *model.EntityDomain)) *MockEntityDomainer_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *model.EntityDomain
+		if args[2] != nil {
+			arg2 = args[2].(*model.EntityDomain)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Create_Call) Return(entityDomain *model.EntityDomain, err error) *MockEntityDomainer_Create_Call {
+	_c.Call.Return(entityDomain, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Create_Call) RunAndReturn(run func(ctx context.Context, userID string, domain *model.EntityDomain) (*model.EntityDomain, error)) *MockEntityDomainer_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Delete(ctx context.Context, entityID string, userID string, id string) error {
+	ret := _mock.Called(ctx, entityID, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, entityID, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEntityDomainer_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockEntityDomainer_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+//   - id string
+func (_e *MockEntityDomainer_Expecter) Delete(ctx interface{}, entityID interface{}, userID interface{}, id interface{}) *MockEntityDomainer_Delete_Call {
+	return &MockEntityDomainer_Delete_Call{Call: _e.mock.On("Delete", ctx, entityID, userID, id)}
+}
+
+func (_c *MockEntityDomainer_Delete_Call) Run(run func(ctx context.Context, entityID string, userID string, id string)) *MockEntityDomainer_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Delete_Call) Return(err error) *MockEntityDomainer_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Delete_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, id string) error) *MockEntityDomainer_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Join provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Join(ctx context.Context, userID string, entityID string) (*model.Membership, error) {
+	ret := _mock.Called(ctx, userID, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Join")
+	}
+
+	var r0 *model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.Membership, error)); ok {
+		return returnFunc(ctx, userID, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.Membership); ok {
+		r0 = returnFunc(ctx, userID, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_Join_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Join'
+type MockEntityDomainer_Join_Call struct {
+	*mock.Call
+}
+
+// Join is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - entityID string
+func (_e *MockEntityDomainer_Expecter) Join(ctx interface{}, userID interface{}, entityID interface{}) *MockEntityDomainer_Join_Call {
+	return &MockEntityDomainer_Join_Call{Call: _e.mock.On("Join", ctx, userID, entityID)}
+}
+
+func (_c *MockEntityDomainer_Join_Call) Run(run func(ctx context.Context, userID string, entityID string)) *MockEntityDomainer_Join_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Join_Call) Return(membership *model.Membership, err error) *MockEntityDomainer_Join_Call {
+	_c.Call.Return(membership, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Join_Call) RunAndReturn(run func(ctx context.Context, userID string, entityID string) (*model.Membership, error)) *MockEntityDomainer_Join_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) List(ctx context.Context, entityID string) ([]*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.EntityDomain); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockEntityDomainer_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockEntityDomainer_Expecter) List(ctx interface{}, entityID interface{}) *MockEntityDomainer_List_Call {
+	return &MockEntityDomainer_List_Call{Call: _e.mock.On("List", ctx, entityID)}
+}
+
+func (_c *MockEntityDomainer_List_Call) Run(run func(ctx context.Context, entityID string)) *MockEntityDomainer_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_List_Call) Return(entityDomains []*model.EntityDomain, err error) *MockEntityDomainer_List_Call {
+	_c.Call.Return(entityDomains, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_List_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.EntityDomain, error)) *MockEntityDomainer_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListJoinable provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) ListJoinable(ctx context.Context, userID string) ([]*model.JoinableEntity, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJoinable")
+	}
+
+	var r0 []*model.JoinableEntity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.JoinableEntity, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.JoinableEntity); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.JoinableEntity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_ListJoinable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJoinable'
+type MockEntityDomainer_ListJoinable_Call struct {
+	*mock.Call
+}
+
+// ListJoinable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockEntityDomainer_Expecter) ListJoinable(ctx interface{}, userID interface{}) *MockEntityDomainer_ListJoinable_Call {
+	return &MockEntityDomainer_ListJoinable_Call{Call: _e.mock.On("ListJoinable", ctx, userID)}
+}
+
+func (_c *MockEntityDomainer_ListJoinable_Call) Run(run func(ctx context.Context, userID string)) *MockEntityDomainer_ListJoinable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_ListJoinable_Call) Return(joinableEntitys []*model.JoinableEntity, err error) *MockEntityDomainer_ListJoinable_Call {
+	_c.Call.Return(joinableEntitys, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_ListJoinable_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.JoinableEntity, error)) *MockEntityDomainer_ListJoinable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Update(ctx context.Context, userID string, domain *model.EntityDomain) (*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, userID, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.EntityDomain) (*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, userID, domain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.EntityDomain) *model.EntityDomain); ok {
+		r0 = returnFunc(ctx, userID, domain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *model.EntityDomain) error); ok {
+		r1 = returnFunc(ctx, userID, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockEntityDomainer_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - domain *model.EntityDomain
+func (_e *MockEntityDomainer_Expecter) Update(ctx interface{}, userID interface{}, domain interface{}) *MockEntityDomainer_Update_Call {
+	return &MockEntityDomainer_Update_Call{Call: _e.mock.On("Update", ctx, userID, domain)}
+}
+
+func (_c *MockEntityDomainer_Update_Call) Run(run func(ctx context.Context, userID string, domain *model.EntityDomain)) *MockEntityDomainer_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *model.EntityDomain
+		if args[2] != nil {
+			arg2 = args[2].(*model.EntityDomain)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Update_Call) Return(entityDomain *model.EntityDomain, err error) *MockEntityDomainer_Update_Call {
+	_c.Call.Return(entityDomain, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Update_Call) RunAndReturn(run func(ctx context.Context, userID string, domain *model.EntityDomain) (*model.EntityDomain, error)) *MockEntityDomainer_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Verify(ctx context.Context, entityID string, userID string, id string) (*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, entityID, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, entityID, userID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *model.EntityDomain); ok {
+		r0 = returnFunc(ctx, entityID, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, entityID, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockEntityDomainer_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+//   - id string
+func (_e *MockEntityDomainer_Expecter) Verify(ctx interface{}, entityID interface{}, userID interface{}, id interface{}) *MockEntityDomainer_Verify_Call {
+	return &MockEntityDomainer_Verify_Call{Call: _e.mock.On("Verify", ctx, entityID, userID, id)}
+}
+
+func (_c *MockEntityDomainer_Verify_Call) Run(run func(ctx context.Context, entityID string, userID string, id string)) *MockEntityDomainer_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Verify_Call) Return(entityDomain *model.EntityDomain, err error) *MockEntityDomainer_Verify_Call {
+	_c.Call.Return(entityDomain, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Verify_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, id string) (*model.EntityDomain, error)) *MockEntityDomainer_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImpersonationer creates a new instance of MockImpersonationer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImpersonationer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImpersonationer {
+	mock := &MockImpersonationer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockImpersonationer is an autogenerated mock type for the Impersonationer type
+type MockImpersonationer struct {
+	mock.Mock
+}
+
+type MockImpersonationer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImpersonationer) EXPECT() *MockImpersonationer_Expecter {
+	return &MockImpersonationer_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function for the type MockImpersonationer
+func (_mock *MockImpersonationer) Record(ctx context.Context, method string, path string, allowed bool) error {
+	ret := _mock.Called(ctx, method, path, allowed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = returnFunc(ctx, method, path, allowed)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockImpersonationer_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockImpersonationer_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - method string
+//   - path string
+//   - allowed bool
+func (_e *MockImpersonationer_Expecter) Record(ctx interface{}, method interface{}, path interface{}, allowed interface{}) *MockImpersonationer_Record_Call {
+	return &MockImpersonationer_Record_Call{Call: _e.mock.On("Record", ctx, method, path, allowed)}
+}
+
+func (_c *MockImpersonationer_Record_Call) Run(run func(ctx context.Context, method string, path string, allowed bool)) *MockImpersonationer_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImpersonationer_Record_Call) Return(err error) *MockImpersonationer_Record_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockImpersonationer_Record_Call) RunAndReturn(run func(ctx context.Context, method string, path string, allowed bool) error) *MockImpersonationer_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function for the type MockImpersonationer
+func (_mock *MockImpersonationer) Start(ctx context.Context, entityID string, actorID string, userID string, reason string) (*model.Session, error) {
+	ret := _mock.Called(ctx, entityID, actorID, userID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, entityID, actorID, userID, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) *model.Session); ok {
+		r0 = returnFunc(ctx, entityID, actorID, userID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, entityID, actorID, userID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImpersonationer_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockImpersonationer_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - actorID string
+//   - userID string
+//   - reason string
+func (_e *MockImpersonationer_Expecter) Start(ctx interface{}, entityID interface{}, actorID interface{}, userID interface{}, reason interface{}) *MockImpersonationer_Start_Call {
+	return &MockImpersonationer_Start_Call{Call: _e.mock.On("Start", ctx, entityID, actorID, userID, reason)}
+}
+
+func (_c *MockImpersonationer_Start_Call) Run(run func(ctx context.Context, entityID string, actorID string, userID string, reason string)) *MockImpersonationer_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImpersonationer_Start_Call) Return(session *model.Session, err error) *MockImpersonationer_Start_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockImpersonationer_Start_Call) RunAndReturn(run func(ctx context.Context, entityID string, actorID string, userID string, reason string) (*model.Session, error)) *MockImpersonationer_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stop provides a mock function for the type MockImpersonationer
+func (_mock *MockImpersonationer) Stop(ctx context.Context, token string, impersonatorToken string) (*model.Session, error) {
+	ret := _mock.Called(ctx, token, impersonatorToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, token, impersonatorToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.Session); ok {
+		r0 = returnFunc(ctx, token, impersonatorToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, token, impersonatorToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImpersonationer_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockImpersonationer_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - impersonatorToken string
+func (_e *MockImpersonationer_Expecter) Stop(ctx interface{}, token interface{}, impersonatorToken interface{}) *MockImpersonationer_Stop_Call {
+	return &MockImpersonationer_Stop_Call{Call: _e.mock.On("Stop", ctx, token, impersonatorToken)}
+}
+
+func (_c *MockImpersonationer_Stop_Call) Run(run func(ctx context.Context, token string, impersonatorToken string)) *MockImpersonationer_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImpersonationer_Stop_Call) Return(session *model.Session, err error) *MockImpersonationer_Stop_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockImpersonationer_Stop_Call) RunAndReturn(run func(ctx context.Context, token string, impersonatorToken string) (*model.Session, error)) *MockImpersonationer_Stop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIPAllowlister creates a new instance of MockIPAllowlister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIPAllowlister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIPAllowlister {
+	mock := &MockIPAllowlister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIPAllowlister is an autogenerated mock type for the IPAllowlister type
+type MockIPAllowlister struct {
+	mock.Mock
+}
+
+type MockIPAllowlister_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIPAllowlister) EXPECT() *MockIPAllowlister_Expecter {
+	return &MockIPAllowlister_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function for the type MockIPAllowlister
+func (_mock *MockIPAllowlister) Check(ctx context.Context, entityID string, ip string) error {
+	ret := _mock.Called(ctx, entityID, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, entityID, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIPAllowlister_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type MockIPAllowlister_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - ip string
+func (_e *MockIPAllowlister_Expecter) Check(ctx interface{}, entityID interface{}, ip interface{}) *MockIPAllowlister_Check_Call {
+	return &MockIPAllowlister_Check_Call{Call: _e.mock.On("Check", ctx, entityID, ip)}
+}
+
+func (_c *MockIPAllowlister_Check_Call) Run(run func(ctx context.Context, entityID string, ip string)) *MockIPAllowlister_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIPAllowlister_Check_Call) Return(err error) *MockIPAllowlister_Check_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIPAllowlister_Check_Call) RunAndReturn(run func(ctx context.Context, entityID string, ip string) error) *MockIPAllowlister_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockIPAllowlister
+func (_mock *MockIPAllowlister) List(ctx context.Context, entityID string) (model.IPAllowlist, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 model.IPAllowlist
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.IPAllowlist, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.IPAllowlist); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.IPAllowlist)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIPAllowlister_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockIPAllowlister_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockIPAllowlister_Expecter) List(ctx interface{}, entityID interface{}) *MockIPAllowlister_List_Call {
+	return &MockIPAllowlister_List_Call{Call: _e.mock.On("List", ctx, entityID)}
+}
+
+func (_c *MockIPAllowlister_List_Call) Run(run func(ctx context.Context, entityID string)) *MockIPAllowlister_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIPAllowlister_List_Call) Return(iPAllowlist model.IPAllowlist, err error) *MockIPAllowlister_List_Call {
+	_c.Call.Return(iPAllowlist, err)
+	return _c
+}
+
+func (_c *MockIPAllowlister_List_Call) RunAndReturn(run func(ctx context.Context, entityID string) (model.IPAllowlist, error)) *MockIPAllowlister_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockIPAllowlister
+func (_mock *MockIPAllowlister) Update(ctx context.Context, entityID string, userID string, entries model.IPAllowlist) (model.IPAllowlist, error) {
+	ret := _mock.Called(ctx, entityID, userID, entries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 model.IPAllowlist
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, model.IPAllowlist) (model.IPAllowlist, error)); ok {
+		return returnFunc(ctx, entityID, userID, entries)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, model.IPAllowlist) model.IPAllowlist); ok {
+		r0 = returnFunc(ctx, entityID, userID, entries)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.IPAllowlist)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, model.IPAllowlist) error); ok {
+		r1 = returnFunc(ctx, entityID, userID, entries)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIPAllowlister_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockIPAllowlister_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+//   - entries model.IPAllowlist
+func (_e *MockIPAllowlister_Expecter) Update(ctx interface{}, entityID interface{}, userID interface{}, entries interface{}) *MockIPAllowlister_Update_Call {
+	return &MockIPAllowlister_Update_Call{Call: _e.mock.On("Update", ctx, entityID, userID, entries)}
+}
+
+func (_c *MockIPAllowlister_Update_Call) Run(run func(ctx context.Context, entityID string, userID string, entries model.IPAllowlist)) *MockIPAllowlister_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 model.IPAllowlist
+		if args[3] != nil {
+			arg3 = args[3].(model.IPAllowlist)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIPAllowlister_Update_Call) Return(iPAllowlist model.IPAllowlist, err error) *MockIPAllowlister_Update_Call {
+	_c.Call.Return(iPAllowlist, err)
+	return _c
+}
+
+func (_c *MockIPAllowlister_Update_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, entries model.IPAllowlist) (model.IPAllowlist, error)) *MockIPAllowlister_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMembershiper creates a new instance of MockMembershiper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMembershiper(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMembershiper {
+	mock := &MockMembershiper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMembershiper is an autogenerated mock type for the Membershiper type
+type MockMembershiper struct {
+	mock.Mock
+}
+
+type MockMembershiper_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMembershiper) EXPECT() *MockMembershiper_Expecter {
+	return &MockMembershiper_Expecter{mock: &_m.Mock}
+}
+
+// GetByEntityID provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) GetByEntityID(ctx context.Context, entityID string) ([]*model.Membership, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEntityID")
+	}
+
+	var r0 []*model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMembershiper_GetByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEntityID'
+type MockMembershiper_GetByEntityID_Call struct {
+	*mock.Call
+}
+
+// GetByEntityID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockMembershiper_Expecter) GetByEntityID(ctx interface{}, entityID interface{}) *MockMembershiper_GetByEntityID_Call {
+	return &MockMembershiper_GetByEntityID_Call{Call: _e.mock.On("GetByEntityID", ctx, entityID)}
+}
+
+func (_c *MockMembershiper_GetByEntityID_Call) Run(run func(ctx context.Context, entityID string)) *MockMembershiper_GetByEntityID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_GetByEntityID_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByEntityID_Call {
+	_c.Call.Return(memberships, err)
+	return _c
+}
+
+func (_c *MockMembershiper_GetByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.Membership, error)) *MockMembershiper_GetByEntityID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) GetByUserID(ctx context.Context, userID string) ([]*model.Membership, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []*model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMembershiper_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockMembershiper_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockMembershiper_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockMembershiper_GetByUserID_Call {
+	return &MockMembershiper_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+}
+
+func (_c *MockMembershiper_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockMembershiper_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_GetByUserID_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByUserID_Call {
+	_c.Call.Return(memberships, err)
+	return _c
+}
+
+func (_c *MockMembershiper_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Membership, error)) *MockMembershiper_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserIDWithInheritance provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) GetByUserIDWithInheritance(ctx context.Context, userID string) ([]*model.Membership, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserIDWithInheritance")
+	}
+
+	var r0 []*model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMembershiper_GetByUserIDWithInheritance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserIDWithInheritance'
+type MockMembershiper_GetByUserIDWithInheritance_Call struct {
+	*mock.Call
+}
+
+// GetByUserIDWithInheritance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockMembershiper_Expecter) GetByUserIDWithInheritance(ctx interface{}, userID interface{}) *MockMembershiper_GetByUserIDWithInheritance_Call {
+	return &MockMembershiper_GetByUserIDWithInheritance_Call{Call: _e.mock.On("GetByUserIDWithInheritance", ctx, userID)}
+}
+
+func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) Run(run func(ctx context.Context, userID string)) *MockMembershiper_GetByUserIDWithInheritance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByUserIDWithInheritance_Call {
+	_c.Call.Return(memberships, err)
+	return _c
+}
+
+func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Membership, error)) *MockMembershiper_GetByUserIDWithInheritance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPasswordPolicier creates a new instance of MockPasswordPolicier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPasswordPolicier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPasswordPolicier {
+	mock := &MockPasswordPolicier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPasswordPolicier is an autogenerated mock type for the PasswordPolicier type
+type MockPasswordPolicier struct {
+	mock.Mock
+}
+
+type MockPasswordPolicier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPasswordPolicier) EXPECT() *MockPasswordPolicier_Expecter {
+	return &MockPasswordPolicier_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockPasswordPolicier
+func (_mock *MockPasswordPolicier) Get(ctx context.Context, entityID string) (*model.PasswordPolicy, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.PasswordPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.PasswordPolicy, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.PasswordPolicy); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PasswordPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasswordPolicier_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockPasswordPolicier_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockPasswordPolicier_Expecter) Get(ctx interface{}, entityID interface{}) *MockPasswordPolicier_Get_Call {
+	return &MockPasswordPolicier_Get_Call{Call: _e.mock.On("Get", ctx, entityID)}
+}
+
+func (_c *MockPasswordPolicier_Get_Call) Run(run func(ctx context.Context, entityID string)) *MockPasswordPolicier_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordPolicier_Get_Call) Return(passwordPolicy *model.PasswordPolicy, err error) *MockPasswordPolicier_Get_Call {
+	_c.Call.Return(passwordPolicy, err)
+	return _c
+}
+
+func (_c *MockPasswordPolicier_Get_Call) RunAndReturn(run func(ctx context.Context, entityID string) (*model.PasswordPolicy, error)) *MockPasswordPolicier_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockPasswordPolicier
+func (_mock *MockPasswordPolicier) Update(ctx context.Context, entityID string, userID string, policy *model.PasswordPolicy) (*model.PasswordPolicy, error) {
+	ret := _mock.Called(ctx, entityID, userID, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.PasswordPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *model.PasswordPolicy) (*model.PasswordPolicy, error)); ok {
+		return returnFunc(ctx, entityID, userID, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *model.PasswordPolicy) *model.PasswordPolicy); ok {
+		r0 = returnFunc(ctx, entityID, userID, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PasswordPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *model.PasswordPolicy) error); ok {
+		r1 = returnFunc(ctx, entityID, userID, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasswordPolicier_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockPasswordPolicier_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+//   - policy *model.PasswordPolicy
+func (_e *MockPasswordPolicier_Expecter) Update(ctx interface{}, entityID interface{}, userID interface{}, policy interface{}) *MockPasswordPolicier_Update_Call {
+	return &MockPasswordPolicier_Update_Call{Call: _e.mock.On("Update", ctx, entityID, userID, policy)}
+}
+
+func (_c *MockPasswordPolicier_Update_Call) Run(run func(ctx context.Context, entityID string, userID string, policy *model.PasswordPolicy)) *MockPasswordPolicier_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *model.PasswordPolicy
+		if args[3] != nil {
+			arg3 = args[3].(*model.PasswordPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordPolicier_Update_Call) Return(passwordPolicy *model.PasswordPolicy, err error) *MockPasswordPolicier_Update_Call {
+	_c.Call.Return(passwordPolicy, err)
+	return _c
+}
+
+func (_c *MockPasswordPolicier_Update_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, policy *model.PasswordPolicy) (*model.PasswordPolicy, error)) *MockPasswordPolicier_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSCIMer creates a new instance of MockSCIMer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSCIMer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSCIMer {
+	mock := &MockSCIMer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSCIMer is an autogenerated mock type for the SCIMer type
+type MockSCIMer struct {
+	mock.Mock
+}
+
+type MockSCIMer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSCIMer) EXPECT() *MockSCIMer_Expecter {
+	return &MockSCIMer_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) Authenticate(ctx context.Context, token string) (*model.SCIMToken, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *model.SCIMToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.SCIMToken, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.SCIMToken); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockSCIMer_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockSCIMer_Expecter) Authenticate(ctx interface{}, token interface{}) *MockSCIMer_Authenticate_Call {
+	return &MockSCIMer_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, token)}
+}
+
+func (_c *MockSCIMer_Authenticate_Call) Run(run func(ctx context.Context, token string)) *MockSCIMer_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_Authenticate_Call) Return(sCIMToken *model.SCIMToken, err error) *MockSCIMer_Authenticate_Call {
+	_c.Call.Return(sCIMToken, err)
+	return _c
+}
+
+func (_c *MockSCIMer_Authenticate_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.SCIMToken, error)) *MockSCIMer_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateToken provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) CreateToken(ctx context.Context, userID string, entityID string, name string) (*model.SCIMToken, string, error) {
+	ret := _mock.Called(ctx, userID, entityID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateToken")
+	}
+
+	var r0 *model.SCIMToken
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.SCIMToken, string, error)); ok {
+		return returnFunc(ctx, userID, entityID, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *model.SCIMToken); ok {
+		r0 = returnFunc(ctx, userID, entityID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) string); ok {
+		r1 = returnFunc(ctx, userID, entityID, name)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = returnFunc(ctx, userID, entityID, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSCIMer_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type MockSCIMer_CreateToken_Call struct {
+	*mock.Call
+}
+
+// CreateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - entityID string
+//   - name string
+func (_e *MockSCIMer_Expecter) CreateToken(ctx interface{}, userID interface{}, entityID interface{}, name interface{}) *MockSCIMer_CreateToken_Call {
+	return &MockSCIMer_CreateToken_Call{Call: _e.mock.On("CreateToken", ctx, userID, entityID, name)}
+}
+
+func (_c *MockSCIMer_CreateToken_Call) Run(run func(ctx context.Context, userID string, entityID string, name string)) *MockSCIMer_CreateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_CreateToken_Call) Return(sCIMToken *model.SCIMToken, s string, err error) *MockSCIMer_CreateToken_Call {
+	_c.Call.Return(sCIMToken, s, err)
+	return _c
+}
+
+func (_c *MockSCIMer_CreateToken_Call) RunAndReturn(run func(ctx context.Context, userID string, entityID string, name string) (*model.SCIMToken, string, error)) *MockSCIMer_CreateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) CreateUser(ctx context.Context, entityID string, user *model.SCIMUser, active bool) (*model.SCIMUser, error) {
+	ret := _mock.Called(ctx, entityID, user, active)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 *model.SCIMUser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.SCIMUser, bool) (*model.SCIMUser, error)); ok {
+		return returnFunc(ctx, entityID, user, active)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.SCIMUser, bool) *model.SCIMUser); ok {
+		r0 = returnFunc(ctx, entityID, user, active)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMUser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *model.SCIMUser, bool) error); ok {
+		r1 = returnFunc(ctx, entityID, user, active)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockSCIMer_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - user *model.SCIMUser
+//   - active bool
+func (_e *MockSCIMer_Expecter) CreateUser(ctx interface{}, entityID interface{}, user interface{}, active interface{}) *MockSCIMer_CreateUser_Call {
+	return &MockSCIMer_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, entityID, user, active)}
+}
+
+func (_c *MockSCIMer_CreateUser_Call) Run(run func(ctx context.Context, entityID string, user *model.SCIMUser, active bool)) *MockSCIMer_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *model.SCIMUser
+		if args[2] != nil {
+			arg2 = args[2].(*model.SCIMUser)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_CreateUser_Call) Return(sCIMUser *model.SCIMUser, err error) *MockSCIMer_CreateUser_Call {
+	_c.Call.Return(sCIMUser, err)
+	return _c
+}
+
+func (_c *MockSCIMer_CreateUser_Call) RunAndReturn(run func(ctx context.Context, entityID string, user *model.SCIMUser, active bool) (*model.SCIMUser, error)) *MockSCIMer_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteToken provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) DeleteToken(ctx context.Context, userID string, entityID string, id string) error {
+	ret := _mock.Called(ctx, userID, entityID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, entityID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSCIMer_DeleteToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteToken'
+type MockSCIMer_DeleteToken_Call struct {
+	*mock.Call
+}
+
+// DeleteToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - entityID string
+//   - id string
+func (_e *MockSCIMer_Expecter) DeleteToken(ctx interface{}, userID interface{}, entityID interface{}, id interface{}) *MockSCIMer_DeleteToken_Call {
+	return &MockSCIMer_DeleteToken_Call{Call: _e.mock.On("DeleteToken", ctx, userID, entityID, id)}
+}
+
+func (_c *MockSCIMer_DeleteToken_Call) Run(run func(ctx context.Context, userID string, entityID string, id string)) *MockSCIMer_DeleteToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_DeleteToken_Call) Return(err error) *MockSCIMer_DeleteToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSCIMer_DeleteToken_Call) RunAndReturn(run func(ctx context.Context, userID string, entityID string, id string) error) *MockSCIMer_DeleteToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) DeleteUser(ctx context.Context, entityID string, userID string) error {
+	ret := _mock.Called(ctx, entityID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, entityID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSCIMer_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockSCIMer_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+func (_e *MockSCIMer_Expecter) DeleteUser(ctx interface{}, entityID interface{}, userID interface{}) *MockSCIMer_DeleteUser_Call {
+	return &MockSCIMer_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, entityID, userID)}
+}
+
+func (_c *MockSCIMer_DeleteUser_Call) Run(run func(ctx context.Context, entityID string, userID string)) *MockSCIMer_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_DeleteUser_Call) Return(err error) *MockSCIMer_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSCIMer_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string) error) *MockSCIMer_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroup provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) GetGroup(ctx context.Context, entityID string, role types.Role) (*model.SCIMGroup, error) {
+	ret := _mock.Called(ctx, entityID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroup")
+	}
+
+	var r0 *model.SCIMGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, types.Role) (*model.SCIMGroup, error)); ok {
+		return returnFunc(ctx, entityID, role)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, types.Role) *model.SCIMGroup); ok {
+		r0 = returnFunc(ctx, entityID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, types.Role) error); ok {
+		r1 = returnFunc(ctx, entityID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_GetGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroup'
+type MockSCIMer_GetGroup_Call struct {
+	*mock.Call
+}
+
+// GetGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - role types.Role
+func (_e *MockSCIMer_Expecter) GetGroup(ctx interface{}, entityID interface{}, role interface{}) *MockSCIMer_GetGroup_Call {
+	return &MockSCIMer_GetGroup_Call{Call: _e.mock.On("GetGroup", ctx, entityID, role)}
+}
+
+func (_c *MockSCIMer_GetGroup_Call) Run(run func(ctx context.Context, entityID string, role types.Role)) *MockSCIMer_GetGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 types.Role
+		if args[2] != nil {
+			arg2 = args[2].(types.Role)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_GetGroup_Call) Return(sCIMGroup *model.SCIMGroup, err error) *MockSCIMer_GetGroup_Call {
+	_c.Call.Return(sCIMGroup, err)
+	return _c
+}
+
+func (_c *MockSCIMer_GetGroup_Call) RunAndReturn(run func(ctx context.Context, entityID string, role types.Role) (*model.SCIMGroup, error)) *MockSCIMer_GetGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) GetUser(ctx context.Context, entityID string, userID string) (*model.SCIMUser, error) {
+	ret := _mock.Called(ctx, entityID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *model.SCIMUser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.SCIMUser, error)); ok {
+		return returnFunc(ctx, entityID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.SCIMUser); ok {
+		r0 = returnFunc(ctx, entityID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMUser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, entityID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockSCIMer_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+func (_e *MockSCIMer_Expecter) GetUser(ctx interface{}, entityID interface{}, userID interface{}) *MockSCIMer_GetUser_Call {
+	return &MockSCIMer_GetUser_Call{Call: _e.mock.On("GetUser", ctx, entityID, userID)}
+}
+
+func (_c *MockSCIMer_GetUser_Call) Run(run func(ctx context.Context, entityID string, userID string)) *MockSCIMer_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_GetUser_Call) Return(sCIMUser *model.SCIMUser, err error) *MockSCIMer_GetUser_Call {
+	_c.Call.Return(sCIMUser, err)
+	return _c
+}
+
+func (_c *MockSCIMer_GetUser_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string) (*model.SCIMUser, error)) *MockSCIMer_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGroups provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) ListGroups(ctx context.Context, entityID string) ([]*model.SCIMGroup, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroups")
+	}
+
+	var r0 []*model.SCIMGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.SCIMGroup, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.SCIMGroup); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SCIMGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_ListGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGroups'
+type MockSCIMer_ListGroups_Call struct {
+	*mock.Call
+}
+
+// ListGroups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockSCIMer_Expecter) ListGroups(ctx interface{}, entityID interface{}) *MockSCIMer_ListGroups_Call {
+	return &MockSCIMer_ListGroups_Call{Call: _e.mock.On("ListGroups", ctx, entityID)}
+}
+
+func (_c *MockSCIMer_ListGroups_Call) Run(run func(ctx context.Context, entityID string)) *MockSCIMer_ListGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_ListGroups_Call) Return(sCIMGroups []*model.SCIMGroup, err error) *MockSCIMer_ListGroups_Call {
+	_c.Call.Return(sCIMGroups, err)
+	return _c
+}
+
+func (_c *MockSCIMer_ListGroups_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.SCIMGroup, error)) *MockSCIMer_ListGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTokens provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) ListTokens(ctx context.Context, entityID string) ([]*model.SCIMToken, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTokens")
+	}
+
+	var r0 []*model.SCIMToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.SCIMToken, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.SCIMToken); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SCIMToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_ListTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTokens'
+type MockSCIMer_ListTokens_Call struct {
+	*mock.Call
+}
+
+// ListTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockSCIMer_Expecter) ListTokens(ctx interface{}, entityID interface{}) *MockSCIMer_ListTokens_Call {
+	return &MockSCIMer_ListTokens_Call{Call: _e.mock.On("ListTokens", ctx, entityID)}
+}
+
+func (_c *MockSCIMer_ListTokens_Call) Run(run func(ctx context.Context, entityID string)) *MockSCIMer_ListTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_ListTokens_Call) Return(sCIMTokens []*model.SCIMToken, err error) *MockSCIMer_ListTokens_Call {
+	_c.Call.Return(sCIMTokens, err)
+	return _c
+}
+
+func (_c *MockSCIMer_ListTokens_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.SCIMToken, error)) *MockSCIMer_ListTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) ListUsers(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset int, limit int) ([]*model.SCIMUser, int, error) {
+	ret := _mock.Called(ctx, entityID, filter, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []*model.SCIMUser
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.SCIMUserFilter, int, int) ([]*model.SCIMUser, int, error)); ok {
+		return returnFunc(ctx, entityID, filter, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.SCIMUserFilter, int, int) []*model.SCIMUser); ok {
+		r0 = returnFunc(ctx, entityID, filter, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SCIMUser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.SCIMUserFilter, int, int) int); ok {
+		r1 = returnFunc(ctx, entityID, filter, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, model.SCIMUserFilter, int, int) error); ok {
+		r2 = returnFunc(ctx, entityID, filter, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSCIMer_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type MockSCIMer_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - filter model.SCIMUserFilter
+//   - offset int
+//   - limit int
+func (_e *MockSCIMer_Expecter) ListUsers(ctx interface{}, entityID interface{}, filter interface{}, offset interface{}, limit interface{}) *MockSCIMer_ListUsers_Call {
+	return &MockSCIMer_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, entityID, filter, offset, limit)}
+}
+
+func (_c *MockSCIMer_ListUsers_Call) Run(run func(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset int, limit int)) *MockSCIMer_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.SCIMUserFilter
+		if args[2] != nil {
+			arg2 = args[2].(model.SCIMUserFilter)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_ListUsers_Call) Return(sCIMUsers []*model.SCIMUser, n int, err error) *MockSCIMer_ListUsers_Call {
+	_c.Call.Return(sCIMUsers, n, err)
+	return _c
+}
+
+func (_c *MockSCIMer_ListUsers_Call) RunAndReturn(run func(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset int, limit int) ([]*model.SCIMUser, int, error)) *MockSCIMer_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceGroup provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) ReplaceGroup(ctx context.Context, entityID string, role types.Role, memberIDs []string) (*model.SCIMGroup, error) {
+	ret := _mock.Called(ctx, entityID, role, memberIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceGroup")
+	}
+
+	var r0 *model.SCIMGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, types.Role, []string) (*model.SCIMGroup, error)); ok {
+		return returnFunc(ctx, entityID, role, memberIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, types.Role, []string) *model.SCIMGroup); ok {
+		r0 = returnFunc(ctx, entityID, role, memberIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, types.Role, []string) error); ok {
+		r1 = returnFunc(ctx, entityID, role, memberIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_ReplaceGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceGroup'
+type MockSCIMer_ReplaceGroup_Call struct {
+	*mock.Call
+}
+
+// ReplaceGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - role types.Role
+//   - memberIDs []string
+func (_e *MockSCIMer_Expecter) ReplaceGroup(ctx interface{}, entityID interface{}, role interface{}, memberIDs interface{}) *MockSCIMer_ReplaceGroup_Call {
+	return &MockSCIMer_ReplaceGroup_Call{Call: _e.mock.On("ReplaceGroup", ctx, entityID, role, memberIDs)}
+}
+
+func (_c *MockSCIMer_ReplaceGroup_Call) Run(run func(ctx context.Context, entityID string, role types.Role, memberIDs []string)) *MockSCIMer_ReplaceGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 types.Role
+		if args[2] != nil {
+			arg2 = args[2].(types.Role)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_ReplaceGroup_Call) Return(sCIMGroup *model.SCIMGroup, err error) *MockSCIMer_ReplaceGroup_Call {
+	_c.Call.Return(sCIMGroup, err)
+	return _c
+}
+
+func (_c *MockSCIMer_ReplaceGroup_Call) RunAndReturn(run func(ctx context.Context, entityID string, role types.Role, memberIDs []string) (*model.SCIMGroup, error)) *MockSCIMer_ReplaceGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateGroup provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) UpdateGroup(ctx context.Context, entityID string, role types.Role, add []string, remove []string) (*model.SCIMGroup, error) {
+	ret := _mock.Called(ctx, entityID, role, add, remove)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGroup")
+	}
+
+	var r0 *model.SCIMGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, types.Role, []string, []string) (*model.SCIMGroup, error)); ok {
+		return returnFunc(ctx, entityID, role, add, remove)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, types.Role, []string, []string) *model.SCIMGroup); ok {
+		r0 = returnFunc(ctx, entityID, role, add, remove)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, types.Role, []string, []string) error); ok {
+		r1 = returnFunc(ctx, entityID, role, add, remove)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_UpdateGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGroup'
+type MockSCIMer_UpdateGroup_Call struct {
+	*mock.Call
+}
+
+// UpdateGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - role types.Role
+//   - add []string
+//   - remove []string
+func (_e *MockSCIMer_Expecter) UpdateGroup(ctx interface{}, entityID interface{}, role interface{}, add interface{}, remove interface{}) *MockSCIMer_UpdateGroup_Call {
+	return &MockSCIMer_UpdateGroup_Call{Call: _e.mock.On("UpdateGroup", ctx, entityID, role, add, remove)}
+}
+
+func (_c *MockSCIMer_UpdateGroup_Call) Run(run func(ctx context.Context, entityID string, role types.Role, add []string, remove []string)) *MockSCIMer_UpdateGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 types.Role
+		if args[2] != nil {
+			arg2 = args[2].(types.Role)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		var arg4 []string
+		if args[4] != nil {
+			arg4 = args[4].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_UpdateGroup_Call) Return(sCIMGroup *model.SCIMGroup, err error) *MockSCIMer_UpdateGroup_Call {
+	_c.Call.Return(sCIMGroup, err)
+	return _c
+}
+
+func (_c *MockSCIMer_UpdateGroup_Call) RunAndReturn(run func(ctx context.Context, entityID string, role types.Role, add []string, remove []string) (*model.SCIMGroup, error)) *MockSCIMer_UpdateGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUser provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) UpdateUser(ctx context.Context, entityID string, userID string, update service.SCIMUserUpdate) (*model.SCIMUser, error) {
+	ret := _mock.Called(ctx, entityID, userID, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 *model.SCIMUser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, service.SCIMUserUpdate) (*model.SCIMUser, error)); ok {
+		return returnFunc(ctx, entityID, userID, update)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, service.SCIMUserUpdate) *model.SCIMUser); ok {
+		r0 = returnFunc(ctx, entityID, userID, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMUser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, service.SCIMUserUpdate) error); ok {
+		r1 = returnFunc(ctx, entityID, userID, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockSCIMer_UpdateUser_Call struct {
+	*mock.Call
+}
+
+// UpdateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+//   - update service.SCIMUserUpdate
+func (_e *MockSCIMer_Expecter) UpdateUser(ctx interface{}, entityID interface{}, userID interface{}, update interface{}) *MockSCIMer_UpdateUser_Call {
+	return &MockSCIMer_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, entityID, userID, update)}
+}
+
+func (_c *MockSCIMer_UpdateUser_Call) Run(run func(ctx context.Context, entityID string, userID string, update service.SCIMUserUpdate)) *MockSCIMer_UpdateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 service.SCIMUserUpdate
+		if args[3] != nil {
+			arg3 = args[3].(service.SCIMUserUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_UpdateUser_Call) Return(sCIMUser *model.SCIMUser, err error) *MockSCIMer_UpdateUser_Call {
+	_c.Call.Return(sCIMUser, err)
+	return _c
+}
+
+func (_c *MockSCIMer_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, update service.SCIMUserUpdate) (*model.SCIMUser, error)) *MockSCIMer_UpdateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessioner creates a new instance of MockSessioner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessioner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessioner {
+	mock := &MockSessioner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSessioner is an autogenerated mock type for the Sessioner type
+type MockSessioner struct {
+	mock.Mock
+}
+
+type MockSessioner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessioner) EXPECT() *MockSessioner_Expecter {
+	return &MockSessioner_Expecter{mock: &_m.Mock}
+}
+
+// CleanUpExpired provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) CleanUpExpired(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CleanUpExpired")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_CleanUpExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanUpExpired'
+type MockSessioner_CleanUpExpired_Call struct {
+	*mock.Call
+}
+
+// CleanUpExpired is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSessioner_Expecter) CleanUpExpired(ctx interface{}) *MockSessioner_CleanUpExpired_Call {
+	return &MockSessioner_CleanUpExpired_Call{Call: _e.mock.On("CleanUpExpired", ctx)}
+}
+
+func (_c *MockSessioner_CleanUpExpired_Call) Run(run func(ctx context.Context)) *MockSessioner_CleanUpExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_CleanUpExpired_Call) Return(err error) *MockSessioner_CleanUpExpired_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_CleanUpExpired_Call) RunAndReturn(run func(ctx context.Context) error) *MockSessioner_CleanUpExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) Create(ctx context.Context, email string, password string, trustedDevice string) (*model.Session, error) {
+	ret := _mock.Called(ctx, email, password, trustedDevice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, email, password, trustedDevice)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *model.Session); ok {
+		r0 = returnFunc(ctx, email, password, trustedDevice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, email, password, trustedDevice)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSessioner_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+//   - trustedDevice string
+func (_e *MockSessioner_Expecter) Create(ctx interface{}, email interface{}, password interface{}, trustedDevice interface{}) *MockSessioner_Create_Call {
+	return &MockSessioner_Create_Call{Call: _e.mock.On("Create", ctx, email, password, trustedDevice)}
+}
+
+func (_c *MockSessioner_Create_Call) Run(run func(ctx context.Context, email string, password string, trustedDevice string)) *MockSessioner_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_Create_Call) Return(session *model.Session, err error) *MockSessioner_Create_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessioner_Create_Call) RunAndReturn(run func(ctx context.Context, email string, password string, trustedDevice string) (*model.Session, error)) *MockSessioner_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWithMagicLink provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) CreateWithMagicLink(ctx context.Context, token string, trustedDevice string) (*model.Session, error) {
+	ret := _mock.Called(ctx, token, trustedDevice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithMagicLink")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, token, trustedDevice)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.Session); ok {
+		r0 = returnFunc(ctx, token, trustedDevice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, token, trustedDevice)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_CreateWithMagicLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithMagicLink'
+type MockSessioner_CreateWithMagicLink_Call struct {
+	*mock.Call
+}
+
+// CreateWithMagicLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - trustedDevice string
+func (_e *MockSessioner_Expecter) CreateWithMagicLink(ctx interface{}, token interface{}, trustedDevice interface{}) *MockSessioner_CreateWithMagicLink_Call {
+	return &MockSessioner_CreateWithMagicLink_Call{Call: _e.mock.On("CreateWithMagicLink", ctx, token, trustedDevice)}
+}
+
+func (_c *MockSessioner_CreateWithMagicLink_Call) Run(run func(ctx context.Context, token string, trustedDevice string)) *MockSessioner_CreateWithMagicLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_CreateWithMagicLink_Call) Return(session *model.Session, err error) *MockSessioner_CreateWithMagicLink_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessioner_CreateWithMagicLink_Call) RunAndReturn(run func(ctx context.Context, token string, trustedDevice string) (*model.Session, error)) *MockSessioner_CreateWithMagicLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWithSSO provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) CreateWithSSO(ctx context.Context, state string, code string, trustedDevice string) (*model.Session, error) {
+	ret := _mock.Called(ctx, state, code, trustedDevice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithSSO")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, state, code, trustedDevice)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *model.Session); ok {
+		r0 = returnFunc(ctx, state, code, trustedDevice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, state, code, trustedDevice)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_CreateWithSSO_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithSSO'
+type MockSessioner_CreateWithSSO_Call struct {
+	*mock.Call
+}
+
+// CreateWithSSO is a helper method to define mock.On call
+//   - ctx context.Context
+//   - state string
+//   - code string
+//   - trustedDevice string
+func (_e *MockSessioner_Expecter) CreateWithSSO(ctx interface{}, state interface{}, code interface{}, trustedDevice interface{}) *MockSessioner_CreateWithSSO_Call {
+	return &MockSessioner_CreateWithSSO_Call{Call: _e.mock.On("CreateWithSSO", ctx, state, code, trustedDevice)}
+}
+
+func (_c *MockSessioner_CreateWithSSO_Call) Run(run func(ctx context.Context, state string, code string, trustedDevice string)) *MockSessioner_CreateWithSSO_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_CreateWithSSO_Call) Return(session *model.Session, err error) *MockSessioner_CreateWithSSO_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessioner_CreateWithSSO_Call) RunAndReturn(run func(ctx context.Context, state string, code string, trustedDevice string) (*model.Session, error)) *MockSessioner_CreateWithSSO_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByToken provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) GetByToken(ctx context.Context, token string) (*model.Session, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByToken")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_GetByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByToken'
+type MockSessioner_GetByToken_Call struct {
+	*mock.Call
+}
+
+// GetByToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockSessioner_Expecter) GetByToken(ctx interface{}, token interface{}) *MockSessioner_GetByToken_Call {
+	return &MockSessioner_GetByToken_Call{Call: _e.mock.On("GetByToken", ctx, token)}
+}
+
+func (_c *MockSessioner_GetByToken_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_GetByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_GetByToken_Call) Return(session *model.Session, err error) *MockSessioner_GetByToken_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessioner_GetByToken_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.Session, error)) *MockSessioner_GetByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTokenFull provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) GetByTokenFull(ctx context.Context, token string) (*model.Session, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTokenFull")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_GetByTokenFull_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTokenFull'
+type MockSessioner_GetByTokenFull_Call struct {
+	*mock.Call
+}
+
+// GetByTokenFull is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockSessioner_Expecter) GetByTokenFull(ctx interface{}, token interface{}) *MockSessioner_GetByTokenFull_Call {
+	return &MockSessioner_GetByTokenFull_Call{Call: _e.mock.On("GetByTokenFull", ctx, token)}
+}
+
+func (_c *MockSessioner_GetByTokenFull_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_GetByTokenFull_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_GetByTokenFull_Call) Return(session *model.Session, err error) *MockSessioner_GetByTokenFull_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessioner_GetByTokenFull_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.Session, error)) *MockSessioner_GetByTokenFull_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Invalidate provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) Invalidate(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invalidate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_Invalidate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invalidate'
+type MockSessioner_Invalidate_Call struct {
+	*mock.Call
+}
+
+// Invalidate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockSessioner_Expecter) Invalidate(ctx interface{}, token interface{}) *MockSessioner_Invalidate_Call {
+	return &MockSessioner_Invalidate_Call{Call: _e.mock.On("Invalidate", ctx, token)}
+}
+
+func (_c *MockSessioner_Invalidate_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_Invalidate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_Invalidate_Call) Return(err error) *MockSessioner_Invalidate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_Invalidate_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockSessioner_Invalidate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateAllSessions provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) InvalidateAllSessions(ctx context.Context, userID string, token string) error {
+	ret := _mock.Called(ctx, userID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateAllSessions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_InvalidateAllSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateAllSessions'
+type MockSessioner_InvalidateAllSessions_Call struct {
+	*mock.Call
+}
+
+// InvalidateAllSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - token string
+func (_e *MockSessioner_Expecter) InvalidateAllSessions(ctx interface{}, userID interface{}, token interface{}) *MockSessioner_InvalidateAllSessions_Call {
+	return &MockSessioner_InvalidateAllSessions_Call{Call: _e.mock.On("InvalidateAllSessions", ctx, userID, token)}
+}
+
+func (_c *MockSessioner_InvalidateAllSessions_Call) Run(run func(ctx context.Context, userID string, token string)) *MockSessioner_InvalidateAllSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateAllSessions_Call) Return(err error) *MockSessioner_InvalidateAllSessions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateAllSessions_Call) RunAndReturn(run func(ctx context.Context, userID string, token string) error) *MockSessioner_InvalidateAllSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateByID provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) InvalidateByID(ctx context.Context, token string, sessionID string) error {
+	ret := _mock.Called(ctx, token, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateByID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, token, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_InvalidateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateByID'
+type MockSessioner_InvalidateByID_Call struct {
+	*mock.Call
+}
+
+// InvalidateByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - sessionID string
+func (_e *MockSessioner_Expecter) InvalidateByID(ctx interface{}, token interface{}, sessionID interface{}) *MockSessioner_InvalidateByID_Call {
+	return &MockSessioner_InvalidateByID_Call{Call: _e.mock.On("InvalidateByID", ctx, token, sessionID)}
+}
+
+func (_c *MockSessioner_InvalidateByID_Call) Run(run func(ctx context.Context, token string, sessionID string)) *MockSessioner_InvalidateByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateByID_Call) Return(err error) *MockSessioner_InvalidateByID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateByID_Call) RunAndReturn(run func(ctx context.Context, token string, sessionID string) error) *MockSessioner_InvalidateByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByToken provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) ListByToken(ctx context.Context, userID string) ([]*model.Session, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByToken")
+	}
+
+	var r0 []*model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Session, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Session); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_ListByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByToken'
+type MockSessioner_ListByToken_Call struct {
+	*mock.Call
+}
+
+// ListByToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockSessioner_Expecter) ListByToken(ctx interface{}, userID interface{}) *MockSessioner_ListByToken_Call {
+	return &MockSessioner_ListByToken_Call{Call: _e.mock.On("ListByToken", ctx, userID)}
+}
+
+func (_c *MockSessioner_ListByToken_Call) Run(run func(ctx context.Context, userID string)) *MockSessioner_ListByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_ListByToken_Call) Return(sessions []*model.Session, err error) *MockSessioner_ListByToken_Call {
+	_c.Call.Return(sessions, err)
+	return _c
+}
+
+func (_c *MockSessioner_ListByToken_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Session, error)) *MockSessioner_ListByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) Refresh(ctx context.Context, refreshToken string) (*model.Session, error) {
+	ret := _mock.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, refreshToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
+		r0 = returnFunc(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockSessioner_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *MockSessioner_Expecter) Refresh(ctx interface{}, refreshToken interface{}) *MockSessioner_Refresh_Call {
+	return &MockSessioner_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken)}
+}
+
+func (_c *MockSessioner_Refresh_Call) Run(run func(ctx context.Context, refreshToken string)) *MockSessioner_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_Refresh_Call) Return(session *model.Session, err error) *MockSessioner_Refresh_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessioner_Refresh_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (*model.Session, error)) *MockSessioner_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestMagicLink provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) RequestMagicLink(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestMagicLink")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_RequestMagicLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestMagicLink'
+type MockSessioner_RequestMagicLink_Call struct {
+	*mock.Call
+}
+
+// RequestMagicLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockSessioner_Expecter) RequestMagicLink(ctx interface{}, email interface{}) *MockSessioner_RequestMagicLink_Call {
+	return &MockSessioner_RequestMagicLink_Call{Call: _e.mock.On("RequestMagicLink", ctx, email)}
+}
+
+func (_c *MockSessioner_RequestMagicLink_Call) Run(run func(ctx context.Context, email string)) *MockSessioner_RequestMagicLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_RequestMagicLink_Call) Return(err error) *MockSessioner_RequestMagicLink_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_RequestMagicLink_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockSessioner_RequestMagicLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSignIn provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) RevokeSignIn(ctx context.Context, token string) (string, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSignIn")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_RevokeSignIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSignIn'
+type MockSessioner_RevokeSignIn_Call struct {
+	*mock.Call
+}
+
+// RevokeSignIn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockSessioner_Expecter) RevokeSignIn(ctx interface{}, token interface{}) *MockSessioner_RevokeSignIn_Call {
+	return &MockSessioner_RevokeSignIn_Call{Call: _e.mock.On("RevokeSignIn", ctx, token)}
+}
+
+func (_c *MockSessioner_RevokeSignIn_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_RevokeSignIn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_RevokeSignIn_Call) Return(s string, err error) *MockSessioner_RevokeSignIn_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSessioner_RevokeSignIn_Call) RunAndReturn(run func(ctx context.Context, token string) (string, error)) *MockSessioner_RevokeSignIn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartSSO provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) StartSSO(ctx context.Context, entityID string) (string, string, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartSSO")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, string, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, entityID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSessioner_StartSSO_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartSSO'
+type MockSessioner_StartSSO_Call struct {
+	*mock.Call
+}
+
+// StartSSO is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockSessioner_Expecter) StartSSO(ctx interface{}, entityID interface{}) *MockSessioner_StartSSO_Call {
+	return &MockSessioner_StartSSO_Call{Call: _e.mock.On("StartSSO", ctx, entityID)}
+}
+
+func (_c *MockSessioner_StartSSO_Call) Run(run func(ctx context.Context, entityID string)) *MockSessioner_StartSSO_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_StartSSO_Call) Return(s string, s1 string, err error) *MockSessioner_StartSSO_Call {
+	_c.Call.Return(s, s1, err)
+	return _c
+}
+
+func (_c *MockSessioner_StartSSO_Call) RunAndReturn(run func(ctx context.Context, entityID string) (string, string, error)) *MockSessioner_StartSSO_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTwoFactorStatus provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) UpdateTwoFactorStatus(ctx context.Context, token string, isPending bool) error {
+	ret := _mock.Called(ctx, token, isPending)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTwoFactorStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, token, isPending)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_UpdateTwoFactorStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTwoFactorStatus'
+type MockSessioner_UpdateTwoFactorStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateTwoFactorStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - isPending bool
+func (_e *MockSessioner_Expecter) UpdateTwoFactorStatus(ctx interface{}, token interface{}, isPending interface{}) *MockSessioner_UpdateTwoFactorStatus_Call {
+	return &MockSessioner_UpdateTwoFactorStatus_Call{Call: _e.mock.On("UpdateTwoFactorStatus", ctx, token, isPending)}
+}
+
+func (_c *MockSessioner_UpdateTwoFactorStatus_Call) Run(run func(ctx context.Context, token string, isPending bool)) *MockSessioner_UpdateTwoFactorStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_UpdateTwoFactorStatus_Call) Return(err error) *MockSessioner_UpdateTwoFactorStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_UpdateTwoFactorStatus_Call) RunAndReturn(run func(ctx context.Context, token string, isPending bool) error) *MockSessioner_UpdateTwoFactorStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Validate provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) Validate(ctx context.Context, token string) (*model.Session, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type MockSessioner_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockSessioner_Expecter) Validate(ctx interface{}, token interface{}) *MockSessioner_Validate_Call {
+	return &MockSessioner_Validate_Call{Call: _e.mock.On("Validate", ctx, token)}
+}
+
+func (_c *MockSessioner_Validate_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_Validate_Call) Return(session *model.Session, err error) *MockSessioner_Validate_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessioner_Validate_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.Session, error)) *MockSessioner_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionPolicier creates a new instance of MockSessionPolicier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionPolicier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionPolicier {
+	mock := &MockSessionPolicier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSessionPolicier is an autogenerated mock type for the SessionPolicier type
+type MockSessionPolicier struct {
+	mock.Mock
+}
+
+type MockSessionPolicier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionPolicier) EXPECT() *MockSessionPolicier_Expecter {
+	return &MockSessionPolicier_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockSessionPolicier
+func (_mock *MockSessionPolicier) Get(ctx context.Context, entityID string) (*model.SessionPolicy, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.SessionPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.SessionPolicy, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.SessionPolicy); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SessionPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionPolicier_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockSessionPolicier_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockSessionPolicier_Expecter) Get(ctx interface{}, entityID interface{}) *MockSessionPolicier_Get_Call {
+	return &MockSessionPolicier_Get_Call{Call: _e.mock.On("Get", ctx, entityID)}
+}
+
+func (_c *MockSessionPolicier_Get_Call) Run(run func(ctx context.Context, entityID string)) *MockSessionPolicier_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionPolicier_Get_Call) Return(sessionPolicy *model.SessionPolicy, err error) *MockSessionPolicier_Get_Call {
+	_c.Call.Return(sessionPolicy, err)
+	return _c
+}
+
+func (_c *MockSessionPolicier_Get_Call) RunAndReturn(run func(ctx context.Context, entityID string) (*model.SessionPolicy, error)) *MockSessionPolicier_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockSessionPolicier
+func (_mock *MockSessionPolicier) Update(ctx context.Context, entityID string, userID string, policy *model.SessionPolicy) (*model.SessionPolicy, error) {
+	ret := _mock.Called(ctx, entityID, userID, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.SessionPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *model.SessionPolicy) (*model.SessionPolicy, error)); ok {
+		return returnFunc(ctx, entityID, userID, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *model.SessionPolicy) *model.SessionPolicy); ok {
+		r0 = returnFunc(ctx, entityID, userID, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SessionPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *model.SessionPolicy) error); ok {
+		r1 = returnFunc(ctx, entityID, userID, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionPolicier_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockSessionPolicier_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+//   - policy *model.SessionPolicy
+func (_e *MockSessionPolicier_Expecter) Update(ctx interface{}, entityID interface{}, userID interface{}, policy interface{}) *MockSessionPolicier_Update_Call {
+	return &MockSessionPolicier_Update_Call{Call: _e.mock.On("Update", ctx, entityID, userID, policy)}
+}
+
+func (_c *MockSessionPolicier_Update_Call) Run(run func(ctx context.Context, entityID string, userID string, policy *model.SessionPolicy)) *MockSessionPolicier_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *model.SessionPolicy
+		if args[3] != nil {
+			arg3 = args[3].(*model.SessionPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionPolicier_Update_Call) Return(sessionPolicy *model.SessionPolicy, err error) *MockSessionPolicier_Update_Call {
+	_c.Call.Return(sessionPolicy, err)
+	return _c
+}
+
+func (_c *MockSessionPolicier_Update_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, policy *model.SessionPolicy) (*model.SessionPolicy, error)) *MockSessionPolicier_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSSOConnectioner creates a new instance of MockSSOConnectioner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSSOConnectioner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSSOConnectioner {
+	mock := &MockSSOConnectioner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSSOConnectioner is an autogenerated mock type for the SSOConnectioner type
+type MockSSOConnectioner struct {
+	mock.Mock
+}
+
+type MockSSOConnectioner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSSOConnectioner) EXPECT() *MockSSOConnectioner_Expecter {
+	return &MockSSOConnectioner_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockSSOConnectioner
+func (_mock *MockSSOConnectioner) Delete(ctx context.Context, userID string, entityID string) error {
+	ret := _mock.Called(ctx, userID, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, entityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSSOConnectioner_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSSOConnectioner_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - entityID string
+func (_e *MockSSOConnectioner_Expecter) Delete(ctx interface{}, userID interface{}, entityID interface{}) *MockSSOConnectioner_Delete_Call {
+	return &MockSSOConnectioner_Delete_Call{Call: _e.mock.On("Delete", ctx, userID, entityID)}
+}
+
+func (_c *MockSSOConnectioner_Delete_Call) Run(run func(ctx context.Context, userID string, entityID string)) *MockSSOConnectioner_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSOConnectioner_Delete_Call) Return(err error) *MockSSOConnectioner_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSSOConnectioner_Delete_Call) RunAndReturn(run func(ctx context.Context, userID string, entityID string) error) *MockSSOConnectioner_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockSSOConnectioner
+func (_mock *MockSSOConnectioner) Get(ctx context.Context, entityID string) (*model.SSOConnection, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.SSOConnection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.SSOConnection, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.SSOConnection); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SSOConnection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSOConnectioner_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockSSOConnectioner_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockSSOConnectioner_Expecter) Get(ctx interface{}, entityID interface{}) *MockSSOConnectioner_Get_Call {
+	return &MockSSOConnectioner_Get_Call{Call: _e.mock.On("Get", ctx, entityID)}
+}
+
+func (_c *MockSSOConnectioner_Get_Call) Run(run func(ctx context.Context, entityID string)) *MockSSOConnectioner_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSOConnectioner_Get_Call) Return(sSOConnection *model.SSOConnection, err error) *MockSSOConnectioner_Get_Call {
+	_c.Call.Return(sSOConnection, err)
+	return _c
+}
+
+func (_c *MockSSOConnectioner_Get_Call) RunAndReturn(run func(ctx context.Context, entityID string) (*model.SSOConnection, error)) *MockSSOConnectioner_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockSSOConnectioner
+func (_mock *MockSSOConnectioner) Upsert(ctx context.Context, userID string, connection *model.SSOConnection, clientSecret string) (*model.SSOConnection, error) {
+	ret := _mock.Called(ctx, userID, connection, clientSecret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 *model.SSOConnection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.SSOConnection, string) (*model.SSOConnection, error)); ok {
+		return returnFunc(ctx, userID, connection, clientSecret)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *model.SSOConnection, string) *model.SSOConnection); ok {
+		r0 = returnFunc(ctx, userID, connection, clientSecret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SSOConnection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *model.SSOConnection, string) error); ok {
+		r1 = returnFunc(ctx, userID, connection, clientSecret)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSOConnectioner_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockSSOConnectioner_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - connection *model.SSOConnection
+//   - clientSecret string
+func (_e *MockSSOConnectioner_Expecter) Upsert(ctx interface{}, userID interface{}, connection interface{}, clientSecret interface{}) *MockSSOConnectioner_Upsert_Call {
+	return &MockSSOConnectioner_Upsert_Call{Call: _e.mock.On("Upsert", ctx, userID, connection, clientSecret)}
+}
+
+func (_c *MockSSOConnectioner_Upsert_Call) Run(run func(ctx context.Context, userID string, connection *model.SSOConnection, clientSecret string)) *MockSSOConnectioner_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *model.SSOConnection
+		if args[2] != nil {
+			arg2 = args[2].(*model.SSOConnection)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSOConnectioner_Upsert_Call) Return(sSOConnection *model.SSOConnection, err error) *MockSSOConnectioner_Upsert_Call {
+	_c.Call.Return(sSOConnection, err)
+	return _c
+}
+
+func (_c *MockSSOConnectioner_Upsert_Call) RunAndReturn(run func(ctx context.Context, userID string, connection *model.SSOConnection, clientSecret string) (*model.SSOConnection, error)) *MockSSOConnectioner_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTrustedDevicer creates a new instance of MockTrustedDevicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrustedDevicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrustedDevicer {
+	mock := &MockTrustedDevicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrustedDevicer is an autogenerated mock type for the TrustedDevicer type
+type MockTrustedDevicer struct {
+	mock.Mock
+}
+
+type MockTrustedDevicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrustedDevicer) EXPECT() *MockTrustedDevicer_Expecter {
+	return &MockTrustedDevicer_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) List(ctx context.Context, userID string) ([]*model.TrustedDevice, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*model.TrustedDevice
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.TrustedDevice, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.TrustedDevice); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TrustedDevice)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrustedDevicer_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTrustedDevicer_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockTrustedDevicer_Expecter) List(ctx interface{}, userID interface{}) *MockTrustedDevicer_List_Call {
+	return &MockTrustedDevicer_List_Call{Call: _e.mock.On("List", ctx, userID)}
+}
+
+func (_c *MockTrustedDevicer_List_Call) Run(run func(ctx context.Context, userID string)) *MockTrustedDevicer_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrustedDevicer_List_Call) Return(trustedDevices []*model.TrustedDevice, err error) *MockTrustedDevicer_List_Call {
+	_c.Call.Return(trustedDevices, err)
+	return _c
+}
+
+func (_c *MockTrustedDevicer_List_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.TrustedDevice, error)) *MockTrustedDevicer_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) Revoke(ctx context.Context, userID string, id string) error {
+	ret := _mock.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTrustedDevicer_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockTrustedDevicer_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - id string
+func (_e *MockTrustedDevicer_Expecter) Revoke(ctx interface{}, userID interface{}, id interface{}) *MockTrustedDevicer_Revoke_Call {
+	return &MockTrustedDevicer_Revoke_Call{Call: _e.mock.On("Revoke", ctx, userID, id)}
+}
+
+func (_c *MockTrustedDevicer_Revoke_Call) Run(run func(ctx context.Context, userID string, id string)) *MockTrustedDevicer_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrustedDevicer_Revoke_Call) Return(err error) *MockTrustedDevicer_Revoke_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTrustedDevicer_Revoke_Call) RunAndReturn(run func(ctx context.Context, userID string, id string) error) *MockTrustedDevicer_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAll provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) RevokeAll(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTrustedDevicer_RevokeAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAll'
+type MockTrustedDevicer_RevokeAll_Call struct {
+	*mock.Call
+}
+
+// RevokeAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockTrustedDevicer_Expecter) RevokeAll(ctx interface{}, userID interface{}) *MockTrustedDevicer_RevokeAll_Call {
+	return &MockTrustedDevicer_RevokeAll_Call{Call: _e.mock.On("RevokeAll", ctx, userID)}
+}
+
+func (_c *MockTrustedDevicer_RevokeAll_Call) Run(run func(ctx context.Context, userID string)) *MockTrustedDevicer_RevokeAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrustedDevicer_RevokeAll_Call) Return(err error) *MockTrustedDevicer_RevokeAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTrustedDevicer_RevokeAll_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockTrustedDevicer_RevokeAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trust provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) Trust(ctx context.Context, userID string) (*model.TrustedDevice, string, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trust")
+	}
+
+	var r0 *model.TrustedDevice
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.TrustedDevice, string, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.TrustedDevice); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TrustedDevice)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockTrustedDevicer_Trust_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trust'
+type MockTrustedDevicer_Trust_Call struct {
+	*mock.Call
+}
+
+// Trust is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockTrustedDevicer_Expecter) Trust(ctx interface{}, userID interface{}) *MockTrustedDevicer_Trust_Call {
+	return &MockTrustedDevicer_Trust_Call{Call: _e.mock.On("Trust", ctx, userID)}
+}
+
+func (_c *MockTrustedDevicer_Trust_Call) Run(run func(ctx context.Context, userID string)) *MockTrustedDevicer_Trust_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrustedDevicer_Trust_Call) Return(trustedDevice *model.TrustedDevice, s string, err error) *MockTrustedDevicer_Trust_Call {
+	_c.Call.Return(trustedDevice, s, err)
+	return _c
+}
+
+func (_c *MockTrustedDevicer_Trust_Call) RunAndReturn(run func(ctx context.Context, userID string) (*model.TrustedDevice, string, error)) *MockTrustedDevicer_Trust_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTwoFactorer creates a new instance of MockTwoFactorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTwoFactorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTwoFactorer {
+	mock := &MockTwoFactorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTwoFactorer is an autogenerated mock type for the TwoFactorer type
+type MockTwoFactorer struct {
+	mock.Mock
+}
+
+type MockTwoFactorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTwoFactorer) EXPECT() *MockTwoFactorer_Expecter {
+	return &MockTwoFactorer_Expecter{mock: &_m.Mock}
+}
+
+// Disable provides a mock function for the type MockTwoFactorer
+func (_mock *MockTwoFactorer) Disable(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTwoFactorer_Disable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Disable'
+type MockTwoFactorer_Disable_Call struct {
+	*mock.Call
+}
+
+// Disable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockTwoFactorer_Expecter) Disable(ctx interface{}, userID interface{}) *MockTwoFactorer_Disable_Call {
+	return &MockTwoFactorer_Disable_Call{Call: _e.mock.On("Disable", ctx, userID)}
+}
+
+func (_c *MockTwoFactorer_Disable_Call) Run(run func(ctx context.Context, userID string)) *MockTwoFactorer_Disable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorer_Disable_Call) Return(err error) *MockTwoFactorer_Disable_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTwoFactorer_Disable_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockTwoFactorer_Disable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enable provides a mock function for the type MockTwoFactorer
+func (_mock *MockTwoFactorer) Enable(ctx context.Context, userID string, code string) error {
+	ret := _mock.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enable")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTwoFactorer_Enable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enable'
+type MockTwoFactorer_Enable_Call struct {
+	*mock.Call
+}
+
+// Enable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - code string
+func (_e *MockTwoFactorer_Expecter) Enable(ctx interface{}, userID interface{}, code interface{}) *MockTwoFactorer_Enable_Call {
+	return &MockTwoFactorer_Enable_Call{Call: _e.mock.On("Enable", ctx, userID, code)}
+}
+
+func (_c *MockTwoFactorer_Enable_Call) Run(run func(ctx context.Context, userID string, code string)) *MockTwoFactorer_Enable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorer_Enable_Call) Return(err error) *MockTwoFactorer_Enable_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTwoFactorer_Enable_Call) RunAndReturn(run func(ctx context.Context, userID string, code string) error) *MockTwoFactorer_Enable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function for the type MockTwoFactorer
+func (_mock *MockTwoFactorer) GetByUserID(ctx context.Context, userID string) (*model.TwoFactor, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 *model.TwoFactor
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.TwoFactor, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.TwoFactor); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TwoFactor)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTwoFactorer_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockTwoFactorer_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockTwoFactorer_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockTwoFactorer_GetByUserID_Call {
+	return &MockTwoFactorer_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+}
+
+func (_c *MockTwoFactorer_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockTwoFactorer_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorer_GetByUserID_Call) Return(twoFactor *model.TwoFactor, err error) *MockTwoFactorer_GetByUserID_Call {
+	_c.Call.Return(twoFactor, err)
+	return _c
+}
+
+func (_c *MockTwoFactorer_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) (*model.TwoFactor, error)) *MockTwoFactorer_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegenerateQRCode provides a mock function for the type MockTwoFactorer
+func (_mock *MockTwoFactorer) RegenerateQRCode(ctx context.Context, userID string) (string, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateQRCode")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTwoFactorer_RegenerateQRCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateQRCode'
+type MockTwoFactorer_RegenerateQRCode_Call struct {
+	*mock.Call
+}
+
+// RegenerateQRCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockTwoFactorer_Expecter) RegenerateQRCode(ctx interface{}, userID interface{}) *MockTwoFactorer_RegenerateQRCode_Call {
+	return &MockTwoFactorer_RegenerateQRCode_Call{Call: _e.mock.On("RegenerateQRCode", ctx, userID)}
+}
+
+func (_c *MockTwoFactorer_RegenerateQRCode_Call) Run(run func(ctx context.Context, userID string)) *MockTwoFactorer_RegenerateQRCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorer_RegenerateQRCode_Call) Return(s string, err error) *MockTwoFactorer_RegenerateQRCode_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockTwoFactorer_RegenerateQRCode_Call) RunAndReturn(run func(ctx context.Context, userID string) (string, error)) *MockTwoFactorer_RegenerateQRCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Setup provides a mock function for the type MockTwoFactorer
+func (_mock *MockTwoFactorer) Setup(ctx context.Context, userID string) (*service.TwoFactorSetupData, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Setup")
+	}
+
+	var r0 *service.TwoFactorSetupData
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*service.TwoFactorSetupData, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *service.TwoFactorSetupData); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.TwoFactorSetupData)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTwoFactorer_Setup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Setup'
+type MockTwoFactorer_Setup_Call struct {
+	*mock.Call
+}
+
+// Setup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockTwoFactorer_Expecter) Setup(ctx interface{}, userID interface{}) *MockTwoFactorer_Setup_Call {
+	return &MockTwoFactorer_Setup_Call{Call: _e.mock.On("Setup", ctx, userID)}
+}
+
+func (_c *MockTwoFactorer_Setup_Call) Run(run func(ctx context.Context, userID string)) *MockTwoFactorer_Setup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTwoFactorer_Setup_Call) Return(twoFactorSetupData *service.TwoFactorSetupData, err error) *MockTwoFactorer_Setup_Call {
+	_c.Call.Return(twoFactorSetupData, err)
+	return _c
+}
+
+func (_c *MockTwoFactorer_Setup_Call) RunAndReturn(run func(ctx context.Context, userID string) (*service.TwoFactorSetupData, error)) *MockTwoFactorer_Setup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function for the type MockTwoFactorer
+func (_mock *MockTwoFactorer) Verify(ctx context.Context, userID string, code string) error {
+	ret := _mock.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTwoFactorer_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockTwoFactorer_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - code string
+func (_e *MockTwoFactorer_Expecter) Verify(ctx interface{}, userID interface{}, code interface{}) *MockTwoFactorer_Verify_Call {
+	return &MockTwoFactorer_Verify_Call{Call: _e.mock.On("Verify", ctx, userID, code)}
+}
+
+func (_c *MockTwoFactorer_Verify_Call) Run(run func(ctx context.Context, userID string, code string)) *MockTwoFactorer_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
@@ -942,125 +6138,370 @@ func (_c *MockSessioner_InvalidateByID_Call) Run(run func(ctx context.Context, t
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateByID_Call) Return(err error) *MockSessioner_InvalidateByID_Call {
+func (_c *MockTwoFactorer_Verify_Call) Return(err error) *MockTwoFactorer_Verify_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateByID_Call) RunAndReturn(run func(ctx context.Context, token string, sessionID string) error) *MockSessioner_InvalidateByID_Call {
+func (_c *MockTwoFactorer_Verify_Call) RunAndReturn(run func(ctx context.Context, userID string, code string) error) *MockTwoFactorer_Verify_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListByToken provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) ListByToken(ctx context.Context, userID string) ([]*model.Session, error) {
+// NewMockUserer creates a new instance of MockUserer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserer {
+	mock := &MockUserer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUserer is an autogenerated mock type for the Userer type
+type MockUserer struct {
+	mock.Mock
+}
+
+type MockUserer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserer) EXPECT() *MockUserer_Expecter {
+	return &MockUserer_Expecter{mock: &_m.Mock}
+}
+
+// CancelDeletion provides a mock function for the type MockUserer
+func (_mock *MockUserer) CancelDeletion(ctx context.Context, userID string) error {
 	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListByToken")
+		panic("no return value specified for CancelDeletion")
 	}
 
-	var r0 []*model.Session
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserer_CancelDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelDeletion'
+type MockUserer_CancelDeletion_Call struct {
+	*mock.Call
+}
+
+// CancelDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserer_Expecter) CancelDeletion(ctx interface{}, userID interface{}) *MockUserer_CancelDeletion_Call {
+	return &MockUserer_CancelDeletion_Call{Call: _e.mock.On("CancelDeletion", ctx, userID)}
+}
+
+func (_c *MockUserer_CancelDeletion_Call) Run(run func(ctx context.Context, userID string)) *MockUserer_CancelDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserer_CancelDeletion_Call) Return(err error) *MockUserer_CancelDeletion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserer_CancelDeletion_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockUserer_CancelDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmEmailChange provides a mock function for the type MockUserer
+func (_mock *MockUserer) ConfirmEmailChange(ctx context.Context, token string, sessionToken string) error {
+	ret := _mock.Called(ctx, token, sessionToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmailChange")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, token, sessionToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserer_ConfirmEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmEmailChange'
+type MockUserer_ConfirmEmailChange_Call struct {
+	*mock.Call
+}
+
+// ConfirmEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - sessionToken string
+func (_e *MockUserer_Expecter) ConfirmEmailChange(ctx interface{}, token interface{}, sessionToken interface{}) *MockUserer_ConfirmEmailChange_Call {
+	return &MockUserer_ConfirmEmailChange_Call{Call: _e.mock.On("ConfirmEmailChange", ctx, token, sessionToken)}
+}
+
+func (_c *MockUserer_ConfirmEmailChange_Call) Run(run func(ctx context.Context, token string, sessionToken string)) *MockUserer_ConfirmEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserer_ConfirmEmailChange_Call) Return(err error) *MockUserer_ConfirmEmailChange_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserer_ConfirmEmailChange_Call) RunAndReturn(run func(ctx context.Context, token string, sessionToken string) error) *MockUserer_ConfirmEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockUserer
+func (_mock *MockUserer) Create(ctx context.Context, user *model.User, password string) (*model.User, error) {
+	ret := _mock.Called(ctx, user, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Session, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User, string) (*model.User, error)); ok {
+		return returnFunc(ctx, user, password)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Session); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User, string) *model.User); ok {
+		r0 = returnFunc(ctx, user, password)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Session)
+			r0 = ret.Get(0).(*model.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.User, string) error); ok {
+		r1 = returnFunc(ctx, user, password)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSessioner_ListByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByToken'
-type MockSessioner_ListByToken_Call struct {
+// MockUserer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUserer_Create_Call struct {
 	*mock.Call
 }
 
-// ListByToken is a helper method to define mock.On call
+// Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockSessioner_Expecter) ListByToken(ctx interface{}, userID interface{}) *MockSessioner_ListByToken_Call {
-	return &MockSessioner_ListByToken_Call{Call: _e.mock.On("ListByToken", ctx, userID)}
+//   - user *model.User
+//   - password string
+func (_e *MockUserer_Expecter) Create(ctx interface{}, user interface{}, password interface{}) *MockUserer_Create_Call {
+	return &MockUserer_Create_Call{Call: _e.mock.On("Create", ctx, user, password)}
 }
 
-func (_c *MockSessioner_ListByToken_Call) Run(run func(ctx context.Context, userID string)) *MockSessioner_ListByToken_Call {
+func (_c *MockUserer_Create_Call) Run(run func(ctx context.Context, user *model.User, password string)) *MockUserer_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *model.User
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*model.User)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_ListByToken_Call) Return(sessions []*model.Session, err error) *MockSessioner_ListByToken_Call {
-	_c.Call.Return(sessions, err)
+func (_c *MockUserer_Create_Call) Return(user1 *model.User, err error) *MockUserer_Create_Call {
+	_c.Call.Return(user1, err)
+	return _c
+}
+
+func (_c *MockUserer_Create_Call) RunAndReturn(run func(ctx context.Context, user *model.User, password string) (*model.User, error)) *MockUserer_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredVerifications provides a mock function for the type MockUserer
+func (_mock *MockUserer) DeleteExpiredVerifications(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredVerifications")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserer_DeleteExpiredVerifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredVerifications'
+type MockUserer_DeleteExpiredVerifications_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredVerifications is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUserer_Expecter) DeleteExpiredVerifications(ctx interface{}) *MockUserer_DeleteExpiredVerifications_Call {
+	return &MockUserer_DeleteExpiredVerifications_Call{Call: _e.mock.On("DeleteExpiredVerifications", ctx)}
+}
+
+func (_c *MockUserer_DeleteExpiredVerifications_Call) Run(run func(ctx context.Context)) *MockUserer_DeleteExpiredVerifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserer_DeleteExpiredVerifications_Call) Return(err error) *MockUserer_DeleteExpiredVerifications_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserer_DeleteExpiredVerifications_Call) RunAndReturn(run func(ctx context.Context) error) *MockUserer_DeleteExpiredVerifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteScheduled provides a mock function for the type MockUserer
+func (_mock *MockUserer) DeleteScheduled(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteScheduled")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserer_DeleteScheduled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteScheduled'
+type MockUserer_DeleteScheduled_Call struct {
+	*mock.Call
+}
+
+// DeleteScheduled is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUserer_Expecter) DeleteScheduled(ctx interface{}) *MockUserer_DeleteScheduled_Call {
+	return &MockUserer_DeleteScheduled_Call{Call: _e.mock.On("DeleteScheduled", ctx)}
+}
+
+func (_c *MockUserer_DeleteScheduled_Call) Run(run func(ctx context.Context)) *MockUserer_DeleteScheduled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserer_DeleteScheduled_Call) Return(err error) *MockUserer_DeleteScheduled_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSessioner_ListByToken_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Session, error)) *MockSessioner_ListByToken_Call {
+func (_c *MockUserer_DeleteScheduled_Call) RunAndReturn(run func(ctx context.Context) error) *MockUserer_DeleteScheduled_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Refresh provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) Refresh(ctx context.Context, refreshToken string) (*model.Session, error) {
-	ret := _mock.Called(ctx, refreshToken)
+// ExportData provides a mock function for the type MockUserer
+func (_mock *MockUserer) ExportData(ctx context.Context, userID string, locale string) error {
+	ret := _mock.Called(ctx, userID, locale)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Refresh")
+		panic("no return value specified for ExportData")
 	}
 
-	var r0 *model.Session
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
-		return returnFunc(ctx, refreshToken)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
-		r0 = returnFunc(ctx, refreshToken)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Session)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, refreshToken)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, locale)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockSessioner_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
-type MockSessioner_Refresh_Call struct {
+// MockUserer_ExportData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportData'
+type MockUserer_ExportData_Call struct {
 	*mock.Call
 }
 
-// Refresh is a helper method to define mock.On call
+// ExportData is a helper method to define mock.On call
 //   - ctx context.Context
-//   - refreshToken string
-func (_e *MockSessioner_Expecter) Refresh(ctx interface{}, refreshToken interface{}) *MockSessioner_Refresh_Call {
-	return &MockSessioner_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken)}
+//   - userID string
+//   - locale string
+func (_e *MockUserer_Expecter) ExportData(ctx interface{}, userID interface{}, locale interface{}) *MockUserer_ExportData_Call {
+	return &MockUserer_ExportData_Call{Call: _e.mock.On("ExportData", ctx, userID, locale)}
 }
 
-func (_c *MockSessioner_Refresh_Call) Run(run func(ctx context.Context, refreshToken string)) *MockSessioner_Refresh_Call {
+func (_c *MockUserer_ExportData_Call) Run(run func(ctx context.Context, userID string, locale string)) *MockUserer_ExportData_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1070,55 +6511,70 @@ func (_c *MockSessioner_Refresh_Call) Run(run func(ctx context.Context, refreshT
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_Refresh_Call) Return(session *model.Session, err error) *MockSessioner_Refresh_Call {
-	_c.Call.Return(session, err)
+func (_c *MockUserer_ExportData_Call) Return(err error) *MockUserer_ExportData_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSessioner_Refresh_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (*model.Session, error)) *MockSessioner_Refresh_Call {
+func (_c *MockUserer_ExportData_Call) RunAndReturn(run func(ctx context.Context, userID string, locale string) error) *MockUserer_ExportData_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTwoFactorStatus provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) UpdateTwoFactorStatus(ctx context.Context, token string, isPending bool) error {
-	ret := _mock.Called(ctx, token, isPending)
+// GetByID provides a mock function for the type MockUserer
+func (_mock *MockUserer) GetByID(ctx context.Context, id string) (*model.User, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTwoFactorStatus")
+		panic("no return value specified for GetByID")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
-		r0 = returnFunc(ctx, token, isPending)
+	var r0 *model.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockSessioner_UpdateTwoFactorStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTwoFactorStatus'
-type MockSessioner_UpdateTwoFactorStatus_Call struct {
+// MockUserer_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockUserer_GetByID_Call struct {
 	*mock.Call
 }
 
-// UpdateTwoFactorStatus is a helper method to define mock.On call
+// GetByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-//   - isPending bool
-func (_e *MockSessioner_Expecter) UpdateTwoFactorStatus(ctx interface{}, token interface{}, isPending interface{}) *MockSessioner_UpdateTwoFactorStatus_Call {
-	return &MockSessioner_UpdateTwoFactorStatus_Call{Call: _e.mock.On("UpdateTwoFactorStatus", ctx, token, isPending)}
+//   - id string
+func (_e *MockUserer_Expecter) GetByID(ctx interface{}, id interface{}) *MockUserer_GetByID_Call {
+	return &MockUserer_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
 }
 
-func (_c *MockSessioner_UpdateTwoFactorStatus_Call) Run(run func(ctx context.Context, token string, isPending bool)) *MockSessioner_UpdateTwoFactorStatus_Call {
+func (_c *MockUserer_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockUserer_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1128,47 +6584,42 @@ func (_c *MockSessioner_UpdateTwoFactorStatus_Call) Run(run func(ctx context.Con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_UpdateTwoFactorStatus_Call) Return(err error) *MockSessioner_UpdateTwoFactorStatus_Call {
-	_c.Call.Return(err)
+func (_c *MockUserer_GetByID_Call) Return(user *model.User, err error) *MockUserer_GetByID_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockSessioner_UpdateTwoFactorStatus_Call) RunAndReturn(run func(ctx context.Context, token string, isPending bool) error) *MockSessioner_UpdateTwoFactorStatus_Call {
+func (_c *MockUserer_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.User, error)) *MockUserer_GetByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Validate provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) Validate(ctx context.Context, token string) (*model.Session, error) {
+// GetEmailVerification provides a mock function for the type MockUserer
+func (_mock *MockUserer) GetEmailVerification(ctx context.Context, token string) (*model.Verification, error) {
 	ret := _mock.Called(ctx, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Validate")
+		panic("no return value specified for GetEmailVerification")
 	}
 
-	var r0 *model.Session
+	var r0 *model.Verification
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Verification, error)); ok {
 		return returnFunc(ctx, token)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Verification); ok {
 		r0 = returnFunc(ctx, token)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Session)
+			r0 = ret.Get(0).(*model.Verification)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -1179,19 +6630,19 @@ func (_mock *MockSessioner) Validate(ctx context.Context, token string) (*model.
 	return r0, r1
 }
 
-// MockSessioner_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
-type MockSessioner_Validate_Call struct {
+// MockUserer_GetEmailVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmailVerification'
+type MockUserer_GetEmailVerification_Call struct {
 	*mock.Call
 }
 
-// Validate is a helper method to define mock.On call
+// GetEmailVerification is a helper method to define mock.On call
 //   - ctx context.Context
 //   - token string
-func (_e *MockSessioner_Expecter) Validate(ctx interface{}, token interface{}) *MockSessioner_Validate_Call {
-	return &MockSessioner_Validate_Call{Call: _e.mock.On("Validate", ctx, token)}
+func (_e *MockUserer_Expecter) GetEmailVerification(ctx interface{}, token interface{}) *MockUserer_GetEmailVerification_Call {
+	return &MockUserer_GetEmailVerification_Call{Call: _e.mock.On("GetEmailVerification", ctx, token)}
 }
 
-func (_c *MockSessioner_Validate_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_Validate_Call {
+func (_c *MockUserer_GetEmailVerification_Call) Run(run func(ctx context.Context, token string)) *MockUserer_GetEmailVerification_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1209,73 +6660,46 @@ func (_c *MockSessioner_Validate_Call) Run(run func(ctx context.Context, token s
 	return _c
 }
 
-func (_c *MockSessioner_Validate_Call) Return(session *model.Session, err error) *MockSessioner_Validate_Call {
-	_c.Call.Return(session, err)
+func (_c *MockUserer_GetEmailVerification_Call) Return(verification *model.Verification, err error) *MockUserer_GetEmailVerification_Call {
+	_c.Call.Return(verification, err)
 	return _c
 }
 
-func (_c *MockSessioner_Validate_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.Session, error)) *MockSessioner_Validate_Call {
+func (_c *MockUserer_GetEmailVerification_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.Verification, error)) *MockUserer_GetEmailVerification_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockTwoFactorer creates a new instance of MockTwoFactorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockTwoFactorer(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockTwoFactorer {
-	mock := &MockTwoFactorer{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockTwoFactorer is an autogenerated mock type for the TwoFactorer type
-type MockTwoFactorer struct {
-	mock.Mock
-}
-
-type MockTwoFactorer_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockTwoFactorer) EXPECT() *MockTwoFactorer_Expecter {
-	return &MockTwoFactorer_Expecter{mock: &_m.Mock}
-}
-
-// Disable provides a mock function for the type MockTwoFactorer
-func (_mock *MockTwoFactorer) Disable(ctx context.Context, userID string) error {
-	ret := _mock.Called(ctx, userID)
+// InitiatePasswordReset provides a mock function for the type MockUserer
+func (_mock *MockUserer) InitiatePasswordReset(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Disable")
+		panic("no return value specified for InitiatePasswordReset")
 	}
 
 	var r0 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, userID)
+		r0 = returnFunc(ctx, email)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockTwoFactorer_Disable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Disable'
-type MockTwoFactorer_Disable_Call struct {
+// MockUserer_InitiatePasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitiatePasswordReset'
+type MockUserer_InitiatePasswordReset_Call struct {
 	*mock.Call
 }
 
-// Disable is a helper method to define mock.On call
+// InitiatePasswordReset is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockTwoFactorer_Expecter) Disable(ctx interface{}, userID interface{}) *MockTwoFactorer_Disable_Call {
-	return &MockTwoFactorer_Disable_Call{Call: _e.mock.On("Disable", ctx, userID)}
+//   - email string
+func (_e *MockUserer_Expecter) InitiatePasswordReset(ctx interface{}, email interface{}) *MockUserer_InitiatePasswordReset_Call {
+	return &MockUserer_InitiatePasswordReset_Call{Call: _e.mock.On("InitiatePasswordReset", ctx, email)}
 }
 
-func (_c *MockTwoFactorer_Disable_Call) Run(run func(ctx context.Context, userID string)) *MockTwoFactorer_Disable_Call {
+func (_c *MockUserer_InitiatePasswordReset_Call) Run(run func(ctx context.Context, email string)) *MockUserer_InitiatePasswordReset_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1293,47 +6717,47 @@ func (_c *MockTwoFactorer_Disable_Call) Run(run func(ctx context.Context, userID
 	return _c
 }
 
-func (_c *MockTwoFactorer_Disable_Call) Return(err error) *MockTwoFactorer_Disable_Call {
+func (_c *MockUserer_InitiatePasswordReset_Call) Return(err error) *MockUserer_InitiatePasswordReset_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTwoFactorer_Disable_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockTwoFactorer_Disable_Call {
+func (_c *MockUserer_InitiatePasswordReset_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockUserer_InitiatePasswordReset_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Enable provides a mock function for the type MockTwoFactorer
-func (_mock *MockTwoFactorer) Enable(ctx context.Context, userID string, code string) error {
-	ret := _mock.Called(ctx, userID, code)
+// ProcessImage provides a mock function for the type MockUserer
+func (_mock *MockUserer) ProcessImage(ctx context.Context, userID string, imageID string) error {
+	ret := _mock.Called(ctx, userID, imageID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Enable")
+		panic("no return value specified for ProcessImage")
 	}
 
 	var r0 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, userID, code)
+		r0 = returnFunc(ctx, userID, imageID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockTwoFactorer_Enable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enable'
-type MockTwoFactorer_Enable_Call struct {
+// MockUserer_ProcessImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessImage'
+type MockUserer_ProcessImage_Call struct {
 	*mock.Call
 }
 
-// Enable is a helper method to define mock.On call
+// ProcessImage is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-//   - code string
-func (_e *MockTwoFactorer_Expecter) Enable(ctx interface{}, userID interface{}, code interface{}) *MockTwoFactorer_Enable_Call {
-	return &MockTwoFactorer_Enable_Call{Call: _e.mock.On("Enable", ctx, userID, code)}
+//   - imageID string
+func (_e *MockUserer_Expecter) ProcessImage(ctx interface{}, userID interface{}, imageID interface{}) *MockUserer_ProcessImage_Call {
+	return &MockUserer_ProcessImage_Call{Call: _e.mock.On("ProcessImage", ctx, userID, imageID)}
 }
 
-func (_c *MockTwoFactorer_Enable_Call) Run(run func(ctx context.Context, userID string, code string)) *MockTwoFactorer_Enable_Call {
+func (_c *MockUserer_ProcessImage_Call) Run(run func(ctx context.Context, userID string, imageID string)) *MockUserer_ProcessImage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1356,57 +6780,46 @@ func (_c *MockTwoFactorer_Enable_Call) Run(run func(ctx context.Context, userID
 	return _c
 }
 
-func (_c *MockTwoFactorer_Enable_Call) Return(err error) *MockTwoFactorer_Enable_Call {
+func (_c *MockUserer_ProcessImage_Call) Return(err error) *MockUserer_ProcessImage_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTwoFactorer_Enable_Call) RunAndReturn(run func(ctx context.Context, userID string, code string) error) *MockTwoFactorer_Enable_Call {
+func (_c *MockUserer_ProcessImage_Call) RunAndReturn(run func(ctx context.Context, userID string, imageID string) error) *MockUserer_ProcessImage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByUserID provides a mock function for the type MockTwoFactorer
-func (_mock *MockTwoFactorer) GetByUserID(ctx context.Context, userID string) (*model.TwoFactor, error) {
+// RequestDataExport provides a mock function for the type MockUserer
+func (_mock *MockUserer) RequestDataExport(ctx context.Context, userID string) error {
 	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUserID")
+		panic("no return value specified for RequestDataExport")
 	}
 
-	var r0 *model.TwoFactor
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.TwoFactor, error)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.TwoFactor); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
 		r0 = returnFunc(ctx, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.TwoFactor)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockTwoFactorer_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
-type MockTwoFactorer_GetByUserID_Call struct {
+// MockUserer_RequestDataExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestDataExport'
+type MockUserer_RequestDataExport_Call struct {
 	*mock.Call
 }
 
-// GetByUserID is a helper method to define mock.On call
+// RequestDataExport is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *MockTwoFactorer_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockTwoFactorer_GetByUserID_Call {
-	return &MockTwoFactorer_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+func (_e *MockUserer_Expecter) RequestDataExport(ctx interface{}, userID interface{}) *MockUserer_RequestDataExport_Call {
+	return &MockUserer_RequestDataExport_Call{Call: _e.mock.On("RequestDataExport", ctx, userID)}
 }
 
-func (_c *MockTwoFactorer_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockTwoFactorer_GetByUserID_Call {
+func (_c *MockUserer_RequestDataExport_Call) Run(run func(ctx context.Context, userID string)) *MockUserer_RequestDataExport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1424,55 +6837,48 @@ func (_c *MockTwoFactorer_GetByUserID_Call) Run(run func(ctx context.Context, us
 	return _c
 }
 
-func (_c *MockTwoFactorer_GetByUserID_Call) Return(twoFactor *model.TwoFactor, err error) *MockTwoFactorer_GetByUserID_Call {
-	_c.Call.Return(twoFactor, err)
+func (_c *MockUserer_RequestDataExport_Call) Return(err error) *MockUserer_RequestDataExport_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTwoFactorer_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) (*model.TwoFactor, error)) *MockTwoFactorer_GetByUserID_Call {
+func (_c *MockUserer_RequestDataExport_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockUserer_RequestDataExport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RegenerateQRCode provides a mock function for the type MockTwoFactorer
-func (_mock *MockTwoFactorer) RegenerateQRCode(ctx context.Context, userID string) (string, error) {
-	ret := _mock.Called(ctx, userID)
+// RequestEmailChange provides a mock function for the type MockUserer
+func (_mock *MockUserer) RequestEmailChange(ctx context.Context, userID string, password string, newEmail string) error {
+	ret := _mock.Called(ctx, userID, password, newEmail)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RegenerateQRCode")
+		panic("no return value specified for RequestEmailChange")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, userID)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, password, newEmail)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockTwoFactorer_RegenerateQRCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateQRCode'
-type MockTwoFactorer_RegenerateQRCode_Call struct {
+// MockUserer_RequestEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestEmailChange'
+type MockUserer_RequestEmailChange_Call struct {
 	*mock.Call
 }
 
-// RegenerateQRCode is a helper method to define mock.On call
+// RequestEmailChange is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *MockTwoFactorer_Expecter) RegenerateQRCode(ctx interface{}, userID interface{}) *MockTwoFactorer_RegenerateQRCode_Call {
-	return &MockTwoFactorer_RegenerateQRCode_Call{Call: _e.mock.On("RegenerateQRCode", ctx, userID)}
+//   - password string
+//   - newEmail string
+func (_e *MockUserer_Expecter) RequestEmailChange(ctx interface{}, userID interface{}, password interface{}, newEmail interface{}) *MockUserer_RequestEmailChange_Call {
+	return &MockUserer_RequestEmailChange_Call{Call: _e.mock.On("RequestEmailChange", ctx, userID, password, newEmail)}
 }
 
-func (_c *MockTwoFactorer_RegenerateQRCode_Call) Run(run func(ctx context.Context, userID string)) *MockTwoFactorer_RegenerateQRCode_Call {
+func (_c *MockUserer_RequestEmailChange_Call) Run(run func(ctx context.Context, userID string, password string, newEmail string)) *MockUserer_RequestEmailChange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1482,65 +6888,64 @@ func (_c *MockTwoFactorer_RegenerateQRCode_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTwoFactorer_RegenerateQRCode_Call) Return(s string, err error) *MockTwoFactorer_RegenerateQRCode_Call {
-	_c.Call.Return(s, err)
+func (_c *MockUserer_RequestEmailChange_Call) Return(err error) *MockUserer_RequestEmailChange_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTwoFactorer_RegenerateQRCode_Call) RunAndReturn(run func(ctx context.Context, userID string) (string, error)) *MockTwoFactorer_RegenerateQRCode_Call {
+func (_c *MockUserer_RequestEmailChange_Call) RunAndReturn(run func(ctx context.Context, userID string, password string, newEmail string) error) *MockUserer_RequestEmailChange_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Setup provides a mock function for the type MockTwoFactorer
-func (_mock *MockTwoFactorer) Setup(ctx context.Context, userID string) (*service.TwoFactorSetupData, error) {
-	ret := _mock.Called(ctx, userID)
+// ResendVerification provides a mock function for the type MockUserer
+func (_mock *MockUserer) ResendVerification(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Setup")
+		panic("no return value specified for ResendVerification")
 	}
 
-	var r0 *service.TwoFactorSetupData
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*service.TwoFactorSetupData, error)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *service.TwoFactorSetupData); ok {
-		r0 = returnFunc(ctx, userID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*service.TwoFactorSetupData)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockTwoFactorer_Setup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Setup'
-type MockTwoFactorer_Setup_Call struct {
+// MockUserer_ResendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerification'
+type MockUserer_ResendVerification_Call struct {
 	*mock.Call
 }
 
-// Setup is a helper method to define mock.On call
+// ResendVerification is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockTwoFactorer_Expecter) Setup(ctx interface{}, userID interface{}) *MockTwoFactorer_Setup_Call {
-	return &MockTwoFactorer_Setup_Call{Call: _e.mock.On("Setup", ctx, userID)}
+//   - email string
+func (_e *MockUserer_Expecter) ResendVerification(ctx interface{}, email interface{}) *MockUserer_ResendVerification_Call {
+	return &MockUserer_ResendVerification_Call{Call: _e.mock.On("ResendVerification", ctx, email)}
 }
 
-func (_c *MockTwoFactorer_Setup_Call) Run(run func(ctx context.Context, userID string)) *MockTwoFactorer_Setup_Call {
+func (_c *MockUserer_ResendVerification_Call) Run(run func(ctx context.Context, email string)) *MockUserer_ResendVerification_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1558,47 +6963,47 @@ func (_c *MockTwoFactorer_Setup_Call) Run(run func(ctx context.Context, userID s
 	return _c
 }
 
-func (_c *MockTwoFactorer_Setup_Call) Return(twoFactorSetupData *service.TwoFactorSetupData, err error) *MockTwoFactorer_Setup_Call {
-	_c.Call.Return(twoFactorSetupData, err)
+func (_c *MockUserer_ResendVerification_Call) Return(err error) *MockUserer_ResendVerification_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTwoFactorer_Setup_Call) RunAndReturn(run func(ctx context.Context, userID string) (*service.TwoFactorSetupData, error)) *MockTwoFactorer_Setup_Call {
+func (_c *MockUserer_ResendVerification_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockUserer_ResendVerification_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Verify provides a mock function for the type MockTwoFactorer
-func (_mock *MockTwoFactorer) Verify(ctx context.Context, userID string, code string) error {
-	ret := _mock.Called(ctx, userID, code)
+// ResetPassword provides a mock function for the type MockUserer
+func (_mock *MockUserer) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	ret := _mock.Called(ctx, token, newPassword)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Verify")
+		panic("no return value specified for ResetPassword")
 	}
 
 	var r0 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, userID, code)
+		r0 = returnFunc(ctx, token, newPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockTwoFactorer_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
-type MockTwoFactorer_Verify_Call struct {
+// MockUserer_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockUserer_ResetPassword_Call struct {
 	*mock.Call
 }
 
-// Verify is a helper method to define mock.On call
+// ResetPassword is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - code string
-func (_e *MockTwoFactorer_Expecter) Verify(ctx interface{}, userID interface{}, code interface{}) *MockTwoFactorer_Verify_Call {
-	return &MockTwoFactorer_Verify_Call{Call: _e.mock.On("Verify", ctx, userID, code)}
+//   - token string
+//   - newPassword string
+func (_e *MockUserer_Expecter) ResetPassword(ctx interface{}, token interface{}, newPassword interface{}) *MockUserer_ResetPassword_Call {
+	return &MockUserer_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, token, newPassword)}
 }
 
-func (_c *MockTwoFactorer_Verify_Call) Run(run func(ctx context.Context, userID string, code string)) *MockTwoFactorer_Verify_Call {
+func (_c *MockUserer_ResetPassword_Call) Run(run func(ctx context.Context, token string, newPassword string)) *MockUserer_ResetPassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1621,158 +7026,124 @@ func (_c *MockTwoFactorer_Verify_Call) Run(run func(ctx context.Context, userID
 	return _c
 }
 
-func (_c *MockTwoFactorer_Verify_Call) Return(err error) *MockTwoFactorer_Verify_Call {
+func (_c *MockUserer_ResetPassword_Call) Return(err error) *MockUserer_ResetPassword_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTwoFactorer_Verify_Call) RunAndReturn(run func(ctx context.Context, userID string, code string) error) *MockTwoFactorer_Verify_Call {
+func (_c *MockUserer_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, token string, newPassword string) error) *MockUserer_ResetPassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockUserer creates a new instance of MockUserer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockUserer(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockUserer {
-	mock := &MockUserer{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockUserer is an autogenerated mock type for the Userer type
-type MockUserer struct {
-	mock.Mock
-}
-
-type MockUserer_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockUserer) EXPECT() *MockUserer_Expecter {
-	return &MockUserer_Expecter{mock: &_m.Mock}
-}
-
-// Create provides a mock function for the type MockUserer
-func (_mock *MockUserer) Create(ctx context.Context, user *model.User, password string) (*model.User, error) {
-	ret := _mock.Called(ctx, user, password)
+// RevertEmailChange provides a mock function for the type MockUserer
+func (_mock *MockUserer) RevertEmailChange(ctx context.Context, token string) (string, error) {
+	ret := _mock.Called(ctx, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for RevertEmailChange")
 	}
 
-	var r0 *model.User
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User, string) (*model.User, error)); ok {
-		return returnFunc(ctx, user, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, token)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.User, string) *model.User); ok {
-		r0 = returnFunc(ctx, user, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.User)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.User, string) error); ok {
-		r1 = returnFunc(ctx, user, password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockUserer_Create_Call struct {
+// MockUserer_RevertEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertEmailChange'
+type MockUserer_RevertEmailChange_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// RevertEmailChange is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *model.User
-//   - password string
-func (_e *MockUserer_Expecter) Create(ctx interface{}, user interface{}, password interface{}) *MockUserer_Create_Call {
-	return &MockUserer_Create_Call{Call: _e.mock.On("Create", ctx, user, password)}
+//   - token string
+func (_e *MockUserer_Expecter) RevertEmailChange(ctx interface{}, token interface{}) *MockUserer_RevertEmailChange_Call {
+	return &MockUserer_RevertEmailChange_Call{Call: _e.mock.On("RevertEmailChange", ctx, token)}
 }
 
-func (_c *MockUserer_Create_Call) Run(run func(ctx context.Context, user *model.User, password string)) *MockUserer_Create_Call {
+func (_c *MockUserer_RevertEmailChange_Call) Run(run func(ctx context.Context, token string)) *MockUserer_RevertEmailChange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.User
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.User)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserer_Create_Call) Return(user1 *model.User, err error) *MockUserer_Create_Call {
-	_c.Call.Return(user1, err)
+func (_c *MockUserer_RevertEmailChange_Call) Return(s string, err error) *MockUserer_RevertEmailChange_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockUserer_Create_Call) RunAndReturn(run func(ctx context.Context, user *model.User, password string) (*model.User, error)) *MockUserer_Create_Call {
+func (_c *MockUserer_RevertEmailChange_Call) RunAndReturn(run func(ctx context.Context, token string) (string, error)) *MockUserer_RevertEmailChange_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type MockUserer
-func (_mock *MockUserer) GetByID(ctx context.Context, id string) (*model.User, error) {
-	ret := _mock.Called(ctx, id)
+// ScheduleDeletion provides a mock function for the type MockUserer
+func (_mock *MockUserer) ScheduleDeletion(ctx context.Context, userID string, password string) (*model.User, error) {
+	ret := _mock.Called(ctx, userID, password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for ScheduleDeletion")
 	}
 
 	var r0 *model.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.User, error)); ok {
+		return returnFunc(ctx, userID, password)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.User); ok {
+		r0 = returnFunc(ctx, userID, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, password)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserer_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockUserer_GetByID_Call struct {
+// MockUserer_ScheduleDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleDeletion'
+type MockUserer_ScheduleDeletion_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// ScheduleDeletion is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockUserer_Expecter) GetByID(ctx interface{}, id interface{}) *MockUserer_GetByID_Call {
-	return &MockUserer_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+//   - userID string
+//   - password string
+func (_e *MockUserer_Expecter) ScheduleDeletion(ctx interface{}, userID interface{}, password interface{}) *MockUserer_ScheduleDeletion_Call {
+	return &MockUserer_ScheduleDeletion_Call{Call: _e.mock.On("ScheduleDeletion", ctx, userID, password)}
 }
 
-func (_c *MockUserer_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockUserer_GetByID_Call {
+func (_c *MockUserer_ScheduleDeletion_Call) Run(run func(ctx context.Context, userID string, password string)) *MockUserer_ScheduleDeletion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1782,54 +7153,59 @@ func (_c *MockUserer_GetByID_Call) Run(run func(ctx context.Context, id string))
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserer_GetByID_Call) Return(user *model.User, err error) *MockUserer_GetByID_Call {
+func (_c *MockUserer_ScheduleDeletion_Call) Return(user *model.User, err error) *MockUserer_ScheduleDeletion_Call {
 	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockUserer_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.User, error)) *MockUserer_GetByID_Call {
+func (_c *MockUserer_ScheduleDeletion_Call) RunAndReturn(run func(ctx context.Context, userID string, password string) (*model.User, error)) *MockUserer_ScheduleDeletion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InitiatePasswordReset provides a mock function for the type MockUserer
-func (_mock *MockUserer) InitiatePasswordReset(ctx context.Context, email string) error {
-	ret := _mock.Called(ctx, email)
+// Unlock provides a mock function for the type MockUserer
+func (_mock *MockUserer) Unlock(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InitiatePasswordReset")
+		panic("no return value specified for Unlock")
 	}
 
 	var r0 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, email)
+		r0 = returnFunc(ctx, token)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockUserer_InitiatePasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InitiatePasswordReset'
-type MockUserer_InitiatePasswordReset_Call struct {
+// MockUserer_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type MockUserer_Unlock_Call struct {
 	*mock.Call
 }
 
-// InitiatePasswordReset is a helper method to define mock.On call
+// Unlock is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-func (_e *MockUserer_Expecter) InitiatePasswordReset(ctx interface{}, email interface{}) *MockUserer_InitiatePasswordReset_Call {
-	return &MockUserer_InitiatePasswordReset_Call{Call: _e.mock.On("InitiatePasswordReset", ctx, email)}
+//   - token string
+func (_e *MockUserer_Expecter) Unlock(ctx interface{}, token interface{}) *MockUserer_Unlock_Call {
+	return &MockUserer_Unlock_Call{Call: _e.mock.On("Unlock", ctx, token)}
 }
 
-func (_c *MockUserer_InitiatePasswordReset_Call) Run(run func(ctx context.Context, email string)) *MockUserer_InitiatePasswordReset_Call {
+func (_c *MockUserer_Unlock_Call) Run(run func(ctx context.Context, token string)) *MockUserer_Unlock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1847,47 +7223,48 @@ func (_c *MockUserer_InitiatePasswordReset_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockUserer_InitiatePasswordReset_Call) Return(err error) *MockUserer_InitiatePasswordReset_Call {
+func (_c *MockUserer_Unlock_Call) Return(err error) *MockUserer_Unlock_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserer_InitiatePasswordReset_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockUserer_InitiatePasswordReset_Call {
+func (_c *MockUserer_Unlock_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockUserer_Unlock_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ResetPassword provides a mock function for the type MockUserer
-func (_mock *MockUserer) ResetPassword(ctx context.Context, token string, newPassword string) error {
-	ret := _mock.Called(ctx, token, newPassword)
+// UnlockMember provides a mock function for the type MockUserer
+func (_mock *MockUserer) UnlockMember(ctx context.Context, entityID string, actorID string, userID string) error {
+	ret := _mock.Called(ctx, entityID, actorID, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResetPassword")
+		panic("no return value specified for UnlockMember")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, token, newPassword)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, entityID, actorID, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockUserer_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
-type MockUserer_ResetPassword_Call struct {
+// MockUserer_UnlockMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockMember'
+type MockUserer_UnlockMember_Call struct {
 	*mock.Call
 }
 
-// ResetPassword is a helper method to define mock.On call
+// UnlockMember is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-//   - newPassword string
-func (_e *MockUserer_Expecter) ResetPassword(ctx interface{}, token interface{}, newPassword interface{}) *MockUserer_ResetPassword_Call {
-	return &MockUserer_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, token, newPassword)}
+//   - entityID string
+//   - actorID string
+//   - userID string
+func (_e *MockUserer_Expecter) UnlockMember(ctx interface{}, entityID interface{}, actorID interface{}, userID interface{}) *MockUserer_UnlockMember_Call {
+	return &MockUserer_UnlockMember_Call{Call: _e.mock.On("UnlockMember", ctx, entityID, actorID, userID)}
 }
 
-func (_c *MockUserer_ResetPassword_Call) Run(run func(ctx context.Context, token string, newPassword string)) *MockUserer_ResetPassword_Call {
+func (_c *MockUserer_UnlockMember_Call) Run(run func(ctx context.Context, entityID string, actorID string, userID string)) *MockUserer_UnlockMember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1901,21 +7278,26 @@ func (_c *MockUserer_ResetPassword_Call) Run(run func(ctx context.Context, token
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserer_ResetPassword_Call) Return(err error) *MockUserer_ResetPassword_Call {
+func (_c *MockUserer_UnlockMember_Call) Return(err error) *MockUserer_UnlockMember_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserer_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, token string, newPassword string) error) *MockUserer_ResetPassword_Call {
+func (_c *MockUserer_UnlockMember_Call) RunAndReturn(run func(ctx context.Context, entityID string, actorID string, userID string) error) *MockUserer_UnlockMember_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1988,6 +7370,69 @@ func (_c *MockUserer_Update_Call) RunAndReturn(run func(ctx context.Context, use
 	return _c
 }
 
+// UpdateImage provides a mock function for the type MockUserer
+func (_mock *MockUserer) UpdateImage(ctx context.Context, userID string, data []byte) error {
+	ret := _mock.Called(ctx, userID, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateImage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, userID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserer_UpdateImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateImage'
+type MockUserer_UpdateImage_Call struct {
+	*mock.Call
+}
+
+// UpdateImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - data []byte
+func (_e *MockUserer_Expecter) UpdateImage(ctx interface{}, userID interface{}, data interface{}) *MockUserer_UpdateImage_Call {
+	return &MockUserer_UpdateImage_Call{Call: _e.mock.On("UpdateImage", ctx, userID, data)}
+}
+
+func (_c *MockUserer_UpdateImage_Call) Run(run func(ctx context.Context, userID string, data []byte)) *MockUserer_UpdateImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserer_UpdateImage_Call) Return(err error) *MockUserer_UpdateImage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserer_UpdateImage_Call) RunAndReturn(run func(ctx context.Context, userID string, data []byte) error) *MockUserer_UpdateImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePassword provides a mock function for the type MockUserer
 func (_mock *MockUserer) UpdatePassword(ctx context.Context, userID string, currentPassword string, newPassword string) error {
 	ret := _mock.Called(ctx, userID, currentPassword, newPassword)
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/api/pkg/password"
+	"autopilot/backends/internal/types"
+	"context"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordPolicier is an interface that wraps the PasswordPolicy methods
+type PasswordPolicier interface {
+	Get(ctx context.Context, entityID string) (*model.PasswordPolicy, error)
+	Update(ctx context.Context, entityID, userID string, policy *model.PasswordPolicy) (*model.PasswordPolicy, error)
+}
+
+// PasswordPolicy is the service for the password requirements entities set
+// for their members.
+type PasswordPolicy struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewPasswordPolicy creates a new PasswordPolicy service.
+func NewPasswordPolicy(container *app.Container, store *store.Manager) PasswordPolicier {
+	return &PasswordPolicy{
+		Container: container,
+		store:     store,
+	}
+}
+
+// Get returns the password policy of an entity. Entities without a policy get
+// one without requirements of its own.
+func (s *PasswordPolicy) Get(ctx context.Context, entityID string) (*model.PasswordPolicy, error) {
+	policy, err := s.store.PasswordPolicy.Get(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if policy == nil {
+		policy = &model.PasswordPolicy{EntityID: entityID}
+	}
+
+	return policy, nil
+}
+
+// Update replaces the password policy of an entity. It applies to the next
+// password each member sets.
+func (s *PasswordPolicy) Update(ctx context.Context, entityID, userID string, policy *model.PasswordPolicy) (*model.PasswordPolicy, error) {
+	before, err := s.Get(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.PasswordPolicy.Upsert(ctx, &model.PasswordPolicy{
+		EntityID:         entityID,
+		MinStrengthScore: policy.MinStrengthScore,
+		HistorySize:      policy.HistorySize,
+		UpdatedBy:        &userID,
+	})
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	changeBefore := map[string]any{"min_strength_score": before.MinStrengthScore, "history_size": before.HistorySize}
+	changeAfter := map[string]any{"min_strength_score": updated.MinStrengthScore, "history_size": updated.HistorySize}
+	if err := auditLogChange(ctx, s.store, types.ResourcePasswordPolicy, types.ActionUpdate, entityID, userID, changeBefore, changeAfter, nil); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// checkPassword checks a new password of a user against the breached password
+// corpus and the strictest password policy of the entities they belong to. A
+// user without an ID, such as one signing up, has no entities yet.
+func checkPassword(ctx context.Context, store *store.Manager, user *model.User, newPassword string) error {
+	if password.IsBreached(newPassword) {
+		return httpx.ErrPasswordBreached
+	}
+
+	var policies []*model.PasswordPolicy
+	if user.ID != "" {
+		var err error
+		policies, err = store.PasswordPolicy.ListByUserID(ctx, user.ID)
+		if err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+	}
+	policy := model.StrictestPasswordPolicy(policies...)
+
+	if policy.MinStrengthScore > 0 && password.Strength(newPassword, user.Email, user.Name) < policy.MinStrengthScore {
+		return httpx.ErrPasswordTooWeak
+	}
+
+	if policy.HistorySize == 0 {
+		return nil
+	}
+
+	history, err := store.PasswordPolicy.ListHistory(ctx, user.ID, policy.HistorySize)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	for _, entry := range history {
+		if bcrypt.CompareHashAndPassword([]byte(entry.PasswordHash), []byte(newPassword)) == nil {
+			return httpx.ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// recordPassword adds the hash of a password a user has set to their history,
+// keeping as many as the strictest policy can ban reusing.
+func recordPassword(ctx context.Context, store store.PasswordPolicier, userID, passwordHash string) error {
+	if err := store.CreateHistory(ctx, &model.PasswordHistoryEntry{UserID: userID, PasswordHash: passwordHash}); err != nil {
+		return err
+	}
+
+	return store.PruneHistory(ctx, userID, model.PasswordHistoryMaxSize)
+}
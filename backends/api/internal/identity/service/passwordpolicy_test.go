@@ -0,0 +1,87 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestCheckPassword(t *testing.T) {
+	t.Parallel()
+
+	reusedHash, err := bcrypt.GenerateFromPassword([]byte("kX9#mQ2$vL7!"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	user := &model.User{ID: "user", Email: "ada.lovelace@example.com", Name: "Ada Lovelace"}
+	tests := []struct {
+		name     string
+		user     *model.User
+		password string
+		policies []*model.PasswordPolicy
+		history  []*model.PasswordHistoryEntry
+		wantErr  error
+	}{
+		{
+			name:     "should reject a breached password without checking the policies",
+			user:     user,
+			password: "P@ssw0rd!",
+			wantErr:  httpx.ErrPasswordBreached,
+		},
+		{
+			name:     "should only reject breached passwords when signing up",
+			user:     &model.User{Email: user.Email, Name: user.Name},
+			password: "Ada.Lovelace1!",
+		},
+		{
+			name:     "should reject a password below the strictest minimum strength",
+			user:     user,
+			password: "Ada.Lovelace1!",
+			policies: []*model.PasswordPolicy{{MinStrengthScore: 1}, {MinStrengthScore: 3}},
+			wantErr:  httpx.ErrPasswordTooWeak,
+		},
+		{
+			name:     "should reject a password in the history",
+			user:     user,
+			password: "kX9#mQ2$vL7!",
+			policies: []*model.PasswordPolicy{{MinStrengthScore: 3, HistorySize: 2}},
+			history:  []*model.PasswordHistoryEntry{{PasswordHash: string(reusedHash)}},
+			wantErr:  httpx.ErrPasswordReused,
+		},
+		{
+			name:     "should accept a strong new password",
+			user:     user,
+			password: "Vq7!tR2#pZ9$",
+			policies: []*model.PasswordPolicy{{MinStrengthScore: 4, HistorySize: 2}},
+			history:  []*model.PasswordHistoryEntry{{PasswordHash: string(reusedHash)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			passwordPolicyStore := mocks.NewMockPasswordPolicier(t)
+			if tt.user.ID != "" && tt.wantErr != httpx.ErrPasswordBreached {
+				passwordPolicyStore.EXPECT().ListByUserID(mock.Anything, tt.user.ID).Return(tt.policies, nil)
+			}
+			if tt.history != nil {
+				passwordPolicyStore.EXPECT().ListHistory(mock.Anything, tt.user.ID, 2).Return(tt.history, nil)
+			}
+
+			err := checkPassword(context.Background(), &store.Manager{PasswordPolicy: passwordPolicyStore}, tt.user, tt.password)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
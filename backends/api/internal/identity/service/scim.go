@@ -0,0 +1,630 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// scimAuditSource marks audit log entries of changes made by a SCIM client
+const scimAuditSource = "scim"
+
+// SCIMer is an interface that wraps the SCIM methods
+type SCIMer interface {
+	Authenticate(ctx context.Context, token string) (*model.SCIMToken, error)
+	CreateToken(ctx context.Context, userID, entityID, name string) (*model.SCIMToken, string, error)
+	CreateUser(ctx context.Context, entityID string, user *model.SCIMUser, active bool) (*model.SCIMUser, error)
+	DeleteToken(ctx context.Context, userID, entityID, id string) error
+	DeleteUser(ctx context.Context, entityID, userID string) error
+	GetGroup(ctx context.Context, entityID string, role types.Role) (*model.SCIMGroup, error)
+	GetUser(ctx context.Context, entityID, userID string) (*model.SCIMUser, error)
+	ListGroups(ctx context.Context, entityID string) ([]*model.SCIMGroup, error)
+	ListTokens(ctx context.Context, entityID string) ([]*model.SCIMToken, error)
+	ListUsers(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset, limit int) ([]*model.SCIMUser, int, error)
+	ReplaceGroup(ctx context.Context, entityID string, role types.Role, memberIDs []string) (*model.SCIMGroup, error)
+	UpdateGroup(ctx context.Context, entityID string, role types.Role, add, remove []string) (*model.SCIMGroup, error)
+	UpdateUser(ctx context.Context, entityID, userID string, update SCIMUserUpdate) (*model.SCIMUser, error)
+}
+
+// SCIMUserUpdate holds the attributes of a SCIM user to change, nil attributes
+// are left as they are.
+type SCIMUserUpdate struct {
+	Active     *bool
+	Email      *string
+	ExternalID *string
+	Name       *string
+}
+
+// SCIM is the service for SCIM provisioning operations.
+type SCIM struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewSCIM creates a new SCIM service.
+func NewSCIM(container *app.Container, store *store.Manager) SCIMer {
+	return &SCIM{
+		Container: container,
+		store:     store,
+	}
+}
+
+// Authenticate returns the SCIM token matching the bearer token.
+func (s *SCIM) Authenticate(ctx context.Context, token string) (*model.SCIMToken, error) {
+	if !strings.HasPrefix(token, model.SCIMTokenPrefix) {
+		return nil, httpx.ErrUnauthenticated
+	}
+
+	scimToken, err := s.store.SCIM.GetTokenByHash(ctx, model.HashSCIMToken(token))
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if scimToken == nil {
+		return nil, httpx.ErrUnauthenticated
+	}
+
+	if err := s.store.SCIM.TouchToken(ctx, scimToken.ID); err != nil {
+		s.Logger.Error("Failed to update SCIM token last used time", "error", err)
+	}
+
+	return scimToken, nil
+}
+
+// CreateToken creates a SCIM token for an entity. The token itself is only
+// returned here, only its hash is stored.
+func (s *SCIM) CreateToken(ctx context.Context, userID, entityID, name string) (*model.SCIMToken, string, error) {
+	secret, err := generateSecureToken(32)
+	if err != nil {
+		return nil, "", httpx.ErrUnknown.WithInternal(err)
+	}
+	token := model.SCIMTokenPrefix + secret
+
+	scimToken, err := s.store.SCIM.CreateToken(ctx, &model.SCIMToken{
+		EntityID:  entityID,
+		Name:      name,
+		TokenHash: model.HashSCIMToken(token),
+		CreatedBy: &userID,
+	})
+	if err != nil {
+		return nil, "", httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"entity_id": entityID,
+		"name":      name,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceSCIMToken, types.ActionCreate, scimToken.ID, userID, metadata); err != nil {
+		return nil, "", err
+	}
+
+	return scimToken, token, nil
+}
+
+// ListTokens lists the SCIM tokens of an entity.
+func (s *SCIM) ListTokens(ctx context.Context, entityID string) ([]*model.SCIMToken, error) {
+	tokens, err := s.store.SCIM.ListTokens(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return tokens, nil
+}
+
+// DeleteToken revokes a SCIM token of an entity.
+func (s *SCIM) DeleteToken(ctx context.Context, userID, entityID, id string) error {
+	token, err := s.store.SCIM.GetTokenByID(ctx, entityID, id)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if token == nil {
+		return httpx.ErrSCIMTokenNotFound
+	}
+
+	if err := s.store.SCIM.DeleteToken(ctx, entityID, id); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"entity_id": entityID,
+		"name":      token.Name,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceSCIMToken, types.ActionDelete, token.ID, userID, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListUsers lists the users of an entity matching the filter, along with the
+// total number of matching users.
+func (s *SCIM) ListUsers(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset, limit int) ([]*model.SCIMUser, int, error) {
+	total, err := s.store.SCIM.CountUsers(ctx, entityID, filter)
+	if err != nil {
+		return nil, 0, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	users, err := s.store.SCIM.ListUsers(ctx, entityID, filter, offset, limit)
+	if err != nil {
+		return nil, 0, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return users, total, nil
+}
+
+// GetUser retrieves a user of an entity.
+func (s *SCIM) GetUser(ctx context.Context, entityID, userID string) (*model.SCIMUser, error) {
+	user, err := s.store.SCIM.GetUser(ctx, entityID, userID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if user == nil {
+		return nil, httpx.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// CreateUser provisions a user into an entity. Unknown users are created
+// without a password, and active users join the entity with the default role.
+// Existing accounts are only linked if the entity has verified their domain.
+func (s *SCIM) CreateUser(ctx context.Context, entityID string, user *model.SCIMUser, active bool) (*model.SCIMUser, error) {
+	connection, err := s.store.SSOConnection.GetByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	// Directories can only provision addresses they can sign in with
+	if connection != nil && !connection.AllowsEmail(user.Email) {
+		return nil, httpx.ErrEmailDomainNotAllowed
+	}
+
+	role := types.RoleViewer
+	if connection != nil {
+		role = connection.DefaultRole
+	}
+
+	var (
+		created    *model.User
+		membership *model.Membership
+	)
+
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		txStore := store.NewManager(tx)
+
+		existing, err := txStore.User.GetByEmail(ctx, user.Email)
+		if err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+
+		userID := ""
+		if existing != nil {
+			scimUser, err := txStore.SCIM.GetUser(ctx, entityID, existing.ID)
+			if err != nil {
+				return httpx.ErrUnknown.WithInternal(err)
+			}
+
+			if scimUser != nil {
+				return httpx.ErrMemberExists
+			}
+
+			// Otherwise any entity could take over an account by provisioning its address
+			err = checkDomainsVerified(ctx, txStore, entityID, model.EmailDomain(existing.Email))
+			if errors.Is(err, httpx.ErrDomainNotVerified) || errors.Is(err, httpx.ErrDomainClaimed) {
+				return httpx.ErrEmailDomainNotAllowed
+			}
+			if err != nil {
+				return err
+			}
+
+			userID = existing.ID
+		} else {
+			created, err = txStore.User.Create(ctx, &model.User{
+				Name:  user.Name,
+				Email: user.Email,
+			})
+			if err != nil {
+				return httpx.ErrUnknown.WithInternal(err)
+			}
+
+			userID = created.ID
+		}
+
+		if active {
+			membership, err = txStore.Membership.Create(ctx, &model.Membership{
+				EntityID: &entityID,
+				Role:     role,
+				UserID:   userID,
+			})
+			if err != nil {
+				return httpx.ErrUnknown.WithInternal(err)
+			}
+		}
+
+		if err := txStore.SCIM.LinkUser(ctx, entityID, userID, user.ExternalID, created != nil); err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+
+		user.ID = userID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if created != nil {
+		metadata := map[string]any{
+			"entity_id": entityID,
+			"source":    scimAuditSource,
+		}
+		if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionCreate, created.ID, "", metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if membership != nil {
+		invalidateCachedSessions(ctx, s.Container, user.ID)
+
+		metadata := map[string]any{
+			"entity_id": entityID,
+			"role":      membership.Role,
+			"source":    scimAuditSource,
+			"user_id":   user.ID,
+		}
+		if err := auditLog(ctx, s.store, types.ResourceEntity, types.ActionCreate, membership.ID, "", metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetUser(ctx, entityID, user.ID)
+}
+
+// UpdateUser changes the attributes of a user of an entity. The name is only
+// changed for users the SCIM client created, as the profile of a linked
+// account belongs to its owner. Deactivating a user removes their membership,
+// the user itself is kept.
+func (s *SCIM) UpdateUser(ctx context.Context, entityID, userID string, update SCIMUserUpdate) (*model.SCIMUser, error) {
+	scimUser, err := s.GetUser(ctx, entityID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The address may be used with other entities, it can only be changed by the user
+	if update.Email != nil && !strings.EqualFold(*update.Email, scimUser.Email) {
+		return nil, httpx.ErrImmutableAttribute
+	}
+
+	if update.Name != nil && *update.Name != scimUser.Name && scimUser.IsCreated {
+		user, err := s.store.User.GetByID(ctx, userID)
+		if err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+
+		if user == nil {
+			return nil, httpx.ErrUserNotFound
+		}
+
+		before := map[string]any{"name": user.Name}
+		user.Name = *update.Name
+		user.UpdatedAt = time.Now()
+		if err := s.store.User.Update(ctx, user); err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+
+		metadata := map[string]any{
+			"entity_id": entityID,
+			"source":    scimAuditSource,
+		}
+		after := map[string]any{"name": user.Name}
+		if err := auditLogChange(ctx, s.store, types.ResourceUser, types.ActionUpdate, userID, "", before, after, metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	externalID := scimUser.ExternalID
+	if update.ExternalID != nil {
+		externalID = update.ExternalID
+	}
+
+	// Linking keeps deactivated users visible to the SCIM client
+	if err := s.store.SCIM.LinkUser(ctx, entityID, userID, externalID, scimUser.IsCreated); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if update.Active != nil && *update.Active != scimUser.IsActive() {
+		if *update.Active {
+			err = s.activate(ctx, entityID, userID)
+		} else {
+			err = s.deprovision(ctx, entityID, userID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetUser(ctx, entityID, userID)
+}
+
+// DeleteUser deprovisions a user from an entity and forgets that the user was
+// provisioned. The user itself is kept, as it may be used with other entities.
+func (s *SCIM) DeleteUser(ctx context.Context, entityID, userID string) error {
+	scimUser, err := s.GetUser(ctx, entityID, userID)
+	if err != nil {
+		return err
+	}
+
+	if scimUser.IsActive() {
+		if err := s.deprovision(ctx, entityID, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.SCIM.UnlinkUser(ctx, entityID, userID); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return nil
+}
+
+// activate gives a deactivated user a membership with the default role again.
+func (s *SCIM) activate(ctx context.Context, entityID, userID string) error {
+	role := types.RoleViewer
+	connection, err := s.store.SSOConnection.GetByEntityID(ctx, entityID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if connection != nil {
+		role = connection.DefaultRole
+	}
+
+	membership, err := s.store.Membership.Create(ctx, &model.Membership{
+		EntityID: &entityID,
+		Role:     role,
+		UserID:   userID,
+	})
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	invalidateCachedSessions(ctx, s.Container, userID)
+
+	metadata := map[string]any{
+		"entity_id": entityID,
+		"role":      membership.Role,
+		"source":    scimAuditSource,
+		"user_id":   userID,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceEntity, types.ActionCreate, membership.ID, "", metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// deprovision removes the membership of a user in an entity and ends the
+// impersonations started from the entity by or of the user. The other sessions
+// of the user are kept, as they may be used with other entities, and lose
+// access to this one with the membership.
+func (s *SCIM) deprovision(ctx context.Context, entityID, userID string) error {
+	var (
+		membership     *model.Membership
+		impersonations []string
+	)
+
+	err := s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		txStore := store.NewManager(tx)
+
+		var err error
+		membership, err = txStore.Membership.GetByEntityIDAndUserID(ctx, entityID, userID)
+		if err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+
+		if membership == nil {
+			return nil
+		}
+
+		if membership.Role == types.RoleOwner {
+			if err := checkNotLastEntityOwner(ctx, txStore, entityID, userID); err != nil {
+				return err
+			}
+		}
+
+		if err := txStore.Membership.Delete(ctx, membership.ID); err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+
+		impersonations, err = txStore.Session.InvalidateImpersonationsByEntityID(ctx, entityID, userID)
+		if err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if membership == nil {
+		return nil
+	}
+	invalidateCachedSessions(ctx, s.Container, append(impersonations, userID)...)
+
+	metadata := map[string]any{
+		"entity_id": entityID,
+		"role":      membership.Role,
+		"source":    scimAuditSource,
+		"user_id":   userID,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceEntity, types.ActionDelete, membership.ID, "", metadata); err != nil {
+		return err
+	}
+
+	if len(impersonations) > 0 {
+		metadata = map[string]any{
+			"entity_id":      entityID,
+			"impersonations": len(impersonations),
+			"source":         scimAuditSource,
+		}
+		if err := auditLog(ctx, s.store, types.ResourceSession, types.ActionDelete, userID, "", metadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListGroups lists the groups of an entity, one for each role exposed to SCIM clients.
+func (s *SCIM) ListGroups(ctx context.Context, entityID string) ([]*model.SCIMGroup, error) {
+	memberships, err := s.store.Membership.GetByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	groups := make([]*model.SCIMGroup, 0, len(model.SCIMGroupRoles))
+	for _, role := range model.SCIMGroupRoles {
+		groups = append(groups, newSCIMGroup(role, memberships))
+	}
+
+	return groups, nil
+}
+
+// GetGroup retrieves the group of a role of an entity.
+func (s *SCIM) GetGroup(ctx context.Context, entityID string, role types.Role) (*model.SCIMGroup, error) {
+	if !model.IsSCIMGroupRole(role) {
+		return nil, httpx.ErrGroupNotFound
+	}
+
+	memberships, err := s.store.Membership.GetByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return newSCIMGroup(role, memberships), nil
+}
+
+// UpdateGroup adds and removes members of the group of a role. Added members
+// get the role, removed members fall back to the viewer role. Owners are
+// managed in the dashboard only and are left untouched.
+func (s *SCIM) UpdateGroup(ctx context.Context, entityID string, role types.Role, add, remove []string) (*model.SCIMGroup, error) {
+	if !model.IsSCIMGroupRole(role) {
+		return nil, httpx.ErrGroupNotFound
+	}
+
+	for _, userID := range add {
+		membership, err := s.store.Membership.GetByEntityIDAndUserID(ctx, entityID, userID)
+		if err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+
+		if membership == nil {
+			return nil, httpx.ErrUserNotFound
+		}
+
+		if err := s.updateRole(ctx, membership, role); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, userID := range remove {
+		membership, err := s.store.Membership.GetByEntityIDAndUserID(ctx, entityID, userID)
+		if err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+
+		if membership == nil || membership.Role != role {
+			continue
+		}
+
+		if err := s.updateRole(ctx, membership, types.RoleViewer); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetGroup(ctx, entityID, role)
+}
+
+// ReplaceGroup sets the members of the group of a role.
+func (s *SCIM) ReplaceGroup(ctx context.Context, entityID string, role types.Role, memberIDs []string) (*model.SCIMGroup, error) {
+	group, err := s.GetGroup(ctx, entityID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	var remove []string
+	for _, userID := range group.MemberIDs {
+		if !slices.Contains(memberIDs, userID) {
+			remove = append(remove, userID)
+		}
+	}
+
+	return s.UpdateGroup(ctx, entityID, role, memberIDs, remove)
+}
+
+// updateRole changes the role of a membership unless it is an owner's.
+func (s *SCIM) updateRole(ctx context.Context, membership *model.Membership, role types.Role) error {
+	if membership.Role == types.RoleOwner || membership.Role == role {
+		return nil
+	}
+
+	if err := s.store.Membership.UpdateRole(ctx, membership.ID, role); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	invalidateCachedSessions(ctx, s.Container, membership.UserID)
+
+	metadata := map[string]any{
+		"entity_id": *membership.EntityID,
+		"source":    scimAuditSource,
+		"user_id":   membership.UserID,
+	}
+	before := map[string]any{"role": membership.Role}
+	after := map[string]any{"role": role}
+	if err := auditLogChange(ctx, s.store, types.ResourceEntity, types.ActionUpdate, membership.ID, "", before, after, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// newSCIMGroup builds the group of a role from the memberships of an entity.
+func newSCIMGroup(role types.Role, memberships []*model.Membership) *model.SCIMGroup {
+	group := &model.SCIMGroup{
+		Role:      role,
+		MemberIDs: []string{},
+	}
+
+	for _, membership := range memberships {
+		if membership.Role == role {
+			group.MemberIDs = append(group.MemberIDs, membership.UserID)
+		}
+	}
+
+	return group
+}
+
+// checkNotLastEntityOwner returns ErrLastEntityOwner if the user is the only
+// owner of the entity.
+func checkNotLastEntityOwner(ctx context.Context, store *store.Manager, entityID, userID string) error {
+	memberships, err := store.Membership.GetByEntityID(ctx, entityID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	for _, m := range memberships {
+		if m.Role == types.RoleOwner && m.UserID != userID {
+			return nil
+		}
+	}
+
+	return httpx.ErrLastEntityOwner
+}
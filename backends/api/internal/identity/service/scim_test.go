@@ -0,0 +1,74 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/internal/types"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSCIMUpdateUser(t *testing.T) {
+	t.Parallel()
+
+	const (
+		entityID = "entity"
+		userID   = "user"
+	)
+	name := "Jane Roe"
+
+	tests := []struct {
+		name       string
+		created    bool
+		wantRename bool
+	}{
+		{
+			name:       "should rename a user the SCIM client created",
+			created:    true,
+			wantRename: true,
+		},
+		{
+			name: "should not rename a linked account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scimUser := &model.SCIMUser{
+				ID:        userID,
+				Name:      "Jane Doe",
+				Email:     "jane@example.com",
+				Role:      types.RoleViewer,
+				IsCreated: tt.created,
+			}
+
+			scimStore := mocks.NewMockSCIMer(t)
+			scimStore.EXPECT().GetUser(mock.Anything, entityID, userID).Return(scimUser, nil)
+			scimStore.EXPECT().LinkUser(mock.Anything, entityID, userID, (*string)(nil), tt.created).Return(nil)
+
+			userStore := mocks.NewMockUserer(t)
+			auditLogStore := mocks.NewMockAuditLoger(t)
+			if tt.wantRename {
+				userStore.EXPECT().GetByID(mock.Anything, userID).Return(&model.User{ID: userID, Name: scimUser.Name}, nil)
+				userStore.EXPECT().Update(mock.Anything, mock.MatchedBy(func(u *model.User) bool {
+					return u.Name == name
+				})).Return(nil)
+				auditLogStore.EXPECT().Create(mock.Anything, mock.Anything).Return(&model.AuditLog{}, nil)
+			}
+
+			s := &SCIM{
+				Container: &app.Container{Config: &app.Config{}},
+				store:     &store.Manager{AuditLog: auditLogStore, SCIM: scimStore, User: userStore},
+			}
+			_, err := s.UpdateUser(context.Background(), entityID, userID, SCIMUserUpdate{Name: &name})
+			assert.NoError(t, err)
+		})
+	}
+}
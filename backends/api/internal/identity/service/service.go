@@ -12,6 +12,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
 	"time"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
@@ -19,11 +21,21 @@ import (
 
 // Manager is a collection of services used by the handlers/workers.
 type Manager struct {
-	Entity     Entityer
-	Membership Membershiper
-	Session    Sessioner
-	TwoFactor  TwoFactorer
-	User       Userer
+	AuditLog       AuditLoger
+	Compliance     Compliancer
+	Entity         Entityer
+	EntityDomain   EntityDomainer
+	Impersonation  Impersonationer
+	IPAllowlist    IPAllowlister
+	Membership     Membershiper
+	PasswordPolicy PasswordPolicier
+	SCIM           SCIMer
+	Session        Sessioner
+	SessionPolicy  SessionPolicier
+	SSOConnection  SSOConnectioner
+	TrustedDevice  TrustedDevicer
+	TwoFactor      TwoFactorer
+	User           Userer
 }
 
 // NewManager creates a new service manager
@@ -34,23 +46,70 @@ func NewManager(container *app.Container, store *store.Manager) *Manager {
 	entityService := NewEntity(container, store)
 
 	return &Manager{
-		Entity:     entityService,
-		Membership: membershipService,
-		Session:    sessionService,
-		TwoFactor:  twoFactorService,
-		User:       NewUser(container, store),
+		AuditLog:       NewAuditLog(container, store),
+		Compliance:     NewCompliance(container, store),
+		Entity:         entityService,
+		EntityDomain:   NewEntityDomain(container, store),
+		Impersonation:  NewImpersonation(container, store),
+		IPAllowlist:    NewIPAllowlist(container, store),
+		Membership:     membershipService,
+		PasswordPolicy: NewPasswordPolicy(container, store),
+		SCIM:           NewSCIM(container, store),
+		Session:        sessionService,
+		SessionPolicy:  NewSessionPolicy(container, store),
+		SSOConnection:  NewSSOConnection(container, store),
+		TrustedDevice:  NewTrustedDevice(container, store),
+		TwoFactor:      twoFactorService,
+		User:           NewUser(container, store),
 	}
 }
 
-// auditLog is a helper function to create audit logs consistently across services
+// auditLog is a helper function to create audit logs consistently across services.
+// An empty userID records an action that wasn't taken by a user, such as a SCIM client.
+// The entry belongs to the active entity of the request, if the caller has access to it.
+// While impersonating, it is recorded against the real actor and their platform entity.
 func auditLog(ctx context.Context, store *store.Manager, resourceType types.Resource, action types.Action, resourceID, userID string, metadata map[string]any) error {
+	return auditLogChange(ctx, store, resourceType, action, resourceID, userID, nil, nil, metadata)
+}
+
+// auditLogChange is like auditLog, and also records the fields whose values
+// differ between before and after. Fields missing from one side are recorded
+// with a nil value there.
+func auditLogChange(ctx context.Context, store *store.Manager, resourceType types.Resource, action types.Action, resourceID, userID string, before, after, metadata map[string]any) error {
 	auditLog := &model.AuditLog{
 		Action:       action,
 		ResourceID:   resourceID,
 		ResourceType: resourceType,
-		UserID:       userID,
 	}
 
+	// The active entity is client provided, so it only counts once the
+	// authenticator resolved a role in it or the credential is entity scoped
+	auth := httpx.GetAuthInfo(ctx)
+	if auth.EntityID != "" && (auth.EntityRole != types.RoleNone || auth.UserID == "") {
+		auditLog.EntityID = &auth.EntityID
+	}
+
+	if auth.IsImpersonating() {
+		auditLog.EntityID = &auth.ImpersonatorEntityID
+		userID = auth.ImpersonatorID
+		metadata = maps.Clone(metadata)
+		if metadata == nil {
+			metadata = make(map[string]any, 1)
+		}
+		metadata["impersonated_user_id"] = auth.UserID
+	}
+
+	actorType := model.AuditLogActorTypeSystem
+	switch {
+	case userID != "":
+		actorType = model.AuditLogActorTypeUser
+		auditLog.UserID = &userID
+	case auth.APIKeyUsed:
+		actorType = model.AuditLogActorTypeAPIKey
+		auditLog.ActorID = &auth.CredentialID
+	}
+	auditLog.ActorType = &actorType
+
 	// Get request metadata for IP and user agent
 	reqMetadata := middleware.GetRequestMetadata(ctx)
 	if reqMetadata != nil {
@@ -58,6 +117,13 @@ func auditLog(ctx context.Context, store *store.Manager, resourceType types.Reso
 		auditLog.UserAgent = &reqMetadata.UserAgent
 	}
 
+	if changes := diffAuditLog(before, after); len(changes) > 0 {
+		changesJSON, err := json.Marshal(changes)
+		if err == nil {
+			auditLog.Changes = changesJSON
+		}
+	}
+
 	// Convert metadata map to JSON if provided
 	if metadata != nil {
 		metadataJSON, err := json.Marshal(metadata)
@@ -73,6 +139,29 @@ func auditLog(ctx context.Context, store *store.Manager, resourceType types.Reso
 	return nil
 }
 
+// auditLogFieldChange is the change of a field as recorded in the audit log
+type auditLogFieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// diffAuditLog returns the fields whose values differ between before and after
+func diffAuditLog(before, after map[string]any) map[string]auditLogFieldChange {
+	changes := map[string]auditLogFieldChange{}
+	for field, value := range before {
+		if !reflect.DeepEqual(value, after[field]) {
+			changes[field] = auditLogFieldChange{Before: value, After: after[field]}
+		}
+	}
+	for field, value := range after {
+		if _, ok := before[field]; !ok && value != nil {
+			changes[field] = auditLogFieldChange{After: value}
+		}
+	}
+
+	return changes
+}
+
 // createEmailVerification creates a new email verification record and sends the verification email
 func createEmailVerification(ctx context.Context, store *store.Manager, container *app.Container, email string, user *model.User) error {
 	now := time.Now()
@@ -127,6 +216,58 @@ func createEmailVerification(ctx context.Context, store *store.Manager, containe
 	return nil
 }
 
+// queueMail localizes the "<template>.title" subject and queues the email for
+// the identity mailer worker. Failures are logged only, as a missing email must
+// not fail the operation that triggered it.
+func queueMail(ctx context.Context, container *app.Container, template, email, fallbackSubject string, data map[string]any) {
+	data["AppName"] = container.Config.App.Name
+	data["AssetsURL"] = container.Config.App.AssetsURL
+
+	locale := middleware.GetLocale(ctx)
+	t := middleware.GetT(ctx)
+	if t == nil {
+		t = i18n.NewLocalizer(container.I18nBundle.Bundle, locale)
+	}
+
+	subject, err := t.Localize(&i18n.LocalizeConfig{
+		MessageID:    template + ".title",
+		TemplateData: data,
+	})
+	if err != nil {
+		container.Logger.Error("Failed to localize email subject", "template", template, "error", err)
+		subject = fallbackSubject
+	}
+
+	if _, err := container.Worker.Insert(ctx, MailerArgs{
+		Data:     data,
+		Email:    email,
+		Locale:   locale,
+		Subject:  subject,
+		Template: template,
+	}, nil); err != nil {
+		container.Logger.Error("Failed to queue email", "template", template, "error", err)
+	}
+}
+
+// brandMail adds the branding of an entity to the data of an email sent on its
+// behalf, such as an audit log export. The transactional layout then shows the
+// entity's name, logo, brand colour and support email address in place of the
+// app's.
+func brandMail(data map[string]any, entity *model.Entity) map[string]any {
+	data["BrandName"] = entity.Name
+	if entity.Logo != nil {
+		data["BrandLogoURL"] = *entity.Logo
+	}
+	if entity.BrandColor != nil {
+		data["BrandColor"] = *entity.BrandColor
+	}
+	if entity.SupportEmail != nil {
+		data["SupportEmail"] = *entity.SupportEmail
+	}
+
+	return data
+}
+
 // generateSecureToken generates a secure random token of the specified length
 func generateSecureToken(length int) (string, error) {
 	token := make([]byte, length)
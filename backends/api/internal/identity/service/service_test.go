@@ -0,0 +1,39 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrandMail(t *testing.T) {
+	t.Parallel()
+
+	logo := "https://api.example.com/v1/identity/images/logo"
+	color := "#0070f3"
+
+	tests := []struct {
+		name   string
+		entity *model.Entity
+		want   map[string]any
+	}{
+		{
+			name:   "should add the name of an entity without branding",
+			entity: &model.Entity{Name: "Acme"},
+			want:   map[string]any{"Name": "Jane", "BrandName": "Acme"},
+		},
+		{
+			name:   "should add the logo and brand colour of an entity",
+			entity: &model.Entity{Name: "Acme", Logo: &logo, BrandColor: &color},
+			want:   map[string]any{"Name": "Jane", "BrandName": "Acme", "BrandLogoURL": logo, "BrandColor": color},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, brandMail(map[string]any{"Name": "Jane"}, tt.entity))
+		})
+	}
+}
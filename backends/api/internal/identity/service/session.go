@@ -8,19 +8,22 @@ import (
 	"autopilot/backends/api/pkg/middleware"
 	"autopilot/backends/internal/types"
 	"context"
+	"fmt"
 	"time"
+
+	"github.com/jmoiron/sqlx"
 )
 
 const (
-	// AccountLockoutDuration is the duration for which an account remains locked after too many failed attempts
-	AccountLockoutDuration = 30 * time.Minute // minutes
-
-	// MaxFailedLoginAttempts is the maximum number of failed login attempts before account lockout
-	MaxFailedLoginAttempts = 5
-
 	// RefreshTokenDuration is the duration for which a refresh token remains valid
 	RefreshTokenDuration = 30 * 24 * time.Hour
 
+	// SessionActivityInterval is how often the activity of a session is recorded at most
+	SessionActivityInterval = time.Minute
+
+	// SessionCacheTTL is the longest a resolved session is served from the cache
+	SessionCacheTTL = time.Minute
+
 	// SessionDuration is the duration for which a session token remains valid
 	SessionDuration = 24 * time.Hour
 
@@ -31,7 +34,9 @@ const (
 // Sessioner is an interface that wraps the Session methods
 type Sessioner interface {
 	CleanUpExpired(ctx context.Context) error
-	Create(ctx context.Context, email, password string) (*model.Session, error)
+	Create(ctx context.Context, email, password, trustedDevice string) (*model.Session, error)
+	CreateWithMagicLink(ctx context.Context, token, trustedDevice string) (*model.Session, error)
+	CreateWithSSO(ctx context.Context, state, code, trustedDevice string) (*model.Session, error)
 	GetByToken(ctx context.Context, token string) (*model.Session, error)
 	GetByTokenFull(ctx context.Context, token string) (*model.Session, error)
 	ListByToken(ctx context.Context, userID string) ([]*model.Session, error)
@@ -39,6 +44,9 @@ type Sessioner interface {
 	InvalidateByID(ctx context.Context, token string, sessionID string) error
 	InvalidateAllSessions(ctx context.Context, userID string, token string) error
 	Refresh(ctx context.Context, refreshToken string) (*model.Session, error)
+	RequestMagicLink(ctx context.Context, email string) error
+	RevokeSignIn(ctx context.Context, token string) (string, error)
+	StartSSO(ctx context.Context, entityID string) (string, string, error)
 	UpdateTwoFactorStatus(ctx context.Context, token string, isPending bool) error
 	Validate(ctx context.Context, token string) (*model.Session, error)
 }
@@ -68,21 +76,63 @@ func (s *Session) CleanUpExpired(ctx context.Context) error {
 		return httpx.ErrUnknown.WithInternal(err)
 	}
 
+	if err := s.store.TrustedDevice.DeleteExpired(ctx); err != nil {
+		s.Logger.Error("Failed to clean up expired trusted devices", "error", err)
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	// Failed sign-ins against unknown addresses are kept for as long as the longest lockout
+	if err := s.store.FailedSignIn.DeleteStale(ctx, time.Now().Add(-model.AccountLockoutMaxDuration)); err != nil {
+		s.Logger.Error("Failed to clean up stale failed sign-ins", "error", err)
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
 	s.Logger.Info("Successfully cleaned up expired sessions")
 	return nil
 }
 
-// CreateSession creates a new session for a user
-func (s *Session) Create(ctx context.Context, email, password string) (*model.Session, error) {
+// CreateSession creates a new session for a user. A trusted device cookie
+// from the browser lets the user skip two-factor authentication.
+func (s *Session) Create(ctx context.Context, email, password, trustedDevice string) (*model.Session, error) {
 	user, err := s.store.User.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
 
 	if user == nil {
+		model.VerifyDummyPassword(password)
+		return nil, s.recordUnknownSignIn(ctx, email)
+	}
+
+	// Check for too many failed login attempts
+	now := time.Now()
+	if user.IsLocked(now) {
+		return nil, httpx.ErrAccountLocked
+	}
+
+	if !user.VerifyPassword(password) {
+		locked := user.RecordFailedLogin(now)
+		if err := s.store.User.Update(ctx, user); err != nil {
+			s.Logger.Error("Failed to update failed login attempts", "error", err)
+			return nil, httpx.ErrInvalidCredentials
+		}
+
+		if locked {
+			s.notifyAccountLocked(ctx, user)
+		}
+
 		return nil, httpx.ErrInvalidCredentials
 	}
 
+	// Reset failed login attempts and lockout tiers on successful login
+	if user.FailedLoginAttempts > 0 || user.LockoutCount > 0 {
+		user.Unlock(now)
+		if err := s.store.User.Update(ctx, user); err != nil {
+			s.Logger.Error("Failed to reset failed login attempts", "error", err)
+		}
+	}
+
+	// Checked after the password, so it doesn't reveal that the account exists
 	if user.EmailVerifiedAt == nil {
 		// Check if there's an existing verification
 		verification, err := s.store.User.GetVerificationByValue(ctx, model.VerificationContextEmailVerification, email)
@@ -108,40 +158,18 @@ func (s *Session) Create(ctx context.Context, email, password string) (*model.Se
 		return nil, httpx.ErrEmailNotVerified
 	}
 
-	// Check for too many failed login attempts
-	if user.FailedLoginAttempts >= MaxFailedLoginAttempts &&
-		user.LockedAt != nil &&
-		time.Now().Before(user.LockedAt.Add(AccountLockoutDuration)) {
-		return nil, httpx.ErrAccountLocked
-	}
-
-	if !user.VerifyPassword(password) {
-		// Increment failed login attempts
-		user.FailedLoginAttempts++
-		if user.FailedLoginAttempts >= MaxFailedLoginAttempts {
-			now := time.Now()
-			user.LockedAt = &now
-		}
-		user.UpdatedAt = time.Now()
-
-		// Update user in database
-		if err := s.store.User.Update(ctx, user); err != nil {
-			s.Logger.Error("Failed to update failed login attempts", "error", err)
-		}
-
-		return nil, httpx.ErrInvalidCredentials
+	if err := s.checkSSOEnforcement(ctx, user.ID); err != nil {
+		return nil, err
 	}
 
-	// Reset failed login attempts on successful login
-	if user.FailedLoginAttempts > 0 {
-		user.FailedLoginAttempts = 0
-		user.LockedAt = nil
-		user.UpdatedAt = time.Now()
-		if err := s.store.User.Update(ctx, user); err != nil {
-			s.Logger.Error("Failed to reset failed login attempts", "error", err)
-		}
-	}
+	return s.createForUser(ctx, user, trustedDevice)
+}
 
+// createForUser creates a session for an authenticated user. If the user has
+// two-factor authentication set up, and the trusted device cookie doesn't
+// trust the device, a temporary session is returned together with
+// ErrTwoFactorPending.
+func (s *Session) createForUser(ctx context.Context, user *model.User, trustedDevice string) (*model.Session, error) {
 	// Get user's memberships
 	memberships, err := s.store.Membership.GetByUserID(ctx, user.ID)
 	if err != nil {
@@ -185,8 +213,14 @@ func (s *Session) Create(ctx context.Context, email, password string) (*model.Se
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
 
-	if twoFactor != nil {
+	var device *model.TrustedDevice
+	if twoFactor != nil && trustedDevice != "" {
+		device = checkTrustedDevice(ctx, s.Container, s.store, user.ID, trustedDevice)
+	}
+
+	if twoFactor != nil && device == nil {
 		session := &model.Session{
+			AuthenticatedAt:    now,
 			ExpiresAt:          now.Add(TempTokenDuration),
 			IPAddress:          ipAddress,
 			Country:            country,
@@ -211,6 +245,7 @@ func (s *Session) Create(ctx context.Context, email, password string) (*model.Se
 
 	// Create a new session
 	session := &model.Session{
+		AuthenticatedAt:  now,
 		ExpiresAt:        now.Add(SessionDuration),
 		IPAddress:        ipAddress,
 		Country:          country,
@@ -222,6 +257,12 @@ func (s *Session) Create(ctx context.Context, email, password string) (*model.Se
 		Memberships:      memberships,
 	}
 
+	policy, err := sessionPolicyForUser(ctx, s.Container, s.store, user.ID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	policy.Apply(session)
+
 	// Store the session
 	created, err := s.store.Session.Create(ctx, session)
 	if err != nil {
@@ -229,22 +270,154 @@ func (s *Session) Create(ctx context.Context, email, password string) (*model.Se
 	}
 	session = created
 
+	if err := s.enforceConcurrentSessions(ctx, user.ID, policy); err != nil {
+		return nil, err
+	}
+
 	// Log session creation
-	if err := auditLog(ctx, s.store, types.ResourceSession, types.ActionCreate, session.ID, session.UserID, nil); err != nil {
+	var metadata map[string]any
+	if device != nil {
+		metadata = map[string]any{"trusted_device_id": device.ID}
+	}
+	if err := auditLog(ctx, s.store, types.ResourceSession, types.ActionCreate, session.ID, session.UserID, metadata); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordSignIn(ctx, user, session); err != nil {
 		return nil, err
 	}
 
 	return session, nil
 }
 
-// GetByToken retrieves a session by token
+// RequestMagicLink emails a single-use sign-in link to the user. Nothing is
+// revealed about whether the account exists or can sign in.
+func (s *Session) RequestMagicLink(ctx context.Context, email string) error {
+	user, err := s.store.User.GetByEmail(ctx, email)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil || user.IsLocked(time.Now()) {
+		return nil
+	}
+
+	value := model.NewUserScopedValue(user.ID, user.Email)
+
+	// Only the latest link can be used
+	previous, err := s.store.User.GetVerificationByValue(ctx, model.VerificationContextMagicLink, value)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if previous != nil {
+		if err := s.store.User.DeleteVerification(ctx, previous.ID); err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+	}
+
+	now := time.Now()
+	verification, err := s.store.User.CreateVerification(ctx, &model.Verification{
+		Context:   model.VerificationContextMagicLink,
+		Value:     value,
+		ExpiresAt: now.Add(model.MagicLinkDuration),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"verification_id": verification.ID,
+		"expiration_time": verification.ExpiresAt,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceSession, types.ActionCreate, verification.ID, user.ID, metadata); err != nil {
+		return err
+	}
+
+	queueMail(ctx, s.Container, "magic_link", user.Email, fmt.Sprintf("Sign in to %s", s.Config.App.Name), map[string]any{
+		"Duration":  model.MagicLinkDuration.Minutes(),
+		"Email":     user.Email,
+		"Name":      user.Name,
+		"SignInURL": fmt.Sprintf("%s/magic-link?token=%s", s.Config.App.DashboardURL, verification.ID),
+	})
+
+	return nil
+}
+
+// CreateWithMagicLink exchanges a sign-in link for a session. The link can be
+// used once and, like a password sign-in, still requires two-factor
+// authentication if it is set up.
+func (s *Session) CreateWithMagicLink(ctx context.Context, token, trustedDevice string) (*model.Session, error) {
+	verification, err := s.store.User.GetVerification(ctx, model.VerificationContextMagicLink, token)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if verification == nil || verification.IsExpired() {
+		return nil, httpx.ErrInvalidOrExpiredToken
+	}
+
+	// Consume the link before anything else so it can't be replayed
+	if err := s.store.User.DeleteVerification(ctx, verification.ID); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	userID, email, ok := verification.UserScopedValue()
+	if !ok {
+		return nil, httpx.ErrInvalidOrExpiredToken
+	}
+
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	// The link is only valid for the address it was sent to
+	if user == nil || user.Email != email {
+		return nil, httpx.ErrInvalidOrExpiredToken
+	}
+
+	if user.IsLocked(time.Now()) {
+		return nil, httpx.ErrAccountLocked
+	}
+
+	if err := s.checkSSOEnforcement(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	// Following the link proves ownership of the address
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		user.UpdatedAt = now
+		if err := s.store.User.Update(ctx, user); err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+	}
+
+	return s.createForUser(ctx, user, trustedDevice)
+}
+
+// GetByToken retrieves a session by token with the memberships of the active
+// entity. Resolved sessions are read through the session cache, and cached
+// sessions are checked and record activity the same as the others.
 func (s *Session) GetByToken(ctx context.Context, token string) (*model.Session, error) {
+	activeEntity := middleware.GetActiveEntity(ctx)
+	if session := getCachedSession(ctx, s.Container, token, activeEntity); session != nil {
+		if !isSessionActive(session) {
+			invalidateCachedSession(ctx, s.Container, token)
+			return nil, httpx.ErrUnauthenticated
+		}
+
+		s.recordActivity(ctx, session)
+		return session, nil
+	}
+
 	session, err := s.store.Session.GetByToken(ctx, token)
 	if err != nil {
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
 
-	if session == nil {
+	if session == nil || !isSessionActive(session) {
 		return nil, httpx.ErrUnauthenticated
 	}
 
@@ -252,8 +425,10 @@ func (s *Session) GetByToken(ctx context.Context, token string) (*model.Session,
 		return session, httpx.ErrTwoFactorPending
 	}
 
+	s.recordActivity(ctx, session)
+
 	// Get user's current membership.
-	if activeEntity := middleware.GetActiveEntity(ctx); activeEntity != "" {
+	if activeEntity != "" {
 		m, err := s.store.Membership.GetByEntityIDWithInheritance(ctx, session.UserID, activeEntity)
 		if err != nil {
 			s.Logger.Warn("invalid entity header found", "userID", session.UserID, "entity", activeEntity)
@@ -262,6 +437,8 @@ func (s *Session) GetByToken(ctx context.Context, token string) (*model.Session,
 		}
 	}
 
+	cacheSession(ctx, s.Container, token, activeEntity, session)
+
 	return session, nil
 }
 
@@ -272,7 +449,7 @@ func (s *Session) GetByTokenFull(ctx context.Context, token string) (*model.Sess
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
 
-	if session == nil {
+	if session == nil || !isSessionActive(session) {
 		return nil, httpx.ErrUnauthenticated
 	}
 
@@ -296,7 +473,7 @@ func (s *Session) ListByToken(ctx context.Context, token string) ([]*model.Sessi
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
 
-	if session == nil {
+	if session == nil || !isSessionActive(session) {
 		return nil, httpx.ErrUnauthenticated
 	}
 
@@ -327,6 +504,7 @@ func (s *Session) Invalidate(ctx context.Context, token string) error {
 	if err := s.store.Session.InvalidateByToken(ctx, token); err != nil {
 		return httpx.ErrUnknown.WithInternal(err)
 	}
+	invalidateCachedSession(ctx, s.Container, token)
 
 	// Log session invalidation
 	if err := auditLog(ctx, s.store, types.ResourceSession, types.ActionDelete, session.ID, session.UserID, nil); err != nil {
@@ -352,6 +530,10 @@ func (s *Session) InvalidateByID(ctx context.Context, token, sessionID string) e
 		return httpx.ErrUnknown.WithInternal(err)
 	}
 
+	// The token of the revoked session isn't known, so all of the user's
+	// cached sessions are dropped
+	invalidateCachedSessions(ctx, s.Container, session.UserID)
+
 	// Log session invalidation
 	if err := auditLog(ctx, s.store, types.ResourceSession, types.ActionDelete, sessionID, session.UserID, nil); err != nil {
 		return err
@@ -365,6 +547,7 @@ func (s *Session) InvalidateAllSessions(ctx context.Context, userID string, toke
 	if err := s.store.Session.InvalidateByUserID(ctx, userID, token); err != nil {
 		return httpx.ErrUnknown.WithInternal(err)
 	}
+	invalidateCachedSessions(ctx, s.Container, userID)
 
 	// Log session invalidation
 	if err := auditLog(ctx, s.store, types.ResourceSession, types.ActionDelete, userID, userID, nil); err != nil {
@@ -382,7 +565,18 @@ func (s *Session) Refresh(ctx context.Context, refreshToken string) (*model.Sess
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
 
-	if oldSession == nil || oldSession.IsTwoFactorPending || time.Now().After(oldSession.RefreshExpiresAt) {
+	now := time.Now()
+	if oldSession == nil || oldSession.IsTwoFactorPending || oldSession.IsImpersonation() || now.After(oldSession.RefreshExpiresAt) || oldSession.IsIdle(now) {
+		return nil, httpx.ErrInvalidRefreshToken
+	}
+
+	// The policy may have changed since the session was created, so the
+	// maximum session length is checked against the current one
+	policy, err := sessionPolicyForUser(ctx, s.Container, s.store, oldSession.UserID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if maxLength := policy.MaxSessionLength(); maxLength > 0 && now.Sub(oldSession.AuthenticatedAt) >= maxLength {
 		return nil, httpx.ErrInvalidRefreshToken
 	}
 
@@ -390,6 +584,7 @@ func (s *Session) Refresh(ctx context.Context, refreshToken string) (*model.Sess
 	if err := s.store.Session.InvalidateByToken(ctx, oldSession.Token); err != nil {
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
+	invalidateCachedSession(ctx, s.Container, oldSession.Token)
 
 	// Generate new tokens
 	newAccessToken, err := generateSecureToken(32)
@@ -408,9 +603,9 @@ func (s *Session) Refresh(ctx context.Context, refreshToken string) (*model.Sess
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
 
-	now := time.Now()
 	// Create new session
 	newSession := &model.Session{
+		AuthenticatedAt:  oldSession.AuthenticatedAt,
 		ExpiresAt:        now.Add(SessionDuration),
 		IPAddress:        oldSession.IPAddress,
 		Country:          oldSession.Country,
@@ -421,6 +616,7 @@ func (s *Session) Refresh(ctx context.Context, refreshToken string) (*model.Sess
 		UserID:           oldSession.UserID,
 		Memberships:      memberships,
 	}
+	policy.Apply(newSession)
 
 	// Store the new session
 	created, err := s.store.Session.Create(ctx, newSession)
@@ -429,6 +625,10 @@ func (s *Session) Refresh(ctx context.Context, refreshToken string) (*model.Sess
 	}
 	newSession = created
 
+	if err := s.enforceConcurrentSessions(ctx, newSession.UserID, policy); err != nil {
+		return nil, err
+	}
+
 	// Log session refresh
 	if err := auditLog(ctx, s.store, types.ResourceSession, types.ActionUpdate, newSession.ID, newSession.UserID, nil); err != nil {
 		return nil, err
@@ -437,6 +637,81 @@ func (s *Session) Refresh(ctx context.Context, refreshToken string) (*model.Sess
 	return newSession, nil
 }
 
+// RevokeSignIn handles the "this wasn't me" link of a new sign-in email. It
+// revokes all of the user's sessions, clears the password so it can no longer
+// be used to sign in and returns a password reset token to choose a new one.
+func (s *Session) RevokeSignIn(ctx context.Context, token string) (string, error) {
+	verification, err := s.store.User.GetVerification(ctx, model.VerificationContextSignInRevoke, token)
+	if err != nil {
+		return "", httpx.ErrUnknown.WithInternal(err)
+	}
+	if verification == nil || verification.IsExpired() {
+		return "", httpx.ErrInvalidOrExpiredToken
+	}
+
+	user, err := s.store.User.GetByID(ctx, verification.Value)
+	if err != nil {
+		return "", httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return "", httpx.ErrUserNotFound
+	}
+
+	now := time.Now()
+	user.PasswordHash = nil
+	user.UpdatedAt = now
+
+	reset := &model.Verification{
+		Context:   model.VerificationContextPasswordReset,
+		Value:     user.Email,
+		ExpiresAt: now.Add(model.PasswordResetDuration),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Clear the password, revoke sessions and issue the reset token within transaction
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		u := s.store.User.WithQuerier(tx)
+
+		if err := u.Update(ctx, user); err != nil {
+			return err
+		}
+
+		// An empty token matches no session, so every session of the user is revoked
+		if err := s.store.Session.WithQuerier(tx).InvalidateByUserID(ctx, user.ID, ""); err != nil {
+			return err
+		}
+
+		if err := s.store.TrustedDevice.WithQuerier(tx).DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+
+		if err := u.DeleteVerification(ctx, verification.ID); err != nil {
+			return err
+		}
+
+		reset, err = u.CreateVerification(ctx, reset)
+		return err
+	})
+	if err != nil {
+		return "", httpx.ErrUnknown.WithInternal(err)
+	}
+	invalidateCachedSessions(ctx, s.Container, user.ID)
+
+	metadata := map[string]any{
+		"reason":          "sign_in_revoked",
+		"verification_id": verification.ID,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceSession, types.ActionDelete, user.ID, user.ID, metadata); err != nil {
+		return "", err
+	}
+	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionResetPassword, user.ID, user.ID, metadata); err != nil {
+		return "", err
+	}
+
+	return reset.ID, nil
+}
+
 // Validate validates a session token
 func (s *Session) Validate(ctx context.Context, token string) (*model.Session, error) {
 	session, err := s.store.Session.GetByToken(ctx, token)
@@ -457,11 +732,258 @@ func (s *Session) Validate(ctx context.Context, token string) (*model.Session, e
 	return session, nil
 }
 
-// UpdateTwoFactorStatus updates the two-factor pending status of a session
+// UpdateTwoFactorStatus updates the two-factor pending status of a session.
+// Completing two-factor authentication completes the sign-in, which is then
+// recorded.
 func (s *Session) UpdateTwoFactorStatus(ctx context.Context, token string, isPending bool) error {
 	if err := s.store.Session.UpdateTwoFactorPending(ctx, token, isPending); err != nil {
 		return httpx.ErrUnknown.WithInternal(err)
 	}
 
+	if isPending {
+		return nil
+	}
+
+	session, err := s.store.Session.GetByToken(ctx, token)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if session == nil {
+		return httpx.ErrUnauthenticated
+	}
+
+	user, err := s.store.User.GetByID(ctx, session.UserID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return httpx.ErrUserNotFound
+	}
+
+	return s.recordSignIn(ctx, user, session)
+}
+
+// enforceConcurrentSessions ends the least recently active sessions of a user
+// beyond the concurrent session limit of their session policy.
+func (s *Session) enforceConcurrentSessions(ctx context.Context, userID string, policy *model.SessionPolicy) error {
+	if policy.MaxConcurrentSessions == nil {
+		return nil
+	}
+
+	deleted, err := s.store.Session.DeleteExcessByUser(ctx, userID, *policy.MaxConcurrentSessions)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if deleted == 0 {
+		return nil
+	}
+
+	// The tokens of the ended sessions aren't known, so all of the user's
+	// cached sessions are dropped
+	invalidateCachedSessions(ctx, s.Container, userID)
+
+	metadata := map[string]any{
+		"reason": "concurrent_session_limit",
+		"count":  deleted,
+	}
+	return auditLog(ctx, s.store, types.ResourceSession, types.ActionDelete, userID, userID, metadata)
+}
+
+// recordActivity records the activity of a session and its user, at most once
+// every SessionActivityInterval. An impersonation isn't activity of the user.
+// Failures are logged only, as they must not fail the request.
+func (s *Session) recordActivity(ctx context.Context, session *model.Session) {
+	now := time.Now()
+	if session.IsImpersonation() || now.Sub(session.LastActiveAt) < SessionActivityInterval {
+		return
+	}
+
+	// A cached session keeps the activity it was cached with, so the cache
+	// tracks when the activity was last recorded instead
+	if !claimSessionActivity(ctx, s.Container, session.Token) {
+		return
+	}
+
+	if err := s.store.Session.Touch(ctx, session.ID); err != nil {
+		s.Logger.Warn("Failed to record session activity", "session_id", session.ID, "error", err)
+		return
+	}
+	session.LastActiveAt = now
+
+	if err := s.store.User.Touch(ctx, session.UserID); err != nil {
+		s.Logger.Warn("Failed to record user activity", "user_id", session.UserID, "error", err)
+	}
+}
+
+// isSessionActive checks if a session can still be used. Sessions end as soon
+// as they expire, which enforces the maximum session length of the entity
+// session policies, and sessions under an idle timeout end once the user is
+// inactive for that long.
+func isSessionActive(session *model.Session) bool {
+	return !session.IsExpired() && !session.IsIdle(time.Now())
+}
+
+// notifyNewSignIn queues a "new sign-in" email with a link to revoke the sign-in.
+// Failures are logged only, as they must not prevent the user from signing in.
+func (s *Session) notifyNewSignIn(ctx context.Context, user *model.User, session *model.Session) {
+	now := time.Now()
+	verification, err := s.store.User.CreateVerification(ctx, &model.Verification{
+		Context:   model.VerificationContextSignInRevoke,
+		Value:     user.ID,
+		ExpiresAt: now.Add(model.SignInRevokeDuration),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		s.Logger.Error("Failed to create sign-in revoke verification", "error", err)
+		return
+	}
+
+	var ipAddress, country string
+	if session.IPAddress != nil {
+		ipAddress = *session.IPAddress
+	}
+	if session.Country != nil {
+		country = *session.Country
+	}
+
+	queueMail(ctx, s.Container, "new_sign_in", user.Email, fmt.Sprintf("New sign-in to your %s account", s.Config.App.Name), map[string]any{
+		"Country":     country,
+		"DeviceClass": session.DeviceClass(),
+		"Duration":    model.SignInRevokeDuration.Hours() / 24,
+		"Email":       user.Email,
+		"IPAddress":   ipAddress,
+		"Name":        user.Name,
+		"RevokeURL":   fmt.Sprintf("%s/revoke-sign-in?token=%s", s.Config.App.DashboardURL, verification.ID),
+		"SignedInAt":  session.CreatedAt.UTC().Format("2006-01-02 15:04 MST"),
+	})
+}
+
+// recordSignIn compares a fully authenticated sign-in against where the user
+// signed in from before, notifying them of a new one, and remembers it, so
+// that signing out doesn't make the next sign-in new. Sign-ins pending
+// two-factor authentication aren't recorded, so that the password alone
+// can't make a country or device known.
+func (s *Session) recordSignIn(ctx context.Context, user *model.User, session *model.Session) error {
+	if session.Country == nil && session.UserAgent == nil {
+		return nil
+	}
+
+	var country string
+	if session.Country != nil {
+		country = *session.Country
+	}
+	deviceClass := session.DeviceClass()
+
+	history, err := s.store.SignInHistory.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	newSignIn := isNewSignIn(history, country, deviceClass)
+
+	if err := s.store.SignInHistory.Record(ctx, user.ID, country, deviceClass); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if newSignIn {
+		s.notifyNewSignIn(ctx, user, session)
+	}
+
 	return nil
 }
+
+// isNewSignIn reports whether a sign-in from the given country and device class
+// differs from the user's sign-in history. Without any history there is
+// nothing to compare against, so the first sign-in is not considered new.
+func isNewSignIn(history []*model.SignInHistory, country, deviceClass string) bool {
+	if len(history) == 0 {
+		return false
+	}
+
+	knownCountry := country == ""
+	knownDevice := deviceClass == model.DeviceClassUnknown
+	for _, entry := range history {
+		if entry.Country == country {
+			knownCountry = true
+		}
+		if entry.DeviceClass == deviceClass {
+			knownDevice = true
+		}
+	}
+
+	return !knownCountry || !knownDevice
+}
+
+// recordUnknownSignIn counts a failed sign-in attempt against an email address
+// without an account, and returns the error the attempt is answered with. The
+// address is locked out like an account would be, so the responses don't
+// reveal whether an account exists.
+func (s *Session) recordUnknownSignIn(ctx context.Context, email string) error {
+	emailHash := model.HashSignInEmail(email)
+	failedSignIn, err := s.store.FailedSignIn.Get(ctx, emailHash)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if failedSignIn == nil {
+		failedSignIn = &model.FailedSignIn{EmailHash: emailHash}
+	}
+
+	now := time.Now()
+	if failedSignIn.IsLocked(now) {
+		return httpx.ErrAccountLocked
+	}
+
+	failedSignIn.RecordFailedAttempt(now)
+	if err := s.store.FailedSignIn.Upsert(ctx, failedSignIn); err != nil {
+		s.Logger.Error("Failed to update failed sign-in attempts", "error", err)
+	}
+
+	return httpx.ErrInvalidCredentials
+}
+
+// notifyAccountLocked audit logs the lockout of an account and queues an
+// email with a link to unlock it. Only the latest link can be used. Failures
+// are logged only, as the account is locked either way.
+func (s *Session) notifyAccountLocked(ctx context.Context, user *model.User) {
+	metadata := map[string]any{
+		"lockout_count": user.LockoutCount,
+		"locked_until":  user.LockedUntil,
+	}
+	// Nobody signed in locked the account, so the system is the actor
+	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionLock, user.ID, "", metadata); err != nil {
+		s.Logger.Error("Failed to audit log account lockout", "error", err)
+	}
+
+	previous, err := s.store.User.GetVerificationByValue(ctx, model.VerificationContextAccountUnlock, user.ID)
+	if err != nil {
+		s.Logger.Error("Failed to get account unlock verification", "error", err)
+		return
+	}
+	if previous != nil {
+		if err := s.store.User.DeleteVerification(ctx, previous.ID); err != nil {
+			s.Logger.Error("Failed to delete account unlock verification", "error", err)
+			return
+		}
+	}
+
+	now := time.Now()
+	verification, err := s.store.User.CreateVerification(ctx, &model.Verification{
+		Context:   model.VerificationContextAccountUnlock,
+		Value:     user.ID,
+		ExpiresAt: now.Add(model.AccountUnlockDuration),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		s.Logger.Error("Failed to create account unlock verification", "error", err)
+		return
+	}
+
+	queueMail(ctx, s.Container, "account_locked", user.Email, fmt.Sprintf("Your %s account is locked", s.Config.App.Name), map[string]any{
+		"Duration":    model.AccountUnlockDuration.Hours(),
+		"Email":       user.Email,
+		"LockedUntil": user.LockedUntil.UTC().Format("2006-01-02 15:04 MST"),
+		"Name":        user.Name,
+		"UnlockURL":   fmt.Sprintf("%s/unlock-account?token=%s", s.Config.App.DashboardURL, verification.ID),
+	})
+}
@@ -0,0 +1,399 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/api/pkg/middleware"
+	"autopilot/backends/internal/core"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestSessionRefreshPastMaxSessionLength(t *testing.T) {
+	t.Parallel()
+
+	maxSession := 60
+	sessionStore := mocks.NewMockSessioner(t)
+	sessionStore.EXPECT().GetByRefreshToken(mock.Anything, "refresh").Return(&model.Session{
+		UserID:           "user",
+		AuthenticatedAt:  time.Now().Add(-2 * time.Hour),
+		LastActiveAt:     time.Now(),
+		RefreshExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+
+	sessionPolicyStore := mocks.NewMockSessionPolicier(t)
+	sessionPolicyStore.EXPECT().ListByUserID(mock.Anything, "user").Return([]*model.SessionPolicy{{MaxSessionMinutes: &maxSession}}, nil)
+
+	s := &Session{
+		Container: &app.Container{Config: &app.Config{}},
+		store:     &store.Manager{Session: sessionStore, SessionPolicy: sessionPolicyStore},
+	}
+	_, err := s.Refresh(context.Background(), "refresh")
+	assert.ErrorIs(t, err, httpx.ErrInvalidRefreshToken)
+}
+
+func TestSessionRecordActivity(t *testing.T) {
+	t.Parallel()
+
+	impersonatorID := "support"
+
+	tests := []struct {
+		name    string
+		session *model.Session
+		want    bool
+	}{
+		{
+			name:    "should record activity once the interval has passed",
+			session: &model.Session{ID: "session", UserID: "user", LastActiveAt: time.Now().Add(-2 * SessionActivityInterval)},
+			want:    true,
+		},
+		{
+			name:    "should not record activity within the interval",
+			session: &model.Session{ID: "session", UserID: "user", LastActiveAt: time.Now()},
+		},
+		{
+			name:    "should not record the activity of an impersonation",
+			session: &model.Session{ID: "session", UserID: "user", LastActiveAt: time.Now().Add(-time.Hour), ImpersonatorID: &impersonatorID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sessionStore := mocks.NewMockSessioner(t)
+			userStore := mocks.NewMockUserer(t)
+			if tt.want {
+				sessionStore.EXPECT().Touch(mock.Anything, "session").Return(nil)
+				userStore.EXPECT().Touch(mock.Anything, "user").Return(nil)
+			}
+
+			s := &Session{
+				Container: &app.Container{Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard})},
+				store:     &store.Manager{Session: sessionStore, User: userStore},
+			}
+			lastActiveAt := tt.session.LastActiveAt
+			s.recordActivity(context.Background(), tt.session)
+			assert.Equal(t, tt.want, tt.session.LastActiveAt.After(lastActiveAt))
+		})
+	}
+}
+
+func TestSessionGetByTokenCached(t *testing.T) {
+	t.Parallel()
+
+	idleTimeout := int((30 * time.Minute).Seconds())
+	impersonatorID := "support"
+
+	tests := []struct {
+		name         string
+		session      *model.Session
+		wantErr      error
+		wantActivity bool
+	}{
+		{
+			name:    "should serve an active session",
+			session: &model.Session{ID: "session", UserID: "user", LastActiveAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)},
+		},
+		{
+			name:         "should record the activity of a cached session",
+			session:      &model.Session{ID: "session", UserID: "user", LastActiveAt: time.Now().Add(-2 * SessionActivityInterval), ExpiresAt: time.Now().Add(time.Hour)},
+			wantActivity: true,
+		},
+		{
+			name: "should end an idle session",
+			session: &model.Session{
+				ID:                 "session",
+				UserID:             "user",
+				LastActiveAt:       time.Now().Add(-time.Hour),
+				ExpiresAt:          time.Now().Add(time.Hour),
+				IdleTimeoutSeconds: &idleTimeout,
+			},
+			wantErr: httpx.ErrUnauthenticated,
+		},
+		{
+			name: "should end a session past its maximum length",
+			session: &model.Session{
+				ID:           "session",
+				UserID:       "user",
+				LastActiveAt: time.Now(),
+				ExpiresAt:    time.Now().Add(-time.Second),
+			},
+			wantErr: httpx.ErrUnauthenticated,
+		},
+		{
+			name: "should end an expired impersonation",
+			session: &model.Session{
+				ID:             "session",
+				UserID:         "user",
+				LastActiveAt:   time.Now(),
+				ExpiresAt:      time.Now().Add(-time.Second),
+				ImpersonatorID: &impersonatorID,
+			},
+			wantErr: httpx.ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			container := &app.Container{
+				Cache:  app.ContainerCache{Identity: core.NewMemoryCache().Namespace("identity")},
+				Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard}),
+			}
+			// Cached directly, as the session may have ended since it was cached
+			require.NoError(t, container.Cache.Identity.Set(context.Background(), sessionCacheKey("token", ""), tt.session, time.Minute, sessionCacheTokenTag("token")))
+
+			sessionStore := mocks.NewMockSessioner(t)
+			userStore := mocks.NewMockUserer(t)
+			if tt.wantActivity {
+				sessionStore.EXPECT().Touch(mock.Anything, "session").Return(nil)
+				userStore.EXPECT().Touch(mock.Anything, "user").Return(nil)
+			}
+
+			s := &Session{
+				Container: container,
+				store:     &store.Manager{Session: sessionStore, User: userStore},
+			}
+			session, err := s.GetByToken(context.Background(), "token")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, getCachedSession(context.Background(), container, "token", ""), "the cached session should be invalidated")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "session", session.ID)
+
+			// The activity is recorded once per interval, even though the cached session keeps its activity
+			_, err = s.GetByToken(context.Background(), "token")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSessionGetByTokenExpired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		get  func(s *Session, token string) error
+	}{
+		{
+			name: "should reject an expired session",
+			get: func(s *Session, token string) error {
+				_, err := s.GetByToken(context.Background(), token)
+				return err
+			},
+		},
+		{
+			name: "should reject an expired session with its memberships",
+			get: func(s *Session, token string) error {
+				_, err := s.GetByTokenFull(context.Background(), token)
+				return err
+			},
+		},
+		{
+			name: "should not list the sessions of an expired session",
+			get: func(s *Session, token string) error {
+				_, err := s.ListByToken(context.Background(), token)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sessionStore := mocks.NewMockSessioner(t)
+			sessionStore.EXPECT().GetByToken(mock.Anything, "token").Return(&model.Session{
+				ID:           "session",
+				UserID:       "user",
+				LastActiveAt: time.Now(),
+				ExpiresAt:    time.Now().Add(-time.Second),
+			}, nil)
+
+			s := &Session{
+				Container: &app.Container{
+					Cache:  app.ContainerCache{Identity: core.NewMemoryCache().Namespace("identity")},
+					Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard}),
+				},
+				store: &store.Manager{Session: sessionStore},
+			}
+			assert.ErrorIs(t, tt.get(s, "token"), httpx.ErrUnauthenticated)
+		})
+	}
+}
+
+func TestSessionCreateUnverifiedEmail(t *testing.T) {
+	t.Parallel()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	passwordHash := string(hash)
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{
+			name:     "should not reveal the unverified email without the password",
+			password: "wrong",
+			wantErr:  httpx.ErrInvalidCredentials,
+		},
+		{
+			name:     "should refuse the unverified email with the password",
+			password: "password",
+			wantErr:  httpx.ErrEmailNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			userStore := mocks.NewMockUserer(t)
+			userStore.EXPECT().GetByEmail(mock.Anything, "jane@example.com").Return(&model.User{
+				ID:           "user",
+				Email:        "jane@example.com",
+				PasswordHash: &passwordHash,
+			}, nil)
+			if tt.wantErr == httpx.ErrInvalidCredentials {
+				userStore.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+			} else {
+				userStore.EXPECT().GetVerificationByValue(mock.Anything, model.VerificationContextEmailVerification, "jane@example.com").
+					Return(&model.Verification{ExpiresAt: time.Now().Add(time.Hour)}, nil)
+			}
+
+			s := &Session{
+				Container: &app.Container{Config: &app.Config{}, Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard})},
+				store:     &store.Manager{User: userStore},
+			}
+			_, err := s.Create(context.Background(), "jane@example.com", tt.password, "")
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSessionRecordSignInAfterTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	user := &model.User{ID: "user", Email: "jane@example.com"}
+	ctx := middleware.AttachRequestMetadata(context.Background(), "203.0.113.1", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)", "SG")
+
+	membershipStore := mocks.NewMockMembershiper(t)
+	membershipStore.EXPECT().GetByUserID(mock.Anything, user.ID).Return(nil, nil)
+	twoFactorStore := mocks.NewMockTwoFactorer(t)
+	twoFactorStore.EXPECT().GetByUserID(mock.Anything, user.ID).Return(&model.TwoFactor{UserID: user.ID}, nil)
+
+	var pending *model.Session
+	sessionStore := mocks.NewMockSessioner(t)
+	sessionStore.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, session *model.Session) (*model.Session, error) {
+		pending = session
+		return session, nil
+	})
+
+	// No sign-in history is expected until two-factor authentication is completed
+	signInHistoryStore := mocks.NewMockSignInHistorier(t)
+	userStore := mocks.NewMockUserer(t)
+
+	s := &Session{
+		Container: &app.Container{Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard})},
+		store: &store.Manager{
+			Membership:    membershipStore,
+			Session:       sessionStore,
+			SignInHistory: signInHistoryStore,
+			TwoFactor:     twoFactorStore,
+			User:          userStore,
+		},
+	}
+	_, err := s.createForUser(ctx, user, "")
+	require.ErrorIs(t, err, httpx.ErrTwoFactorPending)
+
+	sessionStore.EXPECT().UpdateTwoFactorPending(mock.Anything, pending.Token, false).Return(nil)
+	sessionStore.EXPECT().GetByToken(mock.Anything, pending.Token).Return(pending, nil)
+	userStore.EXPECT().GetByID(mock.Anything, user.ID).Return(user, nil)
+	signInHistoryStore.EXPECT().ListByUserID(mock.Anything, user.ID).Return(nil, nil)
+	signInHistoryStore.EXPECT().Record(mock.Anything, user.ID, "SG", model.DeviceClass("Mozilla/5.0 (Windows NT 10.0; Win64; x64)")).Return(nil)
+
+	require.NoError(t, s.UpdateTwoFactorStatus(ctx, pending.Token, false))
+}
+
+func TestIsNewSignIn(t *testing.T) {
+	t.Parallel()
+
+	history := []*model.SignInHistory{
+		{Country: "SG", DeviceClass: model.DeviceClassDesktop},
+		{Country: "MY", DeviceClass: model.DeviceClassMobile},
+	}
+
+	tests := []struct {
+		name        string
+		history     []*model.SignInHistory
+		country     string
+		deviceClass string
+		want        bool
+	}{
+		{
+			name:        "should not treat the first sign-in as new",
+			country:     "SG",
+			deviceClass: model.DeviceClassDesktop,
+		},
+		{
+			name:        "should not treat a known country and device as new",
+			history:     history,
+			country:     "SG",
+			deviceClass: model.DeviceClassDesktop,
+		},
+		{
+			name:        "should compare the country and device separately",
+			history:     history,
+			country:     "MY",
+			deviceClass: model.DeviceClassDesktop,
+		},
+		{
+			name:        "should treat a new country as new",
+			history:     history,
+			country:     "US",
+			deviceClass: model.DeviceClassDesktop,
+			want:        true,
+		},
+		{
+			name:        "should treat a new device class as new",
+			history:     history,
+			country:     "SG",
+			deviceClass: model.DeviceClassTablet,
+			want:        true,
+		},
+		{
+			name:        "should not treat an unresolved country as new",
+			history:     history,
+			deviceClass: model.DeviceClassDesktop,
+		},
+		{
+			name:        "should not treat an unknown device as new",
+			history:     history,
+			country:     "SG",
+			deviceClass: model.DeviceClassUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, isNewSignIn(tt.history, tt.country, tt.deviceClass))
+		})
+	}
+}
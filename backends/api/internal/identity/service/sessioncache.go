@@ -0,0 +1,140 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/app"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// The session cache holds sessions resolved by GetByToken, including the
+// memberships of the active entity, in the identity cache. An entry is keyed
+// by the hashed token and the active entity, and tagged with the token and the
+// user so the entries of either can be invalidated together. Explicit
+// invalidation happens after the database change is committed, and
+// SessionCacheTTL bounds anything that slips through. The cache is optional:
+// without one, or when it fails, sessions are read from the database.
+
+// sessionCacheKey returns the key of the cached session of a token in an entity.
+func sessionCacheKey(token, entityID string) string {
+	return fmt.Sprintf("session:%s:%s", sessionCacheTokenHash(token), entityID)
+}
+
+// sessionCacheTokenHash hashes a token, so the cache never holds usable tokens.
+func sessionCacheTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// sessionCacheTokenTag returns the tag of the cached sessions of a token.
+func sessionCacheTokenTag(token string) string {
+	return "session:" + sessionCacheTokenHash(token)
+}
+
+// sessionCacheUserTag returns the tag of the cached sessions of a user.
+func sessionCacheUserTag(userID string) string {
+	return "user_sessions:" + userID
+}
+
+// getCachedSession returns the cached session of a token in an entity, or nil
+// on a miss.
+func getCachedSession(ctx context.Context, container *app.Container, token, entityID string) *model.Session {
+	cache := container.Cache.Identity
+	if cache == nil {
+		return nil
+	}
+
+	var session model.Session
+	found, err := cache.Get(ctx, sessionCacheKey(token, entityID), &session)
+	if err != nil {
+		container.Logger.Warn("Failed to read cached session", "error", err)
+		return nil
+	}
+	if !found {
+		return nil
+	}
+
+	session.Token = token
+	return &session
+}
+
+// cacheSession caches the session of a token in an entity until SessionCacheTTL
+// passes or the session expires, whichever is first.
+func cacheSession(ctx context.Context, container *app.Container, token, entityID string, session *model.Session) {
+	cache := container.Cache.Identity
+	if cache == nil {
+		return
+	}
+
+	ttl := min(SessionCacheTTL, time.Until(session.ExpiresAt))
+	if ttl <= 0 {
+		return
+	}
+
+	// Neither token is needed to resolve the session
+	cached := *session
+	cached.Token = ""
+	cached.RefreshToken = ""
+	err := cache.Set(ctx, sessionCacheKey(token, entityID), &cached, ttl, sessionCacheTokenTag(token), sessionCacheUserTag(session.UserID))
+	if err != nil {
+		container.Logger.Warn("Failed to cache session", "error", err)
+	}
+}
+
+// invalidateCachedSession removes the cached entries of a single session token.
+func invalidateCachedSession(ctx context.Context, container *app.Container, token string) {
+	cache := container.Cache.Identity
+	if cache == nil {
+		return
+	}
+
+	if err := cache.InvalidateTags(ctx, sessionCacheTokenTag(token)); err != nil {
+		container.Logger.Warn("Failed to invalidate cached session", "error", err)
+	}
+}
+
+// claimSessionActivity reports whether the activity of a session token is due
+// to be recorded, and if so marks it recorded for SessionActivityInterval. The
+// activity is always due without a cache, or when it fails.
+func claimSessionActivity(ctx context.Context, container *app.Container, token string) bool {
+	cache := container.Cache.Identity
+	if cache == nil {
+		return true
+	}
+
+	key := "session_activity:" + sessionCacheTokenHash(token)
+	var recorded bool
+	found, err := cache.Get(ctx, key, &recorded)
+	if err != nil {
+		container.Logger.Warn("Failed to read session activity", "error", err)
+		return true
+	}
+	if found {
+		return false
+	}
+
+	if err := cache.Set(ctx, key, true, SessionActivityInterval, sessionCacheTokenTag(token)); err != nil {
+		container.Logger.Warn("Failed to mark session activity", "error", err)
+	}
+	return true
+}
+
+// invalidateCachedSessions removes the cached sessions of the users, such as
+// after their sessions are revoked or their memberships change.
+func invalidateCachedSessions(ctx context.Context, container *app.Container, userIDs ...string) {
+	cache := container.Cache.Identity
+	if cache == nil || len(userIDs) == 0 {
+		return
+	}
+
+	tags := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		tags = append(tags, sessionCacheUserTag(userID))
+	}
+	if err := cache.InvalidateTags(ctx, tags...); err != nil {
+		container.Logger.Warn("Failed to invalidate cached sessions", "user_ids", userIDs, "error", err)
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/internal/core"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newContainer := func() *app.Container {
+		return &app.Container{
+			Cache:  app.ContainerCache{Identity: core.NewMemoryCache().Namespace("identity")},
+			Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard}),
+		}
+	}
+	session := &model.Session{
+		UserID:       "user",
+		Token:        "token",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+
+	t.Run("should cache sessions without their tokens", func(t *testing.T) {
+		container := newContainer()
+		cacheSession(ctx, container, "token", "entity", session)
+
+		cached := getCachedSession(ctx, container, "token", "entity")
+		require.NotNil(t, cached)
+		assert.Equal(t, "token", cached.Token)
+		assert.Empty(t, cached.RefreshToken)
+		assert.Nil(t, getCachedSession(ctx, container, "token", "other"))
+	})
+
+	t.Run("should invalidate every entity of a token", func(t *testing.T) {
+		container := newContainer()
+		cacheSession(ctx, container, "token", "entity", session)
+		cacheSession(ctx, container, "token", "other", session)
+		cacheSession(ctx, container, "another", "entity", session)
+
+		invalidateCachedSession(ctx, container, "token")
+		assert.Nil(t, getCachedSession(ctx, container, "token", "entity"))
+		assert.Nil(t, getCachedSession(ctx, container, "token", "other"))
+		assert.NotNil(t, getCachedSession(ctx, container, "another", "entity"))
+	})
+
+	t.Run("should invalidate every session of a user", func(t *testing.T) {
+		container := newContainer()
+		cacheSession(ctx, container, "token", "entity", session)
+		cacheSession(ctx, container, "another", "entity", session)
+
+		invalidateCachedSessions(ctx, container, "user")
+		assert.Nil(t, getCachedSession(ctx, container, "token", "entity"))
+		assert.Nil(t, getCachedSession(ctx, container, "another", "entity"))
+	})
+
+	t.Run("should read from the database without a cache", func(t *testing.T) {
+		container := &app.Container{}
+		cacheSession(ctx, container, "token", "entity", session)
+		assert.Nil(t, getCachedSession(ctx, container, "token", "entity"))
+	})
+}
+
+func TestClaimSessionActivity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("should claim the activity once per interval", func(t *testing.T) {
+		container := &app.Container{
+			Cache:  app.ContainerCache{Identity: core.NewMemoryCache().Namespace("identity")},
+			Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard}),
+		}
+
+		assert.True(t, claimSessionActivity(ctx, container, "token"))
+		assert.False(t, claimSessionActivity(ctx, container, "token"))
+		assert.True(t, claimSessionActivity(ctx, container, "another"))
+
+		invalidateCachedSession(ctx, container, "token")
+		assert.True(t, claimSessionActivity(ctx, container, "token"))
+	})
+
+	t.Run("should always claim the activity without a cache", func(t *testing.T) {
+		container := &app.Container{}
+		assert.True(t, claimSessionActivity(ctx, container, "token"))
+		assert.True(t, claimSessionActivity(ctx, container, "token"))
+	})
+}
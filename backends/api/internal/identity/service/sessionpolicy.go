@@ -0,0 +1,98 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+	"time"
+)
+
+// SessionPolicier is an interface that wraps the SessionPolicy methods
+type SessionPolicier interface {
+	Get(ctx context.Context, entityID string) (*model.SessionPolicy, error)
+	Update(ctx context.Context, entityID, userID string, policy *model.SessionPolicy) (*model.SessionPolicy, error)
+}
+
+// SessionPolicy is the service for the session limits entities set for their
+// members.
+type SessionPolicy struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewSessionPolicy creates a new SessionPolicy service.
+func NewSessionPolicy(container *app.Container, store *store.Manager) SessionPolicier {
+	return &SessionPolicy{
+		Container: container,
+		store:     store,
+	}
+}
+
+// Get returns the session policy of an entity. Entities without a policy get
+// one without limits of its own.
+func (s *SessionPolicy) Get(ctx context.Context, entityID string) (*model.SessionPolicy, error) {
+	policy, err := s.store.SessionPolicy.Get(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if policy == nil {
+		policy = &model.SessionPolicy{EntityID: entityID}
+	}
+
+	return policy, nil
+}
+
+// Update replaces the session policy of an entity. It applies to the sessions
+// members sign in to or refresh from then on.
+func (s *SessionPolicy) Update(ctx context.Context, entityID, userID string, policy *model.SessionPolicy) (*model.SessionPolicy, error) {
+	before, err := s.Get(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.SessionPolicy.Upsert(ctx, &model.SessionPolicy{
+		EntityID:              entityID,
+		IdleTimeoutMinutes:    policy.IdleTimeoutMinutes,
+		MaxSessionMinutes:     policy.MaxSessionMinutes,
+		MaxConcurrentSessions: policy.MaxConcurrentSessions,
+		UpdatedBy:             &userID,
+	})
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if err := auditLogChange(ctx, s.store, types.ResourceSessionPolicy, types.ActionUpdate, entityID, userID, sessionPolicyChange(before), sessionPolicyChange(updated), nil); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// sessionPolicyChange returns the limits of a session policy for the audit log
+func sessionPolicyChange(policy *model.SessionPolicy) map[string]any {
+	return map[string]any{
+		"idle_timeout_minutes":    policy.IdleTimeoutMinutes,
+		"max_session_minutes":     policy.MaxSessionMinutes,
+		"max_concurrent_sessions": policy.MaxConcurrentSessions,
+	}
+}
+
+// sessionPolicyForUser returns the strictest session policy of the entities a
+// user belongs to, with the configured idle timeout as the most lenient one.
+func sessionPolicyForUser(ctx context.Context, container *app.Container, store *store.Manager, userID string) (*model.SessionPolicy, error) {
+	policies, err := store.SessionPolicy.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if idleTimeout := container.Config.Identity.SessionIdleTimeout; idleTimeout > 0 {
+		minutes := max(int(idleTimeout/time.Minute), 1)
+		policies = append(policies, &model.SessionPolicy{IdleTimeoutMinutes: &minutes})
+	}
+
+	return model.StrictestSessionPolicy(policies...), nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"autopilot/backends/api/pkg/app"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSessionPolicyForUser(t *testing.T) {
+	t.Parallel()
+
+	limit := func(n int) *int { return &n }
+
+	tests := []struct {
+		name        string
+		idleTimeout time.Duration
+		policies    []*model.SessionPolicy
+		want        *model.SessionPolicy
+	}{
+		{
+			name: "should have no limits without policies or a configured idle timeout",
+			want: &model.SessionPolicy{},
+		},
+		{
+			name:        "should apply the configured idle timeout",
+			idleTimeout: 7 * 24 * time.Hour,
+			want:        &model.SessionPolicy{IdleTimeoutMinutes: limit(7 * 24 * 60)},
+		},
+		{
+			name:        "should apply a stricter idle timeout of an entity",
+			idleTimeout: 7 * 24 * time.Hour,
+			policies:    []*model.SessionPolicy{{IdleTimeoutMinutes: limit(30), MaxConcurrentSessions: limit(2)}},
+			want:        &model.SessionPolicy{IdleTimeoutMinutes: limit(30), MaxConcurrentSessions: limit(2)},
+		},
+		{
+			name:        "should keep the configured idle timeout over a more lenient one",
+			idleTimeout: time.Hour,
+			policies:    []*model.SessionPolicy{{IdleTimeoutMinutes: limit(120)}},
+			want:        &model.SessionPolicy{IdleTimeoutMinutes: limit(60)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			container := &app.Container{Config: &app.Config{}}
+			container.Config.Identity.SessionIdleTimeout = tt.idleTimeout
+
+			sessionPolicyStore := mocks.NewMockSessionPolicier(t)
+			sessionPolicyStore.EXPECT().ListByUserID(mock.Anything, "user").Return(tt.policies, nil)
+
+			got, err := sessionPolicyForUser(context.Background(), container, &store.Manager{SessionPolicy: sessionPolicyStore}, "user")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/oauth2"
+)
+
+// ssoProvider performs the OpenID Connect authorization code flow with PKCE
+// against the identity provider of a connection.
+type ssoProvider struct {
+	config   oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// ssoClaims are the ID token claims used to sign in and provision users.
+type ssoClaims struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// newSSOProvider discovers the endpoints and signing keys of the issuer.
+func newSSOProvider(ctx context.Context, issuer, clientID, clientSecret, redirectURL string) (*ssoProvider, error) {
+	provider, err := oidc.NewProvider(ctx, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discovering issuer: %w", err)
+	}
+
+	return &ssoProvider{
+		config: oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint:     provider.Endpoint(),
+			RedirectURL:  redirectURL,
+			Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: clientID}),
+	}, nil
+}
+
+// authCodeURL returns the URL of the identity provider to send the user to.
+func (p *ssoProvider) authCodeURL(state, codeVerifier, nonce string) string {
+	return p.config.AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier), oidc.Nonce(nonce))
+}
+
+// exchange trades the authorization code for tokens and returns the claims of
+// the verified ID token.
+func (p *ssoProvider) exchange(ctx context.Context, code, codeVerifier, nonce string) (*ssoClaims, error) {
+	token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchanging code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("missing id token")
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verifying id token: %w", err)
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, errors.New("nonce mismatch")
+	}
+
+	var claims ssoClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("parsing claims: %w", err)
+	}
+
+	return &claims, nil
+}
+
+// ssoRedirectURL is where the identity provider sends the user back to.
+func ssoRedirectURL(dashboardURL string) string {
+	return dashboardURL + "/sso/callback"
+}
+
+// newSSOProvider creates the provider of a connection with its decrypted secret.
+func (s *Session) newSSOProvider(ctx context.Context, connection *model.SSOConnection) (*ssoProvider, error) {
+	clientSecret, err := s.Cipher.Decrypt(connection.ClientSecret)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	provider, err := newSSOProvider(ctx, connection.Issuer, connection.ClientID, clientSecret, ssoRedirectURL(s.Config.App.DashboardURL))
+	if err != nil {
+		return nil, httpx.ErrInvalidConnectionCredentials.WithInternal(err)
+	}
+
+	return provider, nil
+}
+
+// StartSSO begins a single sign-on through the connection of an entity and
+// returns the URL of the identity provider to redirect the user to, along
+// with the state, which the browser starting the sign-on has to present to
+// complete it.
+func (s *Session) StartSSO(ctx context.Context, entityID string) (string, string, error) {
+	entity, err := s.store.Entity.Get(ctx, entityID)
+	if err != nil {
+		return "", "", httpx.ErrUnknown.WithInternal(err)
+	}
+	if entity == nil {
+		return "", "", httpx.ErrEntityNotFound
+	}
+
+	connection, err := s.store.SSOConnection.GetByEntityID(ctx, entity.ID)
+	if err != nil {
+		return "", "", httpx.ErrUnknown.WithInternal(err)
+	}
+	if connection == nil {
+		return "", "", httpx.ErrConnectionNotFound
+	}
+
+	provider, err := s.newSSOProvider(ctx, connection)
+	if err != nil {
+		return "", "", err
+	}
+
+	codeVerifier := oauth2.GenerateVerifier()
+	nonce, err := generateSecureToken(16)
+	if err != nil {
+		return "", "", httpx.ErrUnknown.WithInternal(err)
+	}
+
+	// The verification ID is the state, which binds the callback to this sign-on
+	now := time.Now()
+	verification, err := s.store.User.CreateVerification(ctx, &model.Verification{
+		Context:   model.VerificationContextSSOState,
+		Value:     model.NewSSOStateValue(connection.ID, codeVerifier, nonce),
+		ExpiresAt: now.Add(model.SSOStateDuration),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return "", "", httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return provider.authCodeURL(verification.ID, codeVerifier, nonce), verification.ID, nil
+}
+
+// CreateWithSSO completes a single sign-on and creates a session for the user,
+// provisioning the user and their membership on first sign-in. Like a password
+// sign-in, two-factor authentication is still required if it is set up and
+// the device isn't trusted.
+func (s *Session) CreateWithSSO(ctx context.Context, state, code, trustedDevice string) (*model.Session, error) {
+	verification, err := s.store.User.GetVerification(ctx, model.VerificationContextSSOState, state)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if verification == nil || verification.IsExpired() {
+		return nil, httpx.ErrInvalidOrExpiredToken
+	}
+
+	// Consume the state before anything else so it can't be replayed
+	if err := s.store.User.DeleteVerification(ctx, verification.ID); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	connectionID, codeVerifier, nonce, ok := verification.SSOState()
+	if !ok {
+		return nil, httpx.ErrInvalidOrExpiredToken
+	}
+
+	connection, err := s.store.SSOConnection.GetByID(ctx, connectionID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if connection == nil {
+		return nil, httpx.ErrConnectionNotFound
+	}
+
+	provider, err := s.newSSOProvider(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := provider.exchange(ctx, code, codeVerifier, nonce)
+	if err != nil {
+		return nil, httpx.ErrInvalidCredentials.WithInternal(err)
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, httpx.ErrEmailNotVerified
+	}
+
+	if !connection.AllowsEmail(claims.Email) {
+		return nil, httpx.ErrEmailDomainNotAllowed
+	}
+
+	// The domain may have been removed or verified by another entity since
+	// the connection was saved
+	if err := checkDomainsVerified(ctx, s.store, connection.EntityID, model.EmailDomain(claims.Email)); err != nil {
+		if errors.Is(err, httpx.ErrDomainNotVerified) || errors.Is(err, httpx.ErrDomainClaimed) {
+			return nil, httpx.ErrEmailDomainNotAllowed
+		}
+		return nil, err
+	}
+
+	user, err := s.provisionSSOUser(ctx, connection, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsLocked(time.Now()) {
+		return nil, httpx.ErrAccountLocked
+	}
+
+	return s.createForUser(ctx, user, trustedDevice)
+}
+
+// provisionSSOUser returns the user signing in through a connection. Unknown
+// users are created and users without a membership in the entity of the
+// connection join it with the default role of the connection.
+func (s *Session) provisionSSOUser(ctx context.Context, connection *model.SSOConnection, claims *ssoClaims) (*model.User, error) {
+	var (
+		user       *model.User
+		membership *model.Membership
+		created    bool
+		verified   bool
+	)
+
+	err := s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		u := s.store.User.WithQuerier(tx)
+		m := s.store.Membership.WithQuerier(tx)
+
+		var err error
+		user, err = u.GetByEmail(ctx, claims.Email)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if user == nil {
+			name := claims.Name
+			if name == "" {
+				name = claims.Email
+			}
+
+			// Users provisioned through SSO have no password
+			user, err = u.Create(ctx, &model.User{
+				Name:            name,
+				Email:           claims.Email,
+				EmailVerifiedAt: &now,
+			})
+			if err != nil {
+				return err
+			}
+			created = true
+		} else if !user.IsEmailVerified() {
+			if err := verifySSOEmail(ctx, store.NewManager(tx), user, now); err != nil {
+				return err
+			}
+			verified = true
+		}
+
+		memberships, err := m.GetByUserID(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, existing := range memberships {
+			if existing.EntityID != nil && *existing.EntityID == connection.EntityID {
+				return nil
+			}
+		}
+
+		membership, err = m.Create(ctx, &model.Membership{
+			EntityID: &connection.EntityID,
+			Role:     connection.DefaultRole,
+			UserID:   user.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if created {
+		metadata := map[string]any{
+			"sso_connection_id": connection.ID,
+			"subject":           claims.Subject,
+		}
+		if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionCreate, user.ID, user.ID, metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if verified {
+		metadata := map[string]any{
+			"reason":            "sso_email_verified",
+			"sso_connection_id": connection.ID,
+		}
+		if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionUpdate, user.ID, user.ID, metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if membership != nil || verified {
+		invalidateCachedSessions(ctx, s.Container, user.ID)
+	}
+
+	if membership != nil {
+
+		metadata := map[string]any{
+			"entity_id":         connection.EntityID,
+			"role":              membership.Role,
+			"sso_connection_id": connection.ID,
+		}
+		if err := auditLog(ctx, s.store, types.ResourceEntity, types.ActionCreate, membership.ID, user.ID, metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// verifySSOEmail marks the email of an unverified user as verified by an
+// identity provider. Anyone could have registered the address before, so the
+// password, sessions, trusted devices and pending verifications of the
+// account are revoked.
+func verifySSOEmail(ctx context.Context, store *store.Manager, user *model.User, now time.Time) error {
+	user.EmailVerifiedAt = &now
+	user.PasswordHash = nil
+	user.UpdatedAt = now
+	if err := store.User.Update(ctx, user); err != nil {
+		return err
+	}
+
+	// An empty token matches no session, so every session of the user is revoked
+	if err := store.Session.InvalidateByUserID(ctx, user.ID, ""); err != nil {
+		return err
+	}
+
+	if err := store.TrustedDevice.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+
+	return store.Verification.DeleteByUser(ctx, user.ID, user.Email)
+}
+
+// checkSSOEnforcement returns ErrSSORequired if an entity of the user only
+// allows signing in through single sign-on.
+func (s *Session) checkSSOEnforcement(ctx context.Context, userID string) error {
+	connections, err := s.store.SSOConnection.ListEnforcedByUserID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if len(connections) > 0 {
+		return httpx.ErrSSORequired
+	}
+
+	return nil
+}
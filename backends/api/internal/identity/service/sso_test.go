@@ -0,0 +1,236 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// mockIdentityProvider is a minimal OpenID Connect provider that issues an ID
+// token for a single authorization code.
+type mockIdentityProvider struct {
+	*httptest.Server
+	key   *rsa.PrivateKey
+	code  string
+	nonce string
+}
+
+func newMockIdentityProvider(t *testing.T) *mockIdentityProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &mockIdentityProvider{key: key, code: "authorization-code"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/keys", p.keys)
+	mux.HandleFunc("/token", p.token)
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+func (p *mockIdentityProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]any{
+		"issuer":                                p.URL,
+		"authorization_endpoint":                p.URL + "/authorize",
+		"token_endpoint":                        p.URL + "/token",
+		"jwks_uri":                              p.URL + "/keys",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *mockIdentityProvider) keys(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]any{
+		"keys": []map[string]any{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+// token only accepts the known code with a PKCE verifier matching the
+// challenge the authorization URL was created with.
+func (p *mockIdentityProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != p.code {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != p.codeChallenge() {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	clientID, _, _ := r.BasicAuth()
+	writeJSON(w, map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"id_token":     p.sign(clientID),
+	})
+}
+
+// codeChallenge is the challenge the code was issued for.
+func (p *mockIdentityProvider) codeChallenge() string {
+	return oauth2.S256ChallengeFromVerifier(testCodeVerifier)
+}
+
+func (p *mockIdentityProvider) sign(audience string) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test", "typ": "JWT"})
+	payload, _ := json.Marshal(map[string]any{
+		"iss":            p.URL,
+		"sub":            "subject-1",
+		"aud":            audience,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          p.nonce,
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"name":           "Jane Doe",
+	})
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+const testCodeVerifier = "test-code-verifier-with-enough-entropy-0123456789"
+
+func TestSSOProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("builds an authorization URL with PKCE and a nonce", func(t *testing.T) {
+		idp := newMockIdentityProvider(t)
+		provider, err := newSSOProvider(ctx, idp.URL, "client-id", "client-secret", ssoRedirectURL("https://dashboard.test"))
+		require.NoError(t, err)
+
+		authURL, err := url.Parse(provider.authCodeURL("state-1", testCodeVerifier, "nonce-1"))
+		require.NoError(t, err)
+
+		query := authURL.Query()
+		assert.True(t, strings.HasPrefix(authURL.String(), idp.URL+"/authorize"))
+		assert.Equal(t, "state-1", query.Get("state"))
+		assert.Equal(t, "nonce-1", query.Get("nonce"))
+		assert.Equal(t, "S256", query.Get("code_challenge_method"))
+		assert.Equal(t, idp.codeChallenge(), query.Get("code_challenge"))
+		assert.Equal(t, "https://dashboard.test/sso/callback", query.Get("redirect_uri"))
+		assert.Contains(t, query.Get("scope"), "openid")
+	})
+
+	t.Run("exchanges a code for verified claims", func(t *testing.T) {
+		idp := newMockIdentityProvider(t)
+		idp.nonce = "nonce-1"
+		provider, err := newSSOProvider(ctx, idp.URL, "client-id", "client-secret", ssoRedirectURL("https://dashboard.test"))
+		require.NoError(t, err)
+
+		claims, err := provider.exchange(ctx, idp.code, testCodeVerifier, "nonce-1")
+		require.NoError(t, err)
+		assert.Equal(t, "subject-1", claims.Subject)
+		assert.Equal(t, "jane@example.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, "Jane Doe", claims.Name)
+	})
+
+	t.Run("rejects a mismatched code verifier", func(t *testing.T) {
+		idp := newMockIdentityProvider(t)
+		idp.nonce = "nonce-1"
+		provider, err := newSSOProvider(ctx, idp.URL, "client-id", "client-secret", ssoRedirectURL("https://dashboard.test"))
+		require.NoError(t, err)
+
+		_, err = provider.exchange(ctx, idp.code, "another-code-verifier", "nonce-1")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects a mismatched nonce", func(t *testing.T) {
+		idp := newMockIdentityProvider(t)
+		idp.nonce = "nonce-1"
+		provider, err := newSSOProvider(ctx, idp.URL, "client-id", "client-secret", ssoRedirectURL("https://dashboard.test"))
+		require.NoError(t, err)
+
+		_, err = provider.exchange(ctx, idp.code, testCodeVerifier, "nonce-2")
+		assert.ErrorContains(t, err, "nonce mismatch")
+	})
+
+	t.Run("rejects a token issued for another client", func(t *testing.T) {
+		idp := newMockIdentityProvider(t)
+		idp.nonce = "nonce-1"
+		provider, err := newSSOProvider(ctx, idp.URL, "client-id", "client-secret", ssoRedirectURL("https://dashboard.test"))
+		require.NoError(t, err)
+		provider.config.ClientID = "another-client"
+
+		_, err = provider.exchange(ctx, idp.code, testCodeVerifier, "nonce-1")
+		assert.ErrorContains(t, err, "verifying id token")
+	})
+
+	t.Run("fails discovery for an unknown issuer", func(t *testing.T) {
+		idp := newMockIdentityProvider(t)
+
+		_, err := newSSOProvider(ctx, idp.URL+"/unknown", "client-id", "client-secret", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifySSOEmail(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now()
+	passwordHash := "hash"
+	user := &model.User{ID: "user", Email: "jane@example.com", PasswordHash: &passwordHash}
+
+	userStore := mocks.NewMockUserer(t)
+	userStore.EXPECT().Update(mock.Anything, mock.MatchedBy(func(u *model.User) bool {
+		return u.IsEmailVerified() && !u.HasPassword()
+	})).Return(nil)
+	sessionStore := mocks.NewMockSessioner(t)
+	sessionStore.EXPECT().InvalidateByUserID(mock.Anything, user.ID, "").Return(nil)
+	trustedDeviceStore := mocks.NewMockTrustedDevicer(t)
+	trustedDeviceStore.EXPECT().DeleteByUserID(mock.Anything, user.ID).Return(nil)
+	verificationStore := mocks.NewMockVerificationer(t)
+	verificationStore.EXPECT().DeleteByUser(mock.Anything, user.ID, user.Email).Return(nil)
+
+	err := verifySSOEmail(ctx, &store.Manager{
+		Session:       sessionStore,
+		TrustedDevice: trustedDeviceStore,
+		User:          userStore,
+		Verification:  verificationStore,
+	}, user, now)
+	require.NoError(t, err)
+	assert.Nil(t, user.PasswordHash, "the password set before the address was verified should be cleared")
+	assert.Equal(t, &now, user.EmailVerifiedAt)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/types"
+	"context"
+)
+
+// SSOConnectioner is an interface that wraps the SSOConnection methods
+type SSOConnectioner interface {
+	Delete(ctx context.Context, userID string, entityID string) error
+	Get(ctx context.Context, entityID string) (*model.SSOConnection, error)
+	Upsert(ctx context.Context, userID string, connection *model.SSOConnection, clientSecret string) (*model.SSOConnection, error)
+}
+
+// SSOConnection is the service for single sign-on connection operations.
+type SSOConnection struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewSSOConnection creates a new SSOConnection service.
+func NewSSOConnection(container *app.Container, store *store.Manager) SSOConnectioner {
+	return &SSOConnection{
+		Container: container,
+		store:     store,
+	}
+}
+
+// Get retrieves the connection of an entity.
+func (s *SSOConnection) Get(ctx context.Context, entityID string) (*model.SSOConnection, error) {
+	connection, err := s.store.SSOConnection.GetByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if connection == nil {
+		return nil, httpx.ErrConnectionNotFound
+	}
+
+	return connection, nil
+}
+
+// Upsert creates or replaces the connection of an entity. The issuer is
+// discovered before saving to catch misconfigurations early. An empty client
+// secret keeps the secret of the existing connection. Only domains verified by
+// the entity can be allowed, as the connection signs in anyone of them.
+func (s *SSOConnection) Upsert(ctx context.Context, userID string, connection *model.SSOConnection, clientSecret string) (*model.SSOConnection, error) {
+	if err := checkDomainsVerified(ctx, s.store, connection.EntityID, connection.AllowedDomains...); err != nil {
+		return nil, err
+	}
+
+	if clientSecret == "" {
+		existing, err := s.store.SSOConnection.GetByEntityID(ctx, connection.EntityID)
+		if err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+		if existing == nil {
+			return nil, httpx.ErrInvalidConnectionCredentials
+		}
+
+		clientSecret, err = s.Cipher.Decrypt(existing.ClientSecret)
+		if err != nil {
+			return nil, httpx.ErrUnknown.WithInternal(err)
+		}
+	}
+
+	if _, err := newSSOProvider(ctx, connection.Issuer, connection.ClientID, clientSecret, ssoRedirectURL(s.Config.App.DashboardURL)); err != nil {
+		return nil, httpx.ErrInvalidConnectionCredentials.WithInternal(err)
+	}
+
+	encrypted, err := s.Cipher.Encrypt(clientSecret)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	connection.ClientSecret = encrypted
+
+	saved, err := s.store.SSOConnection.Upsert(ctx, connection)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"allowed_domains":   saved.AllowedDomains,
+		"default_role":      saved.DefaultRole,
+		"is_enforced":       saved.IsEnforced,
+		"issuer":            saved.Issuer,
+		"sso_connection_id": saved.ID,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceEntity, types.ActionUpdate, saved.EntityID, userID, metadata); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// Delete removes the connection of an entity.
+func (s *SSOConnection) Delete(ctx context.Context, userID string, entityID string) error {
+	connection, err := s.Get(ctx, entityID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.SSOConnection.DeleteByEntityID(ctx, entityID); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"sso_connection_id": connection.ID,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceEntity, types.ActionDelete, entityID, userID, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/api/pkg/middleware"
+	"autopilot/backends/internal/types"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrustedDevicer is an interface that wraps the TrustedDevice methods
+type TrustedDevicer interface {
+	List(ctx context.Context, userID string) ([]*model.TrustedDevice, error)
+	Revoke(ctx context.Context, userID, id string) error
+	RevokeAll(ctx context.Context, userID string) error
+	Trust(ctx context.Context, userID string) (*model.TrustedDevice, string, error)
+}
+
+// TrustedDevice is the service for the browsers users trust to skip
+// two-factor authentication. A trusted browser holds a cookie with the ID of
+// the device, signed together with its user and class of device, so it can
+// neither be forged nor moved to another user or kind of device.
+type TrustedDevice struct {
+	*app.Container
+	store *store.Manager
+}
+
+// NewTrustedDevice creates a new TrustedDevice service.
+func NewTrustedDevice(container *app.Container, store *store.Manager) TrustedDevicer {
+	return &TrustedDevice{
+		Container: container,
+		store:     store,
+	}
+}
+
+// List lists the devices a user trusts.
+func (s *TrustedDevice) List(ctx context.Context, userID string) ([]*model.TrustedDevice, error) {
+	devices, err := s.store.TrustedDevice.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return devices, nil
+}
+
+// Revoke stops trusting a device of a user.
+func (s *TrustedDevice) Revoke(ctx context.Context, userID, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return httpx.ErrTrustedDeviceNotFound
+	}
+
+	deleted, err := s.store.TrustedDevice.Delete(ctx, id, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if !deleted {
+		return httpx.ErrTrustedDeviceNotFound
+	}
+
+	return auditLog(ctx, s.store, types.ResourceTwoFactor, types.ActionDelete, userID, userID, map[string]any{"trusted_device_id": id})
+}
+
+// RevokeAll stops trusting every device of a user.
+func (s *TrustedDevice) RevokeAll(ctx context.Context, userID string) error {
+	if err := s.store.TrustedDevice.DeleteByUserID(ctx, userID); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return auditLog(ctx, s.store, types.ResourceTwoFactor, types.ActionDelete, userID, userID, map[string]any{"trusted_device_id": "all"})
+}
+
+// Trust trusts the device of the request for TrustedDeviceDuration, and
+// returns it with the value of its cookie.
+func (s *TrustedDevice) Trust(ctx context.Context, userID string) (*model.TrustedDevice, string, error) {
+	key, err := trustedDeviceKey(s.Container)
+	if err != nil {
+		return nil, "", httpx.ErrUnknown.WithInternal(err)
+	}
+
+	device := &model.TrustedDevice{
+		UserID:      userID,
+		DeviceClass: model.DeviceClassUnknown,
+		ExpiresAt:   time.Now().Add(model.TrustedDeviceDuration),
+	}
+	if reqMetadata := middleware.GetRequestMetadata(ctx); reqMetadata != nil {
+		device.DeviceClass = model.DeviceClass(reqMetadata.UserAgent)
+		device.IPAddress = &reqMetadata.IPAddress
+		device.Country = &reqMetadata.Country
+		device.UserAgent = &reqMetadata.UserAgent
+	}
+
+	device, err = s.store.TrustedDevice.Create(ctx, device)
+	if err != nil {
+		return nil, "", httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"trusted_device_id": device.ID,
+		"device_class":      device.DeviceClass,
+		"expires_at":        device.ExpiresAt,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceTwoFactor, types.ActionCreate, userID, userID, metadata); err != nil {
+		return nil, "", err
+	}
+
+	return device, signTrustedDevice(key, device), nil
+}
+
+// checkTrustedDevice returns the trusted device of a cookie if it lets the
+// user skip two-factor authentication from the device of the request, or nil.
+// Failures are logged only, as the user can still verify their second factor.
+func checkTrustedDevice(ctx context.Context, container *app.Container, store *store.Manager, userID, cookie string) *model.TrustedDevice {
+	id, _, ok := strings.Cut(cookie, ".")
+	if !ok {
+		return nil
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil
+	}
+
+	key, err := trustedDeviceKey(container)
+	if err != nil {
+		container.Logger.Error("Failed to decode trusted device key", "error", err)
+		return nil
+	}
+
+	device, err := store.TrustedDevice.Get(ctx, id)
+	if err != nil {
+		container.Logger.Error("Failed to get trusted device", "error", err)
+		return nil
+	}
+	if device == nil || device.UserID != userID || device.IsExpired(time.Now()) {
+		return nil
+	}
+
+	// The cookie is only valid from the class of device it was issued to
+	deviceClass := model.DeviceClassUnknown
+	if reqMetadata := middleware.GetRequestMetadata(ctx); reqMetadata != nil {
+		deviceClass = model.DeviceClass(reqMetadata.UserAgent)
+	}
+	if deviceClass != device.DeviceClass || !hmac.Equal([]byte(cookie), []byte(signTrustedDevice(key, device))) {
+		return nil
+	}
+
+	if err := store.TrustedDevice.Touch(ctx, device.ID); err != nil {
+		container.Logger.Warn("Failed to record trusted device use", "trusted_device_id", device.ID, "error", err)
+	}
+
+	return device
+}
+
+// signTrustedDevice returns the cookie value of a trusted device: its ID and
+// a signature binding it to its user, class of device and expiry.
+func signTrustedDevice(key []byte, device *model.TrustedDevice) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s|%s|%s|%d", device.ID, device.UserID, device.DeviceClass, device.ExpiresAt.Unix())
+
+	return device.ID + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// trustedDeviceKey decodes the key signing the trusted device cookies.
+func trustedDeviceKey(container *app.Container) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(container.Config.Identity.TrustedDeviceKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding trusted device key: %w", err)
+	}
+
+	return key, nil
+}
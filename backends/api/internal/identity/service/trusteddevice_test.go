@@ -0,0 +1,119 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/middleware"
+	"autopilot/backends/internal/core"
+	"context"
+	"encoding/base64"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckTrustedDevice(t *testing.T) {
+	t.Parallel()
+
+	const desktop = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) AppleWebKit/605.1.15 Safari/605.1.15"
+	const mobile = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) Mobile/15E148 Safari/604.1"
+
+	key := []byte("test-trusted-device-key")
+	container := &app.Container{
+		Config: &app.Config{},
+		Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard}),
+	}
+	container.Config.Identity.TrustedDeviceKey = base64.StdEncoding.EncodeToString(key)
+
+	device := &model.TrustedDevice{
+		ID:          "01948450-988e-7976-a454-000000000001",
+		UserID:      "user",
+		DeviceClass: model.DeviceClassDesktop,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	cookie := signTrustedDevice(key, device)
+
+	expired := *device
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		userID    string
+		userAgent string
+		cookie    string
+		stored    *model.TrustedDevice
+		want      bool
+	}{
+		{
+			name:      "should trust a signed cookie from the device class it was issued to",
+			userID:    "user",
+			userAgent: desktop,
+			cookie:    cookie,
+			stored:    device,
+			want:      true,
+		},
+		{
+			name:      "should not trust the cookie from another device class",
+			userID:    "user",
+			userAgent: mobile,
+			cookie:    cookie,
+			stored:    device,
+		},
+		{
+			name:      "should not trust the cookie for another user",
+			userID:    "other",
+			userAgent: desktop,
+			cookie:    cookie,
+			stored:    device,
+		},
+		{
+			name:      "should not trust a tampered signature",
+			userID:    "user",
+			userAgent: desktop,
+			cookie:    device.ID + ".tampered",
+			stored:    device,
+		},
+		{
+			name:      "should not trust an expired device",
+			userID:    "user",
+			userAgent: desktop,
+			cookie:    signTrustedDevice(key, &expired),
+			stored:    &expired,
+		},
+		{
+			name:      "should not trust a revoked device",
+			userID:    "user",
+			userAgent: desktop,
+			cookie:    cookie,
+		},
+		{
+			name:      "should not trust a malformed cookie",
+			userID:    "user",
+			userAgent: desktop,
+			cookie:    "malformed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			trustedDeviceStore := mocks.NewMockTrustedDevicer(t)
+			if tt.cookie != "malformed" {
+				trustedDeviceStore.EXPECT().Get(mock.Anything, device.ID).Return(tt.stored, nil)
+			}
+			if tt.want {
+				trustedDeviceStore.EXPECT().Touch(mock.Anything, device.ID).Return(nil)
+			}
+
+			ctx := middleware.AttachRequestMetadata(context.Background(), "192.0.2.1", tt.userAgent, "NZ")
+			got := checkTrustedDevice(ctx, container, &store.Manager{TrustedDevice: trustedDeviceStore}, tt.userID, tt.cookie)
+			assert.Equal(t, tt.want, got != nil)
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/skip2/go-qrcode"
 )
 
@@ -65,12 +66,22 @@ func (s *TwoFactor) Disable(ctx context.Context, userID string) error {
 		"had_backup_codes": len(twoFactor.BackupCodes),
 		"was_enabled_at":   twoFactor.EnabledAt,
 	}
-	if err := auditLog(ctx, s.store, types.ResourceTwoFactor, types.ActionDisable, twoFactor.ID, userID, metadata); err != nil {
+	before := map[string]any{"enabled": true}
+	after := map[string]any{"enabled": false}
+	if err := auditLogChange(ctx, s.store, types.ResourceTwoFactor, types.ActionDisable, twoFactor.ID, userID, before, after, metadata); err != nil {
 		return err
 	}
 
-	// Delete TwoFactor record - this is all we need to do to disable 2FA
-	if err := s.store.TwoFactor.Delete(ctx, twoFactor.ID); err != nil {
+	// Delete TwoFactor record and end the trust in the user's devices, so a
+	// new setup starts over
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := s.store.TwoFactor.WithQuerier(tx).Delete(ctx, twoFactor.ID); err != nil {
+			return err
+		}
+
+		return s.store.TrustedDevice.WithQuerier(tx).DeleteByUserID(ctx, userID)
+	})
+	if err != nil {
 		return httpx.ErrUnknown.WithInternal(err)
 	}
 
@@ -116,7 +127,9 @@ func (s *TwoFactor) Enable(ctx context.Context, userID string, code string) erro
 		"success":            true,
 		"backup_codes_count": len(twoFactor.BackupCodes),
 	}
-	if err := auditLog(ctx, s.store, types.ResourceTwoFactor, types.ActionEnable, twoFactor.ID, userID, metadata); err != nil {
+	before := map[string]any{"enabled": false}
+	after := map[string]any{"enabled": true}
+	if err := auditLogChange(ctx, s.store, types.ResourceTwoFactor, types.ActionEnable, twoFactor.ID, userID, before, after, metadata); err != nil {
 		return err
 	}
 
@@ -6,9 +6,14 @@ import (
 	"autopilot/backends/api/pkg/app"
 	"autopilot/backends/api/pkg/httpx"
 	"autopilot/backends/api/pkg/middleware"
+	"autopilot/backends/internal/core"
 	"autopilot/backends/internal/types"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -18,11 +23,26 @@ import (
 
 // Userer is an interface that wraps the User methods
 type Userer interface {
+	CancelDeletion(ctx context.Context, userID string) error
+	ConfirmEmailChange(ctx context.Context, token string, sessionToken string) error
 	Create(ctx context.Context, user *model.User, password string) (*model.User, error)
+	DeleteExpiredVerifications(ctx context.Context) error
+	DeleteScheduled(ctx context.Context) error
+	ExportData(ctx context.Context, userID string, locale string) error
 	GetByID(ctx context.Context, id string) (*model.User, error)
+	GetEmailVerification(ctx context.Context, token string) (*model.Verification, error)
 	Update(ctx context.Context, user *model.User) (*model.User, error)
 	InitiatePasswordReset(ctx context.Context, email string) error
+	ProcessImage(ctx context.Context, userID string, imageID string) error
+	RequestDataExport(ctx context.Context, userID string) error
+	RequestEmailChange(ctx context.Context, userID string, password string, newEmail string) error
+	ResendVerification(ctx context.Context, email string) error
 	ResetPassword(ctx context.Context, token string, newPassword string) error
+	RevertEmailChange(ctx context.Context, token string) (string, error)
+	ScheduleDeletion(ctx context.Context, userID string, password string) (*model.User, error)
+	Unlock(ctx context.Context, token string) error
+	UnlockMember(ctx context.Context, entityID, actorID, userID string) error
+	UpdateImage(ctx context.Context, userID string, data []byte) error
 	UpdatePassword(ctx context.Context, userID string, currentPassword string, newPassword string) error
 	VerifyEmail(ctx context.Context, token string) error
 }
@@ -43,6 +63,10 @@ func NewUser(container *app.Container, store *store.Manager) *User {
 
 // Create creates a new user.
 func (s *User) Create(ctx context.Context, user *model.User, password string) (*model.User, error) {
+	if err := checkPassword(ctx, s.store, user, password); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), model.PasswordHashBcryptCost)
 	if err != nil {
@@ -62,7 +86,17 @@ func (s *User) Create(ctx context.Context, user *model.User, password string) (*
 		return nil, httpx.ErrEmailExists
 	}
 
-	created, err := s.store.User.Create(ctx, user)
+	// Create the user and start their password history within transaction
+	var created *model.User
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		var err error
+		created, err = s.store.User.WithQuerier(tx).Create(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		return recordPassword(ctx, s.store.PasswordPolicy.WithQuerier(tx), created.ID, *created.PasswordHash)
+	})
 	if err != nil {
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
@@ -111,6 +145,7 @@ func (s *User) Update(ctx context.Context, u *model.User) (*model.User, error) {
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
 
+	before := map[string]any{"name": user.Name, "image": deref(user.Image)}
 	if u.Name != "" {
 		user.Name = u.Name
 	}
@@ -121,12 +156,57 @@ func (s *User) Update(ctx context.Context, u *model.User) (*model.User, error) {
 		return nil, httpx.ErrUnknown.WithInternal(err)
 	}
 
-	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionUpdate, u.ID, u.ID, nil); err != nil {
+	after := map[string]any{"name": user.Name, "image": deref(user.Image)}
+	if err := auditLogChange(ctx, s.store, types.ResourceUser, types.ActionUpdate, u.ID, u.ID, before, after, nil); err != nil {
 		return nil, err
 	}
 	return user, nil
 }
 
+// UpdateImage stores a new profile image of a user and queues its processing.
+// The image of the user is replaced once its variants are ready.
+func (s *User) UpdateImage(ctx context.Context, userID string, data []byte) error {
+	imageID, err := uploadImage(ctx, s.Container, data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.Worker.Insert(ctx, ImageProcessArgs{
+		ImageID:    imageID,
+		Resource:   types.ResourceUser,
+		ResourceID: userID,
+	}, nil); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return nil
+}
+
+// ProcessImage processes an uploaded profile image into its variants and sets
+// the stable URL of the image on the user.
+func (s *User) ProcessImage(ctx context.Context, userID string, imageID string) error {
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		deleteImageUpload(ctx, s.Container, imageID)
+		return nil
+	}
+
+	if err := processImage(ctx, s.Container, imageID); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	image := model.ImageURL(s.Config.App.BaseURL, imageID)
+	if _, err := s.Update(ctx, &model.User{ID: userID, Image: &image}); err != nil {
+		return err
+	}
+
+	deleteImageUpload(ctx, s.Container, imageID)
+	return nil
+}
+
 // VerifyEmail verifies a user's email address.
 func (s *User) VerifyEmail(ctx context.Context, token string) error {
 	// Get verification
@@ -181,6 +261,69 @@ func (s *User) VerifyEmail(ctx context.Context, token string) error {
 	return nil
 }
 
+// GetEmailVerification returns the outstanding email verification of a token,
+// or nil if it doesn't exist or has expired.
+func (s *User) GetEmailVerification(ctx context.Context, token string) (*model.Verification, error) {
+	verification, err := s.store.User.GetVerification(ctx, model.VerificationContextEmailVerification, token)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if verification == nil || verification.IsExpired() {
+		return nil, nil
+	}
+
+	return verification, nil
+}
+
+// ResendVerification sends a new verification email to an unverified user,
+// invalidating the links of the previous ones. Nothing is revealed about
+// whether the account exists or is verified, and a new email is sent at most
+// once every EmailVerificationResendInterval.
+func (s *User) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.store.User.GetByEmail(ctx, email)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil || user.IsEmailVerified() {
+		return nil
+	}
+
+	latest, err := s.store.User.GetVerificationByValue(ctx, model.VerificationContextEmailVerification, user.Email)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if latest != nil && time.Since(latest.CreatedAt) < model.EmailVerificationResendInterval {
+		return nil
+	}
+
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		txStore := store.NewManager(tx)
+
+		if err := txStore.Verification.DeleteByValue(ctx, model.VerificationContextEmailVerification, user.Email); err != nil {
+			return err
+		}
+
+		return createEmailVerification(ctx, txStore, s.Container, user.Email, user)
+	})
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredVerifications removes all expired verifications, which can no
+// longer be used.
+func (s *User) DeleteExpiredVerifications(ctx context.Context) error {
+	deleted, err := s.store.Verification.DeleteExpired(ctx)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	s.Logger.Info("Deleted expired verifications", "count", deleted)
+	return nil
+}
+
 // InitiatePasswordReset starts the password reset process for a user
 func (s *User) InitiatePasswordReset(ctx context.Context, email string) error {
 	// Check if user exists
@@ -211,10 +354,11 @@ func (s *User) InitiatePasswordReset(ctx context.Context, email string) error {
 	// Create audit log for password reset initiation
 	metadata := map[string]any{
 		"email":           email,
+		"reason":          "password_reset_requested",
 		"verification_id": verification.ID,
 		"expiration_time": verification.ExpiresAt,
 	}
-	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionCreate, verification.ID, user.ID, metadata); err != nil {
+	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionUpdate, user.ID, user.ID, metadata); err != nil {
 		return err
 	}
 
@@ -273,16 +417,26 @@ func (s *User) ResetPassword(ctx context.Context, token string, newPassword stri
 		return httpx.ErrUserNotFound
 	}
 
+	if err := checkPassword(ctx, s.store, user, newPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), model.PasswordHashBcryptCost)
 	if err != nil {
 		return httpx.ErrUnknown.WithInternal(err)
 	}
 
+	now := time.Now()
+	before := map[string]any{
+		"failed_login_attempts": user.FailedLoginAttempts,
+		"locked":                user.IsLocked(now),
+		"password_changed_at":   user.PasswordChangedAt,
+	}
+
 	user.PasswordHash = &[]string{string(hashedPassword)}[0]
-	user.UpdatedAt = time.Now()
-	user.FailedLoginAttempts = 0 // Reset failed login attempts
-	user.LockedAt = nil          // Remove account lock
+	user.PasswordChangedAt = &now
+	user.Unlock(now) // Reset failed login attempts and remove account lock
 
 	// Update password and delete verification within transaction
 	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
@@ -293,6 +447,15 @@ func (s *User) ResetPassword(ctx context.Context, token string, newPassword stri
 			return err
 		}
 
+		if err := recordPassword(ctx, s.store.PasswordPolicy.WithQuerier(tx), user.ID, *user.PasswordHash); err != nil {
+			return err
+		}
+
+		// A new password ends the trust in the user's devices
+		if err := s.store.TrustedDevice.WithQuerier(tx).DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+
 		if err := v.Delete(ctx, verification.ID); err != nil {
 			return err
 		}
@@ -303,14 +466,112 @@ func (s *User) ResetPassword(ctx context.Context, token string, newPassword stri
 		return httpx.ErrUnknown.WithInternal(err)
 	}
 
-	// Create audit log for password reset completion
+	// Create audit log for password reset completion, the changes show whether
+	// the reset also unlocked the account and cleared failed attempts
+	metadata := map[string]any{
+		"verification_id": verification.ID,
+		"reset_at":        now,
+	}
+	after := map[string]any{
+		"failed_login_attempts": user.FailedLoginAttempts,
+		"locked":                false,
+		"password_changed_at":   user.PasswordChangedAt,
+	}
+	if err := auditLogChange(ctx, s.store, types.ResourceUser, types.ActionResetPassword, user.ID, user.ID, before, after, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Unlock handles the unlock link of a lockout email. It removes the lock and
+// resets the failed sign-in attempts of the account.
+func (s *User) Unlock(ctx context.Context, token string) error {
+	verification, err := s.store.User.GetVerification(ctx, model.VerificationContextAccountUnlock, token)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if verification == nil || verification.IsExpired() {
+		return httpx.ErrInvalidOrExpiredToken
+	}
+
+	user, err := s.store.User.GetByID(ctx, verification.Value)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return httpx.ErrUserNotFound
+	}
+
 	metadata := map[string]any{
 		"verification_id": verification.ID,
-		"reset_at":        time.Now(),
-		"unlocked":        user.LockedAt != nil,         // Whether this reset also unlocked the account
-		"attempts_reset":  user.FailedLoginAttempts > 0, // Whether failed attempts were reset
 	}
-	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionResetPassword, user.ID, user.ID, metadata); err != nil {
+	return s.unlock(ctx, user, user.ID, metadata)
+}
+
+// UnlockMember unlocks the account of a member of an entity on behalf of an
+// owner of the entity, such as when the member can't reach their inbox.
+func (s *User) UnlockMember(ctx context.Context, entityID, actorID, userID string) error {
+	membership, err := s.store.Membership.GetByEntityIDAndUserID(ctx, entityID, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if membership == nil {
+		return httpx.ErrUserNotFound
+	}
+
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return httpx.ErrUserNotFound
+	}
+
+	metadata := map[string]any{
+		"entity_id": entityID,
+	}
+	return s.unlock(ctx, user, actorID, metadata)
+}
+
+// unlock removes the lock of an account together with its unlock link, and
+// audit logs the change.
+func (s *User) unlock(ctx context.Context, user *model.User, actorID string, metadata map[string]any) error {
+	now := time.Now()
+	before := map[string]any{
+		"failed_login_attempts": user.FailedLoginAttempts,
+		"locked":                user.IsLocked(now),
+		"lockout_count":         user.LockoutCount,
+	}
+	user.Unlock(now)
+
+	err := s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		u := s.store.User.WithQuerier(tx)
+
+		if err := u.Update(ctx, user); err != nil {
+			return err
+		}
+
+		verification, err := u.GetVerificationByValue(ctx, model.VerificationContextAccountUnlock, user.ID)
+		if err != nil {
+			return err
+		}
+		if verification != nil {
+			return u.DeleteVerification(ctx, verification.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	after := map[string]any{
+		"failed_login_attempts": user.FailedLoginAttempts,
+		"locked":                false,
+		"lockout_count":         user.LockoutCount,
+	}
+	if err := auditLogChange(ctx, s.store, types.ResourceUser, types.ActionUnlock, user.ID, actorID, before, after, metadata); err != nil {
 		return err
 	}
 
@@ -334,6 +595,10 @@ func (s *User) UpdatePassword(ctx context.Context, userID string, currentPasswor
 		return httpx.ErrInvalidCredentials
 	}
 
+	if err := checkPassword(ctx, s.store, user, newPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), model.PasswordHashBcryptCost)
 	if err != nil {
@@ -342,10 +607,24 @@ func (s *User) UpdatePassword(ctx context.Context, userID string, currentPasswor
 
 	// Update user password within transaction
 	now := time.Now()
+	before := map[string]any{"password_changed_at": user.PasswordChangedAt}
 	user.PasswordHash = &[]string{string(hashedPassword)}[0]
+	user.PasswordChangedAt = &now
 	user.UpdatedAt = now
 
-	if err := s.store.User.Update(ctx, user); err != nil {
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		if err := s.store.User.WithQuerier(tx).Update(ctx, user); err != nil {
+			return err
+		}
+
+		// A new password ends the trust in the user's devices
+		if err := s.store.TrustedDevice.WithQuerier(tx).DeleteByUserID(ctx, user.ID); err != nil {
+			return err
+		}
+
+		return recordPassword(ctx, s.store.PasswordPolicy.WithQuerier(tx), user.ID, *user.PasswordHash)
+	})
+	if err != nil {
 		return httpx.ErrUnknown.WithInternal(err)
 	}
 
@@ -353,9 +632,620 @@ func (s *User) UpdatePassword(ctx context.Context, userID string, currentPasswor
 	metadata := map[string]any{
 		"updated_at": now,
 	}
+	after := map[string]any{"password_changed_at": user.PasswordChangedAt}
+	if err := auditLogChange(ctx, s.store, types.ResourceUser, types.ActionUpdate, user.ID, user.ID, before, after, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RequestEmailChange starts an email change by sending a confirmation link to the
+// new address and a notice to the current one.
+func (s *User) RequestEmailChange(ctx context.Context, userID string, password string, newEmail string) error {
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return httpx.ErrUserNotFound
+	}
+
+	if !user.VerifyPassword(password) {
+		return httpx.ErrInvalidCredentials
+	}
+
+	if strings.EqualFold(user.Email, newEmail) {
+		return httpx.ErrEmailExists
+	}
+
+	exists, err := s.store.User.ExistsByEmail(ctx, newEmail)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if exists {
+		return httpx.ErrEmailExists
+	}
+
+	now := time.Now()
+	verification, err := s.store.User.CreateVerification(ctx, &model.Verification{
+		Context:   model.VerificationContextEmailChange,
+		Value:     model.NewUserScopedValue(user.ID, newEmail),
+		ExpiresAt: now.Add(model.EmailChangeDuration),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"new_email":       newEmail,
+		"reason":          "email_change_requested",
+		"verification_id": verification.ID,
+		"expiration_time": verification.ExpiresAt,
+	}
 	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionUpdate, user.ID, user.ID, metadata); err != nil {
 		return err
 	}
 
+	queueMail(ctx, s.Container, "email_change", newEmail, fmt.Sprintf("Confirm your new %s email address", s.Config.App.Name), map[string]any{
+		"ConfirmURL": fmt.Sprintf("%s/confirm-email-change?token=%s", s.Config.App.DashboardURL, verification.ID),
+		"Duration":   model.EmailChangeDuration.Hours(),
+		"Email":      user.Email,
+		"Name":       user.Name,
+		"NewEmail":   newEmail,
+	})
+
+	queueMail(ctx, s.Container, "email_change_requested", user.Email, fmt.Sprintf("Your %s email address is being changed", s.Config.App.Name), map[string]any{
+		"Email":    user.Email,
+		"Name":     user.Name,
+		"NewEmail": newEmail,
+	})
+
+	return nil
+}
+
+// ConfirmEmailChange completes an email change. All sessions other than the one
+// identified by sessionToken are invalidated and the previous address receives a
+// link to revert the change.
+func (s *User) ConfirmEmailChange(ctx context.Context, token string, sessionToken string) error {
+	verification, err := s.store.User.GetVerification(ctx, model.VerificationContextEmailChange, token)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if verification == nil || verification.IsExpired() {
+		return httpx.ErrInvalidOrExpiredToken
+	}
+
+	userID, newEmail, ok := verification.UserScopedValue()
+	if !ok {
+		return httpx.ErrInvalidOrExpiredToken
+	}
+
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return httpx.ErrUserNotFound
+	}
+
+	// The address may have been taken since the change was requested
+	exists, err := s.store.User.ExistsByEmail(ctx, newEmail)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if exists {
+		return httpx.ErrEmailExists
+	}
+
+	now := time.Now()
+	oldEmail := user.Email
+	user.Email = newEmail
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+
+	revert := &model.Verification{
+		Context:   model.VerificationContextEmailChangeRevert,
+		Value:     model.NewUserScopedValue(user.ID, oldEmail),
+		ExpiresAt: now.Add(model.EmailChangeRevertDuration),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Swap the email, invalidate other sessions and issue the revert link within transaction
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		u := s.store.User.WithQuerier(tx)
+
+		if err := u.Update(ctx, user); err != nil {
+			return err
+		}
+
+		if err := s.store.Session.WithQuerier(tx).InvalidateByUserID(ctx, user.ID, sessionToken); err != nil {
+			return err
+		}
+
+		if err := u.DeleteVerification(ctx, verification.ID); err != nil {
+			return err
+		}
+
+		revert, err = u.CreateVerification(ctx, revert)
+		return err
+	})
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	invalidateCachedSessions(ctx, s.Container, user.ID)
+
+	metadata := map[string]any{
+		"old_email":       oldEmail,
+		"new_email":       newEmail,
+		"verification_id": verification.ID,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionUpdate, user.ID, user.ID, metadata); err != nil {
+		return err
+	}
+
+	queueMail(ctx, s.Container, "email_changed", oldEmail, fmt.Sprintf("Your %s email address was changed", s.Config.App.Name), map[string]any{
+		"Duration":  model.EmailChangeRevertDuration.Hours() / 24,
+		"Email":     oldEmail,
+		"Name":      user.Name,
+		"NewEmail":  newEmail,
+		"RevertURL": fmt.Sprintf("%s/revert-email-change?token=%s", s.Config.App.DashboardURL, revert.ID),
+	})
+
+	return nil
+}
+
+// RevertEmailChange restores the previous email address of a user. As the change
+// was not made by the owner of the account, all sessions are revoked and the
+// password is cleared. A password reset token is returned to choose a new one.
+func (s *User) RevertEmailChange(ctx context.Context, token string) (string, error) {
+	verification, err := s.store.User.GetVerification(ctx, model.VerificationContextEmailChangeRevert, token)
+	if err != nil {
+		return "", httpx.ErrUnknown.WithInternal(err)
+	}
+	if verification == nil || verification.IsExpired() {
+		return "", httpx.ErrInvalidOrExpiredToken
+	}
+
+	userID, oldEmail, ok := verification.UserScopedValue()
+	if !ok {
+		return "", httpx.ErrInvalidOrExpiredToken
+	}
+
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return "", httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return "", httpx.ErrUserNotFound
+	}
+
+	exists, err := s.store.User.ExistsByEmail(ctx, oldEmail)
+	if err != nil {
+		return "", httpx.ErrUnknown.WithInternal(err)
+	}
+	if exists {
+		return "", httpx.ErrEmailExists
+	}
+
+	now := time.Now()
+	newEmail := user.Email
+	user.Email = oldEmail
+	user.EmailVerifiedAt = &now
+	user.PasswordHash = nil
+	user.UpdatedAt = now
+
+	reset := &model.Verification{
+		Context:   model.VerificationContextPasswordReset,
+		Value:     oldEmail,
+		ExpiresAt: now.Add(model.PasswordResetDuration),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Restore the email, revoke all sessions and issue the reset token within transaction
+	err = s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+		u := s.store.User.WithQuerier(tx)
+
+		if err := u.Update(ctx, user); err != nil {
+			return err
+		}
+
+		// An empty token matches no session, so every session of the user is revoked
+		if err := s.store.Session.WithQuerier(tx).InvalidateByUserID(ctx, user.ID, ""); err != nil {
+			return err
+		}
+
+		if err := u.DeleteVerification(ctx, verification.ID); err != nil {
+			return err
+		}
+
+		reset, err = u.CreateVerification(ctx, reset)
+		return err
+	})
+	if err != nil {
+		return "", httpx.ErrUnknown.WithInternal(err)
+	}
+	invalidateCachedSessions(ctx, s.Container, user.ID)
+
+	metadata := map[string]any{
+		"old_email":       newEmail,
+		"new_email":       oldEmail,
+		"reason":          "email_change_reverted",
+		"verification_id": verification.ID,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionUpdate, user.ID, user.ID, metadata); err != nil {
+		return "", err
+	}
+
+	return reset.ID, nil
+}
+
+// dataExport is the JSON archive of the personal data held about a user.
+type dataExport struct {
+	ExportedAt    time.Time                 `json:"exportedAt"`
+	Profile       dataExportProfile         `json:"profile"`
+	Memberships   []dataExportMembership    `json:"memberships"`
+	Sessions      []dataExportSession       `json:"sessions"`
+	SignInHistory []dataExportSignInHistory `json:"signInHistory"`
+	AuditLogs     []dataExportAuditLog      `json:"auditLogs"`
+}
+
+type dataExportProfile struct {
+	ID                  string     `json:"id"`
+	Name                string     `json:"name"`
+	Email               string     `json:"email"`
+	EmailVerifiedAt     *time.Time `json:"emailVerifiedAt"`
+	Image               *string    `json:"image"`
+	LastActiveAt        *time.Time `json:"lastActiveAt"`
+	LastLoggedInAt      *time.Time `json:"lastLoggedInAt"`
+	PasswordChangedAt   *time.Time `json:"passwordChangedAt"`
+	DeletionScheduledAt *time.Time `json:"deletionScheduledAt"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+type dataExportMembership struct {
+	ID        string     `json:"id"`
+	EntityID  *string    `json:"entityId"`
+	Role      types.Role `json:"role"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type dataExportSession struct {
+	ID        string    `json:"id"`
+	IPAddress *string   `json:"ipAddress"`
+	Country   *string   `json:"country"`
+	UserAgent *string   `json:"userAgent"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type dataExportSignInHistory struct {
+	Country     string    `json:"country"`
+	DeviceClass string    `json:"deviceClass"`
+	FirstSeenAt time.Time `json:"firstSeenAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+}
+
+type dataExportAuditLog struct {
+	ID           string          `json:"id"`
+	Action       types.Action    `json:"action"`
+	ResourceType types.Resource  `json:"resourceType"`
+	ResourceID   string          `json:"resourceId"`
+	IPAddress    *string         `json:"ipAddress"`
+	UserAgent    *string         `json:"userAgent"`
+	Changes      json.RawMessage `json:"changes"`
+	Metadata     json.RawMessage `json:"metadata"`
+	CreatedAt    time.Time       `json:"createdAt"`
+}
+
+// RequestDataExport queues the export of the personal data of a user. The
+// download link is emailed once the archive is ready.
+func (s *User) RequestDataExport(ctx context.Context, userID string) error {
+	if _, err := s.Worker.Insert(ctx, DataExportArgs{
+		Locale: middleware.GetLocale(ctx),
+		UserID: userID,
+	}, nil); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionExport, userID, userID, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ExportData collects the profile, memberships, sessions, sign-in history and
+// audit logs of a user into a JSON archive and emails a signed download link
+// to the user.
+func (s *User) ExportData(ctx context.Context, userID string, locale string) error {
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return httpx.ErrUserNotFound
+	}
+
+	memberships, err := s.store.Membership.GetByUserID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	sessions, err := s.store.Session.ListByUser(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	history, err := s.store.SignInHistory.ListByUserID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	logs, err := s.store.AuditLog.ListByUser(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	export := dataExport{
+		ExportedAt: time.Now(),
+		Profile: dataExportProfile{
+			ID:                  user.ID,
+			Name:                user.Name,
+			Email:               user.Email,
+			EmailVerifiedAt:     user.EmailVerifiedAt,
+			Image:               user.Image,
+			LastActiveAt:        user.LastActiveAt,
+			LastLoggedInAt:      user.LastLoggedInAt,
+			PasswordChangedAt:   user.PasswordChangedAt,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+			CreatedAt:           user.CreatedAt,
+			UpdatedAt:           user.UpdatedAt,
+		},
+		Memberships:   make([]dataExportMembership, 0, len(memberships)),
+		Sessions:      make([]dataExportSession, 0, len(sessions)),
+		SignInHistory: make([]dataExportSignInHistory, 0, len(history)),
+		AuditLogs:     make([]dataExportAuditLog, 0, len(logs)),
+	}
+
+	for _, m := range memberships {
+		export.Memberships = append(export.Memberships, dataExportMembership{
+			ID:        m.ID,
+			EntityID:  m.EntityID,
+			Role:      m.Role,
+			CreatedAt: m.CreatedAt,
+		})
+	}
+
+	// Tokens are credentials, not personal data, and are left out
+	for _, session := range sessions {
+		export.Sessions = append(export.Sessions, dataExportSession{
+			ID:        session.ID,
+			IPAddress: session.IPAddress,
+			Country:   session.Country,
+			UserAgent: session.UserAgent,
+			ExpiresAt: session.ExpiresAt,
+			CreatedAt: session.CreatedAt,
+		})
+	}
+
+	for _, entry := range history {
+		export.SignInHistory = append(export.SignInHistory, dataExportSignInHistory{
+			Country:     entry.Country,
+			DeviceClass: entry.DeviceClass,
+			FirstSeenAt: entry.FirstSeenAt,
+			LastSeenAt:  entry.LastSeenAt,
+		})
+	}
+
+	for _, log := range logs {
+		export.AuditLogs = append(export.AuditLogs, dataExportAuditLog{
+			ID:           log.ID,
+			Action:       log.Action,
+			ResourceType: log.ResourceType,
+			ResourceID:   log.ResourceID,
+			IPAddress:    log.IPAddress,
+			UserAgent:    log.UserAgent,
+			Changes:      log.Changes,
+			Metadata:     log.Metadata,
+			CreatedAt:    log.CreatedAt,
+		})
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	token, err := generateSecureToken(16)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	key := fmt.Sprintf("exports/%s/%s.json", user.ID, strings.TrimRight(token, "="))
+	if _, err := s.Storage.Identity.Upload(ctx, key, bytes.NewReader(data), &core.ObjectMetadata{
+		ContentType: "application/json",
+	}); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	download, err := s.Storage.Identity.GenerateDownloadURL(ctx, key, model.DataExportLinkDuration)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	// Jobs run outside of a request, so the locale of the requester is restored
+	ctx = context.WithValue(ctx, middleware.LocaleKey, locale)
+	queueMail(ctx, s.Container, "data_export", user.Email, fmt.Sprintf("Your %s data export is ready", s.Config.App.Name), map[string]any{
+		"DownloadURL": download.URL,
+		"Duration":    model.DataExportLinkDuration.Hours() / 24,
+		"Email":       user.Email,
+		"Name":        user.Name,
+	})
+
+	return nil
+}
+
+// ScheduleDeletion schedules the deletion of a user account after the grace
+// period. Deletion is refused while the user is the last owner of an entity.
+func (s *User) ScheduleDeletion(ctx context.Context, userID string, password string) (*model.User, error) {
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return nil, httpx.ErrUserNotFound
+	}
+
+	if !user.VerifyPassword(password) {
+		return nil, httpx.ErrInvalidCredentials
+	}
+
+	if user.IsDeletionScheduled() {
+		return user, nil
+	}
+
+	if err := s.checkNotLastOwner(ctx, s.store, user.ID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	scheduledAt := now.Add(model.AccountDeletionGracePeriod)
+	user.DeletionScheduledAt = &scheduledAt
+	user.UpdatedAt = now
+
+	if err := s.store.User.Update(ctx, user); err != nil {
+		return nil, httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"deletion_scheduled_at": scheduledAt,
+	}
+	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionDelete, user.ID, user.ID, metadata); err != nil {
+		return nil, err
+	}
+
+	queueMail(ctx, s.Container, "account_deletion", user.Email, fmt.Sprintf("Your %s account is scheduled for deletion", s.Config.App.Name), map[string]any{
+		"CancelURL": fmt.Sprintf("%s/settings/profile", s.Config.App.DashboardURL),
+		"Duration":  model.AccountDeletionGracePeriod.Hours() / 24,
+		"Email":     user.Email,
+		"Name":      user.Name,
+	})
+
+	return user, nil
+}
+
+// CancelDeletion cancels the scheduled deletion of a user account.
+func (s *User) CancelDeletion(ctx context.Context, userID string) error {
+	user, err := s.store.User.GetByID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+	if user == nil {
+		return httpx.ErrUserNotFound
+	}
+
+	if !user.IsDeletionScheduled() {
+		return nil
+	}
+
+	user.DeletionScheduledAt = nil
+	user.UpdatedAt = time.Now()
+
+	if err := s.store.User.Update(ctx, user); err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	metadata := map[string]any{
+		"reason": "deletion_cancelled",
+	}
+	if err := auditLog(ctx, s.store, types.ResourceUser, types.ActionUpdate, user.ID, user.ID, metadata); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteScheduled deletes the accounts whose grace period has ended. The audit
+// logs of each user are anonymized and kept. Users who became the last owner
+// of an entity during the grace period are skipped until that is resolved, and
+// failures are logged so they don't hold up the other users.
+func (s *User) DeleteScheduled(ctx context.Context) error {
+	users, err := s.store.User.ListDueForDeletion(ctx, time.Now())
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	for _, user := range users {
+		err := s.DB.Identity.WithTx(ctx, func(ctx context.Context, tx *sqlx.Tx) error {
+			txStore := store.NewManager(tx)
+
+			if err := s.checkNotLastOwner(ctx, txStore, user.ID); err != nil {
+				return err
+			}
+
+			// Logged before the delete, which clears the user reference. Audit
+			// logs are append-only, so the reference can only be cleared once
+			// every entry of the user is anonymized, including this one.
+			if err := auditLog(ctx, txStore, types.ResourceUser, types.ActionDelete, user.ID, user.ID, nil); err != nil {
+				return err
+			}
+
+			if err := txStore.AuditLog.AnonymizeByUser(ctx, user.ID); err != nil {
+				return err
+			}
+
+			return txStore.User.Delete(ctx, user.ID)
+		})
+		if errors.Is(err, httpx.ErrLastEntityOwner) {
+			s.Logger.Warn("Skipping deletion of last entity owner", "user_id", user.ID)
+			continue
+		}
+		if err != nil {
+			// The others are still due, and this one is retried on the next run
+			s.Logger.Error("Failed to delete scheduled user", "user_id", user.ID, "error", err)
+			continue
+		}
+		invalidateCachedSessions(ctx, s.Container, user.ID)
+	}
+
+	return nil
+}
+
+// checkNotLastOwner returns ErrLastEntityOwner if the user is the only owner
+// of any of the entities they belong to.
+func (s *User) checkNotLastOwner(ctx context.Context, store *store.Manager, userID string) error {
+	memberships, err := store.Membership.GetByUserID(ctx, userID)
+	if err != nil {
+		return httpx.ErrUnknown.WithInternal(err)
+	}
+
+	for _, m := range memberships {
+		if m.Role != types.RoleOwner || m.EntityID == nil {
+			continue
+		}
+
+		members, err := store.Membership.GetByEntityID(ctx, *m.EntityID)
+		if err != nil {
+			return httpx.ErrUnknown.WithInternal(err)
+		}
+
+		owners := 0
+		for _, member := range members {
+			if member.Role == types.RoleOwner {
+				owners++
+			}
+		}
+
+		if owners <= 1 {
+			return httpx.ErrLastEntityOwner
+		}
+	}
+
 	return nil
 }
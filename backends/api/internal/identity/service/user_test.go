@@ -0,0 +1,64 @@
+package service
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/api/internal/identity/store"
+	"autopilot/backends/api/internal/identity/store/mocks"
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserResendVerification(t *testing.T) {
+	t.Parallel()
+
+	verifiedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		user   *model.User
+		latest *model.Verification
+	}{
+		{
+			name: "should not reveal an unknown email address",
+		},
+		{
+			name: "should not send an email to a verified user",
+			user: &model.User{ID: "user", Email: "user@example.com", EmailVerifiedAt: &verifiedAt},
+		},
+		{
+			name:   "should not send another email within the resend interval",
+			user:   &model.User{ID: "user", Email: "user@example.com"},
+			latest: &model.Verification{CreatedAt: time.Now().Add(-model.EmailVerificationResendInterval / 2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			userStore := mocks.NewMockUserer(t)
+			userStore.EXPECT().GetByEmail(mock.Anything, "user@example.com").Return(tt.user, nil)
+			if tt.user != nil && !tt.user.IsEmailVerified() {
+				userStore.EXPECT().GetVerificationByValue(mock.Anything, model.VerificationContextEmailVerification, tt.user.Email).Return(tt.latest, nil)
+			}
+
+			s := &User{store: &store.Manager{User: userStore}}
+			err := s.ResendVerification(context.Background(), "user@example.com")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUserUpdateImageInvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	s := &User{Container: &app.Container{Config: &app.Config{}}}
+	err := s.UpdateImage(context.Background(), "user", []byte("not an image"))
+	assert.ErrorIs(t, err, httpx.ErrInvalidImageFormat)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"autopilot/backends/api/pkg/app"
+	"context"
+	"fmt"
+
+	"github.com/riverqueue/river"
+)
+
+// VerificationCleanerArgs is the arguments for the verification cleaner
+type VerificationCleanerArgs struct{}
+
+// Kind returns the kind of the worker
+func (VerificationCleanerArgs) Kind() string {
+	return "verification_cleaner"
+}
+
+// VerificationCleaner is a worker that purges expired verifications periodically
+type VerificationCleaner struct {
+	*app.Container
+	service *Manager
+	river.WorkerDefaults[VerificationCleanerArgs]
+}
+
+// Work is the worker function that purges expired verifications
+func (s *VerificationCleaner) Work(ctx context.Context, job *river.Job[VerificationCleanerArgs]) error {
+	if err := s.service.User.DeleteExpiredVerifications(ctx); err != nil {
+		s.Logger.Error("Failed to delete expired verifications", "error", err)
+		return fmt.Errorf("deleting expired verifications: %w", err)
+	}
+
+	return nil
+}
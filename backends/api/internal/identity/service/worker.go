@@ -9,8 +9,14 @@ import (
 
 // AddWorkers returns the background workers
 func AddWorkers(container *app.Container, workers *river.Workers, serviceManager *Manager) {
+	river.AddWorker(workers, &AccountDeleter{Container: container, service: serviceManager})
+	river.AddWorker(workers, &AuditLogCheckpointer{Container: container, service: serviceManager})
+	river.AddWorker(workers, &AuditLogExporter{Container: container, service: serviceManager})
+	river.AddWorker(workers, &DataExporter{Container: container, service: serviceManager})
+	river.AddWorker(workers, &ImageProcessor{Container: container, service: serviceManager})
 	river.AddWorker(workers, &Mailer{Container: container, service: serviceManager})
 	river.AddWorker(workers, &SessionCleaner{Container: container, service: serviceManager})
+	river.AddWorker(workers, &VerificationCleaner{Container: container, service: serviceManager})
 }
 
 // AddPeriodicJobs returns the periodic jobs
@@ -25,6 +31,33 @@ func AddPeriodicJobs(container *app.Container, serviceManager *Manager) []*river
 				RunOnStart: false,
 			},
 		),
+		river.NewPeriodicJob(
+			river.PeriodicInterval(time.Hour*24),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return AccountDeleterArgs{}, nil
+			},
+			&river.PeriodicJobOpts{
+				RunOnStart: false,
+			},
+		),
+		river.NewPeriodicJob(
+			river.PeriodicInterval(time.Hour*24),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return AuditLogCheckpointArgs{}, nil
+			},
+			&river.PeriodicJobOpts{
+				RunOnStart: false,
+			},
+		),
+		river.NewPeriodicJob(
+			river.PeriodicInterval(time.Hour*12),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return VerificationCleanerArgs{}, nil
+			},
+			&river.PeriodicJobOpts{
+				RunOnStart: false,
+			},
+		),
 	}
 
 	return jobs
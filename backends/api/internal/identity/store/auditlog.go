@@ -4,11 +4,24 @@ import (
 	"autopilot/backends/api/internal/identity/model"
 	"autopilot/backends/internal/core"
 	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 // AuditLoger is the store for audit log operations.
 type AuditLoger interface {
+	AnonymizeByUser(ctx context.Context, userID string) error
 	Create(ctx context.Context, log *model.AuditLog) (*model.AuditLog, error)
+	GetChainHead(ctx context.Context, chain string) (*model.AuditLogChainHead, error)
+	List(ctx context.Context, entityID string, filter model.AuditLogFilter, page model.AuditLogPage) ([]*model.AuditLog, error)
+	ListByUser(ctx context.Context, userID string) ([]*model.AuditLog, error)
+	ListChain(ctx context.Context, chain string, afterSequence int64, limit int) ([]*model.AuditLog, error)
+	ListChainHeads(ctx context.Context) ([]*model.AuditLogChainHead, error)
 	WithQuerier(q core.Querier) AuditLoger
 }
 
@@ -26,43 +39,292 @@ func NewAuditLog(db core.Querier) *AuditLog {
 	return &AuditLog{db}
 }
 
-// Create creates a new audit log entry.
+// auditLogColumns are the columns selected for audit log entries, in the order scanned by scan.
+const auditLogColumns = `
+	id, action, entity_id, resource_type, resource_id, ip_address, metadata, user_agent, user_id, created_at,
+	sequence, previous_hash, personal_hash, hash, anonymized_at, actor_type, actor_id, changes
+`
+
+// Create appends a new audit log entry to the hash chain of its entity. The ID
+// and creation time are set here, as they are part of the hashed contents, and
+// the chain head is advanced in the same statement so concurrent writers queue
+// up on the chain row.
 func (s *AuditLog) Create(ctx context.Context, log *model.AuditLog) (*model.AuditLog, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := *log
+	entry.ID = id.String()
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	personalHash, err := entry.ComputePersonalHash()
+	if err != nil {
+		return nil, err
+	}
+
+	contentHash, err := entry.ComputeContentHash(personalHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Matches model.ChainAuditLogHash
 	query := `
+		WITH head AS (
+			INSERT INTO audit_log_chains AS c (
+				chain_id, sequence, previous_hash, hash
+			) VALUES (
+				$1, 1, $2::text, encode(sha256(convert_to($2::text || $3::text, 'UTF8')), 'hex')
+			)
+			ON CONFLICT (chain_id) DO UPDATE SET
+				sequence = c.sequence + 1,
+				previous_hash = c.hash,
+				hash = encode(sha256(convert_to(c.hash || $3::text, 'UTF8')), 'hex'),
+				updated_at = NOW()
+			RETURNING sequence, previous_hash, hash
+		)
 		INSERT INTO audit_logs (
-			action, resource_type, resource_id, ip_address, metadata, user_agent, user_id
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7
+			id, action, entity_id, resource_type, resource_id, ip_address, metadata, user_agent, user_id, created_at,
+			sequence, previous_hash, personal_hash, hash, actor_type, actor_id, changes
 		)
-		RETURNING
-			id, action, resource_type, resource_id, ip_address, metadata, user_agent, user_id, created_at
-	`
+		SELECT
+			$4::uuid, $5::text, $6::uuid, $7::text, $8::text, $9::text, $10::jsonb, $11::text, $12::uuid, $13::timestamptz,
+			head.sequence, head.previous_hash, $14::text, head.hash, $15::text, $16::uuid, $17::jsonb
+		FROM head
+		RETURNING ` + auditLogColumns
 
-	var created model.AuditLog
-	err := s.QueryRowContext(
+	return s.scan(s.QueryRowContext(
 		ctx,
 		query,
-		log.Action,
-		log.ResourceType,
-		log.ResourceID,
-		log.IPAddress,
-		log.Metadata,
-		log.UserAgent,
-		log.UserID,
-	).Scan(
-		&created.ID,
-		&created.Action,
-		&created.ResourceType,
-		&created.ResourceID,
-		&created.IPAddress,
-		&created.Metadata,
-		&created.UserAgent,
-		&created.UserID,
-		&created.CreatedAt,
-	)
+		entry.Chain(),
+		model.AuditLogGenesisHash,
+		contentHash,
+		entry.ID,
+		entry.Action,
+		entry.EntityID,
+		entry.ResourceType,
+		entry.ResourceID,
+		entry.IPAddress,
+		entry.Metadata,
+		entry.UserAgent,
+		entry.UserID,
+		entry.CreatedAt,
+		personalHash,
+		entry.ActorType,
+		entry.ActorID,
+		entry.Changes,
+	))
+}
+
+// List lists a page of the audit log entries of an entity matching the filter.
+// The entries are returned in page order, newest first unless ascending.
+func (s *AuditLog) List(ctx context.Context, entityID string, filter model.AuditLogFilter, page model.AuditLogPage) ([]*model.AuditLog, error) {
+	conditions := []string{"entity_id = $1"}
+	args := []any{entityID}
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.UserID != "" {
+		where("user_id = $%d", filter.UserID)
+	}
+	if filter.ResourceType != "" {
+		where("resource_type = $%d", filter.ResourceType)
+	}
+	if filter.Action != "" {
+		where("action = $%d", filter.Action)
+	}
+	if filter.From != nil {
+		where("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		where("created_at < $%d", *filter.To)
+	}
+
+	// Paging backwards walks the entries in the opposite order and flips them
+	// back afterwards, so the page is the one adjacent to the cursor.
+	ascending := page.Ascending
+	if page.Before != "" {
+		ascending = !ascending
+		if ascending {
+			where("id > $%d", page.Before)
+		} else {
+			where("id < $%d", page.Before)
+		}
+	} else if page.After != "" {
+		if ascending {
+			where("id > $%d", page.After)
+		} else {
+			where("id < $%d", page.After)
+		}
+	}
+
+	order := "DESC"
+	if ascending {
+		order = "ASC"
+	}
+
+	args = append(args, page.Limit)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM audit_logs
+		WHERE %s
+		ORDER BY id %s
+		LIMIT $%d
+	`, auditLogColumns, strings.Join(conditions, " AND "), order, len(args))
+
+	logs, err := s.list(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Before != "" {
+		slices.Reverse(logs)
+	}
+
+	return logs, nil
+}
+
+// ListByUser lists all audit log entries of a user, oldest first.
+func (s *AuditLog) ListByUser(ctx context.Context, userID string) ([]*model.AuditLog, error) {
+	query := `
+		SELECT ` + auditLogColumns + `
+		FROM
+			audit_logs
+		WHERE
+			user_id = $1
+		ORDER BY created_at
+	`
+
+	return s.list(ctx, query, userID)
+}
+
+// ListChain lists the entries of a hash chain after a sequence, in chain order.
+func (s *AuditLog) ListChain(ctx context.Context, chain string, afterSequence int64, limit int) ([]*model.AuditLog, error) {
+	query := `
+		SELECT ` + auditLogColumns + `
+		FROM audit_logs
+		WHERE COALESCE(entity_id, '00000000-0000-0000-0000-000000000000') = $1
+			AND sequence > $2
+		ORDER BY sequence
+		LIMIT $3
+	`
+
+	return s.list(ctx, query, chain, afterSequence, limit)
+}
+
+// GetChainHead retrieves the head of a hash chain, returning nil if the chain
+// has no entries.
+func (s *AuditLog) GetChainHead(ctx context.Context, chain string) (*model.AuditLogChainHead, error) {
+	query := `SELECT chain_id, sequence, hash, updated_at FROM audit_log_chains WHERE chain_id = $1`
+
+	var head model.AuditLogChainHead
+	err := s.QueryRowContext(ctx, query, chain).Scan(&head.Chain, &head.Sequence, &head.Hash, &head.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &head, nil
+}
+
+// ListChainHeads lists the heads of all hash chains.
+func (s *AuditLog) ListChainHeads(ctx context.Context) ([]*model.AuditLogChainHead, error) {
+	query := `SELECT chain_id, sequence, hash, updated_at FROM audit_log_chains ORDER BY chain_id`
+
+	rows, err := s.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	var heads []*model.AuditLogChainHead
+	for rows.Next() {
+		var head model.AuditLogChainHead
+		if err := rows.Scan(&head.Chain, &head.Sequence, &head.Hash, &head.UpdatedAt); err != nil {
+			return nil, err
+		}
+		heads = append(heads, &head)
+	}
+
+	return heads, rows.Err()
+}
+
+// AnonymizeByUser removes the personal data from the audit log entries of a
+// user, both those they took and those taken on them by others, such as an
+// admin or a SCIM token. The IP address and user agent belong to whoever took
+// the action, so they are only cleared on the entries the user took. The
+// entries themselves are kept, and the user reference is cleared once the user
+// is deleted. IDs, such as the resource ID, are retained as they no longer
+// resolve to the user. Anonymized entries are verified by their personal hash.
+func (s *AuditLog) AnonymizeByUser(ctx context.Context, userID string) error {
+	query := `
+		UPDATE audit_logs SET
+			ip_address = CASE WHEN user_id = $1 THEN NULL ELSE ip_address END,
+			user_agent = CASE WHEN user_id = $1 THEN NULL ELSE user_agent END,
+			metadata = metadata - ARRAY['email', 'new_email', 'old_email', 'name'],
+			changes = changes - ARRAY['email', 'name'],
+			anonymized_at = COALESCE(anonymized_at, NOW())
+		WHERE user_id = $1
+			OR (resource_type IN ('user', 'session', 'impersonation', 'two_factor') AND resource_id = $1)
+			OR metadata->>'user_id' = $1
+			OR metadata->>'impersonated_user_id' = $1
+	`
+
+	_, err := s.ExecContext(ctx, query, userID)
+	return err
+}
+
+// list runs a query selecting audit log entries.
+func (s *AuditLog) list(ctx context.Context, query string, args ...any) ([]*model.AuditLog, error) {
+	rows, err := s.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []*model.AuditLog
+	for rows.Next() {
+		log, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, rows.Err()
+}
+
+// scan scans an audit log row.
+func (s *AuditLog) scan(row interface{ Scan(dest ...any) error }) (*model.AuditLog, error) {
+	var log model.AuditLog
+	if err := row.Scan(
+		&log.ID,
+		&log.Action,
+		&log.EntityID,
+		&log.ResourceType,
+		&log.ResourceID,
+		&log.IPAddress,
+		&log.Metadata,
+		&log.UserAgent,
+		&log.UserID,
+		&log.CreatedAt,
+		&log.Sequence,
+		&log.PreviousHash,
+		&log.PersonalHash,
+		&log.Hash,
+		&log.AnonymizedAt,
+		&log.ActorType,
+		&log.ActorID,
+		&log.Changes,
+	); err != nil {
+		return nil, err
+	}
 
-	return &created, nil
+	return &log, nil
 }
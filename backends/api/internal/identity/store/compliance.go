@@ -0,0 +1,283 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+	"database/sql"
+)
+
+// Compliancer is the store for compliance record operations.
+type Compliancer interface {
+	Create(ctx context.Context, record *model.ComplianceRecord) (*model.ComplianceRecord, error)
+	CreateDocument(ctx context.Context, document *model.ComplianceDocument) (*model.ComplianceDocument, error)
+	DeleteDocument(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.ComplianceRecord, error)
+	GetBySubject(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string, recordType model.ComplianceType) (*model.ComplianceRecord, error)
+	GetDocument(ctx context.Context, id string) (*model.ComplianceDocument, error)
+	ListBySubject(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string) ([]*model.ComplianceRecord, error)
+	ListDocuments(ctx context.Context, recordID string) ([]*model.ComplianceDocument, error)
+	ListForReview(ctx context.Context, platformID string, status model.ComplianceStatus) ([]*model.ComplianceRecord, error)
+	Update(ctx context.Context, record *model.ComplianceRecord) error
+	WithQuerier(q core.Querier) Compliancer
+}
+
+// Compliance is the store for compliance record operations.
+type Compliance struct {
+	core.Querier
+}
+
+func (s *Compliance) WithQuerier(q core.Querier) Compliancer {
+	return &Compliance{q}
+}
+
+// NewCompliance creates a new Compliance store.
+func NewCompliance(db core.Querier) *Compliance {
+	return &Compliance{db}
+}
+
+// complianceRecordColumns are the columns selected for compliance records, in the order scanned by scan.
+const complianceRecordColumns = `
+	id, entity_type, entity_id, type, status, metadata, reason,
+	submitted_at, verified_at, verified_by, created_at, updated_at
+`
+
+// complianceDocumentColumns are the columns selected for compliance documents, in the order scanned by scanDocument.
+const complianceDocumentColumns = `id, record_id, key, name, content_type, created_by, created_at`
+
+// Create creates a new compliance record.
+func (s *Compliance) Create(ctx context.Context, record *model.ComplianceRecord) (*model.ComplianceRecord, error) {
+	query := `
+		INSERT INTO compliance_records (
+			entity_type, entity_id, type, status, metadata
+		) VALUES (
+			$1, $2, $3, $4, COALESCE($5, '{}'::jsonb)
+		) RETURNING ` + complianceRecordColumns
+
+	return s.scan(s.QueryRowContext(
+		ctx,
+		query,
+		record.SubjectType,
+		record.SubjectID,
+		record.Type,
+		record.Status,
+		record.Metadata,
+	))
+}
+
+// GetByID retrieves a compliance record by ID.
+func (s *Compliance) GetByID(ctx context.Context, id string) (*model.ComplianceRecord, error) {
+	query := `SELECT ` + complianceRecordColumns + ` FROM compliance_records WHERE id = $1`
+
+	return s.scan(s.QueryRowContext(ctx, query, id))
+}
+
+// GetBySubject retrieves the compliance record of a type of a subject.
+func (s *Compliance) GetBySubject(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string, recordType model.ComplianceType) (*model.ComplianceRecord, error) {
+	query := `
+		SELECT ` + complianceRecordColumns + `
+		FROM compliance_records
+		WHERE entity_type = $1 AND entity_id = $2 AND type = $3
+	`
+
+	return s.scan(s.QueryRowContext(ctx, query, subjectType, subjectID, recordType))
+}
+
+// ListBySubject lists the compliance records of a subject.
+func (s *Compliance) ListBySubject(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string) ([]*model.ComplianceRecord, error) {
+	query := `
+		SELECT ` + complianceRecordColumns + `
+		FROM compliance_records
+		WHERE entity_type = $1 AND entity_id = $2
+		ORDER BY type
+	`
+
+	return s.list(ctx, query, subjectType, subjectID)
+}
+
+// ListForReview lists the submitted compliance records of the entities below
+// a platform, oldest submission first. An empty status lists every status.
+func (s *Compliance) ListForReview(ctx context.Context, platformID string, status model.ComplianceStatus) ([]*model.ComplianceRecord, error) {
+	query := `
+		WITH RECURSIVE descendants AS (
+			SELECT id FROM entities WHERE parent_id = $1
+
+			UNION ALL
+
+			SELECT e.id
+			FROM entities e
+			INNER JOIN descendants d ON e.parent_id = d.id
+		)
+		SELECT ` + complianceRecordColumns + `
+		FROM compliance_records
+		WHERE entity_type = $2
+			AND entity_id IN (SELECT id::text FROM descendants)
+			AND submitted_at IS NOT NULL
+			AND ($3 = '' OR status = $3)
+		ORDER BY submitted_at
+	`
+
+	return s.list(ctx, query, platformID, model.ComplianceSubjectEntity, status)
+}
+
+// Update updates the status, information and review of a compliance record.
+func (s *Compliance) Update(ctx context.Context, record *model.ComplianceRecord) error {
+	query := `
+		UPDATE compliance_records SET
+			status = $1,
+			metadata = $2,
+			reason = $3,
+			submitted_at = $4,
+			verified_at = $5,
+			verified_by = $6,
+			updated_at = NOW()
+		WHERE id = $7
+	`
+
+	_, err := s.ExecContext(
+		ctx,
+		query,
+		record.Status,
+		record.Metadata,
+		record.Reason,
+		record.SubmittedAt,
+		record.VerifiedAt,
+		record.VerifiedBy,
+		record.ID,
+	)
+	return err
+}
+
+// CreateDocument creates a new compliance document.
+func (s *Compliance) CreateDocument(ctx context.Context, document *model.ComplianceDocument) (*model.ComplianceDocument, error) {
+	query := `
+		INSERT INTO compliance_documents (
+			id, record_id, key, name, content_type, created_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		) RETURNING ` + complianceDocumentColumns
+
+	return s.scanDocument(s.QueryRowContext(
+		ctx,
+		query,
+		document.ID,
+		document.RecordID,
+		document.Key,
+		document.Name,
+		document.ContentType,
+		document.CreatedBy,
+	))
+}
+
+// DeleteDocument deletes a compliance document.
+func (s *Compliance) DeleteDocument(ctx context.Context, id string) error {
+	query := `DELETE FROM compliance_documents WHERE id = $1`
+
+	_, err := s.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetDocument retrieves a compliance document by ID.
+func (s *Compliance) GetDocument(ctx context.Context, id string) (*model.ComplianceDocument, error) {
+	query := `SELECT ` + complianceDocumentColumns + ` FROM compliance_documents WHERE id = $1`
+
+	return s.scanDocument(s.QueryRowContext(ctx, query, id))
+}
+
+// ListDocuments lists the documents of a compliance record, oldest first.
+func (s *Compliance) ListDocuments(ctx context.Context, recordID string) ([]*model.ComplianceDocument, error) {
+	query := `
+		SELECT ` + complianceDocumentColumns + `
+		FROM compliance_documents
+		WHERE record_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := s.QueryContext(ctx, query, recordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []*model.ComplianceDocument
+	for rows.Next() {
+		document, err := s.scanDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, rows.Err()
+}
+
+// list runs a query selecting compliance records.
+func (s *Compliance) list(ctx context.Context, query string, args ...any) ([]*model.ComplianceRecord, error) {
+	rows, err := s.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*model.ComplianceRecord
+	for rows.Next() {
+		record, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// scan scans a compliance record row, returning nil if there is none.
+func (s *Compliance) scan(row interface{ Scan(dest ...any) error }) (*model.ComplianceRecord, error) {
+	var record model.ComplianceRecord
+	err := row.Scan(
+		&record.ID,
+		&record.SubjectType,
+		&record.SubjectID,
+		&record.Type,
+		&record.Status,
+		&record.Metadata,
+		&record.Reason,
+		&record.SubmittedAt,
+		&record.VerifiedAt,
+		&record.VerifiedBy,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// scanDocument scans a compliance document row, returning nil if there is none.
+func (s *Compliance) scanDocument(row interface{ Scan(dest ...any) error }) (*model.ComplianceDocument, error) {
+	var document model.ComplianceDocument
+	err := row.Scan(
+		&document.ID,
+		&document.RecordID,
+		&document.Key,
+		&document.Name,
+		&document.ContentType,
+		&document.CreatedBy,
+		&document.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
@@ -14,6 +14,10 @@ type Entityer interface {
 	Get(ctx context.Context, id string) (*model.Entity, error)
 	GetByID(ctx context.Context, id string) (*model.Entity, error)
 	GetBySlug(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error)
+	IsDescendant(ctx context.Context, id, ancestorID string) (bool, error)
+	UpdateBranding(ctx context.Context, id string, brandColor, supportEmail *string) error
+	UpdateLogo(ctx context.Context, id string, logo *string) error
+	UpdateStatus(ctx context.Context, id string, status model.EntityStatus) error
 	WithQuerier(core.Querier) Entityer
 }
 
@@ -45,7 +49,7 @@ func (s *Entity) Create(ctx context.Context, entity *model.Entity) (*model.Entit
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7
 		) RETURNING
-			id, domain, logo, name, parent_id, slug, status, type, created_at, updated_at
+			id, domain, logo, name, parent_id, slug, status, type, created_at, updated_at, brand_color, support_email
 	`
 
 	var created model.Entity
@@ -70,6 +74,8 @@ func (s *Entity) Create(ctx context.Context, entity *model.Entity) (*model.Entit
 		&created.Type,
 		&created.CreatedAt,
 		&created.UpdatedAt,
+		&created.BrandColor,
+		&created.SupportEmail,
 	)
 	if err != nil {
 		return nil, err
@@ -91,7 +97,9 @@ func (s *Entity) Get(ctx context.Context, id string) (*model.Entity, error) {
 			status,
 			type,
 			created_at,
-			updated_at
+			updated_at,
+			brand_color,
+			support_email
 		FROM entities
 		WHERE id = $1
 		OR slug = $2
@@ -109,7 +117,13 @@ func (s *Entity) Get(ctx context.Context, id string) (*model.Entity, error) {
 		&entity.Type,
 		&entity.CreatedAt,
 		&entity.UpdatedAt,
+		&entity.BrandColor,
+		&entity.SupportEmail,
 	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +144,9 @@ func (s *Entity) GetByID(ctx context.Context, id string) (*model.Entity, error)
 			status,
 			type,
 			created_at,
-			updated_at
+			updated_at,
+			brand_color,
+			support_email
 		FROM entities
 		WHERE id = $1
 	`
@@ -147,7 +163,13 @@ func (s *Entity) GetByID(ctx context.Context, id string) (*model.Entity, error)
 		&entity.Type,
 		&entity.CreatedAt,
 		&entity.UpdatedAt,
+		&entity.BrandColor,
+		&entity.SupportEmail,
 	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +190,9 @@ func (s *Entity) GetBySlug(ctx context.Context, mode types.OperationMode, slug s
 			status,
 			type,
 			created_at,
-			updated_at
+			updated_at,
+			brand_color,
+			support_email
 		FROM entities
 		WHERE mode = $1 AND slug = $2
 	`
@@ -185,6 +209,8 @@ func (s *Entity) GetBySlug(ctx context.Context, mode types.OperationMode, slug s
 		&entity.Type,
 		&entity.CreatedAt,
 		&entity.UpdatedAt,
+		&entity.BrandColor,
+		&entity.SupportEmail,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -196,3 +222,63 @@ func (s *Entity) GetBySlug(ctx context.Context, mode types.OperationMode, slug s
 
 	return &entity, nil
 }
+
+// IsDescendant checks if an entity is below another in the entity hierarchy
+func (s *Entity) IsDescendant(ctx context.Context, id, ancestorID string) (bool, error) {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id FROM entities WHERE id = $1
+
+			UNION ALL
+
+			SELECT e.parent_id
+			FROM entities e
+			INNER JOIN ancestors a ON e.id = a.parent_id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE parent_id = $2)
+	`
+
+	var exists bool
+	err := s.QueryRowContext(ctx, query, id, ancestorID).Scan(&exists)
+	return exists, err
+}
+
+// UpdateBranding updates the brand colour and support email of an entity
+func (s *Entity) UpdateBranding(ctx context.Context, id string, brandColor, supportEmail *string) error {
+	query := `
+		UPDATE entities
+		SET brand_color = $1,
+			support_email = $2,
+			updated_at = NOW()
+		WHERE id = $3
+	`
+
+	_, err := s.ExecContext(ctx, query, brandColor, supportEmail, id)
+	return err
+}
+
+// UpdateLogo updates the logo of an entity
+func (s *Entity) UpdateLogo(ctx context.Context, id string, logo *string) error {
+	query := `
+		UPDATE entities
+		SET logo = $1,
+			updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := s.ExecContext(ctx, query, logo, id)
+	return err
+}
+
+// UpdateStatus updates the status of an entity
+func (s *Entity) UpdateStatus(ctx context.Context, id string, status model.EntityStatus) error {
+	query := `
+		UPDATE entities
+		SET status = $1,
+			updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := s.ExecContext(ctx, query, status, id)
+	return err
+}
@@ -0,0 +1,226 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+	"database/sql"
+)
+
+// EntityDomainer is the store for entity domain operations.
+type EntityDomainer interface {
+	Create(ctx context.Context, domain *model.EntityDomain) (*model.EntityDomain, error)
+	Delete(ctx context.Context, id, entityID string) (bool, error)
+	Get(ctx context.Context, id, entityID string) (*model.EntityDomain, error)
+	GetByDomain(ctx context.Context, entityID, domain string) (*model.EntityDomain, error)
+	GetVerifiedByDomain(ctx context.Context, domain string) (*model.EntityDomain, error)
+	ListByEntityID(ctx context.Context, entityID string) ([]*model.EntityDomain, error)
+	Update(ctx context.Context, domain *model.EntityDomain) (*model.EntityDomain, error)
+	Verify(ctx context.Context, id string) (*model.EntityDomain, error)
+	WithQuerier(q core.Querier) EntityDomainer
+}
+
+// EntityDomain is the store for entity domain operations.
+type EntityDomain struct {
+	core.Querier
+}
+
+func (s *EntityDomain) WithQuerier(q core.Querier) EntityDomainer {
+	return &EntityDomain{q}
+}
+
+// NewEntityDomain creates a new EntityDomain store.
+func NewEntityDomain(db core.Querier) *EntityDomain {
+	return &EntityDomain{db}
+}
+
+// Create claims a domain for an entity.
+func (s *EntityDomain) Create(ctx context.Context, domain *model.EntityDomain) (*model.EntityDomain, error) {
+	query := `
+		INSERT INTO entity_domains (
+			entity_id, domain, verification_token, auto_join, default_role, created_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		) RETURNING
+			id, entity_id, domain, verification_token, verified_at, auto_join,
+			default_role, created_by, created_at, updated_at
+	`
+
+	return s.scan(s.QueryRowContext(
+		ctx,
+		query,
+		domain.EntityID,
+		domain.Domain,
+		domain.VerificationToken,
+		domain.AutoJoin,
+		domain.DefaultRole,
+		domain.CreatedBy,
+	))
+}
+
+// Delete deletes a domain of an entity, reporting whether it existed.
+func (s *EntityDomain) Delete(ctx context.Context, id, entityID string) (bool, error) {
+	query := `DELETE FROM entity_domains WHERE id = $1 AND entity_id = $2`
+
+	result, err := s.ExecContext(ctx, query, id, entityID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// Get retrieves a domain of an entity.
+func (s *EntityDomain) Get(ctx context.Context, id, entityID string) (*model.EntityDomain, error) {
+	query := `
+		SELECT
+			id, entity_id, domain, verification_token, verified_at, auto_join,
+			default_role, created_by, created_at, updated_at
+		FROM entity_domains
+		WHERE id = $1 AND entity_id = $2
+	`
+
+	domain, err := s.scan(s.QueryRowContext(ctx, query, id, entityID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return domain, err
+}
+
+// GetByDomain retrieves the claim of an entity on a domain.
+func (s *EntityDomain) GetByDomain(ctx context.Context, entityID, domain string) (*model.EntityDomain, error) {
+	query := `
+		SELECT
+			id, entity_id, domain, verification_token, verified_at, auto_join,
+			default_role, created_by, created_at, updated_at
+		FROM entity_domains
+		WHERE entity_id = $1 AND domain = $2
+	`
+
+	entityDomain, err := s.scan(s.QueryRowContext(ctx, query, entityID, domain))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return entityDomain, err
+}
+
+// GetVerifiedByDomain retrieves the verified claim on a domain, of whichever
+// entity it belongs to.
+func (s *EntityDomain) GetVerifiedByDomain(ctx context.Context, domain string) (*model.EntityDomain, error) {
+	query := `
+		SELECT
+			id, entity_id, domain, verification_token, verified_at, auto_join,
+			default_role, created_by, created_at, updated_at
+		FROM entity_domains
+		WHERE domain = $1 AND verified_at IS NOT NULL
+	`
+
+	entityDomain, err := s.scan(s.QueryRowContext(ctx, query, domain))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return entityDomain, err
+}
+
+// ListByEntityID lists the domains of an entity.
+func (s *EntityDomain) ListByEntityID(ctx context.Context, entityID string) ([]*model.EntityDomain, error) {
+	query := `
+		SELECT
+			id, entity_id, domain, verification_token, verified_at, auto_join,
+			default_role, created_by, created_at, updated_at
+		FROM entity_domains
+		WHERE entity_id = $1
+		ORDER BY domain
+	`
+
+	rows, err := s.QueryContext(ctx, query, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var domains []*model.EntityDomain
+	for rows.Next() {
+		domain, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, domain)
+	}
+
+	return domains, rows.Err()
+}
+
+// Update updates the automatic joining settings of a domain.
+func (s *EntityDomain) Update(ctx context.Context, domain *model.EntityDomain) (*model.EntityDomain, error) {
+	query := `
+		UPDATE entity_domains SET
+			auto_join = $1,
+			default_role = $2,
+			updated_at = NOW()
+		WHERE id = $3 AND entity_id = $4
+		RETURNING
+			id, entity_id, domain, verification_token, verified_at, auto_join,
+			default_role, created_by, created_at, updated_at
+	`
+
+	updated, err := s.scan(s.QueryRowContext(ctx, query, domain.AutoJoin, domain.DefaultRole, domain.ID, domain.EntityID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return updated, err
+}
+
+// Verify marks a domain as verified, unless another entity has verified it
+// in the meantime, in which case nil is returned.
+func (s *EntityDomain) Verify(ctx context.Context, id string) (*model.EntityDomain, error) {
+	query := `
+		UPDATE entity_domains d SET
+			verified_at = NOW(),
+			updated_at = NOW()
+		WHERE d.id = $1 AND NOT EXISTS (
+			SELECT 1 FROM entity_domains v
+			WHERE v.domain = d.domain AND v.verified_at IS NOT NULL AND v.id <> d.id
+		)
+		RETURNING
+			id, entity_id, domain, verification_token, verified_at, auto_join,
+			default_role, created_by, created_at, updated_at
+	`
+
+	domain, err := s.scan(s.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return domain, err
+}
+
+// scan scans an entity domain row.
+func (s *EntityDomain) scan(row interface{ Scan(dest ...any) error }) (*model.EntityDomain, error) {
+	var domain model.EntityDomain
+	if err := row.Scan(
+		&domain.ID,
+		&domain.EntityID,
+		&domain.Domain,
+		&domain.VerificationToken,
+		&domain.VerifiedAt,
+		&domain.AutoJoin,
+		&domain.DefaultRole,
+		&domain.CreatedBy,
+		&domain.CreatedAt,
+		&domain.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &domain, nil
+}
@@ -0,0 +1,99 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+	"database/sql"
+	"time"
+)
+
+// FailedSignIner is the store for failed sign-in operations.
+type FailedSignIner interface {
+	DeleteStale(ctx context.Context, before time.Time) error
+	Get(ctx context.Context, emailHash string) (*model.FailedSignIn, error)
+	Upsert(ctx context.Context, failedSignIn *model.FailedSignIn) error
+	WithQuerier(q core.Querier) FailedSignIner
+}
+
+// FailedSignIn is the store for failed sign-in operations.
+type FailedSignIn struct {
+	core.Querier
+}
+
+func (s *FailedSignIn) WithQuerier(q core.Querier) FailedSignIner {
+	return &FailedSignIn{q}
+}
+
+// NewFailedSignIn creates a new FailedSignIn.
+func NewFailedSignIn(db core.Querier) *FailedSignIn {
+	return &FailedSignIn{db}
+}
+
+// DeleteStale removes the failed sign-ins that haven't changed since before
+// and are no longer locked.
+func (s *FailedSignIn) DeleteStale(ctx context.Context, before time.Time) error {
+	query := `
+		DELETE FROM failed_sign_ins
+		WHERE updated_at < $1
+			AND (locked_until IS NULL OR locked_until < NOW())
+	`
+
+	_, err := s.ExecContext(ctx, query, before)
+	return err
+}
+
+// Get returns the failed sign-ins against a hashed email address.
+func (s *FailedSignIn) Get(ctx context.Context, emailHash string) (*model.FailedSignIn, error) {
+	query := `
+		SELECT
+			email_hash, failed_attempts, lockout_count, locked_until, updated_at
+		FROM failed_sign_ins
+		WHERE email_hash = $1
+	`
+
+	var failedSignIn model.FailedSignIn
+	err := s.QueryRowContext(ctx, query, emailHash).Scan(
+		&failedSignIn.EmailHash,
+		&failedSignIn.FailedAttempts,
+		&failedSignIn.LockoutCount,
+		&failedSignIn.LockedUntil,
+		&failedSignIn.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &failedSignIn, nil
+}
+
+// Upsert creates or replaces the failed sign-ins against a hashed email address.
+func (s *FailedSignIn) Upsert(ctx context.Context, failedSignIn *model.FailedSignIn) error {
+	query := `
+		INSERT INTO failed_sign_ins (
+			email_hash, failed_attempts, lockout_count, locked_until, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5
+		)
+		ON CONFLICT (email_hash) DO UPDATE SET
+			failed_attempts = EXCLUDED.failed_attempts,
+			lockout_count = EXCLUDED.lockout_count,
+			locked_until = EXCLUDED.locked_until,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := s.ExecContext(
+		ctx,
+		query,
+		failedSignIn.EmailHash,
+		failedSignIn.FailedAttempts,
+		failedSignIn.LockoutCount,
+		failedSignIn.LockedUntil,
+		failedSignIn.UpdatedAt,
+	)
+	return err
+}
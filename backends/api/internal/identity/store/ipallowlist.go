@@ -0,0 +1,105 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+)
+
+// IPAllowlister is the store for IP allowlist operations.
+type IPAllowlister interface {
+	Create(ctx context.Context, entry *model.IPAllowlistEntry) (*model.IPAllowlistEntry, error)
+	DeleteByEntityID(ctx context.Context, entityID string) error
+	ListByEntityID(ctx context.Context, entityID string) (model.IPAllowlist, error)
+	WithQuerier(q core.Querier) IPAllowlister
+}
+
+// IPAllowlist is the store for IP allowlist operations.
+type IPAllowlist struct {
+	core.Querier
+}
+
+func (s *IPAllowlist) WithQuerier(q core.Querier) IPAllowlister {
+	return &IPAllowlist{q}
+}
+
+// NewIPAllowlist creates a new IPAllowlist.
+func NewIPAllowlist(db core.Querier) *IPAllowlist {
+	return &IPAllowlist{db}
+}
+
+// Create adds an address range to the allowlist of an entity.
+func (s *IPAllowlist) Create(ctx context.Context, entry *model.IPAllowlistEntry) (*model.IPAllowlistEntry, error) {
+	query := `
+		INSERT INTO ip_allowlist_entries (
+			entity_id, cidr, description, created_by
+		) VALUES (
+			$1, $2::text::cidr, $3, $4
+		)
+		RETURNING
+			id, entity_id, cidr::text, description, created_by, created_at
+	`
+
+	return s.scan(s.QueryRowContext(
+		ctx,
+		query,
+		entry.EntityID,
+		entry.CIDR,
+		entry.Description,
+		entry.CreatedBy,
+	))
+}
+
+// DeleteByEntityID removes all address ranges from the allowlist of an entity.
+func (s *IPAllowlist) DeleteByEntityID(ctx context.Context, entityID string) error {
+	query := `DELETE FROM ip_allowlist_entries WHERE entity_id = $1`
+
+	_, err := s.ExecContext(ctx, query, entityID)
+	return err
+}
+
+// ListByEntityID lists the allowlist of an entity, oldest first.
+func (s *IPAllowlist) ListByEntityID(ctx context.Context, entityID string) (model.IPAllowlist, error) {
+	query := `
+		SELECT
+			id, entity_id, cidr::text, description, created_by, created_at
+		FROM ip_allowlist_entries
+		WHERE entity_id = $1
+		ORDER BY id
+	`
+
+	rows, err := s.QueryContext(ctx, query, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries model.IPAllowlist
+	for rows.Next() {
+		entry, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// scan scans an IP allowlist entry row.
+func (s *IPAllowlist) scan(row interface{ Scan(dest ...any) error }) (*model.IPAllowlistEntry, error) {
+	var entry model.IPAllowlistEntry
+	if err := row.Scan(
+		&entry.ID,
+		&entry.EntityID,
+		&entry.CIDR,
+		&entry.Description,
+		&entry.CreatedBy,
+		&entry.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
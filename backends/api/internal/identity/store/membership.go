@@ -3,16 +3,21 @@ package store
 import (
 	"autopilot/backends/api/internal/identity/model"
 	"autopilot/backends/internal/core"
+	"autopilot/backends/internal/types"
 	"context"
+	"database/sql"
 )
 
 // Membershiper defines the interface for membership store operations
 type Membershiper interface {
 	Create(ctx context.Context, membership *model.Membership) (*model.Membership, error)
+	Delete(ctx context.Context, id string) error
 	GetByUserID(ctx context.Context, userID string) ([]*model.Membership, error)
 	GetByEntityID(ctx context.Context, entityID string) ([]*model.Membership, error)
+	GetByEntityIDAndUserID(ctx context.Context, entityID, userID string) (*model.Membership, error)
 	GetByEntityIDWithInheritance(ctx context.Context, userID, entityID string) ([]*model.Membership, error)
 	GetByUserIDWithInheritance(ctx context.Context, userID string) ([]*model.Membership, error)
+	UpdateRole(ctx context.Context, id string, role types.Role) error
 	WithQuerier(q core.Querier) Membershiper
 }
 
@@ -58,6 +63,27 @@ func (s *Membership) Create(ctx context.Context, membership *model.Membership) (
 	return membership, nil
 }
 
+// Delete deletes a membership
+func (s *Membership) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM memberships WHERE id = $1`
+
+	_, err := s.ExecContext(ctx, query, id)
+	return err
+}
+
+// UpdateRole updates the role of a membership
+func (s *Membership) UpdateRole(ctx context.Context, id string, role types.Role) error {
+	query := `
+		UPDATE memberships
+		SET role = $1,
+			updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := s.ExecContext(ctx, query, role, id)
+	return err
+}
+
 // GetByUserID retrieves all memberships for a user
 func (s *Membership) GetByUserID(ctx context.Context, userID string) ([]*model.Membership, error) {
 	query := `
@@ -140,6 +166,40 @@ func (s *Membership) GetByEntityID(ctx context.Context, entityID string) ([]*mod
 	return memberships, rows.Err()
 }
 
+// GetByEntityIDAndUserID retrieves the direct membership of a user in an entity
+func (s *Membership) GetByEntityIDAndUserID(ctx context.Context, entityID, userID string) (*model.Membership, error) {
+	query := `
+		SELECT
+			id,
+			entity_id,
+			role,
+			user_id,
+			created_at,
+			updated_at
+		FROM memberships
+		WHERE entity_id = $1 AND user_id = $2
+	`
+
+	membership := &model.Membership{}
+	err := s.QueryRowContext(ctx, query, entityID, userID).Scan(
+		&membership.ID,
+		&membership.EntityID,
+		&membership.Role,
+		&membership.UserID,
+		&membership.CreatedAt,
+		&membership.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
 // GetByEntityIDWithInheritance retrieves a membership with the specified
 // entityID. The function also ensures that a valid membership, via inheritence
 // is indeed present.
@@ -10,6 +10,7 @@ import (
 	"autopilot/backends/internal/core"
 	"autopilot/backends/internal/types"
 	"context"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -41,6 +42,63 @@ func (_m *MockAuditLoger) EXPECT() *MockAuditLoger_Expecter {
 	return &MockAuditLoger_Expecter{mock: &_m.Mock}
 }
 
+// AnonymizeByUser provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) AnonymizeByUser(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnonymizeByUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditLoger_AnonymizeByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnonymizeByUser'
+type MockAuditLoger_AnonymizeByUser_Call struct {
+	*mock.Call
+}
+
+// AnonymizeByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuditLoger_Expecter) AnonymizeByUser(ctx interface{}, userID interface{}) *MockAuditLoger_AnonymizeByUser_Call {
+	return &MockAuditLoger_AnonymizeByUser_Call{Call: _e.mock.On("AnonymizeByUser", ctx, userID)}
+}
+
+func (_c *MockAuditLoger_AnonymizeByUser_Call) Run(run func(ctx context.Context, userID string)) *MockAuditLoger_AnonymizeByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditLoger_AnonymizeByUser_Call) Return(err error) *MockAuditLoger_AnonymizeByUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditLoger_AnonymizeByUser_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockAuditLoger_AnonymizeByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockAuditLoger
 func (_mock *MockAuditLoger) Create(ctx context.Context, log *model.AuditLog) (*model.AuditLog, error) {
 	ret := _mock.Called(ctx, log)
@@ -109,195 +167,195 @@ func (_c *MockAuditLoger_Create_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
-// WithQuerier provides a mock function for the type MockAuditLoger
-func (_mock *MockAuditLoger) WithQuerier(q core.Querier) store.AuditLoger {
-	ret := _mock.Called(q)
+// GetChainHead provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) GetChainHead(ctx context.Context, chain string) (*model.AuditLogChainHead, error) {
+	ret := _mock.Called(ctx, chain)
 
 	if len(ret) == 0 {
-		panic("no return value specified for WithQuerier")
+		panic("no return value specified for GetChainHead")
 	}
 
-	var r0 store.AuditLoger
-	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.AuditLoger); ok {
-		r0 = returnFunc(q)
+	var r0 *model.AuditLogChainHead
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.AuditLogChainHead, error)); ok {
+		return returnFunc(ctx, chain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.AuditLogChainHead); ok {
+		r0 = returnFunc(ctx, chain)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(store.AuditLoger)
+			r0 = ret.Get(0).(*model.AuditLogChainHead)
 		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, chain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockAuditLoger_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
-type MockAuditLoger_WithQuerier_Call struct {
+// MockAuditLoger_GetChainHead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChainHead'
+type MockAuditLoger_GetChainHead_Call struct {
 	*mock.Call
 }
 
-// WithQuerier is a helper method to define mock.On call
-//   - q core.Querier
-func (_e *MockAuditLoger_Expecter) WithQuerier(q interface{}) *MockAuditLoger_WithQuerier_Call {
-	return &MockAuditLoger_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+// GetChainHead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chain string
+func (_e *MockAuditLoger_Expecter) GetChainHead(ctx interface{}, chain interface{}) *MockAuditLoger_GetChainHead_Call {
+	return &MockAuditLoger_GetChainHead_Call{Call: _e.mock.On("GetChainHead", ctx, chain)}
 }
 
-func (_c *MockAuditLoger_WithQuerier_Call) Run(run func(q core.Querier)) *MockAuditLoger_WithQuerier_Call {
+func (_c *MockAuditLoger_GetChainHead_Call) Run(run func(ctx context.Context, chain string)) *MockAuditLoger_GetChainHead_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 core.Querier
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(core.Querier)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAuditLoger_WithQuerier_Call) Return(auditLoger store.AuditLoger) *MockAuditLoger_WithQuerier_Call {
-	_c.Call.Return(auditLoger)
+func (_c *MockAuditLoger_GetChainHead_Call) Return(auditLogChainHead *model.AuditLogChainHead, err error) *MockAuditLoger_GetChainHead_Call {
+	_c.Call.Return(auditLogChainHead, err)
 	return _c
 }
 
-func (_c *MockAuditLoger_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.AuditLoger) *MockAuditLoger_WithQuerier_Call {
+func (_c *MockAuditLoger_GetChainHead_Call) RunAndReturn(run func(ctx context.Context, chain string) (*model.AuditLogChainHead, error)) *MockAuditLoger_GetChainHead_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockEntityer creates a new instance of MockEntityer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockEntityer(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockEntityer {
-	mock := &MockEntityer{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockEntityer is an autogenerated mock type for the Entityer type
-type MockEntityer struct {
-	mock.Mock
-}
-
-type MockEntityer_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockEntityer) EXPECT() *MockEntityer_Expecter {
-	return &MockEntityer_Expecter{mock: &_m.Mock}
-}
-
-// Create provides a mock function for the type MockEntityer
-func (_mock *MockEntityer) Create(ctx context.Context, entity *model.Entity) (*model.Entity, error) {
-	ret := _mock.Called(ctx, entity)
+// List provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) List(ctx context.Context, entityID string, filter model.AuditLogFilter, page model.AuditLogPage) ([]*model.AuditLog, error) {
+	ret := _mock.Called(ctx, entityID, filter, page)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for List")
 	}
 
-	var r0 *model.Entity
+	var r0 []*model.AuditLog
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Entity) (*model.Entity, error)); ok {
-		return returnFunc(ctx, entity)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.AuditLogFilter, model.AuditLogPage) ([]*model.AuditLog, error)); ok {
+		return returnFunc(ctx, entityID, filter, page)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Entity) *model.Entity); ok {
-		r0 = returnFunc(ctx, entity)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.AuditLogFilter, model.AuditLogPage) []*model.AuditLog); ok {
+		r0 = returnFunc(ctx, entityID, filter, page)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Entity)
+			r0 = ret.Get(0).([]*model.AuditLog)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.Entity) error); ok {
-		r1 = returnFunc(ctx, entity)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.AuditLogFilter, model.AuditLogPage) error); ok {
+		r1 = returnFunc(ctx, entityID, filter, page)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockEntityer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockEntityer_Create_Call struct {
+// MockAuditLoger_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockAuditLoger_List_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - entity *model.Entity
-func (_e *MockEntityer_Expecter) Create(ctx interface{}, entity interface{}) *MockEntityer_Create_Call {
-	return &MockEntityer_Create_Call{Call: _e.mock.On("Create", ctx, entity)}
+//   - entityID string
+//   - filter model.AuditLogFilter
+//   - page model.AuditLogPage
+func (_e *MockAuditLoger_Expecter) List(ctx interface{}, entityID interface{}, filter interface{}, page interface{}) *MockAuditLoger_List_Call {
+	return &MockAuditLoger_List_Call{Call: _e.mock.On("List", ctx, entityID, filter, page)}
 }
 
-func (_c *MockEntityer_Create_Call) Run(run func(ctx context.Context, entity *model.Entity)) *MockEntityer_Create_Call {
+func (_c *MockAuditLoger_List_Call) Run(run func(ctx context.Context, entityID string, filter model.AuditLogFilter, page model.AuditLogPage)) *MockAuditLoger_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.Entity
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.Entity)
+			arg1 = args[1].(string)
+		}
+		var arg2 model.AuditLogFilter
+		if args[2] != nil {
+			arg2 = args[2].(model.AuditLogFilter)
+		}
+		var arg3 model.AuditLogPage
+		if args[3] != nil {
+			arg3 = args[3].(model.AuditLogPage)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEntityer_Create_Call) Return(entity1 *model.Entity, err error) *MockEntityer_Create_Call {
-	_c.Call.Return(entity1, err)
+func (_c *MockAuditLoger_List_Call) Return(auditLogs []*model.AuditLog, err error) *MockAuditLoger_List_Call {
+	_c.Call.Return(auditLogs, err)
 	return _c
 }
 
-func (_c *MockEntityer_Create_Call) RunAndReturn(run func(ctx context.Context, entity *model.Entity) (*model.Entity, error)) *MockEntityer_Create_Call {
+func (_c *MockAuditLoger_List_Call) RunAndReturn(run func(ctx context.Context, entityID string, filter model.AuditLogFilter, page model.AuditLogPage) ([]*model.AuditLog, error)) *MockAuditLoger_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function for the type MockEntityer
-func (_mock *MockEntityer) Get(ctx context.Context, id string) (*model.Entity, error) {
-	ret := _mock.Called(ctx, id)
+// ListByUser provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) ListByUser(ctx context.Context, userID string) ([]*model.AuditLog, error) {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for ListByUser")
 	}
 
-	var r0 *model.Entity
+	var r0 []*model.AuditLog
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Entity, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.AuditLog, error)); ok {
+		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Entity); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.AuditLog); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Entity)
+			r0 = ret.Get(0).([]*model.AuditLog)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockEntityer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockEntityer_Get_Call struct {
+// MockAuditLoger_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type MockAuditLoger_ListByUser_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// ListByUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockEntityer_Expecter) Get(ctx interface{}, id interface{}) *MockEntityer_Get_Call {
-	return &MockEntityer_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+//   - userID string
+func (_e *MockAuditLoger_Expecter) ListByUser(ctx interface{}, userID interface{}) *MockAuditLoger_ListByUser_Call {
+	return &MockAuditLoger_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
 }
 
-func (_c *MockEntityer_Get_Call) Run(run func(ctx context.Context, id string)) *MockEntityer_Get_Call {
+func (_c *MockAuditLoger_ListByUser_Call) Run(run func(ctx context.Context, userID string)) *MockAuditLoger_ListByUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -315,57 +373,59 @@ func (_c *MockEntityer_Get_Call) Run(run func(ctx context.Context, id string)) *
 	return _c
 }
 
-func (_c *MockEntityer_Get_Call) Return(entity *model.Entity, err error) *MockEntityer_Get_Call {
-	_c.Call.Return(entity, err)
+func (_c *MockAuditLoger_ListByUser_Call) Return(auditLogs []*model.AuditLog, err error) *MockAuditLoger_ListByUser_Call {
+	_c.Call.Return(auditLogs, err)
 	return _c
 }
 
-func (_c *MockEntityer_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Entity, error)) *MockEntityer_Get_Call {
+func (_c *MockAuditLoger_ListByUser_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.AuditLog, error)) *MockAuditLoger_ListByUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type MockEntityer
-func (_mock *MockEntityer) GetByID(ctx context.Context, id string) (*model.Entity, error) {
-	ret := _mock.Called(ctx, id)
+// ListChain provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) ListChain(ctx context.Context, chain string, afterSequence int64, limit int) ([]*model.AuditLog, error) {
+	ret := _mock.Called(ctx, chain, afterSequence, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for ListChain")
 	}
 
-	var r0 *model.Entity
+	var r0 []*model.AuditLog
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Entity, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int) ([]*model.AuditLog, error)); ok {
+		return returnFunc(ctx, chain, afterSequence, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Entity); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, int) []*model.AuditLog); ok {
+		r0 = returnFunc(ctx, chain, afterSequence, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Entity)
+			r0 = ret.Get(0).([]*model.AuditLog)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64, int) error); ok {
+		r1 = returnFunc(ctx, chain, afterSequence, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockEntityer_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockEntityer_GetByID_Call struct {
+// MockAuditLoger_ListChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChain'
+type MockAuditLoger_ListChain_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// ListChain is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockEntityer_Expecter) GetByID(ctx interface{}, id interface{}) *MockEntityer_GetByID_Call {
-	return &MockEntityer_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+//   - chain string
+//   - afterSequence int64
+//   - limit int
+func (_e *MockAuditLoger_Expecter) ListChain(ctx interface{}, chain interface{}, afterSequence interface{}, limit interface{}) *MockAuditLoger_ListChain_Call {
+	return &MockAuditLoger_ListChain_Call{Call: _e.mock.On("ListChain", ctx, chain, afterSequence, limit)}
 }
 
-func (_c *MockEntityer_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockEntityer_GetByID_Call {
+func (_c *MockAuditLoger_ListChain_Call) Run(run func(ctx context.Context, chain string, afterSequence int64, limit int)) *MockAuditLoger_ListChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -375,129 +435,127 @@ func (_c *MockEntityer_GetByID_Call) Run(run func(ctx context.Context, id string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEntityer_GetByID_Call) Return(entity *model.Entity, err error) *MockEntityer_GetByID_Call {
-	_c.Call.Return(entity, err)
+func (_c *MockAuditLoger_ListChain_Call) Return(auditLogs []*model.AuditLog, err error) *MockAuditLoger_ListChain_Call {
+	_c.Call.Return(auditLogs, err)
 	return _c
 }
 
-func (_c *MockEntityer_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Entity, error)) *MockEntityer_GetByID_Call {
+func (_c *MockAuditLoger_ListChain_Call) RunAndReturn(run func(ctx context.Context, chain string, afterSequence int64, limit int) ([]*model.AuditLog, error)) *MockAuditLoger_ListChain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBySlug provides a mock function for the type MockEntityer
-func (_mock *MockEntityer) GetBySlug(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error) {
-	ret := _mock.Called(ctx, mode, slug)
+// ListChainHeads provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) ListChainHeads(ctx context.Context) ([]*model.AuditLogChainHead, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBySlug")
+		panic("no return value specified for ListChainHeads")
 	}
 
-	var r0 *model.Entity
+	var r0 []*model.AuditLogChainHead
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, types.OperationMode, string) (*model.Entity, error)); ok {
-		return returnFunc(ctx, mode, slug)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*model.AuditLogChainHead, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, types.OperationMode, string) *model.Entity); ok {
-		r0 = returnFunc(ctx, mode, slug)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*model.AuditLogChainHead); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Entity)
+			r0 = ret.Get(0).([]*model.AuditLogChainHead)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, types.OperationMode, string) error); ok {
-		r1 = returnFunc(ctx, mode, slug)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockEntityer_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
-type MockEntityer_GetBySlug_Call struct {
+// MockAuditLoger_ListChainHeads_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChainHeads'
+type MockAuditLoger_ListChainHeads_Call struct {
 	*mock.Call
 }
 
-// GetBySlug is a helper method to define mock.On call
+// ListChainHeads is a helper method to define mock.On call
 //   - ctx context.Context
-//   - mode types.OperationMode
-//   - slug string
-func (_e *MockEntityer_Expecter) GetBySlug(ctx interface{}, mode interface{}, slug interface{}) *MockEntityer_GetBySlug_Call {
-	return &MockEntityer_GetBySlug_Call{Call: _e.mock.On("GetBySlug", ctx, mode, slug)}
+func (_e *MockAuditLoger_Expecter) ListChainHeads(ctx interface{}) *MockAuditLoger_ListChainHeads_Call {
+	return &MockAuditLoger_ListChainHeads_Call{Call: _e.mock.On("ListChainHeads", ctx)}
 }
 
-func (_c *MockEntityer_GetBySlug_Call) Run(run func(ctx context.Context, mode types.OperationMode, slug string)) *MockEntityer_GetBySlug_Call {
+func (_c *MockAuditLoger_ListChainHeads_Call) Run(run func(ctx context.Context)) *MockAuditLoger_ListChainHeads_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 types.OperationMode
-		if args[1] != nil {
-			arg1 = args[1].(types.OperationMode)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEntityer_GetBySlug_Call) Return(entity *model.Entity, err error) *MockEntityer_GetBySlug_Call {
-	_c.Call.Return(entity, err)
+func (_c *MockAuditLoger_ListChainHeads_Call) Return(auditLogChainHeads []*model.AuditLogChainHead, err error) *MockAuditLoger_ListChainHeads_Call {
+	_c.Call.Return(auditLogChainHeads, err)
 	return _c
 }
 
-func (_c *MockEntityer_GetBySlug_Call) RunAndReturn(run func(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error)) *MockEntityer_GetBySlug_Call {
+func (_c *MockAuditLoger_ListChainHeads_Call) RunAndReturn(run func(ctx context.Context) ([]*model.AuditLogChainHead, error)) *MockAuditLoger_ListChainHeads_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// WithQuerier provides a mock function for the type MockEntityer
-func (_mock *MockEntityer) WithQuerier(querier core.Querier) store.Entityer {
-	ret := _mock.Called(querier)
+// WithQuerier provides a mock function for the type MockAuditLoger
+func (_mock *MockAuditLoger) WithQuerier(q core.Querier) store.AuditLoger {
+	ret := _mock.Called(q)
 
 	if len(ret) == 0 {
 		panic("no return value specified for WithQuerier")
 	}
 
-	var r0 store.Entityer
-	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.Entityer); ok {
-		r0 = returnFunc(querier)
+	var r0 store.AuditLoger
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.AuditLoger); ok {
+		r0 = returnFunc(q)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(store.Entityer)
+			r0 = ret.Get(0).(store.AuditLoger)
 		}
 	}
 	return r0
 }
 
-// MockEntityer_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
-type MockEntityer_WithQuerier_Call struct {
+// MockAuditLoger_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockAuditLoger_WithQuerier_Call struct {
 	*mock.Call
 }
 
 // WithQuerier is a helper method to define mock.On call
-//   - querier core.Querier
-func (_e *MockEntityer_Expecter) WithQuerier(querier interface{}) *MockEntityer_WithQuerier_Call {
-	return &MockEntityer_WithQuerier_Call{Call: _e.mock.On("WithQuerier", querier)}
+//   - q core.Querier
+func (_e *MockAuditLoger_Expecter) WithQuerier(q interface{}) *MockAuditLoger_WithQuerier_Call {
+	return &MockAuditLoger_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
 }
 
-func (_c *MockEntityer_WithQuerier_Call) Run(run func(querier core.Querier)) *MockEntityer_WithQuerier_Call {
+func (_c *MockAuditLoger_WithQuerier_Call) Run(run func(q core.Querier)) *MockAuditLoger_WithQuerier_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 core.Querier
 		if args[0] != nil {
@@ -510,23 +568,23 @@ func (_c *MockEntityer_WithQuerier_Call) Run(run func(querier core.Querier)) *Mo
 	return _c
 }
 
-func (_c *MockEntityer_WithQuerier_Call) Return(entityer store.Entityer) *MockEntityer_WithQuerier_Call {
-	_c.Call.Return(entityer)
+func (_c *MockAuditLoger_WithQuerier_Call) Return(auditLoger store.AuditLoger) *MockAuditLoger_WithQuerier_Call {
+	_c.Call.Return(auditLoger)
 	return _c
 }
 
-func (_c *MockEntityer_WithQuerier_Call) RunAndReturn(run func(querier core.Querier) store.Entityer) *MockEntityer_WithQuerier_Call {
+func (_c *MockAuditLoger_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.AuditLoger) *MockAuditLoger_WithQuerier_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMembershiper creates a new instance of MockMembershiper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockCompliancer creates a new instance of MockCompliancer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockMembershiper(t interface {
+func NewMockCompliancer(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockMembershiper {
-	mock := &MockMembershiper{}
+}) *MockCompliancer {
+	mock := &MockCompliancer{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -534,68 +592,68 @@ func NewMockMembershiper(t interface {
 	return mock
 }
 
-// MockMembershiper is an autogenerated mock type for the Membershiper type
-type MockMembershiper struct {
+// MockCompliancer is an autogenerated mock type for the Compliancer type
+type MockCompliancer struct {
 	mock.Mock
 }
 
-type MockMembershiper_Expecter struct {
+type MockCompliancer_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockMembershiper) EXPECT() *MockMembershiper_Expecter {
-	return &MockMembershiper_Expecter{mock: &_m.Mock}
+func (_m *MockCompliancer) EXPECT() *MockCompliancer_Expecter {
+	return &MockCompliancer_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type MockMembershiper
-func (_mock *MockMembershiper) Create(ctx context.Context, membership *model.Membership) (*model.Membership, error) {
-	ret := _mock.Called(ctx, membership)
+// Create provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) Create(ctx context.Context, record *model.ComplianceRecord) (*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, record)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 *model.Membership
+	var r0 *model.ComplianceRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Membership) (*model.Membership, error)); ok {
-		return returnFunc(ctx, membership)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.ComplianceRecord) (*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, record)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Membership) *model.Membership); ok {
-		r0 = returnFunc(ctx, membership)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.ComplianceRecord) *model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, record)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Membership)
+			r0 = ret.Get(0).(*model.ComplianceRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.Membership) error); ok {
-		r1 = returnFunc(ctx, membership)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.ComplianceRecord) error); ok {
+		r1 = returnFunc(ctx, record)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMembershiper_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockMembershiper_Create_Call struct {
+// MockCompliancer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockCompliancer_Create_Call struct {
 	*mock.Call
 }
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - membership *model.Membership
-func (_e *MockMembershiper_Expecter) Create(ctx interface{}, membership interface{}) *MockMembershiper_Create_Call {
-	return &MockMembershiper_Create_Call{Call: _e.mock.On("Create", ctx, membership)}
+//   - record *model.ComplianceRecord
+func (_e *MockCompliancer_Expecter) Create(ctx interface{}, record interface{}) *MockCompliancer_Create_Call {
+	return &MockCompliancer_Create_Call{Call: _e.mock.On("Create", ctx, record)}
 }
 
-func (_c *MockMembershiper_Create_Call) Run(run func(ctx context.Context, membership *model.Membership)) *MockMembershiper_Create_Call {
+func (_c *MockCompliancer_Create_Call) Run(run func(ctx context.Context, record *model.ComplianceRecord)) *MockCompliancer_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.Membership
+		var arg1 *model.ComplianceRecord
 		if args[1] != nil {
-			arg1 = args[1].(*model.Membership)
+			arg1 = args[1].(*model.ComplianceRecord)
 		}
 		run(
 			arg0,
@@ -605,65 +663,65 @@ func (_c *MockMembershiper_Create_Call) Run(run func(ctx context.Context, member
 	return _c
 }
 
-func (_c *MockMembershiper_Create_Call) Return(membership1 *model.Membership, err error) *MockMembershiper_Create_Call {
-	_c.Call.Return(membership1, err)
+func (_c *MockCompliancer_Create_Call) Return(complianceRecord *model.ComplianceRecord, err error) *MockCompliancer_Create_Call {
+	_c.Call.Return(complianceRecord, err)
 	return _c
 }
 
-func (_c *MockMembershiper_Create_Call) RunAndReturn(run func(ctx context.Context, membership *model.Membership) (*model.Membership, error)) *MockMembershiper_Create_Call {
+func (_c *MockCompliancer_Create_Call) RunAndReturn(run func(ctx context.Context, record *model.ComplianceRecord) (*model.ComplianceRecord, error)) *MockCompliancer_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByEntityID provides a mock function for the type MockMembershiper
-func (_mock *MockMembershiper) GetByEntityID(ctx context.Context, entityID string) ([]*model.Membership, error) {
-	ret := _mock.Called(ctx, entityID)
+// CreateDocument provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) CreateDocument(ctx context.Context, document *model.ComplianceDocument) (*model.ComplianceDocument, error) {
+	ret := _mock.Called(ctx, document)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByEntityID")
+		panic("no return value specified for CreateDocument")
 	}
 
-	var r0 []*model.Membership
+	var r0 *model.ComplianceDocument
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
-		return returnFunc(ctx, entityID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.ComplianceDocument) (*model.ComplianceDocument, error)); ok {
+		return returnFunc(ctx, document)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
-		r0 = returnFunc(ctx, entityID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.ComplianceDocument) *model.ComplianceDocument); ok {
+		r0 = returnFunc(ctx, document)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Membership)
+			r0 = ret.Get(0).(*model.ComplianceDocument)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, entityID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.ComplianceDocument) error); ok {
+		r1 = returnFunc(ctx, document)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMembershiper_GetByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEntityID'
-type MockMembershiper_GetByEntityID_Call struct {
+// MockCompliancer_CreateDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDocument'
+type MockCompliancer_CreateDocument_Call struct {
 	*mock.Call
 }
 
-// GetByEntityID is a helper method to define mock.On call
+// CreateDocument is a helper method to define mock.On call
 //   - ctx context.Context
-//   - entityID string
-func (_e *MockMembershiper_Expecter) GetByEntityID(ctx interface{}, entityID interface{}) *MockMembershiper_GetByEntityID_Call {
-	return &MockMembershiper_GetByEntityID_Call{Call: _e.mock.On("GetByEntityID", ctx, entityID)}
+//   - document *model.ComplianceDocument
+func (_e *MockCompliancer_Expecter) CreateDocument(ctx interface{}, document interface{}) *MockCompliancer_CreateDocument_Call {
+	return &MockCompliancer_CreateDocument_Call{Call: _e.mock.On("CreateDocument", ctx, document)}
 }
 
-func (_c *MockMembershiper_GetByEntityID_Call) Run(run func(ctx context.Context, entityID string)) *MockMembershiper_GetByEntityID_Call {
+func (_c *MockCompliancer_CreateDocument_Call) Run(run func(ctx context.Context, document *model.ComplianceDocument)) *MockCompliancer_CreateDocument_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *model.ComplianceDocument
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*model.ComplianceDocument)
 		}
 		run(
 			arg0,
@@ -673,58 +731,46 @@ func (_c *MockMembershiper_GetByEntityID_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockMembershiper_GetByEntityID_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByEntityID_Call {
-	_c.Call.Return(memberships, err)
+func (_c *MockCompliancer_CreateDocument_Call) Return(complianceDocument *model.ComplianceDocument, err error) *MockCompliancer_CreateDocument_Call {
+	_c.Call.Return(complianceDocument, err)
 	return _c
 }
 
-func (_c *MockMembershiper_GetByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.Membership, error)) *MockMembershiper_GetByEntityID_Call {
+func (_c *MockCompliancer_CreateDocument_Call) RunAndReturn(run func(ctx context.Context, document *model.ComplianceDocument) (*model.ComplianceDocument, error)) *MockCompliancer_CreateDocument_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByEntityIDWithInheritance provides a mock function for the type MockMembershiper
-func (_mock *MockMembershiper) GetByEntityIDWithInheritance(ctx context.Context, userID string, entityID string) ([]*model.Membership, error) {
-	ret := _mock.Called(ctx, userID, entityID)
+// DeleteDocument provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) DeleteDocument(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByEntityIDWithInheritance")
+		panic("no return value specified for DeleteDocument")
 	}
 
-	var r0 []*model.Membership
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]*model.Membership, error)); ok {
-		return returnFunc(ctx, userID, entityID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []*model.Membership); ok {
-		r0 = returnFunc(ctx, userID, entityID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Membership)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, userID, entityID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockMembershiper_GetByEntityIDWithInheritance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEntityIDWithInheritance'
-type MockMembershiper_GetByEntityIDWithInheritance_Call struct {
+// MockCompliancer_DeleteDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDocument'
+type MockCompliancer_DeleteDocument_Call struct {
 	*mock.Call
 }
 
-// GetByEntityIDWithInheritance is a helper method to define mock.On call
+// DeleteDocument is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - entityID string
-func (_e *MockMembershiper_Expecter) GetByEntityIDWithInheritance(ctx interface{}, userID interface{}, entityID interface{}) *MockMembershiper_GetByEntityIDWithInheritance_Call {
-	return &MockMembershiper_GetByEntityIDWithInheritance_Call{Call: _e.mock.On("GetByEntityIDWithInheritance", ctx, userID, entityID)}
+//   - id string
+func (_e *MockCompliancer_Expecter) DeleteDocument(ctx interface{}, id interface{}) *MockCompliancer_DeleteDocument_Call {
+	return &MockCompliancer_DeleteDocument_Call{Call: _e.mock.On("DeleteDocument", ctx, id)}
 }
 
-func (_c *MockMembershiper_GetByEntityIDWithInheritance_Call) Run(run func(ctx context.Context, userID string, entityID string)) *MockMembershiper_GetByEntityIDWithInheritance_Call {
+func (_c *MockCompliancer_DeleteDocument_Call) Run(run func(ctx context.Context, id string)) *MockCompliancer_DeleteDocument_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -734,70 +780,65 @@ func (_c *MockMembershiper_GetByEntityIDWithInheritance_Call) Run(run func(ctx c
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMembershiper_GetByEntityIDWithInheritance_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByEntityIDWithInheritance_Call {
-	_c.Call.Return(memberships, err)
+func (_c *MockCompliancer_DeleteDocument_Call) Return(err error) *MockCompliancer_DeleteDocument_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMembershiper_GetByEntityIDWithInheritance_Call) RunAndReturn(run func(ctx context.Context, userID string, entityID string) ([]*model.Membership, error)) *MockMembershiper_GetByEntityIDWithInheritance_Call {
+func (_c *MockCompliancer_DeleteDocument_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockCompliancer_DeleteDocument_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByUserID provides a mock function for the type MockMembershiper
-func (_mock *MockMembershiper) GetByUserID(ctx context.Context, userID string) ([]*model.Membership, error) {
-	ret := _mock.Called(ctx, userID)
+// GetByID provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) GetByID(ctx context.Context, id string) (*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUserID")
+		panic("no return value specified for GetByID")
 	}
 
-	var r0 []*model.Membership
+	var r0 *model.ComplianceRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Membership)
+			r0 = ret.Get(0).(*model.ComplianceRecord)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMembershiper_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
-type MockMembershiper_GetByUserID_Call struct {
+// MockCompliancer_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockCompliancer_GetByID_Call struct {
 	*mock.Call
 }
 
-// GetByUserID is a helper method to define mock.On call
+// GetByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockMembershiper_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockMembershiper_GetByUserID_Call {
-	return &MockMembershiper_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+//   - id string
+func (_e *MockCompliancer_Expecter) GetByID(ctx interface{}, id interface{}) *MockCompliancer_GetByID_Call {
+	return &MockCompliancer_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
 }
 
-func (_c *MockMembershiper_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockMembershiper_GetByUserID_Call {
+func (_c *MockCompliancer_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockCompliancer_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -815,264 +856,5722 @@ func (_c *MockMembershiper_GetByUserID_Call) Run(run func(ctx context.Context, u
 	return _c
 }
 
-func (_c *MockMembershiper_GetByUserID_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByUserID_Call {
-	_c.Call.Return(memberships, err)
+func (_c *MockCompliancer_GetByID_Call) Return(complianceRecord *model.ComplianceRecord, err error) *MockCompliancer_GetByID_Call {
+	_c.Call.Return(complianceRecord, err)
 	return _c
 }
 
-func (_c *MockMembershiper_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Membership, error)) *MockMembershiper_GetByUserID_Call {
+func (_c *MockCompliancer_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.ComplianceRecord, error)) *MockCompliancer_GetByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByUserIDWithInheritance provides a mock function for the type MockMembershiper
-func (_mock *MockMembershiper) GetByUserIDWithInheritance(ctx context.Context, userID string) ([]*model.Membership, error) {
-	ret := _mock.Called(ctx, userID)
+// GetBySubject provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) GetBySubject(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string, recordType model.ComplianceType) (*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, subjectType, subjectID, recordType)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUserIDWithInheritance")
+		panic("no return value specified for GetBySubject")
 	}
 
-	var r0 []*model.Membership
+	var r0 *model.ComplianceRecord
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.ComplianceSubjectType, string, model.ComplianceType) (*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, subjectType, subjectID, recordType)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.ComplianceSubjectType, string, model.ComplianceType) *model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, subjectType, subjectID, recordType)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Membership)
+			r0 = ret.Get(0).(*model.ComplianceRecord)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.ComplianceSubjectType, string, model.ComplianceType) error); ok {
+		r1 = returnFunc(ctx, subjectType, subjectID, recordType)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockMembershiper_GetByUserIDWithInheritance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserIDWithInheritance'
-type MockMembershiper_GetByUserIDWithInheritance_Call struct {
+// MockCompliancer_GetBySubject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySubject'
+type MockCompliancer_GetBySubject_Call struct {
 	*mock.Call
 }
 
-// GetByUserIDWithInheritance is a helper method to define mock.On call
+// GetBySubject is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockMembershiper_Expecter) GetByUserIDWithInheritance(ctx interface{}, userID interface{}) *MockMembershiper_GetByUserIDWithInheritance_Call {
-	return &MockMembershiper_GetByUserIDWithInheritance_Call{Call: _e.mock.On("GetByUserIDWithInheritance", ctx, userID)}
+//   - subjectType model.ComplianceSubjectType
+//   - subjectID string
+//   - recordType model.ComplianceType
+func (_e *MockCompliancer_Expecter) GetBySubject(ctx interface{}, subjectType interface{}, subjectID interface{}, recordType interface{}) *MockCompliancer_GetBySubject_Call {
+	return &MockCompliancer_GetBySubject_Call{Call: _e.mock.On("GetBySubject", ctx, subjectType, subjectID, recordType)}
 }
 
-func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) Run(run func(ctx context.Context, userID string)) *MockMembershiper_GetByUserIDWithInheritance_Call {
+func (_c *MockCompliancer_GetBySubject_Call) Run(run func(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string, recordType model.ComplianceType)) *MockCompliancer_GetBySubject_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 model.ComplianceSubjectType
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(model.ComplianceSubjectType)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 model.ComplianceType
+		if args[3] != nil {
+			arg3 = args[3].(model.ComplianceType)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByUserIDWithInheritance_Call {
-	_c.Call.Return(memberships, err)
+func (_c *MockCompliancer_GetBySubject_Call) Return(complianceRecord *model.ComplianceRecord, err error) *MockCompliancer_GetBySubject_Call {
+	_c.Call.Return(complianceRecord, err)
 	return _c
 }
 
-func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Membership, error)) *MockMembershiper_GetByUserIDWithInheritance_Call {
+func (_c *MockCompliancer_GetBySubject_Call) RunAndReturn(run func(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string, recordType model.ComplianceType) (*model.ComplianceRecord, error)) *MockCompliancer_GetBySubject_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// WithQuerier provides a mock function for the type MockMembershiper
-func (_mock *MockMembershiper) WithQuerier(q core.Querier) store.Membershiper {
-	ret := _mock.Called(q)
+// GetDocument provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) GetDocument(ctx context.Context, id string) (*model.ComplianceDocument, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for WithQuerier")
+		panic("no return value specified for GetDocument")
 	}
 
-	var r0 store.Membershiper
-	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.Membershiper); ok {
-		r0 = returnFunc(q)
+	var r0 *model.ComplianceDocument
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.ComplianceDocument, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.ComplianceDocument); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(store.Membershiper)
+			r0 = ret.Get(0).(*model.ComplianceDocument)
 		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockMembershiper_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
-type MockMembershiper_WithQuerier_Call struct {
+// MockCompliancer_GetDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDocument'
+type MockCompliancer_GetDocument_Call struct {
 	*mock.Call
 }
 
-// WithQuerier is a helper method to define mock.On call
-//   - q core.Querier
-func (_e *MockMembershiper_Expecter) WithQuerier(q interface{}) *MockMembershiper_WithQuerier_Call {
-	return &MockMembershiper_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+// GetDocument is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockCompliancer_Expecter) GetDocument(ctx interface{}, id interface{}) *MockCompliancer_GetDocument_Call {
+	return &MockCompliancer_GetDocument_Call{Call: _e.mock.On("GetDocument", ctx, id)}
 }
 
-func (_c *MockMembershiper_WithQuerier_Call) Run(run func(q core.Querier)) *MockMembershiper_WithQuerier_Call {
+func (_c *MockCompliancer_GetDocument_Call) Run(run func(ctx context.Context, id string)) *MockCompliancer_GetDocument_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 core.Querier
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(core.Querier)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMembershiper_WithQuerier_Call) Return(membershiper store.Membershiper) *MockMembershiper_WithQuerier_Call {
-	_c.Call.Return(membershiper)
+func (_c *MockCompliancer_GetDocument_Call) Return(complianceDocument *model.ComplianceDocument, err error) *MockCompliancer_GetDocument_Call {
+	_c.Call.Return(complianceDocument, err)
 	return _c
 }
 
-func (_c *MockMembershiper_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.Membershiper) *MockMembershiper_WithQuerier_Call {
+func (_c *MockCompliancer_GetDocument_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.ComplianceDocument, error)) *MockCompliancer_GetDocument_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockSessioner creates a new instance of MockSessioner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockSessioner(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockSessioner {
-	mock := &MockSessioner{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockSessioner is an autogenerated mock type for the Sessioner type
-type MockSessioner struct {
-	mock.Mock
-}
-
-type MockSessioner_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockSessioner) EXPECT() *MockSessioner_Expecter {
-	return &MockSessioner_Expecter{mock: &_m.Mock}
-}
-
-// CleanUpExpired provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) CleanUpExpired(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// ListBySubject provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) ListBySubject(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string) ([]*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, subjectType, subjectID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CleanUpExpired")
+		panic("no return value specified for ListBySubject")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	var r0 []*model.ComplianceRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.ComplianceSubjectType, string) ([]*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, subjectType, subjectID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.ComplianceSubjectType, string) []*model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, subjectType, subjectID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ComplianceRecord)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.ComplianceSubjectType, string) error); ok {
+		r1 = returnFunc(ctx, subjectType, subjectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockSessioner_CleanUpExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanUpExpired'
-type MockSessioner_CleanUpExpired_Call struct {
+// MockCompliancer_ListBySubject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBySubject'
+type MockCompliancer_ListBySubject_Call struct {
 	*mock.Call
 }
 
-// CleanUpExpired is a helper method to define mock.On call
+// ListBySubject is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockSessioner_Expecter) CleanUpExpired(ctx interface{}) *MockSessioner_CleanUpExpired_Call {
-	return &MockSessioner_CleanUpExpired_Call{Call: _e.mock.On("CleanUpExpired", ctx)}
+//   - subjectType model.ComplianceSubjectType
+//   - subjectID string
+func (_e *MockCompliancer_Expecter) ListBySubject(ctx interface{}, subjectType interface{}, subjectID interface{}) *MockCompliancer_ListBySubject_Call {
+	return &MockCompliancer_ListBySubject_Call{Call: _e.mock.On("ListBySubject", ctx, subjectType, subjectID)}
 }
 
-func (_c *MockSessioner_CleanUpExpired_Call) Run(run func(ctx context.Context)) *MockSessioner_CleanUpExpired_Call {
+func (_c *MockCompliancer_ListBySubject_Call) Run(run func(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string)) *MockCompliancer_ListBySubject_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		run(
-			arg0,
-		)
+		var arg1 model.ComplianceSubjectType
+		if args[1] != nil {
+			arg1 = args[1].(model.ComplianceSubjectType)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCompliancer_ListBySubject_Call) Return(complianceRecords []*model.ComplianceRecord, err error) *MockCompliancer_ListBySubject_Call {
+	_c.Call.Return(complianceRecords, err)
+	return _c
+}
+
+func (_c *MockCompliancer_ListBySubject_Call) RunAndReturn(run func(ctx context.Context, subjectType model.ComplianceSubjectType, subjectID string) ([]*model.ComplianceRecord, error)) *MockCompliancer_ListBySubject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDocuments provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) ListDocuments(ctx context.Context, recordID string) ([]*model.ComplianceDocument, error) {
+	ret := _mock.Called(ctx, recordID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDocuments")
+	}
+
+	var r0 []*model.ComplianceDocument
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.ComplianceDocument, error)); ok {
+		return returnFunc(ctx, recordID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.ComplianceDocument); ok {
+		r0 = returnFunc(ctx, recordID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ComplianceDocument)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, recordID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCompliancer_ListDocuments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDocuments'
+type MockCompliancer_ListDocuments_Call struct {
+	*mock.Call
+}
+
+// ListDocuments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recordID string
+func (_e *MockCompliancer_Expecter) ListDocuments(ctx interface{}, recordID interface{}) *MockCompliancer_ListDocuments_Call {
+	return &MockCompliancer_ListDocuments_Call{Call: _e.mock.On("ListDocuments", ctx, recordID)}
+}
+
+func (_c *MockCompliancer_ListDocuments_Call) Run(run func(ctx context.Context, recordID string)) *MockCompliancer_ListDocuments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCompliancer_ListDocuments_Call) Return(complianceDocuments []*model.ComplianceDocument, err error) *MockCompliancer_ListDocuments_Call {
+	_c.Call.Return(complianceDocuments, err)
+	return _c
+}
+
+func (_c *MockCompliancer_ListDocuments_Call) RunAndReturn(run func(ctx context.Context, recordID string) ([]*model.ComplianceDocument, error)) *MockCompliancer_ListDocuments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListForReview provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) ListForReview(ctx context.Context, platformID string, status model.ComplianceStatus) ([]*model.ComplianceRecord, error) {
+	ret := _mock.Called(ctx, platformID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListForReview")
+	}
+
+	var r0 []*model.ComplianceRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ComplianceStatus) ([]*model.ComplianceRecord, error)); ok {
+		return returnFunc(ctx, platformID, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ComplianceStatus) []*model.ComplianceRecord); ok {
+		r0 = returnFunc(ctx, platformID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ComplianceRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.ComplianceStatus) error); ok {
+		r1 = returnFunc(ctx, platformID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCompliancer_ListForReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListForReview'
+type MockCompliancer_ListForReview_Call struct {
+	*mock.Call
+}
+
+// ListForReview is a helper method to define mock.On call
+//   - ctx context.Context
+//   - platformID string
+//   - status model.ComplianceStatus
+func (_e *MockCompliancer_Expecter) ListForReview(ctx interface{}, platformID interface{}, status interface{}) *MockCompliancer_ListForReview_Call {
+	return &MockCompliancer_ListForReview_Call{Call: _e.mock.On("ListForReview", ctx, platformID, status)}
+}
+
+func (_c *MockCompliancer_ListForReview_Call) Run(run func(ctx context.Context, platformID string, status model.ComplianceStatus)) *MockCompliancer_ListForReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.ComplianceStatus
+		if args[2] != nil {
+			arg2 = args[2].(model.ComplianceStatus)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCompliancer_ListForReview_Call) Return(complianceRecords []*model.ComplianceRecord, err error) *MockCompliancer_ListForReview_Call {
+	_c.Call.Return(complianceRecords, err)
+	return _c
+}
+
+func (_c *MockCompliancer_ListForReview_Call) RunAndReturn(run func(ctx context.Context, platformID string, status model.ComplianceStatus) ([]*model.ComplianceRecord, error)) *MockCompliancer_ListForReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) Update(ctx context.Context, record *model.ComplianceRecord) error {
+	ret := _mock.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.ComplianceRecord) error); ok {
+		r0 = returnFunc(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCompliancer_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockCompliancer_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *model.ComplianceRecord
+func (_e *MockCompliancer_Expecter) Update(ctx interface{}, record interface{}) *MockCompliancer_Update_Call {
+	return &MockCompliancer_Update_Call{Call: _e.mock.On("Update", ctx, record)}
+}
+
+func (_c *MockCompliancer_Update_Call) Run(run func(ctx context.Context, record *model.ComplianceRecord)) *MockCompliancer_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.ComplianceRecord
+		if args[1] != nil {
+			arg1 = args[1].(*model.ComplianceRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCompliancer_Update_Call) Return(err error) *MockCompliancer_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCompliancer_Update_Call) RunAndReturn(run func(ctx context.Context, record *model.ComplianceRecord) error) *MockCompliancer_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockCompliancer
+func (_mock *MockCompliancer) WithQuerier(q core.Querier) store.Compliancer {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.Compliancer
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.Compliancer); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Compliancer)
+		}
+	}
+	return r0
+}
+
+// MockCompliancer_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockCompliancer_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockCompliancer_Expecter) WithQuerier(q interface{}) *MockCompliancer_WithQuerier_Call {
+	return &MockCompliancer_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockCompliancer_WithQuerier_Call) Run(run func(q core.Querier)) *MockCompliancer_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCompliancer_WithQuerier_Call) Return(compliancer store.Compliancer) *MockCompliancer_WithQuerier_Call {
+	_c.Call.Return(compliancer)
+	return _c
+}
+
+func (_c *MockCompliancer_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.Compliancer) *MockCompliancer_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEntityer creates a new instance of MockEntityer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEntityer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEntityer {
+	mock := &MockEntityer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEntityer is an autogenerated mock type for the Entityer type
+type MockEntityer struct {
+	mock.Mock
+}
+
+type MockEntityer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEntityer) EXPECT() *MockEntityer_Expecter {
+	return &MockEntityer_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) Create(ctx context.Context, entity *model.Entity) (*model.Entity, error) {
+	ret := _mock.Called(ctx, entity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Entity) (*model.Entity, error)); ok {
+		return returnFunc(ctx, entity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Entity) *model.Entity); ok {
+		r0 = returnFunc(ctx, entity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.Entity) error); ok {
+		r1 = returnFunc(ctx, entity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockEntityer_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entity *model.Entity
+func (_e *MockEntityer_Expecter) Create(ctx interface{}, entity interface{}) *MockEntityer_Create_Call {
+	return &MockEntityer_Create_Call{Call: _e.mock.On("Create", ctx, entity)}
+}
+
+func (_c *MockEntityer_Create_Call) Run(run func(ctx context.Context, entity *model.Entity)) *MockEntityer_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Entity
+		if args[1] != nil {
+			arg1 = args[1].(*model.Entity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_Create_Call) Return(entity1 *model.Entity, err error) *MockEntityer_Create_Call {
+	_c.Call.Return(entity1, err)
+	return _c
+}
+
+func (_c *MockEntityer_Create_Call) RunAndReturn(run func(ctx context.Context, entity *model.Entity) (*model.Entity, error)) *MockEntityer_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) Get(ctx context.Context, id string) (*model.Entity, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Entity, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Entity); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockEntityer_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEntityer_Expecter) Get(ctx interface{}, id interface{}) *MockEntityer_Get_Call {
+	return &MockEntityer_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockEntityer_Get_Call) Run(run func(ctx context.Context, id string)) *MockEntityer_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_Get_Call) Return(entity *model.Entity, err error) *MockEntityer_Get_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockEntityer_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Entity, error)) *MockEntityer_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) GetByID(ctx context.Context, id string) (*model.Entity, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Entity, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Entity); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockEntityer_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEntityer_Expecter) GetByID(ctx interface{}, id interface{}) *MockEntityer_GetByID_Call {
+	return &MockEntityer_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockEntityer_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockEntityer_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_GetByID_Call) Return(entity *model.Entity, err error) *MockEntityer_GetByID_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockEntityer_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Entity, error)) *MockEntityer_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBySlug provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) GetBySlug(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error) {
+	ret := _mock.Called(ctx, mode, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySlug")
+	}
+
+	var r0 *model.Entity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, types.OperationMode, string) (*model.Entity, error)); ok {
+		return returnFunc(ctx, mode, slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, types.OperationMode, string) *model.Entity); ok {
+		r0 = returnFunc(ctx, mode, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Entity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, types.OperationMode, string) error); ok {
+		r1 = returnFunc(ctx, mode, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_GetBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBySlug'
+type MockEntityer_GetBySlug_Call struct {
+	*mock.Call
+}
+
+// GetBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mode types.OperationMode
+//   - slug string
+func (_e *MockEntityer_Expecter) GetBySlug(ctx interface{}, mode interface{}, slug interface{}) *MockEntityer_GetBySlug_Call {
+	return &MockEntityer_GetBySlug_Call{Call: _e.mock.On("GetBySlug", ctx, mode, slug)}
+}
+
+func (_c *MockEntityer_GetBySlug_Call) Run(run func(ctx context.Context, mode types.OperationMode, slug string)) *MockEntityer_GetBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 types.OperationMode
+		if args[1] != nil {
+			arg1 = args[1].(types.OperationMode)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_GetBySlug_Call) Return(entity *model.Entity, err error) *MockEntityer_GetBySlug_Call {
+	_c.Call.Return(entity, err)
+	return _c
+}
+
+func (_c *MockEntityer_GetBySlug_Call) RunAndReturn(run func(ctx context.Context, mode types.OperationMode, slug string) (*model.Entity, error)) *MockEntityer_GetBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsDescendant provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) IsDescendant(ctx context.Context, id string, ancestorID string) (bool, error) {
+	ret := _mock.Called(ctx, id, ancestorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsDescendant")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, id, ancestorID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, id, ancestorID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, ancestorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityer_IsDescendant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsDescendant'
+type MockEntityer_IsDescendant_Call struct {
+	*mock.Call
+}
+
+// IsDescendant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ancestorID string
+func (_e *MockEntityer_Expecter) IsDescendant(ctx interface{}, id interface{}, ancestorID interface{}) *MockEntityer_IsDescendant_Call {
+	return &MockEntityer_IsDescendant_Call{Call: _e.mock.On("IsDescendant", ctx, id, ancestorID)}
+}
+
+func (_c *MockEntityer_IsDescendant_Call) Run(run func(ctx context.Context, id string, ancestorID string)) *MockEntityer_IsDescendant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_IsDescendant_Call) Return(b bool, err error) *MockEntityer_IsDescendant_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockEntityer_IsDescendant_Call) RunAndReturn(run func(ctx context.Context, id string, ancestorID string) (bool, error)) *MockEntityer_IsDescendant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBranding provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) UpdateBranding(ctx context.Context, id string, brandColor *string, supportEmail *string) error {
+	ret := _mock.Called(ctx, id, brandColor, supportEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBranding")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *string, *string) error); ok {
+		r0 = returnFunc(ctx, id, brandColor, supportEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEntityer_UpdateBranding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBranding'
+type MockEntityer_UpdateBranding_Call struct {
+	*mock.Call
+}
+
+// UpdateBranding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - brandColor *string
+//   - supportEmail *string
+func (_e *MockEntityer_Expecter) UpdateBranding(ctx interface{}, id interface{}, brandColor interface{}, supportEmail interface{}) *MockEntityer_UpdateBranding_Call {
+	return &MockEntityer_UpdateBranding_Call{Call: _e.mock.On("UpdateBranding", ctx, id, brandColor, supportEmail)}
+}
+
+func (_c *MockEntityer_UpdateBranding_Call) Run(run func(ctx context.Context, id string, brandColor *string, supportEmail *string)) *MockEntityer_UpdateBranding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *string
+		if args[2] != nil {
+			arg2 = args[2].(*string)
+		}
+		var arg3 *string
+		if args[3] != nil {
+			arg3 = args[3].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_UpdateBranding_Call) Return(err error) *MockEntityer_UpdateBranding_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEntityer_UpdateBranding_Call) RunAndReturn(run func(ctx context.Context, id string, brandColor *string, supportEmail *string) error) *MockEntityer_UpdateBranding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLogo provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) UpdateLogo(ctx context.Context, id string, logo *string) error {
+	ret := _mock.Called(ctx, id, logo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLogo")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *string) error); ok {
+		r0 = returnFunc(ctx, id, logo)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEntityer_UpdateLogo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLogo'
+type MockEntityer_UpdateLogo_Call struct {
+	*mock.Call
+}
+
+// UpdateLogo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - logo *string
+func (_e *MockEntityer_Expecter) UpdateLogo(ctx interface{}, id interface{}, logo interface{}) *MockEntityer_UpdateLogo_Call {
+	return &MockEntityer_UpdateLogo_Call{Call: _e.mock.On("UpdateLogo", ctx, id, logo)}
+}
+
+func (_c *MockEntityer_UpdateLogo_Call) Run(run func(ctx context.Context, id string, logo *string)) *MockEntityer_UpdateLogo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *string
+		if args[2] != nil {
+			arg2 = args[2].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_UpdateLogo_Call) Return(err error) *MockEntityer_UpdateLogo_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEntityer_UpdateLogo_Call) RunAndReturn(run func(ctx context.Context, id string, logo *string) error) *MockEntityer_UpdateLogo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) UpdateStatus(ctx context.Context, id string, status model.EntityStatus) error {
+	ret := _mock.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.EntityStatus) error); ok {
+		r0 = returnFunc(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEntityer_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type MockEntityer_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status model.EntityStatus
+func (_e *MockEntityer_Expecter) UpdateStatus(ctx interface{}, id interface{}, status interface{}) *MockEntityer_UpdateStatus_Call {
+	return &MockEntityer_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, status)}
+}
+
+func (_c *MockEntityer_UpdateStatus_Call) Run(run func(ctx context.Context, id string, status model.EntityStatus)) *MockEntityer_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.EntityStatus
+		if args[2] != nil {
+			arg2 = args[2].(model.EntityStatus)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_UpdateStatus_Call) Return(err error) *MockEntityer_UpdateStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEntityer_UpdateStatus_Call) RunAndReturn(run func(ctx context.Context, id string, status model.EntityStatus) error) *MockEntityer_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockEntityer
+func (_mock *MockEntityer) WithQuerier(querier core.Querier) store.Entityer {
+	ret := _mock.Called(querier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.Entityer
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.Entityer); ok {
+		r0 = returnFunc(querier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Entityer)
+		}
+	}
+	return r0
+}
+
+// MockEntityer_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockEntityer_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - querier core.Querier
+func (_e *MockEntityer_Expecter) WithQuerier(querier interface{}) *MockEntityer_WithQuerier_Call {
+	return &MockEntityer_WithQuerier_Call{Call: _e.mock.On("WithQuerier", querier)}
+}
+
+func (_c *MockEntityer_WithQuerier_Call) Run(run func(querier core.Querier)) *MockEntityer_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityer_WithQuerier_Call) Return(entityer store.Entityer) *MockEntityer_WithQuerier_Call {
+	_c.Call.Return(entityer)
+	return _c
+}
+
+func (_c *MockEntityer_WithQuerier_Call) RunAndReturn(run func(querier core.Querier) store.Entityer) *MockEntityer_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEntityDomainer creates a new instance of MockEntityDomainer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEntityDomainer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEntityDomainer {
+	mock := &MockEntityDomainer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEntityDomainer is an autogenerated mock type for the EntityDomainer type
+type MockEntityDomainer struct {
+	mock.Mock
+}
+
+type MockEntityDomainer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEntityDomainer) EXPECT() *MockEntityDomainer_Expecter {
+	return &MockEntityDomainer_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Create(ctx context.Context, domain *model.EntityDomain) (*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.EntityDomain) (*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, domain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.EntityDomain) *model.EntityDomain); ok {
+		r0 = returnFunc(ctx, domain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.EntityDomain) error); ok {
+		r1 = returnFunc(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockEntityDomainer_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain *model.EntityDomain
+func (_e *MockEntityDomainer_Expecter) Create(ctx interface{}, domain interface{}) *MockEntityDomainer_Create_Call {
+	return &MockEntityDomainer_Create_Call{Call: _e.mock.On("Create", ctx, domain)}
+}
+
+func (_c *MockEntityDomainer_Create_Call) Run(run func(ctx context.Context, domain *model.EntityDomain)) *MockEntityDomainer_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.EntityDomain
+		if args[1] != nil {
+			arg1 = args[1].(*model.EntityDomain)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Create_Call) Return(entityDomain *model.EntityDomain, err error) *MockEntityDomainer_Create_Call {
+	_c.Call.Return(entityDomain, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Create_Call) RunAndReturn(run func(ctx context.Context, domain *model.EntityDomain) (*model.EntityDomain, error)) *MockEntityDomainer_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Delete(ctx context.Context, id string, entityID string) (bool, error) {
+	ret := _mock.Called(ctx, id, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, id, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, id, entityID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockEntityDomainer_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - entityID string
+func (_e *MockEntityDomainer_Expecter) Delete(ctx interface{}, id interface{}, entityID interface{}) *MockEntityDomainer_Delete_Call {
+	return &MockEntityDomainer_Delete_Call{Call: _e.mock.On("Delete", ctx, id, entityID)}
+}
+
+func (_c *MockEntityDomainer_Delete_Call) Run(run func(ctx context.Context, id string, entityID string)) *MockEntityDomainer_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Delete_Call) Return(b bool, err error) *MockEntityDomainer_Delete_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Delete_Call) RunAndReturn(run func(ctx context.Context, id string, entityID string) (bool, error)) *MockEntityDomainer_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Get(ctx context.Context, id string, entityID string) (*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, id, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, id, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.EntityDomain); ok {
+		r0 = returnFunc(ctx, id, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockEntityDomainer_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - entityID string
+func (_e *MockEntityDomainer_Expecter) Get(ctx interface{}, id interface{}, entityID interface{}) *MockEntityDomainer_Get_Call {
+	return &MockEntityDomainer_Get_Call{Call: _e.mock.On("Get", ctx, id, entityID)}
+}
+
+func (_c *MockEntityDomainer_Get_Call) Run(run func(ctx context.Context, id string, entityID string)) *MockEntityDomainer_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Get_Call) Return(entityDomain *model.EntityDomain, err error) *MockEntityDomainer_Get_Call {
+	_c.Call.Return(entityDomain, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Get_Call) RunAndReturn(run func(ctx context.Context, id string, entityID string) (*model.EntityDomain, error)) *MockEntityDomainer_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByDomain provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) GetByDomain(ctx context.Context, entityID string, domain string) (*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, entityID, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByDomain")
+	}
+
+	var r0 *model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, entityID, domain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.EntityDomain); ok {
+		r0 = returnFunc(ctx, entityID, domain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, entityID, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_GetByDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByDomain'
+type MockEntityDomainer_GetByDomain_Call struct {
+	*mock.Call
+}
+
+// GetByDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - domain string
+func (_e *MockEntityDomainer_Expecter) GetByDomain(ctx interface{}, entityID interface{}, domain interface{}) *MockEntityDomainer_GetByDomain_Call {
+	return &MockEntityDomainer_GetByDomain_Call{Call: _e.mock.On("GetByDomain", ctx, entityID, domain)}
+}
+
+func (_c *MockEntityDomainer_GetByDomain_Call) Run(run func(ctx context.Context, entityID string, domain string)) *MockEntityDomainer_GetByDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_GetByDomain_Call) Return(entityDomain *model.EntityDomain, err error) *MockEntityDomainer_GetByDomain_Call {
+	_c.Call.Return(entityDomain, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_GetByDomain_Call) RunAndReturn(run func(ctx context.Context, entityID string, domain string) (*model.EntityDomain, error)) *MockEntityDomainer_GetByDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVerifiedByDomain provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) GetVerifiedByDomain(ctx context.Context, domain string) (*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVerifiedByDomain")
+	}
+
+	var r0 *model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, domain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.EntityDomain); ok {
+		r0 = returnFunc(ctx, domain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_GetVerifiedByDomain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVerifiedByDomain'
+type MockEntityDomainer_GetVerifiedByDomain_Call struct {
+	*mock.Call
+}
+
+// GetVerifiedByDomain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+func (_e *MockEntityDomainer_Expecter) GetVerifiedByDomain(ctx interface{}, domain interface{}) *MockEntityDomainer_GetVerifiedByDomain_Call {
+	return &MockEntityDomainer_GetVerifiedByDomain_Call{Call: _e.mock.On("GetVerifiedByDomain", ctx, domain)}
+}
+
+func (_c *MockEntityDomainer_GetVerifiedByDomain_Call) Run(run func(ctx context.Context, domain string)) *MockEntityDomainer_GetVerifiedByDomain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_GetVerifiedByDomain_Call) Return(entityDomain *model.EntityDomain, err error) *MockEntityDomainer_GetVerifiedByDomain_Call {
+	_c.Call.Return(entityDomain, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_GetVerifiedByDomain_Call) RunAndReturn(run func(ctx context.Context, domain string) (*model.EntityDomain, error)) *MockEntityDomainer_GetVerifiedByDomain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByEntityID provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) ListByEntityID(ctx context.Context, entityID string) ([]*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByEntityID")
+	}
+
+	var r0 []*model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.EntityDomain); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_ListByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByEntityID'
+type MockEntityDomainer_ListByEntityID_Call struct {
+	*mock.Call
+}
+
+// ListByEntityID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockEntityDomainer_Expecter) ListByEntityID(ctx interface{}, entityID interface{}) *MockEntityDomainer_ListByEntityID_Call {
+	return &MockEntityDomainer_ListByEntityID_Call{Call: _e.mock.On("ListByEntityID", ctx, entityID)}
+}
+
+func (_c *MockEntityDomainer_ListByEntityID_Call) Run(run func(ctx context.Context, entityID string)) *MockEntityDomainer_ListByEntityID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_ListByEntityID_Call) Return(entityDomains []*model.EntityDomain, err error) *MockEntityDomainer_ListByEntityID_Call {
+	_c.Call.Return(entityDomains, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_ListByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.EntityDomain, error)) *MockEntityDomainer_ListByEntityID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Update(ctx context.Context, domain *model.EntityDomain) (*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.EntityDomain) (*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, domain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.EntityDomain) *model.EntityDomain); ok {
+		r0 = returnFunc(ctx, domain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.EntityDomain) error); ok {
+		r1 = returnFunc(ctx, domain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockEntityDomainer_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain *model.EntityDomain
+func (_e *MockEntityDomainer_Expecter) Update(ctx interface{}, domain interface{}) *MockEntityDomainer_Update_Call {
+	return &MockEntityDomainer_Update_Call{Call: _e.mock.On("Update", ctx, domain)}
+}
+
+func (_c *MockEntityDomainer_Update_Call) Run(run func(ctx context.Context, domain *model.EntityDomain)) *MockEntityDomainer_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.EntityDomain
+		if args[1] != nil {
+			arg1 = args[1].(*model.EntityDomain)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Update_Call) Return(entityDomain *model.EntityDomain, err error) *MockEntityDomainer_Update_Call {
+	_c.Call.Return(entityDomain, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Update_Call) RunAndReturn(run func(ctx context.Context, domain *model.EntityDomain) (*model.EntityDomain, error)) *MockEntityDomainer_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) Verify(ctx context.Context, id string) (*model.EntityDomain, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *model.EntityDomain
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.EntityDomain, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.EntityDomain); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EntityDomain)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEntityDomainer_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockEntityDomainer_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEntityDomainer_Expecter) Verify(ctx interface{}, id interface{}) *MockEntityDomainer_Verify_Call {
+	return &MockEntityDomainer_Verify_Call{Call: _e.mock.On("Verify", ctx, id)}
+}
+
+func (_c *MockEntityDomainer_Verify_Call) Run(run func(ctx context.Context, id string)) *MockEntityDomainer_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_Verify_Call) Return(entityDomain *model.EntityDomain, err error) *MockEntityDomainer_Verify_Call {
+	_c.Call.Return(entityDomain, err)
+	return _c
+}
+
+func (_c *MockEntityDomainer_Verify_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.EntityDomain, error)) *MockEntityDomainer_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockEntityDomainer
+func (_mock *MockEntityDomainer) WithQuerier(q core.Querier) store.EntityDomainer {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.EntityDomainer
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.EntityDomainer); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EntityDomainer)
+		}
+	}
+	return r0
+}
+
+// MockEntityDomainer_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockEntityDomainer_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockEntityDomainer_Expecter) WithQuerier(q interface{}) *MockEntityDomainer_WithQuerier_Call {
+	return &MockEntityDomainer_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockEntityDomainer_WithQuerier_Call) Run(run func(q core.Querier)) *MockEntityDomainer_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEntityDomainer_WithQuerier_Call) Return(entityDomainer store.EntityDomainer) *MockEntityDomainer_WithQuerier_Call {
+	_c.Call.Return(entityDomainer)
+	return _c
+}
+
+func (_c *MockEntityDomainer_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.EntityDomainer) *MockEntityDomainer_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFailedSignIner creates a new instance of MockFailedSignIner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFailedSignIner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFailedSignIner {
+	mock := &MockFailedSignIner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFailedSignIner is an autogenerated mock type for the FailedSignIner type
+type MockFailedSignIner struct {
+	mock.Mock
+}
+
+type MockFailedSignIner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFailedSignIner) EXPECT() *MockFailedSignIner_Expecter {
+	return &MockFailedSignIner_Expecter{mock: &_m.Mock}
+}
+
+// DeleteStale provides a mock function for the type MockFailedSignIner
+func (_mock *MockFailedSignIner) DeleteStale(ctx context.Context, before time.Time) error {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStale")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFailedSignIner_DeleteStale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStale'
+type MockFailedSignIner_DeleteStale_Call struct {
+	*mock.Call
+}
+
+// DeleteStale is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockFailedSignIner_Expecter) DeleteStale(ctx interface{}, before interface{}) *MockFailedSignIner_DeleteStale_Call {
+	return &MockFailedSignIner_DeleteStale_Call{Call: _e.mock.On("DeleteStale", ctx, before)}
+}
+
+func (_c *MockFailedSignIner_DeleteStale_Call) Run(run func(ctx context.Context, before time.Time)) *MockFailedSignIner_DeleteStale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFailedSignIner_DeleteStale_Call) Return(err error) *MockFailedSignIner_DeleteStale_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFailedSignIner_DeleteStale_Call) RunAndReturn(run func(ctx context.Context, before time.Time) error) *MockFailedSignIner_DeleteStale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockFailedSignIner
+func (_mock *MockFailedSignIner) Get(ctx context.Context, emailHash string) (*model.FailedSignIn, error) {
+	ret := _mock.Called(ctx, emailHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.FailedSignIn
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.FailedSignIn, error)); ok {
+		return returnFunc(ctx, emailHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.FailedSignIn); ok {
+		r0 = returnFunc(ctx, emailHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FailedSignIn)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, emailHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFailedSignIner_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockFailedSignIner_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - emailHash string
+func (_e *MockFailedSignIner_Expecter) Get(ctx interface{}, emailHash interface{}) *MockFailedSignIner_Get_Call {
+	return &MockFailedSignIner_Get_Call{Call: _e.mock.On("Get", ctx, emailHash)}
+}
+
+func (_c *MockFailedSignIner_Get_Call) Run(run func(ctx context.Context, emailHash string)) *MockFailedSignIner_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFailedSignIner_Get_Call) Return(failedSignIn *model.FailedSignIn, err error) *MockFailedSignIner_Get_Call {
+	_c.Call.Return(failedSignIn, err)
+	return _c
+}
+
+func (_c *MockFailedSignIner_Get_Call) RunAndReturn(run func(ctx context.Context, emailHash string) (*model.FailedSignIn, error)) *MockFailedSignIner_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockFailedSignIner
+func (_mock *MockFailedSignIner) Upsert(ctx context.Context, failedSignIn *model.FailedSignIn) error {
+	ret := _mock.Called(ctx, failedSignIn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.FailedSignIn) error); ok {
+		r0 = returnFunc(ctx, failedSignIn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFailedSignIner_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockFailedSignIner_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - failedSignIn *model.FailedSignIn
+func (_e *MockFailedSignIner_Expecter) Upsert(ctx interface{}, failedSignIn interface{}) *MockFailedSignIner_Upsert_Call {
+	return &MockFailedSignIner_Upsert_Call{Call: _e.mock.On("Upsert", ctx, failedSignIn)}
+}
+
+func (_c *MockFailedSignIner_Upsert_Call) Run(run func(ctx context.Context, failedSignIn *model.FailedSignIn)) *MockFailedSignIner_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.FailedSignIn
+		if args[1] != nil {
+			arg1 = args[1].(*model.FailedSignIn)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFailedSignIner_Upsert_Call) Return(err error) *MockFailedSignIner_Upsert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFailedSignIner_Upsert_Call) RunAndReturn(run func(ctx context.Context, failedSignIn *model.FailedSignIn) error) *MockFailedSignIner_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockFailedSignIner
+func (_mock *MockFailedSignIner) WithQuerier(q core.Querier) store.FailedSignIner {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.FailedSignIner
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.FailedSignIner); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.FailedSignIner)
+		}
+	}
+	return r0
+}
+
+// MockFailedSignIner_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockFailedSignIner_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockFailedSignIner_Expecter) WithQuerier(q interface{}) *MockFailedSignIner_WithQuerier_Call {
+	return &MockFailedSignIner_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockFailedSignIner_WithQuerier_Call) Run(run func(q core.Querier)) *MockFailedSignIner_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFailedSignIner_WithQuerier_Call) Return(failedSignIner store.FailedSignIner) *MockFailedSignIner_WithQuerier_Call {
+	_c.Call.Return(failedSignIner)
+	return _c
+}
+
+func (_c *MockFailedSignIner_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.FailedSignIner) *MockFailedSignIner_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIPAllowlister creates a new instance of MockIPAllowlister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIPAllowlister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIPAllowlister {
+	mock := &MockIPAllowlister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIPAllowlister is an autogenerated mock type for the IPAllowlister type
+type MockIPAllowlister struct {
+	mock.Mock
+}
+
+type MockIPAllowlister_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIPAllowlister) EXPECT() *MockIPAllowlister_Expecter {
+	return &MockIPAllowlister_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockIPAllowlister
+func (_mock *MockIPAllowlister) Create(ctx context.Context, entry *model.IPAllowlistEntry) (*model.IPAllowlistEntry, error) {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.IPAllowlistEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.IPAllowlistEntry) (*model.IPAllowlistEntry, error)); ok {
+		return returnFunc(ctx, entry)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.IPAllowlistEntry) *model.IPAllowlistEntry); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IPAllowlistEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.IPAllowlistEntry) error); ok {
+		r1 = returnFunc(ctx, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIPAllowlister_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockIPAllowlister_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *model.IPAllowlistEntry
+func (_e *MockIPAllowlister_Expecter) Create(ctx interface{}, entry interface{}) *MockIPAllowlister_Create_Call {
+	return &MockIPAllowlister_Create_Call{Call: _e.mock.On("Create", ctx, entry)}
+}
+
+func (_c *MockIPAllowlister_Create_Call) Run(run func(ctx context.Context, entry *model.IPAllowlistEntry)) *MockIPAllowlister_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.IPAllowlistEntry
+		if args[1] != nil {
+			arg1 = args[1].(*model.IPAllowlistEntry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIPAllowlister_Create_Call) Return(iPAllowlistEntry *model.IPAllowlistEntry, err error) *MockIPAllowlister_Create_Call {
+	_c.Call.Return(iPAllowlistEntry, err)
+	return _c
+}
+
+func (_c *MockIPAllowlister_Create_Call) RunAndReturn(run func(ctx context.Context, entry *model.IPAllowlistEntry) (*model.IPAllowlistEntry, error)) *MockIPAllowlister_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByEntityID provides a mock function for the type MockIPAllowlister
+func (_mock *MockIPAllowlister) DeleteByEntityID(ctx context.Context, entityID string) error {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByEntityID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIPAllowlister_DeleteByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByEntityID'
+type MockIPAllowlister_DeleteByEntityID_Call struct {
+	*mock.Call
+}
+
+// DeleteByEntityID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockIPAllowlister_Expecter) DeleteByEntityID(ctx interface{}, entityID interface{}) *MockIPAllowlister_DeleteByEntityID_Call {
+	return &MockIPAllowlister_DeleteByEntityID_Call{Call: _e.mock.On("DeleteByEntityID", ctx, entityID)}
+}
+
+func (_c *MockIPAllowlister_DeleteByEntityID_Call) Run(run func(ctx context.Context, entityID string)) *MockIPAllowlister_DeleteByEntityID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIPAllowlister_DeleteByEntityID_Call) Return(err error) *MockIPAllowlister_DeleteByEntityID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIPAllowlister_DeleteByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string) error) *MockIPAllowlister_DeleteByEntityID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByEntityID provides a mock function for the type MockIPAllowlister
+func (_mock *MockIPAllowlister) ListByEntityID(ctx context.Context, entityID string) (model.IPAllowlist, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByEntityID")
+	}
+
+	var r0 model.IPAllowlist
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.IPAllowlist, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.IPAllowlist); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.IPAllowlist)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIPAllowlister_ListByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByEntityID'
+type MockIPAllowlister_ListByEntityID_Call struct {
+	*mock.Call
+}
+
+// ListByEntityID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockIPAllowlister_Expecter) ListByEntityID(ctx interface{}, entityID interface{}) *MockIPAllowlister_ListByEntityID_Call {
+	return &MockIPAllowlister_ListByEntityID_Call{Call: _e.mock.On("ListByEntityID", ctx, entityID)}
+}
+
+func (_c *MockIPAllowlister_ListByEntityID_Call) Run(run func(ctx context.Context, entityID string)) *MockIPAllowlister_ListByEntityID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIPAllowlister_ListByEntityID_Call) Return(iPAllowlist model.IPAllowlist, err error) *MockIPAllowlister_ListByEntityID_Call {
+	_c.Call.Return(iPAllowlist, err)
+	return _c
+}
+
+func (_c *MockIPAllowlister_ListByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string) (model.IPAllowlist, error)) *MockIPAllowlister_ListByEntityID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockIPAllowlister
+func (_mock *MockIPAllowlister) WithQuerier(q core.Querier) store.IPAllowlister {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.IPAllowlister
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.IPAllowlister); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.IPAllowlister)
+		}
+	}
+	return r0
+}
+
+// MockIPAllowlister_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockIPAllowlister_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockIPAllowlister_Expecter) WithQuerier(q interface{}) *MockIPAllowlister_WithQuerier_Call {
+	return &MockIPAllowlister_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockIPAllowlister_WithQuerier_Call) Run(run func(q core.Querier)) *MockIPAllowlister_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIPAllowlister_WithQuerier_Call) Return(iPAllowlister store.IPAllowlister) *MockIPAllowlister_WithQuerier_Call {
+	_c.Call.Return(iPAllowlister)
+	return _c
+}
+
+func (_c *MockIPAllowlister_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.IPAllowlister) *MockIPAllowlister_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMembershiper creates a new instance of MockMembershiper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMembershiper(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMembershiper {
+	mock := &MockMembershiper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMembershiper is an autogenerated mock type for the Membershiper type
+type MockMembershiper struct {
+	mock.Mock
+}
+
+type MockMembershiper_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMembershiper) EXPECT() *MockMembershiper_Expecter {
+	return &MockMembershiper_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) Create(ctx context.Context, membership *model.Membership) (*model.Membership, error) {
+	ret := _mock.Called(ctx, membership)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Membership) (*model.Membership, error)); ok {
+		return returnFunc(ctx, membership)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Membership) *model.Membership); ok {
+		r0 = returnFunc(ctx, membership)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.Membership) error); ok {
+		r1 = returnFunc(ctx, membership)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMembershiper_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockMembershiper_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - membership *model.Membership
+func (_e *MockMembershiper_Expecter) Create(ctx interface{}, membership interface{}) *MockMembershiper_Create_Call {
+	return &MockMembershiper_Create_Call{Call: _e.mock.On("Create", ctx, membership)}
+}
+
+func (_c *MockMembershiper_Create_Call) Run(run func(ctx context.Context, membership *model.Membership)) *MockMembershiper_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Membership
+		if args[1] != nil {
+			arg1 = args[1].(*model.Membership)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_Create_Call) Return(membership1 *model.Membership, err error) *MockMembershiper_Create_Call {
+	_c.Call.Return(membership1, err)
+	return _c
+}
+
+func (_c *MockMembershiper_Create_Call) RunAndReturn(run func(ctx context.Context, membership *model.Membership) (*model.Membership, error)) *MockMembershiper_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMembershiper_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockMembershiper_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockMembershiper_Expecter) Delete(ctx interface{}, id interface{}) *MockMembershiper_Delete_Call {
+	return &MockMembershiper_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockMembershiper_Delete_Call) Run(run func(ctx context.Context, id string)) *MockMembershiper_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_Delete_Call) Return(err error) *MockMembershiper_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMembershiper_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockMembershiper_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByEntityID provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) GetByEntityID(ctx context.Context, entityID string) ([]*model.Membership, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEntityID")
+	}
+
+	var r0 []*model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMembershiper_GetByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEntityID'
+type MockMembershiper_GetByEntityID_Call struct {
+	*mock.Call
+}
+
+// GetByEntityID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockMembershiper_Expecter) GetByEntityID(ctx interface{}, entityID interface{}) *MockMembershiper_GetByEntityID_Call {
+	return &MockMembershiper_GetByEntityID_Call{Call: _e.mock.On("GetByEntityID", ctx, entityID)}
+}
+
+func (_c *MockMembershiper_GetByEntityID_Call) Run(run func(ctx context.Context, entityID string)) *MockMembershiper_GetByEntityID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_GetByEntityID_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByEntityID_Call {
+	_c.Call.Return(memberships, err)
+	return _c
+}
+
+func (_c *MockMembershiper_GetByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.Membership, error)) *MockMembershiper_GetByEntityID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByEntityIDAndUserID provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) GetByEntityIDAndUserID(ctx context.Context, entityID string, userID string) (*model.Membership, error) {
+	ret := _mock.Called(ctx, entityID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEntityIDAndUserID")
+	}
+
+	var r0 *model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.Membership, error)); ok {
+		return returnFunc(ctx, entityID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.Membership); ok {
+		r0 = returnFunc(ctx, entityID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, entityID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMembershiper_GetByEntityIDAndUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEntityIDAndUserID'
+type MockMembershiper_GetByEntityIDAndUserID_Call struct {
+	*mock.Call
+}
+
+// GetByEntityIDAndUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+func (_e *MockMembershiper_Expecter) GetByEntityIDAndUserID(ctx interface{}, entityID interface{}, userID interface{}) *MockMembershiper_GetByEntityIDAndUserID_Call {
+	return &MockMembershiper_GetByEntityIDAndUserID_Call{Call: _e.mock.On("GetByEntityIDAndUserID", ctx, entityID, userID)}
+}
+
+func (_c *MockMembershiper_GetByEntityIDAndUserID_Call) Run(run func(ctx context.Context, entityID string, userID string)) *MockMembershiper_GetByEntityIDAndUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_GetByEntityIDAndUserID_Call) Return(membership *model.Membership, err error) *MockMembershiper_GetByEntityIDAndUserID_Call {
+	_c.Call.Return(membership, err)
+	return _c
+}
+
+func (_c *MockMembershiper_GetByEntityIDAndUserID_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string) (*model.Membership, error)) *MockMembershiper_GetByEntityIDAndUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByEntityIDWithInheritance provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) GetByEntityIDWithInheritance(ctx context.Context, userID string, entityID string) ([]*model.Membership, error) {
+	ret := _mock.Called(ctx, userID, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEntityIDWithInheritance")
+	}
+
+	var r0 []*model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]*model.Membership, error)); ok {
+		return returnFunc(ctx, userID, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []*model.Membership); ok {
+		r0 = returnFunc(ctx, userID, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMembershiper_GetByEntityIDWithInheritance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEntityIDWithInheritance'
+type MockMembershiper_GetByEntityIDWithInheritance_Call struct {
+	*mock.Call
+}
+
+// GetByEntityIDWithInheritance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - entityID string
+func (_e *MockMembershiper_Expecter) GetByEntityIDWithInheritance(ctx interface{}, userID interface{}, entityID interface{}) *MockMembershiper_GetByEntityIDWithInheritance_Call {
+	return &MockMembershiper_GetByEntityIDWithInheritance_Call{Call: _e.mock.On("GetByEntityIDWithInheritance", ctx, userID, entityID)}
+}
+
+func (_c *MockMembershiper_GetByEntityIDWithInheritance_Call) Run(run func(ctx context.Context, userID string, entityID string)) *MockMembershiper_GetByEntityIDWithInheritance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_GetByEntityIDWithInheritance_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByEntityIDWithInheritance_Call {
+	_c.Call.Return(memberships, err)
+	return _c
+}
+
+func (_c *MockMembershiper_GetByEntityIDWithInheritance_Call) RunAndReturn(run func(ctx context.Context, userID string, entityID string) ([]*model.Membership, error)) *MockMembershiper_GetByEntityIDWithInheritance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserID provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) GetByUserID(ctx context.Context, userID string) ([]*model.Membership, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserID")
+	}
+
+	var r0 []*model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMembershiper_GetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserID'
+type MockMembershiper_GetByUserID_Call struct {
+	*mock.Call
+}
+
+// GetByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockMembershiper_Expecter) GetByUserID(ctx interface{}, userID interface{}) *MockMembershiper_GetByUserID_Call {
+	return &MockMembershiper_GetByUserID_Call{Call: _e.mock.On("GetByUserID", ctx, userID)}
+}
+
+func (_c *MockMembershiper_GetByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockMembershiper_GetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_GetByUserID_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByUserID_Call {
+	_c.Call.Return(memberships, err)
+	return _c
+}
+
+func (_c *MockMembershiper_GetByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Membership, error)) *MockMembershiper_GetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserIDWithInheritance provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) GetByUserIDWithInheritance(ctx context.Context, userID string) ([]*model.Membership, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserIDWithInheritance")
+	}
+
+	var r0 []*model.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Membership, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Membership); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMembershiper_GetByUserIDWithInheritance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserIDWithInheritance'
+type MockMembershiper_GetByUserIDWithInheritance_Call struct {
+	*mock.Call
+}
+
+// GetByUserIDWithInheritance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockMembershiper_Expecter) GetByUserIDWithInheritance(ctx interface{}, userID interface{}) *MockMembershiper_GetByUserIDWithInheritance_Call {
+	return &MockMembershiper_GetByUserIDWithInheritance_Call{Call: _e.mock.On("GetByUserIDWithInheritance", ctx, userID)}
+}
+
+func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) Run(run func(ctx context.Context, userID string)) *MockMembershiper_GetByUserIDWithInheritance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) Return(memberships []*model.Membership, err error) *MockMembershiper_GetByUserIDWithInheritance_Call {
+	_c.Call.Return(memberships, err)
+	return _c
+}
+
+func (_c *MockMembershiper_GetByUserIDWithInheritance_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Membership, error)) *MockMembershiper_GetByUserIDWithInheritance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) UpdateRole(ctx context.Context, id string, role types.Role) error {
+	ret := _mock.Called(ctx, id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, types.Role) error); ok {
+		r0 = returnFunc(ctx, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMembershiper_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type MockMembershiper_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - role types.Role
+func (_e *MockMembershiper_Expecter) UpdateRole(ctx interface{}, id interface{}, role interface{}) *MockMembershiper_UpdateRole_Call {
+	return &MockMembershiper_UpdateRole_Call{Call: _e.mock.On("UpdateRole", ctx, id, role)}
+}
+
+func (_c *MockMembershiper_UpdateRole_Call) Run(run func(ctx context.Context, id string, role types.Role)) *MockMembershiper_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 types.Role
+		if args[2] != nil {
+			arg2 = args[2].(types.Role)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_UpdateRole_Call) Return(err error) *MockMembershiper_UpdateRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMembershiper_UpdateRole_Call) RunAndReturn(run func(ctx context.Context, id string, role types.Role) error) *MockMembershiper_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockMembershiper
+func (_mock *MockMembershiper) WithQuerier(q core.Querier) store.Membershiper {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.Membershiper
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.Membershiper); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Membershiper)
+		}
+	}
+	return r0
+}
+
+// MockMembershiper_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockMembershiper_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockMembershiper_Expecter) WithQuerier(q interface{}) *MockMembershiper_WithQuerier_Call {
+	return &MockMembershiper_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockMembershiper_WithQuerier_Call) Run(run func(q core.Querier)) *MockMembershiper_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMembershiper_WithQuerier_Call) Return(membershiper store.Membershiper) *MockMembershiper_WithQuerier_Call {
+	_c.Call.Return(membershiper)
+	return _c
+}
+
+func (_c *MockMembershiper_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.Membershiper) *MockMembershiper_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPasswordPolicier creates a new instance of MockPasswordPolicier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPasswordPolicier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPasswordPolicier {
+	mock := &MockPasswordPolicier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPasswordPolicier is an autogenerated mock type for the PasswordPolicier type
+type MockPasswordPolicier struct {
+	mock.Mock
+}
+
+type MockPasswordPolicier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPasswordPolicier) EXPECT() *MockPasswordPolicier_Expecter {
+	return &MockPasswordPolicier_Expecter{mock: &_m.Mock}
+}
+
+// CreateHistory provides a mock function for the type MockPasswordPolicier
+func (_mock *MockPasswordPolicier) CreateHistory(ctx context.Context, entry *model.PasswordHistoryEntry) error {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHistory")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.PasswordHistoryEntry) error); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPasswordPolicier_CreateHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHistory'
+type MockPasswordPolicier_CreateHistory_Call struct {
+	*mock.Call
+}
+
+// CreateHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *model.PasswordHistoryEntry
+func (_e *MockPasswordPolicier_Expecter) CreateHistory(ctx interface{}, entry interface{}) *MockPasswordPolicier_CreateHistory_Call {
+	return &MockPasswordPolicier_CreateHistory_Call{Call: _e.mock.On("CreateHistory", ctx, entry)}
+}
+
+func (_c *MockPasswordPolicier_CreateHistory_Call) Run(run func(ctx context.Context, entry *model.PasswordHistoryEntry)) *MockPasswordPolicier_CreateHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.PasswordHistoryEntry
+		if args[1] != nil {
+			arg1 = args[1].(*model.PasswordHistoryEntry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordPolicier_CreateHistory_Call) Return(err error) *MockPasswordPolicier_CreateHistory_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPasswordPolicier_CreateHistory_Call) RunAndReturn(run func(ctx context.Context, entry *model.PasswordHistoryEntry) error) *MockPasswordPolicier_CreateHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockPasswordPolicier
+func (_mock *MockPasswordPolicier) Get(ctx context.Context, entityID string) (*model.PasswordPolicy, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.PasswordPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.PasswordPolicy, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.PasswordPolicy); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PasswordPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasswordPolicier_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockPasswordPolicier_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockPasswordPolicier_Expecter) Get(ctx interface{}, entityID interface{}) *MockPasswordPolicier_Get_Call {
+	return &MockPasswordPolicier_Get_Call{Call: _e.mock.On("Get", ctx, entityID)}
+}
+
+func (_c *MockPasswordPolicier_Get_Call) Run(run func(ctx context.Context, entityID string)) *MockPasswordPolicier_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordPolicier_Get_Call) Return(passwordPolicy *model.PasswordPolicy, err error) *MockPasswordPolicier_Get_Call {
+	_c.Call.Return(passwordPolicy, err)
+	return _c
+}
+
+func (_c *MockPasswordPolicier_Get_Call) RunAndReturn(run func(ctx context.Context, entityID string) (*model.PasswordPolicy, error)) *MockPasswordPolicier_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUserID provides a mock function for the type MockPasswordPolicier
+func (_mock *MockPasswordPolicier) ListByUserID(ctx context.Context, userID string) ([]*model.PasswordPolicy, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserID")
+	}
+
+	var r0 []*model.PasswordPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.PasswordPolicy, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.PasswordPolicy); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PasswordPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasswordPolicier_ListByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUserID'
+type MockPasswordPolicier_ListByUserID_Call struct {
+	*mock.Call
+}
+
+// ListByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockPasswordPolicier_Expecter) ListByUserID(ctx interface{}, userID interface{}) *MockPasswordPolicier_ListByUserID_Call {
+	return &MockPasswordPolicier_ListByUserID_Call{Call: _e.mock.On("ListByUserID", ctx, userID)}
+}
+
+func (_c *MockPasswordPolicier_ListByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockPasswordPolicier_ListByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordPolicier_ListByUserID_Call) Return(passwordPolicys []*model.PasswordPolicy, err error) *MockPasswordPolicier_ListByUserID_Call {
+	_c.Call.Return(passwordPolicys, err)
+	return _c
+}
+
+func (_c *MockPasswordPolicier_ListByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.PasswordPolicy, error)) *MockPasswordPolicier_ListByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListHistory provides a mock function for the type MockPasswordPolicier
+func (_mock *MockPasswordPolicier) ListHistory(ctx context.Context, userID string, limit int) ([]*model.PasswordHistoryEntry, error) {
+	ret := _mock.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHistory")
+	}
+
+	var r0 []*model.PasswordHistoryEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]*model.PasswordHistoryEntry, error)); ok {
+		return returnFunc(ctx, userID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []*model.PasswordHistoryEntry); ok {
+		r0 = returnFunc(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PasswordHistoryEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasswordPolicier_ListHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListHistory'
+type MockPasswordPolicier_ListHistory_Call struct {
+	*mock.Call
+}
+
+// ListHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - limit int
+func (_e *MockPasswordPolicier_Expecter) ListHistory(ctx interface{}, userID interface{}, limit interface{}) *MockPasswordPolicier_ListHistory_Call {
+	return &MockPasswordPolicier_ListHistory_Call{Call: _e.mock.On("ListHistory", ctx, userID, limit)}
+}
+
+func (_c *MockPasswordPolicier_ListHistory_Call) Run(run func(ctx context.Context, userID string, limit int)) *MockPasswordPolicier_ListHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordPolicier_ListHistory_Call) Return(passwordHistoryEntrys []*model.PasswordHistoryEntry, err error) *MockPasswordPolicier_ListHistory_Call {
+	_c.Call.Return(passwordHistoryEntrys, err)
+	return _c
+}
+
+func (_c *MockPasswordPolicier_ListHistory_Call) RunAndReturn(run func(ctx context.Context, userID string, limit int) ([]*model.PasswordHistoryEntry, error)) *MockPasswordPolicier_ListHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneHistory provides a mock function for the type MockPasswordPolicier
+func (_mock *MockPasswordPolicier) PruneHistory(ctx context.Context, userID string, keep int) error {
+	ret := _mock.Called(ctx, userID, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneHistory")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, userID, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPasswordPolicier_PruneHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneHistory'
+type MockPasswordPolicier_PruneHistory_Call struct {
+	*mock.Call
+}
+
+// PruneHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - keep int
+func (_e *MockPasswordPolicier_Expecter) PruneHistory(ctx interface{}, userID interface{}, keep interface{}) *MockPasswordPolicier_PruneHistory_Call {
+	return &MockPasswordPolicier_PruneHistory_Call{Call: _e.mock.On("PruneHistory", ctx, userID, keep)}
+}
+
+func (_c *MockPasswordPolicier_PruneHistory_Call) Run(run func(ctx context.Context, userID string, keep int)) *MockPasswordPolicier_PruneHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordPolicier_PruneHistory_Call) Return(err error) *MockPasswordPolicier_PruneHistory_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPasswordPolicier_PruneHistory_Call) RunAndReturn(run func(ctx context.Context, userID string, keep int) error) *MockPasswordPolicier_PruneHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockPasswordPolicier
+func (_mock *MockPasswordPolicier) Upsert(ctx context.Context, policy *model.PasswordPolicy) (*model.PasswordPolicy, error) {
+	ret := _mock.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 *model.PasswordPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.PasswordPolicy) (*model.PasswordPolicy, error)); ok {
+		return returnFunc(ctx, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.PasswordPolicy) *model.PasswordPolicy); ok {
+		r0 = returnFunc(ctx, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PasswordPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.PasswordPolicy) error); ok {
+		r1 = returnFunc(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPasswordPolicier_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockPasswordPolicier_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy *model.PasswordPolicy
+func (_e *MockPasswordPolicier_Expecter) Upsert(ctx interface{}, policy interface{}) *MockPasswordPolicier_Upsert_Call {
+	return &MockPasswordPolicier_Upsert_Call{Call: _e.mock.On("Upsert", ctx, policy)}
+}
+
+func (_c *MockPasswordPolicier_Upsert_Call) Run(run func(ctx context.Context, policy *model.PasswordPolicy)) *MockPasswordPolicier_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.PasswordPolicy
+		if args[1] != nil {
+			arg1 = args[1].(*model.PasswordPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordPolicier_Upsert_Call) Return(passwordPolicy *model.PasswordPolicy, err error) *MockPasswordPolicier_Upsert_Call {
+	_c.Call.Return(passwordPolicy, err)
+	return _c
+}
+
+func (_c *MockPasswordPolicier_Upsert_Call) RunAndReturn(run func(ctx context.Context, policy *model.PasswordPolicy) (*model.PasswordPolicy, error)) *MockPasswordPolicier_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockPasswordPolicier
+func (_mock *MockPasswordPolicier) WithQuerier(q core.Querier) store.PasswordPolicier {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.PasswordPolicier
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.PasswordPolicier); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PasswordPolicier)
+		}
+	}
+	return r0
+}
+
+// MockPasswordPolicier_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockPasswordPolicier_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockPasswordPolicier_Expecter) WithQuerier(q interface{}) *MockPasswordPolicier_WithQuerier_Call {
+	return &MockPasswordPolicier_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockPasswordPolicier_WithQuerier_Call) Run(run func(q core.Querier)) *MockPasswordPolicier_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordPolicier_WithQuerier_Call) Return(passwordPolicier store.PasswordPolicier) *MockPasswordPolicier_WithQuerier_Call {
+	_c.Call.Return(passwordPolicier)
+	return _c
+}
+
+func (_c *MockPasswordPolicier_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.PasswordPolicier) *MockPasswordPolicier_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSCIMer creates a new instance of MockSCIMer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSCIMer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSCIMer {
+	mock := &MockSCIMer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSCIMer is an autogenerated mock type for the SCIMer type
+type MockSCIMer struct {
+	mock.Mock
+}
+
+type MockSCIMer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSCIMer) EXPECT() *MockSCIMer_Expecter {
+	return &MockSCIMer_Expecter{mock: &_m.Mock}
+}
+
+// CountUsers provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) CountUsers(ctx context.Context, entityID string, filter model.SCIMUserFilter) (int, error) {
+	ret := _mock.Called(ctx, entityID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsers")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.SCIMUserFilter) (int, error)); ok {
+		return returnFunc(ctx, entityID, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.SCIMUserFilter) int); ok {
+		r0 = returnFunc(ctx, entityID, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.SCIMUserFilter) error); ok {
+		r1 = returnFunc(ctx, entityID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_CountUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUsers'
+type MockSCIMer_CountUsers_Call struct {
+	*mock.Call
+}
+
+// CountUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - filter model.SCIMUserFilter
+func (_e *MockSCIMer_Expecter) CountUsers(ctx interface{}, entityID interface{}, filter interface{}) *MockSCIMer_CountUsers_Call {
+	return &MockSCIMer_CountUsers_Call{Call: _e.mock.On("CountUsers", ctx, entityID, filter)}
+}
+
+func (_c *MockSCIMer_CountUsers_Call) Run(run func(ctx context.Context, entityID string, filter model.SCIMUserFilter)) *MockSCIMer_CountUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.SCIMUserFilter
+		if args[2] != nil {
+			arg2 = args[2].(model.SCIMUserFilter)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_CountUsers_Call) Return(n int, err error) *MockSCIMer_CountUsers_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockSCIMer_CountUsers_Call) RunAndReturn(run func(ctx context.Context, entityID string, filter model.SCIMUserFilter) (int, error)) *MockSCIMer_CountUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateToken provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) CreateToken(ctx context.Context, token *model.SCIMToken) (*model.SCIMToken, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateToken")
+	}
+
+	var r0 *model.SCIMToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SCIMToken) (*model.SCIMToken, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SCIMToken) *model.SCIMToken); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.SCIMToken) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type MockSCIMer_CreateToken_Call struct {
+	*mock.Call
+}
+
+// CreateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *model.SCIMToken
+func (_e *MockSCIMer_Expecter) CreateToken(ctx interface{}, token interface{}) *MockSCIMer_CreateToken_Call {
+	return &MockSCIMer_CreateToken_Call{Call: _e.mock.On("CreateToken", ctx, token)}
+}
+
+func (_c *MockSCIMer_CreateToken_Call) Run(run func(ctx context.Context, token *model.SCIMToken)) *MockSCIMer_CreateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.SCIMToken
+		if args[1] != nil {
+			arg1 = args[1].(*model.SCIMToken)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_CreateToken_Call) Return(sCIMToken *model.SCIMToken, err error) *MockSCIMer_CreateToken_Call {
+	_c.Call.Return(sCIMToken, err)
+	return _c
+}
+
+func (_c *MockSCIMer_CreateToken_Call) RunAndReturn(run func(ctx context.Context, token *model.SCIMToken) (*model.SCIMToken, error)) *MockSCIMer_CreateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteToken provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) DeleteToken(ctx context.Context, entityID string, id string) error {
+	ret := _mock.Called(ctx, entityID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, entityID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSCIMer_DeleteToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteToken'
+type MockSCIMer_DeleteToken_Call struct {
+	*mock.Call
+}
+
+// DeleteToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - id string
+func (_e *MockSCIMer_Expecter) DeleteToken(ctx interface{}, entityID interface{}, id interface{}) *MockSCIMer_DeleteToken_Call {
+	return &MockSCIMer_DeleteToken_Call{Call: _e.mock.On("DeleteToken", ctx, entityID, id)}
+}
+
+func (_c *MockSCIMer_DeleteToken_Call) Run(run func(ctx context.Context, entityID string, id string)) *MockSCIMer_DeleteToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_DeleteToken_Call) Return(err error) *MockSCIMer_DeleteToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSCIMer_DeleteToken_Call) RunAndReturn(run func(ctx context.Context, entityID string, id string) error) *MockSCIMer_DeleteToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokenByHash provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) GetTokenByHash(ctx context.Context, tokenHash string) (*model.SCIMToken, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenByHash")
+	}
+
+	var r0 *model.SCIMToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.SCIMToken, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.SCIMToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_GetTokenByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokenByHash'
+type MockSCIMer_GetTokenByHash_Call struct {
+	*mock.Call
+}
+
+// GetTokenByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockSCIMer_Expecter) GetTokenByHash(ctx interface{}, tokenHash interface{}) *MockSCIMer_GetTokenByHash_Call {
+	return &MockSCIMer_GetTokenByHash_Call{Call: _e.mock.On("GetTokenByHash", ctx, tokenHash)}
+}
+
+func (_c *MockSCIMer_GetTokenByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockSCIMer_GetTokenByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_GetTokenByHash_Call) Return(sCIMToken *model.SCIMToken, err error) *MockSCIMer_GetTokenByHash_Call {
+	_c.Call.Return(sCIMToken, err)
+	return _c
+}
+
+func (_c *MockSCIMer_GetTokenByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (*model.SCIMToken, error)) *MockSCIMer_GetTokenByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokenByID provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) GetTokenByID(ctx context.Context, entityID string, id string) (*model.SCIMToken, error) {
+	ret := _mock.Called(ctx, entityID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenByID")
+	}
+
+	var r0 *model.SCIMToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.SCIMToken, error)); ok {
+		return returnFunc(ctx, entityID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.SCIMToken); ok {
+		r0 = returnFunc(ctx, entityID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, entityID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_GetTokenByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokenByID'
+type MockSCIMer_GetTokenByID_Call struct {
+	*mock.Call
+}
+
+// GetTokenByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - id string
+func (_e *MockSCIMer_Expecter) GetTokenByID(ctx interface{}, entityID interface{}, id interface{}) *MockSCIMer_GetTokenByID_Call {
+	return &MockSCIMer_GetTokenByID_Call{Call: _e.mock.On("GetTokenByID", ctx, entityID, id)}
+}
+
+func (_c *MockSCIMer_GetTokenByID_Call) Run(run func(ctx context.Context, entityID string, id string)) *MockSCIMer_GetTokenByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_GetTokenByID_Call) Return(sCIMToken *model.SCIMToken, err error) *MockSCIMer_GetTokenByID_Call {
+	_c.Call.Return(sCIMToken, err)
+	return _c
+}
+
+func (_c *MockSCIMer_GetTokenByID_Call) RunAndReturn(run func(ctx context.Context, entityID string, id string) (*model.SCIMToken, error)) *MockSCIMer_GetTokenByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) GetUser(ctx context.Context, entityID string, userID string) (*model.SCIMUser, error) {
+	ret := _mock.Called(ctx, entityID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *model.SCIMUser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.SCIMUser, error)); ok {
+		return returnFunc(ctx, entityID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.SCIMUser); ok {
+		r0 = returnFunc(ctx, entityID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMUser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, entityID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockSCIMer_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+func (_e *MockSCIMer_Expecter) GetUser(ctx interface{}, entityID interface{}, userID interface{}) *MockSCIMer_GetUser_Call {
+	return &MockSCIMer_GetUser_Call{Call: _e.mock.On("GetUser", ctx, entityID, userID)}
+}
+
+func (_c *MockSCIMer_GetUser_Call) Run(run func(ctx context.Context, entityID string, userID string)) *MockSCIMer_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_GetUser_Call) Return(sCIMUser *model.SCIMUser, err error) *MockSCIMer_GetUser_Call {
+	_c.Call.Return(sCIMUser, err)
+	return _c
+}
+
+func (_c *MockSCIMer_GetUser_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string) (*model.SCIMUser, error)) *MockSCIMer_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LinkUser provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) LinkUser(ctx context.Context, entityID string, userID string, externalID *string, created bool) error {
+	ret := _mock.Called(ctx, entityID, userID, externalID, created)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *string, bool) error); ok {
+		r0 = returnFunc(ctx, entityID, userID, externalID, created)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSCIMer_LinkUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkUser'
+type MockSCIMer_LinkUser_Call struct {
+	*mock.Call
+}
+
+// LinkUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+//   - externalID *string
+//   - created bool
+func (_e *MockSCIMer_Expecter) LinkUser(ctx interface{}, entityID interface{}, userID interface{}, externalID interface{}, created interface{}) *MockSCIMer_LinkUser_Call {
+	return &MockSCIMer_LinkUser_Call{Call: _e.mock.On("LinkUser", ctx, entityID, userID, externalID, created)}
+}
+
+func (_c *MockSCIMer_LinkUser_Call) Run(run func(ctx context.Context, entityID string, userID string, externalID *string, created bool)) *MockSCIMer_LinkUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *string
+		if args[3] != nil {
+			arg3 = args[3].(*string)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_LinkUser_Call) Return(err error) *MockSCIMer_LinkUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSCIMer_LinkUser_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string, externalID *string, created bool) error) *MockSCIMer_LinkUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTokens provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) ListTokens(ctx context.Context, entityID string) ([]*model.SCIMToken, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTokens")
+	}
+
+	var r0 []*model.SCIMToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.SCIMToken, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.SCIMToken); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SCIMToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_ListTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTokens'
+type MockSCIMer_ListTokens_Call struct {
+	*mock.Call
+}
+
+// ListTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockSCIMer_Expecter) ListTokens(ctx interface{}, entityID interface{}) *MockSCIMer_ListTokens_Call {
+	return &MockSCIMer_ListTokens_Call{Call: _e.mock.On("ListTokens", ctx, entityID)}
+}
+
+func (_c *MockSCIMer_ListTokens_Call) Run(run func(ctx context.Context, entityID string)) *MockSCIMer_ListTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_ListTokens_Call) Return(sCIMTokens []*model.SCIMToken, err error) *MockSCIMer_ListTokens_Call {
+	_c.Call.Return(sCIMTokens, err)
+	return _c
+}
+
+func (_c *MockSCIMer_ListTokens_Call) RunAndReturn(run func(ctx context.Context, entityID string) ([]*model.SCIMToken, error)) *MockSCIMer_ListTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) ListUsers(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset int, limit int) ([]*model.SCIMUser, error) {
+	ret := _mock.Called(ctx, entityID, filter, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []*model.SCIMUser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.SCIMUserFilter, int, int) ([]*model.SCIMUser, error)); ok {
+		return returnFunc(ctx, entityID, filter, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.SCIMUserFilter, int, int) []*model.SCIMUser); ok {
+		r0 = returnFunc(ctx, entityID, filter, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SCIMUser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.SCIMUserFilter, int, int) error); ok {
+		r1 = returnFunc(ctx, entityID, filter, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSCIMer_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type MockSCIMer_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - filter model.SCIMUserFilter
+//   - offset int
+//   - limit int
+func (_e *MockSCIMer_Expecter) ListUsers(ctx interface{}, entityID interface{}, filter interface{}, offset interface{}, limit interface{}) *MockSCIMer_ListUsers_Call {
+	return &MockSCIMer_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, entityID, filter, offset, limit)}
+}
+
+func (_c *MockSCIMer_ListUsers_Call) Run(run func(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset int, limit int)) *MockSCIMer_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.SCIMUserFilter
+		if args[2] != nil {
+			arg2 = args[2].(model.SCIMUserFilter)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_ListUsers_Call) Return(sCIMUsers []*model.SCIMUser, err error) *MockSCIMer_ListUsers_Call {
+	_c.Call.Return(sCIMUsers, err)
+	return _c
+}
+
+func (_c *MockSCIMer_ListUsers_Call) RunAndReturn(run func(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset int, limit int) ([]*model.SCIMUser, error)) *MockSCIMer_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchToken provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) TouchToken(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSCIMer_TouchToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchToken'
+type MockSCIMer_TouchToken_Call struct {
+	*mock.Call
+}
+
+// TouchToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockSCIMer_Expecter) TouchToken(ctx interface{}, id interface{}) *MockSCIMer_TouchToken_Call {
+	return &MockSCIMer_TouchToken_Call{Call: _e.mock.On("TouchToken", ctx, id)}
+}
+
+func (_c *MockSCIMer_TouchToken_Call) Run(run func(ctx context.Context, id string)) *MockSCIMer_TouchToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_TouchToken_Call) Return(err error) *MockSCIMer_TouchToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSCIMer_TouchToken_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockSCIMer_TouchToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlinkUser provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) UnlinkUser(ctx context.Context, entityID string, userID string) error {
+	ret := _mock.Called(ctx, entityID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlinkUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, entityID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSCIMer_UnlinkUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlinkUser'
+type MockSCIMer_UnlinkUser_Call struct {
+	*mock.Call
+}
+
+// UnlinkUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+func (_e *MockSCIMer_Expecter) UnlinkUser(ctx interface{}, entityID interface{}, userID interface{}) *MockSCIMer_UnlinkUser_Call {
+	return &MockSCIMer_UnlinkUser_Call{Call: _e.mock.On("UnlinkUser", ctx, entityID, userID)}
+}
+
+func (_c *MockSCIMer_UnlinkUser_Call) Run(run func(ctx context.Context, entityID string, userID string)) *MockSCIMer_UnlinkUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_UnlinkUser_Call) Return(err error) *MockSCIMer_UnlinkUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSCIMer_UnlinkUser_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string) error) *MockSCIMer_UnlinkUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockSCIMer
+func (_mock *MockSCIMer) WithQuerier(q core.Querier) store.SCIMer {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.SCIMer
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.SCIMer); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SCIMer)
+		}
+	}
+	return r0
+}
+
+// MockSCIMer_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockSCIMer_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockSCIMer_Expecter) WithQuerier(q interface{}) *MockSCIMer_WithQuerier_Call {
+	return &MockSCIMer_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockSCIMer_WithQuerier_Call) Run(run func(q core.Querier)) *MockSCIMer_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSCIMer_WithQuerier_Call) Return(sCIMer store.SCIMer) *MockSCIMer_WithQuerier_Call {
+	_c.Call.Return(sCIMer)
+	return _c
+}
+
+func (_c *MockSCIMer_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.SCIMer) *MockSCIMer_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessioner creates a new instance of MockSessioner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessioner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessioner {
+	mock := &MockSessioner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSessioner is an autogenerated mock type for the Sessioner type
+type MockSessioner struct {
+	mock.Mock
+}
+
+type MockSessioner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessioner) EXPECT() *MockSessioner_Expecter {
+	return &MockSessioner_Expecter{mock: &_m.Mock}
+}
+
+// CleanUpExpired provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) CleanUpExpired(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CleanUpExpired")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_CleanUpExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanUpExpired'
+type MockSessioner_CleanUpExpired_Call struct {
+	*mock.Call
+}
+
+// CleanUpExpired is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSessioner_Expecter) CleanUpExpired(ctx interface{}) *MockSessioner_CleanUpExpired_Call {
+	return &MockSessioner_CleanUpExpired_Call{Call: _e.mock.On("CleanUpExpired", ctx)}
+}
+
+func (_c *MockSessioner_CleanUpExpired_Call) Run(run func(ctx context.Context)) *MockSessioner_CleanUpExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_CleanUpExpired_Call) Return(err error) *MockSessioner_CleanUpExpired_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_CleanUpExpired_Call) RunAndReturn(run func(ctx context.Context) error) *MockSessioner_CleanUpExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) Create(ctx context.Context, session *model.Session) (*model.Session, error) {
+	ret := _mock.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Session) (*model.Session, error)); ok {
+		return returnFunc(ctx, session)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Session) *model.Session); ok {
+		r0 = returnFunc(ctx, session)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.Session) error); ok {
+		r1 = returnFunc(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSessioner_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session *model.Session
+func (_e *MockSessioner_Expecter) Create(ctx interface{}, session interface{}) *MockSessioner_Create_Call {
+	return &MockSessioner_Create_Call{Call: _e.mock.On("Create", ctx, session)}
+}
+
+func (_c *MockSessioner_Create_Call) Run(run func(ctx context.Context, session *model.Session)) *MockSessioner_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Session
+		if args[1] != nil {
+			arg1 = args[1].(*model.Session)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_Create_Call) Return(session1 *model.Session, err error) *MockSessioner_Create_Call {
+	_c.Call.Return(session1, err)
+	return _c
+}
+
+func (_c *MockSessioner_Create_Call) RunAndReturn(run func(ctx context.Context, session *model.Session) (*model.Session, error)) *MockSessioner_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExcessByUser provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) DeleteExcessByUser(ctx context.Context, userID string, keep int) (int64, error) {
+	ret := _mock.Called(ctx, userID, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExcessByUser")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (int64, error)); ok {
+		return returnFunc(ctx, userID, keep)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) int64); ok {
+		r0 = returnFunc(ctx, userID, keep)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, userID, keep)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_DeleteExcessByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExcessByUser'
+type MockSessioner_DeleteExcessByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteExcessByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - keep int
+func (_e *MockSessioner_Expecter) DeleteExcessByUser(ctx interface{}, userID interface{}, keep interface{}) *MockSessioner_DeleteExcessByUser_Call {
+	return &MockSessioner_DeleteExcessByUser_Call{Call: _e.mock.On("DeleteExcessByUser", ctx, userID, keep)}
+}
+
+func (_c *MockSessioner_DeleteExcessByUser_Call) Run(run func(ctx context.Context, userID string, keep int)) *MockSessioner_DeleteExcessByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_DeleteExcessByUser_Call) Return(n int64, err error) *MockSessioner_DeleteExcessByUser_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockSessioner_DeleteExcessByUser_Call) RunAndReturn(run func(ctx context.Context, userID string, keep int) (int64, error)) *MockSessioner_DeleteExcessByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByRefreshToken provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) GetByRefreshToken(ctx context.Context, refreshToken string) (*model.Session, error) {
+	ret := _mock.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRefreshToken")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, refreshToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
+		r0 = returnFunc(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_GetByRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByRefreshToken'
+type MockSessioner_GetByRefreshToken_Call struct {
+	*mock.Call
+}
+
+// GetByRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *MockSessioner_Expecter) GetByRefreshToken(ctx interface{}, refreshToken interface{}) *MockSessioner_GetByRefreshToken_Call {
+	return &MockSessioner_GetByRefreshToken_Call{Call: _e.mock.On("GetByRefreshToken", ctx, refreshToken)}
+}
+
+func (_c *MockSessioner_GetByRefreshToken_Call) Run(run func(ctx context.Context, refreshToken string)) *MockSessioner_GetByRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_GetByRefreshToken_Call) Return(session *model.Session, err error) *MockSessioner_GetByRefreshToken_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessioner_GetByRefreshToken_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (*model.Session, error)) *MockSessioner_GetByRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByToken provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) GetByToken(ctx context.Context, token string) (*model.Session, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByToken")
+	}
+
+	var r0 *model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_GetByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByToken'
+type MockSessioner_GetByToken_Call struct {
+	*mock.Call
+}
+
+// GetByToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockSessioner_Expecter) GetByToken(ctx interface{}, token interface{}) *MockSessioner_GetByToken_Call {
+	return &MockSessioner_GetByToken_Call{Call: _e.mock.On("GetByToken", ctx, token)}
+}
+
+func (_c *MockSessioner_GetByToken_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_GetByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_GetByToken_Call) Return(session *model.Session, err error) *MockSessioner_GetByToken_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessioner_GetByToken_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.Session, error)) *MockSessioner_GetByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateByID provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) InvalidateByID(ctx context.Context, id string, userID string) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateByID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_InvalidateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateByID'
+type MockSessioner_InvalidateByID_Call struct {
+	*mock.Call
+}
+
+// InvalidateByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - userID string
+func (_e *MockSessioner_Expecter) InvalidateByID(ctx interface{}, id interface{}, userID interface{}) *MockSessioner_InvalidateByID_Call {
+	return &MockSessioner_InvalidateByID_Call{Call: _e.mock.On("InvalidateByID", ctx, id, userID)}
+}
+
+func (_c *MockSessioner_InvalidateByID_Call) Run(run func(ctx context.Context, id string, userID string)) *MockSessioner_InvalidateByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateByID_Call) Return(err error) *MockSessioner_InvalidateByID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateByID_Call) RunAndReturn(run func(ctx context.Context, id string, userID string) error) *MockSessioner_InvalidateByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateByToken provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) InvalidateByToken(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateByToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_InvalidateByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateByToken'
+type MockSessioner_InvalidateByToken_Call struct {
+	*mock.Call
+}
+
+// InvalidateByToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockSessioner_Expecter) InvalidateByToken(ctx interface{}, token interface{}) *MockSessioner_InvalidateByToken_Call {
+	return &MockSessioner_InvalidateByToken_Call{Call: _e.mock.On("InvalidateByToken", ctx, token)}
+}
+
+func (_c *MockSessioner_InvalidateByToken_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_InvalidateByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateByToken_Call) Return(err error) *MockSessioner_InvalidateByToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateByToken_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockSessioner_InvalidateByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateByUserID provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) InvalidateByUserID(ctx context.Context, userID string, token string) error {
+	ret := _mock.Called(ctx, userID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateByUserID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_InvalidateByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateByUserID'
+type MockSessioner_InvalidateByUserID_Call struct {
+	*mock.Call
+}
+
+// InvalidateByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - token string
+func (_e *MockSessioner_Expecter) InvalidateByUserID(ctx interface{}, userID interface{}, token interface{}) *MockSessioner_InvalidateByUserID_Call {
+	return &MockSessioner_InvalidateByUserID_Call{Call: _e.mock.On("InvalidateByUserID", ctx, userID, token)}
+}
+
+func (_c *MockSessioner_InvalidateByUserID_Call) Run(run func(ctx context.Context, userID string, token string)) *MockSessioner_InvalidateByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateByUserID_Call) Return(err error) *MockSessioner_InvalidateByUserID_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string, token string) error) *MockSessioner_InvalidateByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateImpersonationsByEntityID provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) InvalidateImpersonationsByEntityID(ctx context.Context, entityID string, userID string) ([]string, error) {
+	ret := _mock.Called(ctx, entityID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateImpersonationsByEntityID")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return returnFunc(ctx, entityID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = returnFunc(ctx, entityID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, entityID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_InvalidateImpersonationsByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateImpersonationsByEntityID'
+type MockSessioner_InvalidateImpersonationsByEntityID_Call struct {
+	*mock.Call
+}
+
+// InvalidateImpersonationsByEntityID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+//   - userID string
+func (_e *MockSessioner_Expecter) InvalidateImpersonationsByEntityID(ctx interface{}, entityID interface{}, userID interface{}) *MockSessioner_InvalidateImpersonationsByEntityID_Call {
+	return &MockSessioner_InvalidateImpersonationsByEntityID_Call{Call: _e.mock.On("InvalidateImpersonationsByEntityID", ctx, entityID, userID)}
+}
+
+func (_c *MockSessioner_InvalidateImpersonationsByEntityID_Call) Run(run func(ctx context.Context, entityID string, userID string)) *MockSessioner_InvalidateImpersonationsByEntityID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateImpersonationsByEntityID_Call) Return(ss []string, err error) *MockSessioner_InvalidateImpersonationsByEntityID_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockSessioner_InvalidateImpersonationsByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string, userID string) ([]string, error)) *MockSessioner_InvalidateImpersonationsByEntityID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUser provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) ListByUser(ctx context.Context, userID string) ([]*model.Session, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []*model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Session, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Session); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessioner_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type MockSessioner_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockSessioner_Expecter) ListByUser(ctx interface{}, userID interface{}) *MockSessioner_ListByUser_Call {
+	return &MockSessioner_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+}
+
+func (_c *MockSessioner_ListByUser_Call) Run(run func(ctx context.Context, userID string)) *MockSessioner_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_ListByUser_Call) Return(sessions []*model.Session, err error) *MockSessioner_ListByUser_Call {
+	_c.Call.Return(sessions, err)
+	return _c
+}
+
+func (_c *MockSessioner_ListByUser_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Session, error)) *MockSessioner_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) Touch(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockSessioner_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockSessioner_Expecter) Touch(ctx interface{}, id interface{}) *MockSessioner_Touch_Call {
+	return &MockSessioner_Touch_Call{Call: _e.mock.On("Touch", ctx, id)}
+}
+
+func (_c *MockSessioner_Touch_Call) Run(run func(ctx context.Context, id string)) *MockSessioner_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_Touch_Call) Return(err error) *MockSessioner_Touch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_Touch_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockSessioner_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTwoFactorPending provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) UpdateTwoFactorPending(ctx context.Context, token string, isPending bool) error {
+	ret := _mock.Called(ctx, token, isPending)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTwoFactorPending")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, token, isPending)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessioner_UpdateTwoFactorPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTwoFactorPending'
+type MockSessioner_UpdateTwoFactorPending_Call struct {
+	*mock.Call
+}
+
+// UpdateTwoFactorPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - isPending bool
+func (_e *MockSessioner_Expecter) UpdateTwoFactorPending(ctx interface{}, token interface{}, isPending interface{}) *MockSessioner_UpdateTwoFactorPending_Call {
+	return &MockSessioner_UpdateTwoFactorPending_Call{Call: _e.mock.On("UpdateTwoFactorPending", ctx, token, isPending)}
+}
+
+func (_c *MockSessioner_UpdateTwoFactorPending_Call) Run(run func(ctx context.Context, token string, isPending bool)) *MockSessioner_UpdateTwoFactorPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_UpdateTwoFactorPending_Call) Return(err error) *MockSessioner_UpdateTwoFactorPending_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessioner_UpdateTwoFactorPending_Call) RunAndReturn(run func(ctx context.Context, token string, isPending bool) error) *MockSessioner_UpdateTwoFactorPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockSessioner
+func (_mock *MockSessioner) WithQuerier(q core.Querier) store.Sessioner {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.Sessioner
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.Sessioner); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.Sessioner)
+		}
+	}
+	return r0
+}
+
+// MockSessioner_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockSessioner_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockSessioner_Expecter) WithQuerier(q interface{}) *MockSessioner_WithQuerier_Call {
+	return &MockSessioner_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockSessioner_WithQuerier_Call) Run(run func(q core.Querier)) *MockSessioner_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessioner_WithQuerier_Call) Return(sessioner store.Sessioner) *MockSessioner_WithQuerier_Call {
+	_c.Call.Return(sessioner)
+	return _c
+}
+
+func (_c *MockSessioner_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.Sessioner) *MockSessioner_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionPolicier creates a new instance of MockSessionPolicier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionPolicier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionPolicier {
+	mock := &MockSessionPolicier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSessionPolicier is an autogenerated mock type for the SessionPolicier type
+type MockSessionPolicier struct {
+	mock.Mock
+}
+
+type MockSessionPolicier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionPolicier) EXPECT() *MockSessionPolicier_Expecter {
+	return &MockSessionPolicier_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockSessionPolicier
+func (_mock *MockSessionPolicier) Get(ctx context.Context, entityID string) (*model.SessionPolicy, error) {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.SessionPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.SessionPolicy, error)); ok {
+		return returnFunc(ctx, entityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.SessionPolicy); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SessionPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionPolicier_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockSessionPolicier_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockSessionPolicier_Expecter) Get(ctx interface{}, entityID interface{}) *MockSessionPolicier_Get_Call {
+	return &MockSessionPolicier_Get_Call{Call: _e.mock.On("Get", ctx, entityID)}
+}
+
+func (_c *MockSessionPolicier_Get_Call) Run(run func(ctx context.Context, entityID string)) *MockSessionPolicier_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionPolicier_Get_Call) Return(sessionPolicy *model.SessionPolicy, err error) *MockSessionPolicier_Get_Call {
+	_c.Call.Return(sessionPolicy, err)
+	return _c
+}
+
+func (_c *MockSessionPolicier_Get_Call) RunAndReturn(run func(ctx context.Context, entityID string) (*model.SessionPolicy, error)) *MockSessionPolicier_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUserID provides a mock function for the type MockSessionPolicier
+func (_mock *MockSessionPolicier) ListByUserID(ctx context.Context, userID string) ([]*model.SessionPolicy, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserID")
+	}
+
+	var r0 []*model.SessionPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.SessionPolicy, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.SessionPolicy); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SessionPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionPolicier_ListByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUserID'
+type MockSessionPolicier_ListByUserID_Call struct {
+	*mock.Call
+}
+
+// ListByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockSessionPolicier_Expecter) ListByUserID(ctx interface{}, userID interface{}) *MockSessionPolicier_ListByUserID_Call {
+	return &MockSessionPolicier_ListByUserID_Call{Call: _e.mock.On("ListByUserID", ctx, userID)}
+}
+
+func (_c *MockSessionPolicier_ListByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockSessionPolicier_ListByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionPolicier_ListByUserID_Call) Return(sessionPolicys []*model.SessionPolicy, err error) *MockSessionPolicier_ListByUserID_Call {
+	_c.Call.Return(sessionPolicys, err)
+	return _c
+}
+
+func (_c *MockSessionPolicier_ListByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.SessionPolicy, error)) *MockSessionPolicier_ListByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockSessionPolicier
+func (_mock *MockSessionPolicier) Upsert(ctx context.Context, policy *model.SessionPolicy) (*model.SessionPolicy, error) {
+	ret := _mock.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 *model.SessionPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SessionPolicy) (*model.SessionPolicy, error)); ok {
+		return returnFunc(ctx, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SessionPolicy) *model.SessionPolicy); ok {
+		r0 = returnFunc(ctx, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SessionPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.SessionPolicy) error); ok {
+		r1 = returnFunc(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionPolicier_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockSessionPolicier_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy *model.SessionPolicy
+func (_e *MockSessionPolicier_Expecter) Upsert(ctx interface{}, policy interface{}) *MockSessionPolicier_Upsert_Call {
+	return &MockSessionPolicier_Upsert_Call{Call: _e.mock.On("Upsert", ctx, policy)}
+}
+
+func (_c *MockSessionPolicier_Upsert_Call) Run(run func(ctx context.Context, policy *model.SessionPolicy)) *MockSessionPolicier_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.SessionPolicy
+		if args[1] != nil {
+			arg1 = args[1].(*model.SessionPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionPolicier_Upsert_Call) Return(sessionPolicy *model.SessionPolicy, err error) *MockSessionPolicier_Upsert_Call {
+	_c.Call.Return(sessionPolicy, err)
+	return _c
+}
+
+func (_c *MockSessionPolicier_Upsert_Call) RunAndReturn(run func(ctx context.Context, policy *model.SessionPolicy) (*model.SessionPolicy, error)) *MockSessionPolicier_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockSessionPolicier
+func (_mock *MockSessionPolicier) WithQuerier(q core.Querier) store.SessionPolicier {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.SessionPolicier
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.SessionPolicier); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SessionPolicier)
+		}
+	}
+	return r0
+}
+
+// MockSessionPolicier_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockSessionPolicier_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockSessionPolicier_Expecter) WithQuerier(q interface{}) *MockSessionPolicier_WithQuerier_Call {
+	return &MockSessionPolicier_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockSessionPolicier_WithQuerier_Call) Run(run func(q core.Querier)) *MockSessionPolicier_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionPolicier_WithQuerier_Call) Return(sessionPolicier store.SessionPolicier) *MockSessionPolicier_WithQuerier_Call {
+	_c.Call.Return(sessionPolicier)
+	return _c
+}
+
+func (_c *MockSessionPolicier_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.SessionPolicier) *MockSessionPolicier_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSignInHistorier creates a new instance of MockSignInHistorier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSignInHistorier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSignInHistorier {
+	mock := &MockSignInHistorier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSignInHistorier is an autogenerated mock type for the SignInHistorier type
+type MockSignInHistorier struct {
+	mock.Mock
+}
+
+type MockSignInHistorier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSignInHistorier) EXPECT() *MockSignInHistorier_Expecter {
+	return &MockSignInHistorier_Expecter{mock: &_m.Mock}
+}
+
+// ListByUserID provides a mock function for the type MockSignInHistorier
+func (_mock *MockSignInHistorier) ListByUserID(ctx context.Context, userID string) ([]*model.SignInHistory, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserID")
+	}
+
+	var r0 []*model.SignInHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.SignInHistory, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.SignInHistory); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SignInHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSignInHistorier_ListByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUserID'
+type MockSignInHistorier_ListByUserID_Call struct {
+	*mock.Call
+}
+
+// ListByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockSignInHistorier_Expecter) ListByUserID(ctx interface{}, userID interface{}) *MockSignInHistorier_ListByUserID_Call {
+	return &MockSignInHistorier_ListByUserID_Call{Call: _e.mock.On("ListByUserID", ctx, userID)}
+}
+
+func (_c *MockSignInHistorier_ListByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockSignInHistorier_ListByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSignInHistorier_ListByUserID_Call) Return(signInHistorys []*model.SignInHistory, err error) *MockSignInHistorier_ListByUserID_Call {
+	_c.Call.Return(signInHistorys, err)
+	return _c
+}
+
+func (_c *MockSignInHistorier_ListByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.SignInHistory, error)) *MockSignInHistorier_ListByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockSignInHistorier
+func (_mock *MockSignInHistorier) Record(ctx context.Context, userID string, country string, deviceClass string) error {
+	ret := _mock.Called(ctx, userID, country, deviceClass)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, country, deviceClass)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSignInHistorier_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockSignInHistorier_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - country string
+//   - deviceClass string
+func (_e *MockSignInHistorier_Expecter) Record(ctx interface{}, userID interface{}, country interface{}, deviceClass interface{}) *MockSignInHistorier_Record_Call {
+	return &MockSignInHistorier_Record_Call{Call: _e.mock.On("Record", ctx, userID, country, deviceClass)}
+}
+
+func (_c *MockSignInHistorier_Record_Call) Run(run func(ctx context.Context, userID string, country string, deviceClass string)) *MockSignInHistorier_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSignInHistorier_Record_Call) Return(err error) *MockSignInHistorier_Record_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSignInHistorier_Record_Call) RunAndReturn(run func(ctx context.Context, userID string, country string, deviceClass string) error) *MockSignInHistorier_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockSignInHistorier
+func (_mock *MockSignInHistorier) WithQuerier(q core.Querier) store.SignInHistorier {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.SignInHistorier
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.SignInHistorier); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SignInHistorier)
+		}
+	}
+	return r0
+}
+
+// MockSignInHistorier_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockSignInHistorier_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockSignInHistorier_Expecter) WithQuerier(q interface{}) *MockSignInHistorier_WithQuerier_Call {
+	return &MockSignInHistorier_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockSignInHistorier_WithQuerier_Call) Run(run func(q core.Querier)) *MockSignInHistorier_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSignInHistorier_WithQuerier_Call) Return(signInHistorier store.SignInHistorier) *MockSignInHistorier_WithQuerier_Call {
+	_c.Call.Return(signInHistorier)
+	return _c
+}
+
+func (_c *MockSignInHistorier_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.SignInHistorier) *MockSignInHistorier_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSSOConnectioner creates a new instance of MockSSOConnectioner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSSOConnectioner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSSOConnectioner {
+	mock := &MockSSOConnectioner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSSOConnectioner is an autogenerated mock type for the SSOConnectioner type
+type MockSSOConnectioner struct {
+	mock.Mock
+}
+
+type MockSSOConnectioner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSSOConnectioner) EXPECT() *MockSSOConnectioner_Expecter {
+	return &MockSSOConnectioner_Expecter{mock: &_m.Mock}
+}
+
+// DeleteByEntityID provides a mock function for the type MockSSOConnectioner
+func (_mock *MockSSOConnectioner) DeleteByEntityID(ctx context.Context, entityID string) error {
+	ret := _mock.Called(ctx, entityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByEntityID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, entityID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSSOConnectioner_DeleteByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByEntityID'
+type MockSSOConnectioner_DeleteByEntityID_Call struct {
+	*mock.Call
+}
+
+// DeleteByEntityID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entityID string
+func (_e *MockSSOConnectioner_Expecter) DeleteByEntityID(ctx interface{}, entityID interface{}) *MockSSOConnectioner_DeleteByEntityID_Call {
+	return &MockSSOConnectioner_DeleteByEntityID_Call{Call: _e.mock.On("DeleteByEntityID", ctx, entityID)}
+}
+
+func (_c *MockSSOConnectioner_DeleteByEntityID_Call) Run(run func(ctx context.Context, entityID string)) *MockSSOConnectioner_DeleteByEntityID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_CleanUpExpired_Call) Return(err error) *MockSessioner_CleanUpExpired_Call {
+func (_c *MockSSOConnectioner_DeleteByEntityID_Call) Return(err error) *MockSSOConnectioner_DeleteByEntityID_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSessioner_CleanUpExpired_Call) RunAndReturn(run func(ctx context.Context) error) *MockSessioner_CleanUpExpired_Call {
+func (_c *MockSSOConnectioner_DeleteByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string) error) *MockSSOConnectioner_DeleteByEntityID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Create provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) Create(ctx context.Context, session *model.Session) (*model.Session, error) {
-	ret := _mock.Called(ctx, session)
+// GetByEntityID provides a mock function for the type MockSSOConnectioner
+func (_mock *MockSSOConnectioner) GetByEntityID(ctx context.Context, entityID string) (*model.SSOConnection, error) {
+	ret := _mock.Called(ctx, entityID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for GetByEntityID")
 	}
 
-	var r0 *model.Session
+	var r0 *model.SSOConnection
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Session) (*model.Session, error)); ok {
-		return returnFunc(ctx, session)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.SSOConnection, error)); ok {
+		return returnFunc(ctx, entityID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Session) *model.Session); ok {
-		r0 = returnFunc(ctx, session)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.SSOConnection); ok {
+		r0 = returnFunc(ctx, entityID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Session)
+			r0 = ret.Get(0).(*model.SSOConnection)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.Session) error); ok {
-		r1 = returnFunc(ctx, session)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, entityID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSessioner_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockSessioner_Create_Call struct {
+// MockSSOConnectioner_GetByEntityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEntityID'
+type MockSSOConnectioner_GetByEntityID_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// GetByEntityID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - session *model.Session
-func (_e *MockSessioner_Expecter) Create(ctx interface{}, session interface{}) *MockSessioner_Create_Call {
-	return &MockSessioner_Create_Call{Call: _e.mock.On("Create", ctx, session)}
+//   - entityID string
+func (_e *MockSSOConnectioner_Expecter) GetByEntityID(ctx interface{}, entityID interface{}) *MockSSOConnectioner_GetByEntityID_Call {
+	return &MockSSOConnectioner_GetByEntityID_Call{Call: _e.mock.On("GetByEntityID", ctx, entityID)}
 }
 
-func (_c *MockSessioner_Create_Call) Run(run func(ctx context.Context, session *model.Session)) *MockSessioner_Create_Call {
+func (_c *MockSSOConnectioner_GetByEntityID_Call) Run(run func(ctx context.Context, entityID string)) *MockSSOConnectioner_GetByEntityID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.Session
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.Session)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -1082,65 +6581,349 @@ func (_c *MockSessioner_Create_Call) Run(run func(ctx context.Context, session *
 	return _c
 }
 
-func (_c *MockSessioner_Create_Call) Return(session1 *model.Session, err error) *MockSessioner_Create_Call {
-	_c.Call.Return(session1, err)
+func (_c *MockSSOConnectioner_GetByEntityID_Call) Return(sSOConnection *model.SSOConnection, err error) *MockSSOConnectioner_GetByEntityID_Call {
+	_c.Call.Return(sSOConnection, err)
 	return _c
 }
 
-func (_c *MockSessioner_Create_Call) RunAndReturn(run func(ctx context.Context, session *model.Session) (*model.Session, error)) *MockSessioner_Create_Call {
+func (_c *MockSSOConnectioner_GetByEntityID_Call) RunAndReturn(run func(ctx context.Context, entityID string) (*model.SSOConnection, error)) *MockSSOConnectioner_GetByEntityID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByRefreshToken provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) GetByRefreshToken(ctx context.Context, refreshToken string) (*model.Session, error) {
-	ret := _mock.Called(ctx, refreshToken)
+// GetByID provides a mock function for the type MockSSOConnectioner
+func (_mock *MockSSOConnectioner) GetByID(ctx context.Context, id string) (*model.SSOConnection, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.SSOConnection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.SSOConnection, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.SSOConnection); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SSOConnection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSOConnectioner_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockSSOConnectioner_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockSSOConnectioner_Expecter) GetByID(ctx interface{}, id interface{}) *MockSSOConnectioner_GetByID_Call {
+	return &MockSSOConnectioner_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockSSOConnectioner_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockSSOConnectioner_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSOConnectioner_GetByID_Call) Return(sSOConnection *model.SSOConnection, err error) *MockSSOConnectioner_GetByID_Call {
+	_c.Call.Return(sSOConnection, err)
+	return _c
+}
+
+func (_c *MockSSOConnectioner_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.SSOConnection, error)) *MockSSOConnectioner_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEnforcedByUserID provides a mock function for the type MockSSOConnectioner
+func (_mock *MockSSOConnectioner) ListEnforcedByUserID(ctx context.Context, userID string) ([]*model.SSOConnection, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEnforcedByUserID")
+	}
+
+	var r0 []*model.SSOConnection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.SSOConnection, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.SSOConnection); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SSOConnection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSOConnectioner_ListEnforcedByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEnforcedByUserID'
+type MockSSOConnectioner_ListEnforcedByUserID_Call struct {
+	*mock.Call
+}
+
+// ListEnforcedByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockSSOConnectioner_Expecter) ListEnforcedByUserID(ctx interface{}, userID interface{}) *MockSSOConnectioner_ListEnforcedByUserID_Call {
+	return &MockSSOConnectioner_ListEnforcedByUserID_Call{Call: _e.mock.On("ListEnforcedByUserID", ctx, userID)}
+}
+
+func (_c *MockSSOConnectioner_ListEnforcedByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockSSOConnectioner_ListEnforcedByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSOConnectioner_ListEnforcedByUserID_Call) Return(sSOConnections []*model.SSOConnection, err error) *MockSSOConnectioner_ListEnforcedByUserID_Call {
+	_c.Call.Return(sSOConnections, err)
+	return _c
+}
+
+func (_c *MockSSOConnectioner_ListEnforcedByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.SSOConnection, error)) *MockSSOConnectioner_ListEnforcedByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockSSOConnectioner
+func (_mock *MockSSOConnectioner) Upsert(ctx context.Context, connection *model.SSOConnection) (*model.SSOConnection, error) {
+	ret := _mock.Called(ctx, connection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 *model.SSOConnection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SSOConnection) (*model.SSOConnection, error)); ok {
+		return returnFunc(ctx, connection)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.SSOConnection) *model.SSOConnection); ok {
+		r0 = returnFunc(ctx, connection)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SSOConnection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.SSOConnection) error); ok {
+		r1 = returnFunc(ctx, connection)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSOConnectioner_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockSSOConnectioner_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - connection *model.SSOConnection
+func (_e *MockSSOConnectioner_Expecter) Upsert(ctx interface{}, connection interface{}) *MockSSOConnectioner_Upsert_Call {
+	return &MockSSOConnectioner_Upsert_Call{Call: _e.mock.On("Upsert", ctx, connection)}
+}
+
+func (_c *MockSSOConnectioner_Upsert_Call) Run(run func(ctx context.Context, connection *model.SSOConnection)) *MockSSOConnectioner_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.SSOConnection
+		if args[1] != nil {
+			arg1 = args[1].(*model.SSOConnection)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSOConnectioner_Upsert_Call) Return(sSOConnection *model.SSOConnection, err error) *MockSSOConnectioner_Upsert_Call {
+	_c.Call.Return(sSOConnection, err)
+	return _c
+}
+
+func (_c *MockSSOConnectioner_Upsert_Call) RunAndReturn(run func(ctx context.Context, connection *model.SSOConnection) (*model.SSOConnection, error)) *MockSSOConnectioner_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithQuerier provides a mock function for the type MockSSOConnectioner
+func (_mock *MockSSOConnectioner) WithQuerier(q core.Querier) store.SSOConnectioner {
+	ret := _mock.Called(q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithQuerier")
+	}
+
+	var r0 store.SSOConnectioner
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.SSOConnectioner); ok {
+		r0 = returnFunc(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SSOConnectioner)
+		}
+	}
+	return r0
+}
+
+// MockSSOConnectioner_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockSSOConnectioner_WithQuerier_Call struct {
+	*mock.Call
+}
+
+// WithQuerier is a helper method to define mock.On call
+//   - q core.Querier
+func (_e *MockSSOConnectioner_Expecter) WithQuerier(q interface{}) *MockSSOConnectioner_WithQuerier_Call {
+	return &MockSSOConnectioner_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+}
+
+func (_c *MockSSOConnectioner_WithQuerier_Call) Run(run func(q core.Querier)) *MockSSOConnectioner_WithQuerier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 core.Querier
+		if args[0] != nil {
+			arg0 = args[0].(core.Querier)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSOConnectioner_WithQuerier_Call) Return(sSOConnectioner store.SSOConnectioner) *MockSSOConnectioner_WithQuerier_Call {
+	_c.Call.Return(sSOConnectioner)
+	return _c
+}
+
+func (_c *MockSSOConnectioner_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.SSOConnectioner) *MockSSOConnectioner_WithQuerier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTrustedDevicer creates a new instance of MockTrustedDevicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrustedDevicer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrustedDevicer {
+	mock := &MockTrustedDevicer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrustedDevicer is an autogenerated mock type for the TrustedDevicer type
+type MockTrustedDevicer struct {
+	mock.Mock
+}
+
+type MockTrustedDevicer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrustedDevicer) EXPECT() *MockTrustedDevicer_Expecter {
+	return &MockTrustedDevicer_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) Create(ctx context.Context, device *model.TrustedDevice) (*model.TrustedDevice, error) {
+	ret := _mock.Called(ctx, device)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByRefreshToken")
+		panic("no return value specified for Create")
 	}
 
-	var r0 *model.Session
+	var r0 *model.TrustedDevice
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
-		return returnFunc(ctx, refreshToken)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.TrustedDevice) (*model.TrustedDevice, error)); ok {
+		return returnFunc(ctx, device)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
-		r0 = returnFunc(ctx, refreshToken)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.TrustedDevice) *model.TrustedDevice); ok {
+		r0 = returnFunc(ctx, device)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Session)
+			r0 = ret.Get(0).(*model.TrustedDevice)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, refreshToken)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.TrustedDevice) error); ok {
+		r1 = returnFunc(ctx, device)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSessioner_GetByRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByRefreshToken'
-type MockSessioner_GetByRefreshToken_Call struct {
+// MockTrustedDevicer_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTrustedDevicer_Create_Call struct {
 	*mock.Call
 }
 
-// GetByRefreshToken is a helper method to define mock.On call
+// Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - refreshToken string
-func (_e *MockSessioner_Expecter) GetByRefreshToken(ctx interface{}, refreshToken interface{}) *MockSessioner_GetByRefreshToken_Call {
-	return &MockSessioner_GetByRefreshToken_Call{Call: _e.mock.On("GetByRefreshToken", ctx, refreshToken)}
+//   - device *model.TrustedDevice
+func (_e *MockTrustedDevicer_Expecter) Create(ctx interface{}, device interface{}) *MockTrustedDevicer_Create_Call {
+	return &MockTrustedDevicer_Create_Call{Call: _e.mock.On("Create", ctx, device)}
 }
 
-func (_c *MockSessioner_GetByRefreshToken_Call) Run(run func(ctx context.Context, refreshToken string)) *MockSessioner_GetByRefreshToken_Call {
+func (_c *MockTrustedDevicer_Create_Call) Run(run func(ctx context.Context, device *model.TrustedDevice)) *MockTrustedDevicer_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *model.TrustedDevice
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*model.TrustedDevice)
 		}
 		run(
 			arg0,
@@ -1150,57 +6933,56 @@ func (_c *MockSessioner_GetByRefreshToken_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockSessioner_GetByRefreshToken_Call) Return(session *model.Session, err error) *MockSessioner_GetByRefreshToken_Call {
-	_c.Call.Return(session, err)
+func (_c *MockTrustedDevicer_Create_Call) Return(trustedDevice *model.TrustedDevice, err error) *MockTrustedDevicer_Create_Call {
+	_c.Call.Return(trustedDevice, err)
 	return _c
 }
 
-func (_c *MockSessioner_GetByRefreshToken_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (*model.Session, error)) *MockSessioner_GetByRefreshToken_Call {
+func (_c *MockTrustedDevicer_Create_Call) RunAndReturn(run func(ctx context.Context, device *model.TrustedDevice) (*model.TrustedDevice, error)) *MockTrustedDevicer_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByToken provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) GetByToken(ctx context.Context, token string) (*model.Session, error) {
-	ret := _mock.Called(ctx, token)
+// Delete provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) Delete(ctx context.Context, id string, userID string) (bool, error) {
+	ret := _mock.Called(ctx, id, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByToken")
+		panic("no return value specified for Delete")
 	}
 
-	var r0 *model.Session
+	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Session, error)); ok {
-		return returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, id, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Session); ok {
-		r0 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, id, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Session)
-		}
+		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSessioner_GetByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByToken'
-type MockSessioner_GetByToken_Call struct {
+// MockTrustedDevicer_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockTrustedDevicer_Delete_Call struct {
 	*mock.Call
 }
 
-// GetByToken is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-func (_e *MockSessioner_Expecter) GetByToken(ctx interface{}, token interface{}) *MockSessioner_GetByToken_Call {
-	return &MockSessioner_GetByToken_Call{Call: _e.mock.On("GetByToken", ctx, token)}
+//   - id string
+//   - userID string
+func (_e *MockTrustedDevicer_Expecter) Delete(ctx interface{}, id interface{}, userID interface{}) *MockTrustedDevicer_Delete_Call {
+	return &MockTrustedDevicer_Delete_Call{Call: _e.mock.On("Delete", ctx, id, userID)}
 }
 
-func (_c *MockSessioner_GetByToken_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_GetByToken_Call {
+func (_c *MockTrustedDevicer_Delete_Call) Run(run func(ctx context.Context, id string, userID string)) *MockTrustedDevicer_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1210,55 +6992,59 @@ func (_c *MockSessioner_GetByToken_Call) Run(run func(ctx context.Context, token
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_GetByToken_Call) Return(session *model.Session, err error) *MockSessioner_GetByToken_Call {
-	_c.Call.Return(session, err)
+func (_c *MockTrustedDevicer_Delete_Call) Return(b bool, err error) *MockTrustedDevicer_Delete_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockSessioner_GetByToken_Call) RunAndReturn(run func(ctx context.Context, token string) (*model.Session, error)) *MockSessioner_GetByToken_Call {
+func (_c *MockTrustedDevicer_Delete_Call) RunAndReturn(run func(ctx context.Context, id string, userID string) (bool, error)) *MockTrustedDevicer_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InvalidateByID provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) InvalidateByID(ctx context.Context, id string, userID string) error {
-	ret := _mock.Called(ctx, id, userID)
+// DeleteByUserID provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) DeleteByUserID(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InvalidateByID")
+		panic("no return value specified for DeleteByUserID")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, id, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockSessioner_InvalidateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateByID'
-type MockSessioner_InvalidateByID_Call struct {
+// MockTrustedDevicer_DeleteByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUserID'
+type MockTrustedDevicer_DeleteByUserID_Call struct {
 	*mock.Call
 }
 
-// InvalidateByID is a helper method to define mock.On call
+// DeleteByUserID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
 //   - userID string
-func (_e *MockSessioner_Expecter) InvalidateByID(ctx interface{}, id interface{}, userID interface{}) *MockSessioner_InvalidateByID_Call {
-	return &MockSessioner_InvalidateByID_Call{Call: _e.mock.On("InvalidateByID", ctx, id, userID)}
+func (_e *MockTrustedDevicer_Expecter) DeleteByUserID(ctx interface{}, userID interface{}) *MockTrustedDevicer_DeleteByUserID_Call {
+	return &MockTrustedDevicer_DeleteByUserID_Call{Call: _e.mock.On("DeleteByUserID", ctx, userID)}
 }
 
-func (_c *MockSessioner_InvalidateByID_Call) Run(run func(ctx context.Context, id string, userID string)) *MockSessioner_InvalidateByID_Call {
+func (_c *MockTrustedDevicer_DeleteByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockTrustedDevicer_DeleteByUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1268,117 +7054,116 @@ func (_c *MockSessioner_InvalidateByID_Call) Run(run func(ctx context.Context, i
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateByID_Call) Return(err error) *MockSessioner_InvalidateByID_Call {
+func (_c *MockTrustedDevicer_DeleteByUserID_Call) Return(err error) *MockTrustedDevicer_DeleteByUserID_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateByID_Call) RunAndReturn(run func(ctx context.Context, id string, userID string) error) *MockSessioner_InvalidateByID_Call {
+func (_c *MockTrustedDevicer_DeleteByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockTrustedDevicer_DeleteByUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InvalidateByToken provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) InvalidateByToken(ctx context.Context, token string) error {
-	ret := _mock.Called(ctx, token)
+// DeleteExpired provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) DeleteExpired(ctx context.Context) error {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InvalidateByToken")
+		panic("no return value specified for DeleteExpired")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockSessioner_InvalidateByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateByToken'
-type MockSessioner_InvalidateByToken_Call struct {
+// MockTrustedDevicer_DeleteExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpired'
+type MockTrustedDevicer_DeleteExpired_Call struct {
 	*mock.Call
 }
 
-// InvalidateByToken is a helper method to define mock.On call
+// DeleteExpired is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-func (_e *MockSessioner_Expecter) InvalidateByToken(ctx interface{}, token interface{}) *MockSessioner_InvalidateByToken_Call {
-	return &MockSessioner_InvalidateByToken_Call{Call: _e.mock.On("InvalidateByToken", ctx, token)}
+func (_e *MockTrustedDevicer_Expecter) DeleteExpired(ctx interface{}) *MockTrustedDevicer_DeleteExpired_Call {
+	return &MockTrustedDevicer_DeleteExpired_Call{Call: _e.mock.On("DeleteExpired", ctx)}
 }
 
-func (_c *MockSessioner_InvalidateByToken_Call) Run(run func(ctx context.Context, token string)) *MockSessioner_InvalidateByToken_Call {
+func (_c *MockTrustedDevicer_DeleteExpired_Call) Run(run func(ctx context.Context)) *MockTrustedDevicer_DeleteExpired_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateByToken_Call) Return(err error) *MockSessioner_InvalidateByToken_Call {
+func (_c *MockTrustedDevicer_DeleteExpired_Call) Return(err error) *MockTrustedDevicer_DeleteExpired_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateByToken_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockSessioner_InvalidateByToken_Call {
+func (_c *MockTrustedDevicer_DeleteExpired_Call) RunAndReturn(run func(ctx context.Context) error) *MockTrustedDevicer_DeleteExpired_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InvalidateByUserID provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) InvalidateByUserID(ctx context.Context, userID string, token string) error {
-	ret := _mock.Called(ctx, userID, token)
+// Get provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) Get(ctx context.Context, id string) (*model.TrustedDevice, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InvalidateByUserID")
+		panic("no return value specified for Get")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, userID, token)
+	var r0 *model.TrustedDevice
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.TrustedDevice, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.TrustedDevice); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TrustedDevice)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockSessioner_InvalidateByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateByUserID'
-type MockSessioner_InvalidateByUserID_Call struct {
+// MockTrustedDevicer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockTrustedDevicer_Get_Call struct {
 	*mock.Call
 }
 
-// InvalidateByUserID is a helper method to define mock.On call
+// Get is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - token string
-func (_e *MockSessioner_Expecter) InvalidateByUserID(ctx interface{}, userID interface{}, token interface{}) *MockSessioner_InvalidateByUserID_Call {
-	return &MockSessioner_InvalidateByUserID_Call{Call: _e.mock.On("InvalidateByUserID", ctx, userID, token)}
+//   - id string
+func (_e *MockTrustedDevicer_Expecter) Get(ctx interface{}, id interface{}) *MockTrustedDevicer_Get_Call {
+	return &MockTrustedDevicer_Get_Call{Call: _e.mock.On("Get", ctx, id)}
 }
 
-func (_c *MockSessioner_InvalidateByUserID_Call) Run(run func(ctx context.Context, userID string, token string)) *MockSessioner_InvalidateByUserID_Call {
+func (_c *MockTrustedDevicer_Get_Call) Run(run func(ctx context.Context, id string)) *MockTrustedDevicer_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1388,47 +7173,42 @@ func (_c *MockSessioner_InvalidateByUserID_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateByUserID_Call) Return(err error) *MockSessioner_InvalidateByUserID_Call {
-	_c.Call.Return(err)
+func (_c *MockTrustedDevicer_Get_Call) Return(trustedDevice *model.TrustedDevice, err error) *MockTrustedDevicer_Get_Call {
+	_c.Call.Return(trustedDevice, err)
 	return _c
 }
 
-func (_c *MockSessioner_InvalidateByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string, token string) error) *MockSessioner_InvalidateByUserID_Call {
+func (_c *MockTrustedDevicer_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.TrustedDevice, error)) *MockTrustedDevicer_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListByUser provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) ListByUser(ctx context.Context, userID string) ([]*model.Session, error) {
+// ListByUserID provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) ListByUserID(ctx context.Context, userID string) ([]*model.TrustedDevice, error) {
 	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListByUser")
+		panic("no return value specified for ListByUserID")
 	}
 
-	var r0 []*model.Session
+	var r0 []*model.TrustedDevice
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Session, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.TrustedDevice, error)); ok {
 		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Session); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.TrustedDevice); ok {
 		r0 = returnFunc(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Session)
+			r0 = ret.Get(0).([]*model.TrustedDevice)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -1439,19 +7219,19 @@ func (_mock *MockSessioner) ListByUser(ctx context.Context, userID string) ([]*m
 	return r0, r1
 }
 
-// MockSessioner_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
-type MockSessioner_ListByUser_Call struct {
+// MockTrustedDevicer_ListByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUserID'
+type MockTrustedDevicer_ListByUserID_Call struct {
 	*mock.Call
 }
 
-// ListByUser is a helper method to define mock.On call
+// ListByUserID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *MockSessioner_Expecter) ListByUser(ctx interface{}, userID interface{}) *MockSessioner_ListByUser_Call {
-	return &MockSessioner_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+func (_e *MockTrustedDevicer_Expecter) ListByUserID(ctx interface{}, userID interface{}) *MockTrustedDevicer_ListByUserID_Call {
+	return &MockTrustedDevicer_ListByUserID_Call{Call: _e.mock.On("ListByUserID", ctx, userID)}
 }
 
-func (_c *MockSessioner_ListByUser_Call) Run(run func(ctx context.Context, userID string)) *MockSessioner_ListByUser_Call {
+func (_c *MockTrustedDevicer_ListByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockTrustedDevicer_ListByUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1469,47 +7249,46 @@ func (_c *MockSessioner_ListByUser_Call) Run(run func(ctx context.Context, userI
 	return _c
 }
 
-func (_c *MockSessioner_ListByUser_Call) Return(sessions []*model.Session, err error) *MockSessioner_ListByUser_Call {
-	_c.Call.Return(sessions, err)
+func (_c *MockTrustedDevicer_ListByUserID_Call) Return(trustedDevices []*model.TrustedDevice, err error) *MockTrustedDevicer_ListByUserID_Call {
+	_c.Call.Return(trustedDevices, err)
 	return _c
 }
 
-func (_c *MockSessioner_ListByUser_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.Session, error)) *MockSessioner_ListByUser_Call {
+func (_c *MockTrustedDevicer_ListByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*model.TrustedDevice, error)) *MockTrustedDevicer_ListByUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTwoFactorPending provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) UpdateTwoFactorPending(ctx context.Context, token string, isPending bool) error {
-	ret := _mock.Called(ctx, token, isPending)
+// Touch provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) Touch(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateTwoFactorPending")
+		panic("no return value specified for Touch")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
-		r0 = returnFunc(ctx, token, isPending)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockSessioner_UpdateTwoFactorPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTwoFactorPending'
-type MockSessioner_UpdateTwoFactorPending_Call struct {
+// MockTrustedDevicer_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockTrustedDevicer_Touch_Call struct {
 	*mock.Call
 }
 
-// UpdateTwoFactorPending is a helper method to define mock.On call
+// Touch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-//   - isPending bool
-func (_e *MockSessioner_Expecter) UpdateTwoFactorPending(ctx interface{}, token interface{}, isPending interface{}) *MockSessioner_UpdateTwoFactorPending_Call {
-	return &MockSessioner_UpdateTwoFactorPending_Call{Call: _e.mock.On("UpdateTwoFactorPending", ctx, token, isPending)}
+//   - id string
+func (_e *MockTrustedDevicer_Expecter) Touch(ctx interface{}, id interface{}) *MockTrustedDevicer_Touch_Call {
+	return &MockTrustedDevicer_Touch_Call{Call: _e.mock.On("Touch", ctx, id)}
 }
 
-func (_c *MockSessioner_UpdateTwoFactorPending_Call) Run(run func(ctx context.Context, token string, isPending bool)) *MockSessioner_UpdateTwoFactorPending_Call {
+func (_c *MockTrustedDevicer_Touch_Call) Run(run func(ctx context.Context, id string)) *MockTrustedDevicer_Touch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1519,60 +7298,55 @@ func (_c *MockSessioner_UpdateTwoFactorPending_Call) Run(run func(ctx context.Co
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSessioner_UpdateTwoFactorPending_Call) Return(err error) *MockSessioner_UpdateTwoFactorPending_Call {
+func (_c *MockTrustedDevicer_Touch_Call) Return(err error) *MockTrustedDevicer_Touch_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSessioner_UpdateTwoFactorPending_Call) RunAndReturn(run func(ctx context.Context, token string, isPending bool) error) *MockSessioner_UpdateTwoFactorPending_Call {
+func (_c *MockTrustedDevicer_Touch_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockTrustedDevicer_Touch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// WithQuerier provides a mock function for the type MockSessioner
-func (_mock *MockSessioner) WithQuerier(q core.Querier) store.Sessioner {
+// WithQuerier provides a mock function for the type MockTrustedDevicer
+func (_mock *MockTrustedDevicer) WithQuerier(q core.Querier) store.TrustedDevicer {
 	ret := _mock.Called(q)
 
 	if len(ret) == 0 {
 		panic("no return value specified for WithQuerier")
 	}
 
-	var r0 store.Sessioner
-	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.Sessioner); ok {
+	var r0 store.TrustedDevicer
+	if returnFunc, ok := ret.Get(0).(func(core.Querier) store.TrustedDevicer); ok {
 		r0 = returnFunc(q)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(store.Sessioner)
+			r0 = ret.Get(0).(store.TrustedDevicer)
 		}
 	}
 	return r0
 }
 
-// MockSessioner_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
-type MockSessioner_WithQuerier_Call struct {
+// MockTrustedDevicer_WithQuerier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithQuerier'
+type MockTrustedDevicer_WithQuerier_Call struct {
 	*mock.Call
 }
 
 // WithQuerier is a helper method to define mock.On call
 //   - q core.Querier
-func (_e *MockSessioner_Expecter) WithQuerier(q interface{}) *MockSessioner_WithQuerier_Call {
-	return &MockSessioner_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
+func (_e *MockTrustedDevicer_Expecter) WithQuerier(q interface{}) *MockTrustedDevicer_WithQuerier_Call {
+	return &MockTrustedDevicer_WithQuerier_Call{Call: _e.mock.On("WithQuerier", q)}
 }
 
-func (_c *MockSessioner_WithQuerier_Call) Run(run func(q core.Querier)) *MockSessioner_WithQuerier_Call {
+func (_c *MockTrustedDevicer_WithQuerier_Call) Run(run func(q core.Querier)) *MockTrustedDevicer_WithQuerier_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 core.Querier
 		if args[0] != nil {
@@ -1585,12 +7359,12 @@ func (_c *MockSessioner_WithQuerier_Call) Run(run func(q core.Querier)) *MockSes
 	return _c
 }
 
-func (_c *MockSessioner_WithQuerier_Call) Return(sessioner store.Sessioner) *MockSessioner_WithQuerier_Call {
-	_c.Call.Return(sessioner)
+func (_c *MockTrustedDevicer_WithQuerier_Call) Return(trustedDevicer store.TrustedDevicer) *MockTrustedDevicer_WithQuerier_Call {
+	_c.Call.Return(trustedDevicer)
 	return _c
 }
 
-func (_c *MockSessioner_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.Sessioner) *MockSessioner_WithQuerier_Call {
+func (_c *MockTrustedDevicer_WithQuerier_Call) RunAndReturn(run func(q core.Querier) store.TrustedDevicer) *MockTrustedDevicer_WithQuerier_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2111,32 +7885,89 @@ func (_mock *MockUserer) CreateVerification(ctx context.Context, verification *m
 	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.Verification) error); ok {
 		r1 = returnFunc(ctx, verification)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserer_CreateVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVerification'
+type MockUserer_CreateVerification_Call struct {
+	*mock.Call
+}
+
+// CreateVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - verification *model.Verification
+func (_e *MockUserer_Expecter) CreateVerification(ctx interface{}, verification interface{}) *MockUserer_CreateVerification_Call {
+	return &MockUserer_CreateVerification_Call{Call: _e.mock.On("CreateVerification", ctx, verification)}
+}
+
+func (_c *MockUserer_CreateVerification_Call) Run(run func(ctx context.Context, verification *model.Verification)) *MockUserer_CreateVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Verification
+		if args[1] != nil {
+			arg1 = args[1].(*model.Verification)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserer_CreateVerification_Call) Return(verification1 *model.Verification, err error) *MockUserer_CreateVerification_Call {
+	_c.Call.Return(verification1, err)
+	return _c
+}
+
+func (_c *MockUserer_CreateVerification_Call) RunAndReturn(run func(ctx context.Context, verification *model.Verification) (*model.Verification, error)) *MockUserer_CreateVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockUserer
+func (_mock *MockUserer) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUserer_CreateVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVerification'
-type MockUserer_CreateVerification_Call struct {
+// MockUserer_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUserer_Delete_Call struct {
 	*mock.Call
 }
 
-// CreateVerification is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - verification *model.Verification
-func (_e *MockUserer_Expecter) CreateVerification(ctx interface{}, verification interface{}) *MockUserer_CreateVerification_Call {
-	return &MockUserer_CreateVerification_Call{Call: _e.mock.On("CreateVerification", ctx, verification)}
+//   - id string
+func (_e *MockUserer_Expecter) Delete(ctx interface{}, id interface{}) *MockUserer_Delete_Call {
+	return &MockUserer_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
 }
 
-func (_c *MockUserer_CreateVerification_Call) Run(run func(ctx context.Context, verification *model.Verification)) *MockUserer_CreateVerification_Call {
+func (_c *MockUserer_Delete_Call) Run(run func(ctx context.Context, id string)) *MockUserer_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.Verification
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.Verification)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -2146,12 +7977,12 @@ func (_c *MockUserer_CreateVerification_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockUserer_CreateVerification_Call) Return(verification1 *model.Verification, err error) *MockUserer_CreateVerification_Call {
-	_c.Call.Return(verification1, err)
+func (_c *MockUserer_Delete_Call) Return(err error) *MockUserer_Delete_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserer_CreateVerification_Call) RunAndReturn(run func(ctx context.Context, verification *model.Verification) (*model.Verification, error)) *MockUserer_CreateVerification_Call {
+func (_c *MockUserer_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockUserer_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2563,6 +8394,131 @@ func (_c *MockUserer_GetVerificationByValue_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// ListDueForDeletion provides a mock function for the type MockUserer
+func (_mock *MockUserer) ListDueForDeletion(ctx context.Context, before time.Time) ([]*model.User, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDueForDeletion")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]*model.User, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []*model.User); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserer_ListDueForDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDueForDeletion'
+type MockUserer_ListDueForDeletion_Call struct {
+	*mock.Call
+}
+
+// ListDueForDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockUserer_Expecter) ListDueForDeletion(ctx interface{}, before interface{}) *MockUserer_ListDueForDeletion_Call {
+	return &MockUserer_ListDueForDeletion_Call{Call: _e.mock.On("ListDueForDeletion", ctx, before)}
+}
+
+func (_c *MockUserer_ListDueForDeletion_Call) Run(run func(ctx context.Context, before time.Time)) *MockUserer_ListDueForDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserer_ListDueForDeletion_Call) Return(users []*model.User, err error) *MockUserer_ListDueForDeletion_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserer_ListDueForDeletion_Call) RunAndReturn(run func(ctx context.Context, before time.Time) ([]*model.User, error)) *MockUserer_ListDueForDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function for the type MockUserer
+func (_mock *MockUserer) Touch(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserer_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockUserer_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockUserer_Expecter) Touch(ctx interface{}, id interface{}) *MockUserer_Touch_Call {
+	return &MockUserer_Touch_Call{Call: _e.mock.On("Touch", ctx, id)}
+}
+
+func (_c *MockUserer_Touch_Call) Run(run func(ctx context.Context, id string)) *MockUserer_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserer_Touch_Call) Return(err error) *MockUserer_Touch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserer_Touch_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockUserer_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type MockUserer
 func (_mock *MockUserer) Update(ctx context.Context, user *model.User) error {
 	ret := _mock.Called(ctx, user)
@@ -2757,6 +8713,192 @@ func (_c *MockVerificationer_Delete_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// DeleteByUser provides a mock function for the type MockVerificationer
+func (_mock *MockVerificationer) DeleteByUser(ctx context.Context, userID string, email string) error {
+	ret := _mock.Called(ctx, userID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockVerificationer_DeleteByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUser'
+type MockVerificationer_DeleteByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - email string
+func (_e *MockVerificationer_Expecter) DeleteByUser(ctx interface{}, userID interface{}, email interface{}) *MockVerificationer_DeleteByUser_Call {
+	return &MockVerificationer_DeleteByUser_Call{Call: _e.mock.On("DeleteByUser", ctx, userID, email)}
+}
+
+func (_c *MockVerificationer_DeleteByUser_Call) Run(run func(ctx context.Context, userID string, email string)) *MockVerificationer_DeleteByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVerificationer_DeleteByUser_Call) Return(err error) *MockVerificationer_DeleteByUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockVerificationer_DeleteByUser_Call) RunAndReturn(run func(ctx context.Context, userID string, email string) error) *MockVerificationer_DeleteByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByValue provides a mock function for the type MockVerificationer
+func (_mock *MockVerificationer) DeleteByValue(ctx context.Context, context1 string, value string) error {
+	ret := _mock.Called(ctx, context1, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByValue")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, context1, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockVerificationer_DeleteByValue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByValue'
+type MockVerificationer_DeleteByValue_Call struct {
+	*mock.Call
+}
+
+// DeleteByValue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - context1 string
+//   - value string
+func (_e *MockVerificationer_Expecter) DeleteByValue(ctx interface{}, context1 interface{}, value interface{}) *MockVerificationer_DeleteByValue_Call {
+	return &MockVerificationer_DeleteByValue_Call{Call: _e.mock.On("DeleteByValue", ctx, context1, value)}
+}
+
+func (_c *MockVerificationer_DeleteByValue_Call) Run(run func(ctx context.Context, context1 string, value string)) *MockVerificationer_DeleteByValue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVerificationer_DeleteByValue_Call) Return(err error) *MockVerificationer_DeleteByValue_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockVerificationer_DeleteByValue_Call) RunAndReturn(run func(ctx context.Context, context1 string, value string) error) *MockVerificationer_DeleteByValue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpired provides a mock function for the type MockVerificationer
+func (_mock *MockVerificationer) DeleteExpired(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockVerificationer_DeleteExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpired'
+type MockVerificationer_DeleteExpired_Call struct {
+	*mock.Call
+}
+
+// DeleteExpired is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockVerificationer_Expecter) DeleteExpired(ctx interface{}) *MockVerificationer_DeleteExpired_Call {
+	return &MockVerificationer_DeleteExpired_Call{Call: _e.mock.On("DeleteExpired", ctx)}
+}
+
+func (_c *MockVerificationer_DeleteExpired_Call) Run(run func(ctx context.Context)) *MockVerificationer_DeleteExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockVerificationer_DeleteExpired_Call) Return(n int64, err error) *MockVerificationer_DeleteExpired_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockVerificationer_DeleteExpired_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockVerificationer_DeleteExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByValue provides a mock function for the type MockVerificationer
 func (_mock *MockVerificationer) GetByValue(ctx context.Context, context1 string, value string) (*model.Verification, error) {
 	ret := _mock.Called(ctx, context1, value)
@@ -0,0 +1,180 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+	"database/sql"
+)
+
+// PasswordPolicier is the store for password policy operations.
+type PasswordPolicier interface {
+	CreateHistory(ctx context.Context, entry *model.PasswordHistoryEntry) error
+	Get(ctx context.Context, entityID string) (*model.PasswordPolicy, error)
+	ListByUserID(ctx context.Context, userID string) ([]*model.PasswordPolicy, error)
+	ListHistory(ctx context.Context, userID string, limit int) ([]*model.PasswordHistoryEntry, error)
+	PruneHistory(ctx context.Context, userID string, keep int) error
+	Upsert(ctx context.Context, policy *model.PasswordPolicy) (*model.PasswordPolicy, error)
+	WithQuerier(q core.Querier) PasswordPolicier
+}
+
+// PasswordPolicy is the store for password policy operations.
+type PasswordPolicy struct {
+	core.Querier
+}
+
+func (s *PasswordPolicy) WithQuerier(q core.Querier) PasswordPolicier {
+	return &PasswordPolicy{q}
+}
+
+// NewPasswordPolicy creates a new PasswordPolicy.
+func NewPasswordPolicy(db core.Querier) *PasswordPolicy {
+	return &PasswordPolicy{db}
+}
+
+// CreateHistory adds a password hash to the history of a user.
+func (s *PasswordPolicy) CreateHistory(ctx context.Context, entry *model.PasswordHistoryEntry) error {
+	query := `INSERT INTO password_history (user_id, password_hash) VALUES ($1, $2)`
+
+	_, err := s.ExecContext(ctx, query, entry.UserID, entry.PasswordHash)
+	return err
+}
+
+// Get returns the password policy of an entity.
+func (s *PasswordPolicy) Get(ctx context.Context, entityID string) (*model.PasswordPolicy, error) {
+	query := `
+		SELECT
+			entity_id, min_strength_score, history_size, updated_by, created_at, updated_at
+		FROM password_policies
+		WHERE entity_id = $1
+	`
+
+	policy, err := s.scan(s.QueryRowContext(ctx, query, entityID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return policy, err
+}
+
+// ListByUserID lists the password policies of the entities a user is a member of.
+func (s *PasswordPolicy) ListByUserID(ctx context.Context, userID string) ([]*model.PasswordPolicy, error) {
+	query := `
+		SELECT
+			p.entity_id, p.min_strength_score, p.history_size, p.updated_by, p.created_at, p.updated_at
+		FROM password_policies p
+		JOIN memberships m ON m.entity_id = p.entity_id
+		WHERE m.user_id = $1
+	`
+
+	rows, err := s.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []*model.PasswordPolicy
+	for rows.Next() {
+		policy, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, rows.Err()
+}
+
+// ListHistory lists the latest password hashes of a user, newest first.
+func (s *PasswordPolicy) ListHistory(ctx context.Context, userID string, limit int) ([]*model.PasswordHistoryEntry, error) {
+	query := `
+		SELECT
+			id, user_id, password_hash, created_at
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := s.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*model.PasswordHistoryEntry
+	for rows.Next() {
+		var entry model.PasswordHistoryEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.PasswordHash,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// PruneHistory removes all but the latest keep password hashes of a user.
+func (s *PasswordPolicy) PruneHistory(ctx context.Context, userID string, keep int) error {
+	query := `
+		DELETE FROM password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC
+			LIMIT $2
+		)
+	`
+
+	_, err := s.ExecContext(ctx, query, userID, keep)
+	return err
+}
+
+// Upsert creates or replaces the password policy of an entity.
+func (s *PasswordPolicy) Upsert(ctx context.Context, policy *model.PasswordPolicy) (*model.PasswordPolicy, error) {
+	query := `
+		INSERT INTO password_policies (
+			entity_id, min_strength_score, history_size, updated_by
+		) VALUES (
+			$1, $2, $3, $4
+		)
+		ON CONFLICT (entity_id) DO UPDATE SET
+			min_strength_score = EXCLUDED.min_strength_score,
+			history_size = EXCLUDED.history_size,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING
+			entity_id, min_strength_score, history_size, updated_by, created_at, updated_at
+	`
+
+	return s.scan(s.QueryRowContext(
+		ctx,
+		query,
+		policy.EntityID,
+		policy.MinStrengthScore,
+		policy.HistorySize,
+		policy.UpdatedBy,
+	))
+}
+
+// scan scans a password policy row.
+func (s *PasswordPolicy) scan(row interface{ Scan(dest ...any) error }) (*model.PasswordPolicy, error) {
+	var policy model.PasswordPolicy
+	if err := row.Scan(
+		&policy.EntityID,
+		&policy.MinStrengthScore,
+		&policy.HistorySize,
+		&policy.UpdatedBy,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
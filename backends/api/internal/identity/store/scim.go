@@ -0,0 +1,271 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+	"database/sql"
+)
+
+// SCIMer is the store for SCIM provisioning operations.
+type SCIMer interface {
+	CountUsers(ctx context.Context, entityID string, filter model.SCIMUserFilter) (int, error)
+	CreateToken(ctx context.Context, token *model.SCIMToken) (*model.SCIMToken, error)
+	DeleteToken(ctx context.Context, entityID, id string) error
+	GetTokenByHash(ctx context.Context, tokenHash string) (*model.SCIMToken, error)
+	GetTokenByID(ctx context.Context, entityID, id string) (*model.SCIMToken, error)
+	GetUser(ctx context.Context, entityID, userID string) (*model.SCIMUser, error)
+	LinkUser(ctx context.Context, entityID, userID string, externalID *string, created bool) error
+	ListTokens(ctx context.Context, entityID string) ([]*model.SCIMToken, error)
+	ListUsers(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset, limit int) ([]*model.SCIMUser, error)
+	TouchToken(ctx context.Context, id string) error
+	UnlinkUser(ctx context.Context, entityID, userID string) error
+	WithQuerier(q core.Querier) SCIMer
+}
+
+// SCIM is the store for SCIM provisioning operations.
+type SCIM struct {
+	core.Querier
+}
+
+func (s *SCIM) WithQuerier(q core.Querier) SCIMer {
+	return &SCIM{q}
+}
+
+// NewSCIM creates a new SCIM store.
+func NewSCIM(db core.Querier) *SCIM {
+	return &SCIM{db}
+}
+
+// CreateToken creates a new SCIM token.
+func (s *SCIM) CreateToken(ctx context.Context, token *model.SCIMToken) (*model.SCIMToken, error) {
+	query := `
+		INSERT INTO scim_tokens (
+			entity_id, name, token_hash, created_by
+		) VALUES (
+			$1, $2, $3, $4
+		)
+		RETURNING
+			id, entity_id, name, token_hash, created_by, last_used_at, created_at
+	`
+
+	return s.scanToken(s.QueryRowContext(
+		ctx,
+		query,
+		token.EntityID,
+		token.Name,
+		token.TokenHash,
+		token.CreatedBy,
+	))
+}
+
+// DeleteToken deletes a SCIM token of an entity.
+func (s *SCIM) DeleteToken(ctx context.Context, entityID, id string) error {
+	query := `DELETE FROM scim_tokens WHERE id = $1 AND entity_id = $2`
+
+	_, err := s.ExecContext(ctx, query, id, entityID)
+	return err
+}
+
+// GetTokenByHash retrieves a SCIM token by the hash of the token.
+func (s *SCIM) GetTokenByHash(ctx context.Context, tokenHash string) (*model.SCIMToken, error) {
+	query := `
+		SELECT
+			id, entity_id, name, token_hash, created_by, last_used_at, created_at
+		FROM scim_tokens
+		WHERE token_hash = $1
+	`
+
+	return s.scanToken(s.QueryRowContext(ctx, query, tokenHash))
+}
+
+// GetTokenByID retrieves a SCIM token of an entity by ID.
+func (s *SCIM) GetTokenByID(ctx context.Context, entityID, id string) (*model.SCIMToken, error) {
+	query := `
+		SELECT
+			id, entity_id, name, token_hash, created_by, last_used_at, created_at
+		FROM scim_tokens
+		WHERE id = $1 AND entity_id = $2
+	`
+
+	return s.scanToken(s.QueryRowContext(ctx, query, id, entityID))
+}
+
+// ListTokens lists the SCIM tokens of an entity, newest first.
+func (s *SCIM) ListTokens(ctx context.Context, entityID string) ([]*model.SCIMToken, error) {
+	query := `
+		SELECT
+			id, entity_id, name, token_hash, created_by, last_used_at, created_at
+		FROM scim_tokens
+		WHERE entity_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := s.QueryContext(ctx, query, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*model.SCIMToken
+	for rows.Next() {
+		token, err := s.scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// TouchToken records that a SCIM token was just used.
+func (s *SCIM) TouchToken(ctx context.Context, id string) error {
+	query := `UPDATE scim_tokens SET last_used_at = NOW() WHERE id = $1`
+
+	_, err := s.ExecContext(ctx, query, id)
+	return err
+}
+
+// scanToken scans a SCIM token row, returning nil if there is none.
+func (s *SCIM) scanToken(row interface{ Scan(dest ...any) error }) (*model.SCIMToken, error) {
+	var token model.SCIMToken
+	err := row.Scan(
+		&token.ID,
+		&token.EntityID,
+		&token.Name,
+		&token.TokenHash,
+		&token.CreatedBy,
+		&token.LastUsedAt,
+		&token.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// scimUsersQuery selects the users provisioned into or members of the entity
+// given as the first parameter.
+const scimUsersQuery = `
+	FROM users u
+	LEFT JOIN scim_users su ON su.user_id = u.id AND su.entity_id = $1
+	LEFT JOIN memberships m ON m.user_id = u.id AND m.entity_id = $1
+	WHERE (su.id IS NOT NULL OR m.id IS NOT NULL)
+`
+
+// CountUsers counts the users of an entity matching the filter.
+func (s *SCIM) CountUsers(ctx context.Context, entityID string, filter model.SCIMUserFilter) (int, error) {
+	query := `
+		SELECT COUNT(*)` + scimUsersQuery + `
+			AND ($2 = '' OR LOWER(u.email) = LOWER($2))
+			AND ($3 = '' OR su.external_id = $3)
+	`
+
+	var count int
+	err := s.QueryRowContext(ctx, query, entityID, filter.Email, filter.ExternalID).Scan(&count)
+	return count, err
+}
+
+// GetUser retrieves a user of an entity.
+func (s *SCIM) GetUser(ctx context.Context, entityID, userID string) (*model.SCIMUser, error) {
+	query := `
+		SELECT
+			u.id, u.name, u.email, su.external_id, COALESCE(m.role, ''), COALESCE(su.created_user, FALSE),
+			u.created_at, u.updated_at
+		` + scimUsersQuery + `
+			AND u.id = $2
+	`
+
+	user, err := s.scanUser(s.QueryRowContext(ctx, query, entityID, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return user, err
+}
+
+// ListUsers lists the users of an entity matching the filter, oldest first.
+func (s *SCIM) ListUsers(ctx context.Context, entityID string, filter model.SCIMUserFilter, offset, limit int) ([]*model.SCIMUser, error) {
+	query := `
+		SELECT
+			u.id, u.name, u.email, su.external_id, COALESCE(m.role, ''), COALESCE(su.created_user, FALSE),
+			u.created_at, u.updated_at
+		` + scimUsersQuery + `
+			AND ($2 = '' OR LOWER(u.email) = LOWER($2))
+			AND ($3 = '' OR su.external_id = $3)
+		ORDER BY u.created_at, u.id
+		OFFSET $4
+		LIMIT $5
+	`
+
+	rows, err := s.QueryContext(ctx, query, entityID, filter.Email, filter.ExternalID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*model.SCIMUser
+	for rows.Next() {
+		user, err := s.scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// LinkUser records that a user was provisioned into an entity, and whether
+// the user was created for it. The external ID is updated if the user is
+// already linked, the created flag is kept.
+func (s *SCIM) LinkUser(ctx context.Context, entityID, userID string, externalID *string, created bool) error {
+	query := `
+		INSERT INTO scim_users (
+			entity_id, user_id, external_id, created_user
+		) VALUES (
+			$1, $2, $3, $4
+		)
+		ON CONFLICT (entity_id, user_id) DO UPDATE SET
+			external_id = EXCLUDED.external_id,
+			updated_at = NOW()
+	`
+
+	_, err := s.ExecContext(ctx, query, entityID, userID, externalID, created)
+	return err
+}
+
+// UnlinkUser removes the provisioning record of a user in an entity.
+func (s *SCIM) UnlinkUser(ctx context.Context, entityID, userID string) error {
+	query := `DELETE FROM scim_users WHERE entity_id = $1 AND user_id = $2`
+
+	_, err := s.ExecContext(ctx, query, entityID, userID)
+	return err
+}
+
+// scanUser scans a SCIM user row.
+func (s *SCIM) scanUser(row interface{ Scan(dest ...any) error }) (*model.SCIMUser, error) {
+	var user model.SCIMUser
+	if err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.ExternalID,
+		&user.Role,
+		&user.IsCreated,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -12,12 +12,15 @@ import (
 type Sessioner interface {
 	CleanUpExpired(ctx context.Context) error
 	Create(ctx context.Context, session *model.Session) (*model.Session, error)
+	DeleteExcessByUser(ctx context.Context, userID string, keep int) (int64, error)
 	GetByToken(ctx context.Context, token string) (*model.Session, error)
 	ListByUser(ctx context.Context, userID string) ([]*model.Session, error)
 	GetByRefreshToken(ctx context.Context, refreshToken string) (*model.Session, error)
 	InvalidateByToken(ctx context.Context, token string) error
 	InvalidateByUserID(ctx context.Context, userID string, token string) error
+	InvalidateImpersonationsByEntityID(ctx context.Context, entityID, userID string) ([]string, error)
 	InvalidateByID(ctx context.Context, id, userID string) error
+	Touch(ctx context.Context, id string) error
 	UpdateTwoFactorPending(ctx context.Context, token string, isPending bool) error
 	WithQuerier(q core.Querier) Sessioner
 }
@@ -42,15 +45,19 @@ func (s *Session) Create(ctx context.Context, session *model.Session) (*model.Se
 		INSERT INTO sessions (
 			expires_at, ip_address, token, country,
 			refresh_token, refresh_expires_at, user_agent,
-			user_id, is_two_factor_pending
+			user_id, is_two_factor_pending, impersonator_id,
+			impersonator_entity_id, authenticated_at, idle_timeout_seconds
 		) VALUES (
 			$1, $2, $3, $4,
 			$5, $6, $7, $8,
-			$9
+			$9, $10, $11, $12,
+			$13
 		) RETURNING
 			id, expires_at, ip_address, country, token,
 			refresh_token, refresh_expires_at, user_agent,
-			user_id, is_two_factor_pending, created_at, updated_at
+			user_id, is_two_factor_pending, created_at, updated_at,
+			impersonator_id, impersonator_entity_id, last_active_at,
+			authenticated_at, idle_timeout_seconds
 	`
 
 	var created model.Session
@@ -66,6 +73,10 @@ func (s *Session) Create(ctx context.Context, session *model.Session) (*model.Se
 		session.UserAgent,
 		session.UserID,
 		session.IsTwoFactorPending,
+		session.ImpersonatorID,
+		session.ImpersonatorEntityID,
+		session.AuthenticatedAt,
+		session.IdleTimeoutSeconds,
 	).Scan(
 		&created.ID,
 		&created.ExpiresAt,
@@ -79,6 +90,11 @@ func (s *Session) Create(ctx context.Context, session *model.Session) (*model.Se
 		&created.IsTwoFactorPending,
 		&created.CreatedAt,
 		&created.UpdatedAt,
+		&created.ImpersonatorID,
+		&created.ImpersonatorEntityID,
+		&created.LastActiveAt,
+		&created.AuthenticatedAt,
+		&created.IdleTimeoutSeconds,
 	)
 	if err != nil {
 		return nil, err
@@ -87,9 +103,13 @@ func (s *Session) Create(ctx context.Context, session *model.Session) (*model.Se
 	return &created, nil
 }
 
-// CleanUpExpired removes all expired sessions.
+// CleanUpExpired removes all expired and idle sessions.
 func (s *Session) CleanUpExpired(ctx context.Context) error {
-	query := `DELETE FROM sessions WHERE expires_at < NOW()`
+	query := `
+		DELETE FROM sessions
+		WHERE expires_at < NOW()
+			OR last_active_at + idle_timeout_seconds * INTERVAL '1 second' < NOW()
+	`
 
 	_, err := s.ExecContext(ctx, query)
 	if err != nil {
@@ -117,6 +137,11 @@ func (s *Session) GetByRefreshToken(ctx context.Context, refreshToken string) (*
 		&session.IsTwoFactorPending,
 		&session.CreatedAt,
 		&session.UpdatedAt,
+		&session.ImpersonatorID,
+		&session.ImpersonatorEntityID,
+		&session.LastActiveAt,
+		&session.AuthenticatedAt,
+		&session.IdleTimeoutSeconds,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -146,6 +171,11 @@ func (s *Session) GetByToken(ctx context.Context, token string) (*model.Session,
 		&session.IsTwoFactorPending,
 		&session.CreatedAt,
 		&session.UpdatedAt,
+		&session.ImpersonatorID,
+		&session.ImpersonatorEntityID,
+		&session.LastActiveAt,
+		&session.AuthenticatedAt,
+		&session.IdleTimeoutSeconds,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -187,6 +217,11 @@ func (s *Session) ListByUser(ctx context.Context, userID string) ([]*model.Sessi
 			&session.IsTwoFactorPending,
 			&session.CreatedAt,
 			&session.UpdatedAt,
+			&session.ImpersonatorID,
+			&session.ImpersonatorEntityID,
+			&session.LastActiveAt,
+			&session.AuthenticatedAt,
+			&session.IdleTimeoutSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -208,6 +243,35 @@ func (s *Session) InvalidateByUserID(ctx context.Context, userID string, token s
 	return nil
 }
 
+// InvalidateImpersonationsByEntityID invalidates the impersonation sessions
+// started from an entity by or of a user, returning the IDs of the users whose
+// sessions were invalidated.
+func (s *Session) InvalidateImpersonationsByEntityID(ctx context.Context, entityID, userID string) ([]string, error) {
+	query := `
+		DELETE FROM sessions
+		WHERE impersonator_entity_id = $1
+			AND (impersonator_id = $2 OR user_id = $2)
+		RETURNING user_id
+	`
+
+	rows, err := s.QueryContext(ctx, query, entityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	return userIDs, rows.Err()
+}
+
 // InvalidateByToken invalidates a specific session by token.
 func (s *Session) InvalidateByToken(ctx context.Context, token string) error {
 	query := `DELETE FROM sessions WHERE token = $1`
@@ -224,6 +288,39 @@ func (s *Session) InvalidateByID(ctx context.Context, id, userID string) error {
 	return err
 }
 
+// DeleteExcessByUser deletes the signed-in sessions of a user beyond the keep
+// most recently active ones, returning how many were deleted. Sessions pending
+// two-factor authentication and impersonations don't count.
+func (s *Session) DeleteExcessByUser(ctx context.Context, userID string, keep int) (int64, error) {
+	query := `
+		DELETE FROM sessions
+		WHERE id IN (
+			SELECT id FROM sessions
+			WHERE user_id = $1
+				AND is_two_factor_pending = FALSE
+				AND impersonator_id IS NULL
+				AND refresh_expires_at > NOW()
+			ORDER BY last_active_at DESC, created_at DESC
+			OFFSET $2
+		)
+	`
+
+	result, err := s.ExecContext(ctx, query, userID, keep)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Touch records activity on a session.
+func (s *Session) Touch(ctx context.Context, id string) error {
+	query := `UPDATE sessions SET last_active_at = NOW() WHERE id = $1`
+
+	_, err := s.ExecContext(ctx, query, id)
+	return err
+}
+
 // UpdateTwoFactorPending updates the is_two_factor_pending flag for a session
 func (s *Session) UpdateTwoFactorPending(ctx context.Context, token string, isPending bool) error {
 	query := `
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+	"database/sql"
+)
+
+// SessionPolicier is the store for session policy operations.
+type SessionPolicier interface {
+	Get(ctx context.Context, entityID string) (*model.SessionPolicy, error)
+	ListByUserID(ctx context.Context, userID string) ([]*model.SessionPolicy, error)
+	Upsert(ctx context.Context, policy *model.SessionPolicy) (*model.SessionPolicy, error)
+	WithQuerier(q core.Querier) SessionPolicier
+}
+
+// SessionPolicy is the store for session policy operations.
+type SessionPolicy struct {
+	core.Querier
+}
+
+func (s *SessionPolicy) WithQuerier(q core.Querier) SessionPolicier {
+	return &SessionPolicy{q}
+}
+
+// NewSessionPolicy creates a new SessionPolicy.
+func NewSessionPolicy(db core.Querier) *SessionPolicy {
+	return &SessionPolicy{db}
+}
+
+// Get returns the session policy of an entity.
+func (s *SessionPolicy) Get(ctx context.Context, entityID string) (*model.SessionPolicy, error) {
+	query := `
+		SELECT
+			entity_id, idle_timeout_minutes, max_session_minutes, max_concurrent_sessions,
+			updated_by, created_at, updated_at
+		FROM session_policies
+		WHERE entity_id = $1
+	`
+
+	policy, err := s.scan(s.QueryRowContext(ctx, query, entityID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return policy, err
+}
+
+// ListByUserID lists the session policies of the entities a user is a member of.
+func (s *SessionPolicy) ListByUserID(ctx context.Context, userID string) ([]*model.SessionPolicy, error) {
+	query := `
+		SELECT
+			p.entity_id, p.idle_timeout_minutes, p.max_session_minutes, p.max_concurrent_sessions,
+			p.updated_by, p.created_at, p.updated_at
+		FROM session_policies p
+		JOIN memberships m ON m.entity_id = p.entity_id
+		WHERE m.user_id = $1
+	`
+
+	rows, err := s.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []*model.SessionPolicy
+	for rows.Next() {
+		policy, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, rows.Err()
+}
+
+// Upsert creates or replaces the session policy of an entity.
+func (s *SessionPolicy) Upsert(ctx context.Context, policy *model.SessionPolicy) (*model.SessionPolicy, error) {
+	query := `
+		INSERT INTO session_policies (
+			entity_id, idle_timeout_minutes, max_session_minutes, max_concurrent_sessions,
+			updated_by
+		) VALUES (
+			$1, $2, $3, $4,
+			$5
+		)
+		ON CONFLICT (entity_id) DO UPDATE SET
+			idle_timeout_minutes = EXCLUDED.idle_timeout_minutes,
+			max_session_minutes = EXCLUDED.max_session_minutes,
+			max_concurrent_sessions = EXCLUDED.max_concurrent_sessions,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING
+			entity_id, idle_timeout_minutes, max_session_minutes, max_concurrent_sessions,
+			updated_by, created_at, updated_at
+	`
+
+	return s.scan(s.QueryRowContext(
+		ctx,
+		query,
+		policy.EntityID,
+		policy.IdleTimeoutMinutes,
+		policy.MaxSessionMinutes,
+		policy.MaxConcurrentSessions,
+		policy.UpdatedBy,
+	))
+}
+
+// scan scans a session policy row.
+func (s *SessionPolicy) scan(row interface{ Scan(dest ...any) error }) (*model.SessionPolicy, error) {
+	var policy model.SessionPolicy
+	if err := row.Scan(
+		&policy.EntityID,
+		&policy.IdleTimeoutMinutes,
+		&policy.MaxSessionMinutes,
+		&policy.MaxConcurrentSessions,
+		&policy.UpdatedBy,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+)
+
+// SignInHistorier is the store for sign-in history operations.
+type SignInHistorier interface {
+	ListByUserID(ctx context.Context, userID string) ([]*model.SignInHistory, error)
+	Record(ctx context.Context, userID, country, deviceClass string) error
+	WithQuerier(q core.Querier) SignInHistorier
+}
+
+// SignInHistory is the store for sign-in history operations.
+type SignInHistory struct {
+	core.Querier
+}
+
+func (s *SignInHistory) WithQuerier(q core.Querier) SignInHistorier {
+	return &SignInHistory{q}
+}
+
+// NewSignInHistory creates a new SignInHistory.
+func NewSignInHistory(db core.Querier) *SignInHistory {
+	return &SignInHistory{db}
+}
+
+// ListByUserID lists the countries and device classes a user signed in from.
+func (s *SignInHistory) ListByUserID(ctx context.Context, userID string) ([]*model.SignInHistory, error) {
+	query := `
+		SELECT
+			user_id, country, device_class, first_seen_at, last_seen_at
+		FROM sign_in_history
+		WHERE user_id = $1
+	`
+
+	rows, err := s.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []*model.SignInHistory
+	for rows.Next() {
+		var entry model.SignInHistory
+		if err := rows.Scan(
+			&entry.UserID,
+			&entry.Country,
+			&entry.DeviceClass,
+			&entry.FirstSeenAt,
+			&entry.LastSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		history = append(history, &entry)
+	}
+
+	return history, rows.Err()
+}
+
+// Record records a sign-in of a user from a country and device class.
+func (s *SignInHistory) Record(ctx context.Context, userID, country, deviceClass string) error {
+	query := `
+		INSERT INTO sign_in_history (user_id, country, device_class)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, country, device_class) DO UPDATE SET
+			last_seen_at = NOW()
+	`
+
+	_, err := s.ExecContext(ctx, query, userID, country, deviceClass)
+	return err
+}
@@ -0,0 +1,191 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// SSOConnectioner is the store for single sign-on connection operations.
+type SSOConnectioner interface {
+	DeleteByEntityID(ctx context.Context, entityID string) error
+	GetByEntityID(ctx context.Context, entityID string) (*model.SSOConnection, error)
+	GetByID(ctx context.Context, id string) (*model.SSOConnection, error)
+	ListEnforcedByUserID(ctx context.Context, userID string) ([]*model.SSOConnection, error)
+	Upsert(ctx context.Context, connection *model.SSOConnection) (*model.SSOConnection, error)
+	WithQuerier(q core.Querier) SSOConnectioner
+}
+
+// SSOConnection is the store for single sign-on connection operations.
+type SSOConnection struct {
+	core.Querier
+}
+
+func (s *SSOConnection) WithQuerier(q core.Querier) SSOConnectioner {
+	return &SSOConnection{q}
+}
+
+// NewSSOConnection creates a new SSOConnection store.
+func NewSSOConnection(db core.Querier) *SSOConnection {
+	return &SSOConnection{db}
+}
+
+// DeleteByEntityID deletes the connection of an entity.
+func (s *SSOConnection) DeleteByEntityID(ctx context.Context, entityID string) error {
+	query := `DELETE FROM sso_connections WHERE entity_id = $1`
+
+	_, err := s.ExecContext(ctx, query, entityID)
+	return err
+}
+
+// GetByEntityID retrieves the connection of an entity.
+func (s *SSOConnection) GetByEntityID(ctx context.Context, entityID string) (*model.SSOConnection, error) {
+	query := `
+		SELECT
+			id,
+			entity_id,
+			issuer,
+			client_id,
+			client_secret,
+			allowed_domains,
+			default_role,
+			is_enforced,
+			created_at,
+			updated_at
+		FROM sso_connections
+		WHERE entity_id = $1
+	`
+
+	return s.scan(s.QueryRowContext(ctx, query, entityID))
+}
+
+// GetByID retrieves a connection by ID.
+func (s *SSOConnection) GetByID(ctx context.Context, id string) (*model.SSOConnection, error) {
+	query := `
+		SELECT
+			id,
+			entity_id,
+			issuer,
+			client_id,
+			client_secret,
+			allowed_domains,
+			default_role,
+			is_enforced,
+			created_at,
+			updated_at
+		FROM sso_connections
+		WHERE id = $1
+	`
+
+	return s.scan(s.QueryRowContext(ctx, query, id))
+}
+
+// ListEnforcedByUserID lists the enforced connections of the entities the user is a member of.
+func (s *SSOConnection) ListEnforcedByUserID(ctx context.Context, userID string) ([]*model.SSOConnection, error) {
+	query := `
+		SELECT
+			c.id,
+			c.entity_id,
+			c.issuer,
+			c.client_id,
+			c.client_secret,
+			c.allowed_domains,
+			c.default_role,
+			c.is_enforced,
+			c.created_at,
+			c.updated_at
+		FROM sso_connections c
+		JOIN memberships m ON m.entity_id = c.entity_id
+		WHERE m.user_id = $1 AND c.is_enforced
+	`
+
+	rows, err := s.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var connections []*model.SSOConnection
+	for rows.Next() {
+		connection, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, connection)
+	}
+
+	return connections, rows.Err()
+}
+
+// Upsert creates the connection of an entity or replaces the existing one.
+func (s *SSOConnection) Upsert(ctx context.Context, connection *model.SSOConnection) (*model.SSOConnection, error) {
+	query := `
+		INSERT INTO sso_connections (
+			entity_id, issuer, client_id, client_secret,
+			allowed_domains, default_role, is_enforced
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		)
+		ON CONFLICT (entity_id) DO UPDATE SET
+			issuer = EXCLUDED.issuer,
+			client_id = EXCLUDED.client_id,
+			client_secret = EXCLUDED.client_secret,
+			allowed_domains = EXCLUDED.allowed_domains,
+			default_role = EXCLUDED.default_role,
+			is_enforced = EXCLUDED.is_enforced,
+			updated_at = NOW()
+		RETURNING
+			id, entity_id, issuer, client_id, client_secret,
+			allowed_domains, default_role, is_enforced, created_at, updated_at
+	`
+
+	return s.scan(s.QueryRowContext(
+		ctx,
+		query,
+		connection.EntityID,
+		connection.Issuer,
+		connection.ClientID,
+		connection.ClientSecret,
+		connection.AllowedDomains,
+		connection.DefaultRole,
+		connection.IsEnforced,
+	))
+}
+
+// scan scans a connection row, returning nil if there is none.
+func (s *SSOConnection) scan(row interface{ Scan(dest ...any) error }) (*model.SSOConnection, error) {
+	var (
+		allowedDomainsJSON []byte // temporary holder for JSONB data
+		connection         model.SSOConnection
+	)
+	err := row.Scan(
+		&connection.ID,
+		&connection.EntityID,
+		&connection.Issuer,
+		&connection.ClientID,
+		&connection.ClientSecret,
+		&allowedDomainsJSON,
+		&connection.DefaultRole,
+		&connection.IsEnforced,
+		&connection.CreatedAt,
+		&connection.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the JSONB data into the AllowedDomains slice
+	if err := json.Unmarshal(allowedDomainsJSON, &connection.AllowedDomains); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal allowed domains: %w", err)
+	}
+
+	return &connection, nil
+}
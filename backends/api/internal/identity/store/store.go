@@ -6,24 +6,44 @@ import (
 
 // Manager is a collection of stores used by the services.
 type Manager struct {
-	AuditLog     AuditLoger
-	Entity       Entityer
-	Membership   Membershiper
-	Session      Sessioner
-	TwoFactor    TwoFactorer
-	User         Userer
-	Verification Verificationer
+	AuditLog       AuditLoger
+	Compliance     Compliancer
+	Entity         Entityer
+	EntityDomain   EntityDomainer
+	FailedSignIn   FailedSignIner
+	IPAllowlist    IPAllowlister
+	Membership     Membershiper
+	PasswordPolicy PasswordPolicier
+	SCIM           SCIMer
+	Session        Sessioner
+	SessionPolicy  SessionPolicier
+	SignInHistory  SignInHistorier
+	SSOConnection  SSOConnectioner
+	TrustedDevice  TrustedDevicer
+	TwoFactor      TwoFactorer
+	User           Userer
+	Verification   Verificationer
 }
 
 // NewManager creates a new Manager.
 func NewManager(q core.Querier) *Manager {
 	return &Manager{
-		AuditLog:     NewAuditLog(q),
-		Entity:       NewEntity(q),
-		Membership:   NewMembership(q),
-		Session:      NewSession(q),
-		TwoFactor:    NewTwoFactor(q),
-		User:         NewUser(q),
-		Verification: NewVerification(q),
+		AuditLog:       NewAuditLog(q),
+		Compliance:     NewCompliance(q),
+		Entity:         NewEntity(q),
+		EntityDomain:   NewEntityDomain(q),
+		FailedSignIn:   NewFailedSignIn(q),
+		IPAllowlist:    NewIPAllowlist(q),
+		Membership:     NewMembership(q),
+		PasswordPolicy: NewPasswordPolicy(q),
+		SCIM:           NewSCIM(q),
+		Session:        NewSession(q),
+		SessionPolicy:  NewSessionPolicy(q),
+		SignInHistory:  NewSignInHistory(q),
+		SSOConnection:  NewSSOConnection(q),
+		TrustedDevice:  NewTrustedDevice(q),
+		TwoFactor:      NewTwoFactor(q),
+		User:           NewUser(q),
+		Verification:   NewVerification(q),
 	}
 }
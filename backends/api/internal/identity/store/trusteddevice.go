@@ -0,0 +1,165 @@
+package store
+
+import (
+	"autopilot/backends/api/internal/identity/model"
+	"autopilot/backends/internal/core"
+	"context"
+	"database/sql"
+)
+
+// TrustedDevicer is the store for trusted device operations.
+type TrustedDevicer interface {
+	Create(ctx context.Context, device *model.TrustedDevice) (*model.TrustedDevice, error)
+	Delete(ctx context.Context, id, userID string) (bool, error)
+	DeleteByUserID(ctx context.Context, userID string) error
+	DeleteExpired(ctx context.Context) error
+	Get(ctx context.Context, id string) (*model.TrustedDevice, error)
+	ListByUserID(ctx context.Context, userID string) ([]*model.TrustedDevice, error)
+	Touch(ctx context.Context, id string) error
+	WithQuerier(q core.Querier) TrustedDevicer
+}
+
+// TrustedDevice is the store for trusted device operations.
+type TrustedDevice struct {
+	core.Querier
+}
+
+func (s *TrustedDevice) WithQuerier(q core.Querier) TrustedDevicer {
+	return &TrustedDevice{q}
+}
+
+// NewTrustedDevice creates a new TrustedDevice.
+func NewTrustedDevice(db core.Querier) *TrustedDevice {
+	return &TrustedDevice{db}
+}
+
+// Create trusts a device of a user.
+func (s *TrustedDevice) Create(ctx context.Context, device *model.TrustedDevice) (*model.TrustedDevice, error) {
+	query := `
+		INSERT INTO trusted_devices (
+			user_id, device_class, ip_address, country,
+			user_agent, expires_at
+		) VALUES (
+			$1, $2, $3, $4,
+			$5, $6
+		)
+		RETURNING
+			id, user_id, device_class, ip_address, country,
+			user_agent, expires_at, last_used_at, created_at
+	`
+
+	return s.scan(s.QueryRowContext(
+		ctx,
+		query,
+		device.UserID,
+		device.DeviceClass,
+		device.IPAddress,
+		device.Country,
+		device.UserAgent,
+		device.ExpiresAt,
+	))
+}
+
+// Delete revokes a trusted device of a user, returning whether it existed.
+func (s *TrustedDevice) Delete(ctx context.Context, id, userID string) (bool, error) {
+	query := `DELETE FROM trusted_devices WHERE id = $1 AND user_id = $2`
+
+	result, err := s.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// DeleteByUserID revokes all trusted devices of a user.
+func (s *TrustedDevice) DeleteByUserID(ctx context.Context, userID string) error {
+	query := `DELETE FROM trusted_devices WHERE user_id = $1`
+
+	_, err := s.ExecContext(ctx, query, userID)
+	return err
+}
+
+// DeleteExpired removes the trusted devices whose trust has expired.
+func (s *TrustedDevice) DeleteExpired(ctx context.Context) error {
+	query := `DELETE FROM trusted_devices WHERE expires_at < NOW()`
+
+	_, err := s.ExecContext(ctx, query)
+	return err
+}
+
+// Get gets a trusted device by ID.
+func (s *TrustedDevice) Get(ctx context.Context, id string) (*model.TrustedDevice, error) {
+	query := `
+		SELECT
+			id, user_id, device_class, ip_address, country,
+			user_agent, expires_at, last_used_at, created_at
+		FROM trusted_devices
+		WHERE id = $1
+	`
+
+	device, err := s.scan(s.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return device, err
+}
+
+// ListByUserID lists the unexpired trusted devices of a user, most recently
+// trusted first.
+func (s *TrustedDevice) ListByUserID(ctx context.Context, userID string) ([]*model.TrustedDevice, error) {
+	query := `
+		SELECT
+			id, user_id, device_class, ip_address, country,
+			user_agent, expires_at, last_used_at, created_at
+		FROM trusted_devices
+		WHERE user_id = $1 AND expires_at > NOW()
+		ORDER BY id DESC
+	`
+
+	rows, err := s.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []*model.TrustedDevice
+	for rows.Next() {
+		device, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+// Touch records that a trusted device was used to skip two-factor authentication.
+func (s *TrustedDevice) Touch(ctx context.Context, id string) error {
+	query := `UPDATE trusted_devices SET last_used_at = NOW() WHERE id = $1`
+
+	_, err := s.ExecContext(ctx, query, id)
+	return err
+}
+
+func (s *TrustedDevice) scan(row interface{ Scan(dest ...any) error }) (*model.TrustedDevice, error) {
+	var device model.TrustedDevice
+	if err := row.Scan(
+		&device.ID,
+		&device.UserID,
+		&device.DeviceClass,
+		&device.IPAddress,
+		&device.Country,
+		&device.UserAgent,
+		&device.ExpiresAt,
+		&device.LastUsedAt,
+		&device.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &device, nil
+}
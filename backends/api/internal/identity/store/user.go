@@ -5,18 +5,22 @@ import (
 	"autopilot/backends/internal/core"
 	"context"
 	"database/sql"
+	"time"
 )
 
 // Userer is the store for user operations.
 type Userer interface {
 	Create(ctx context.Context, user *model.User) (*model.User, error)
 	CreateVerification(ctx context.Context, verification *model.Verification) (*model.Verification, error)
+	Delete(ctx context.Context, id string) error
 	DeleteVerification(ctx context.Context, id string) error
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	GetByID(ctx context.Context, id string) (*model.User, error)
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
 	GetVerification(ctx context.Context, context string, id string) (*model.Verification, error)
 	GetVerificationByValue(ctx context.Context, context string, value string) (*model.Verification, error)
+	ListDueForDeletion(ctx context.Context, before time.Time) ([]*model.User, error)
+	Touch(ctx context.Context, id string) error
 	Update(ctx context.Context, user *model.User) error
 	WithQuerier(q core.Querier) Userer
 }
@@ -48,8 +52,8 @@ func (s *User) Create(ctx context.Context, user *model.User) (*model.User, error
 			$9, $10
 		) RETURNING
 			id, name, email, email_verified_at, failed_login_attempts,
-			image, last_active_at, last_logged_in_at, locked_at,
-			password_changed_at, password_hash, created_at, updated_at
+			image, last_active_at, last_logged_in_at, locked_at, locked_until, lockout_count,
+			password_changed_at, password_hash, deletion_scheduled_at, created_at, updated_at
 	`
 
 	var created model.User
@@ -76,8 +80,11 @@ func (s *User) Create(ctx context.Context, user *model.User) (*model.User, error
 		&created.LastActiveAt,
 		&created.LastLoggedInAt,
 		&created.LockedAt,
+		&created.LockedUntil,
+		&created.LockoutCount,
 		&created.PasswordChangedAt,
 		&created.PasswordHash,
+		&created.DeletionScheduledAt,
 		&created.CreatedAt,
 		&created.UpdatedAt,
 	)
@@ -121,6 +128,14 @@ func (s *User) CreateVerification(ctx context.Context, verification *model.Verif
 	return &created, nil
 }
 
+// Delete deletes a user.
+func (s *User) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM users WHERE id = $1`
+
+	_, err := s.ExecContext(ctx, query, id)
+	return err
+}
+
 // DeleteVerification deletes a verification.
 func (s *User) DeleteVerification(ctx context.Context, id string) error {
 	query := `DELETE FROM verifications WHERE id = $1`
@@ -158,7 +173,14 @@ func (s *User) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 // GetByID gets a user by ID.
 func (s *User) GetByID(ctx context.Context, id string) (*model.User, error) {
 	var user model.User
-	query := `SELECT * FROM users WHERE id = $1`
+	query := `SELECT
+			id, name, email, email_verified_at, failed_login_attempts,
+			image, last_active_at, last_logged_in_at, locked_at, locked_until, lockout_count,
+			password_changed_at, password_hash, deletion_scheduled_at, created_at, updated_at
+		FROM
+			users
+		WHERE
+			id = $1`
 
 	err := s.QueryRowContext(ctx, query, id).Scan(
 		&user.ID,
@@ -170,8 +192,11 @@ func (s *User) GetByID(ctx context.Context, id string) (*model.User, error) {
 		&user.LastActiveAt,
 		&user.LastLoggedInAt,
 		&user.LockedAt,
+		&user.LockedUntil,
+		&user.LockoutCount,
 		&user.PasswordChangedAt,
 		&user.PasswordHash,
+		&user.DeletionScheduledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -191,8 +216,8 @@ func (s *User) GetByEmail(ctx context.Context, email string) (*model.User, error
 	var user model.User
 	query := `SELECT
 			id, name, email, email_verified_at, failed_login_attempts,
-			image, last_active_at, last_logged_in_at, locked_at,
-			password_changed_at, password_hash, created_at, updated_at
+			image, last_active_at, last_logged_in_at, locked_at, locked_until, lockout_count,
+			password_changed_at, password_hash, deletion_scheduled_at, created_at, updated_at
 		FROM
 			users
 		WHERE
@@ -208,8 +233,11 @@ func (s *User) GetByEmail(ctx context.Context, email string) (*model.User, error
 		&user.LastActiveAt,
 		&user.LastLoggedInAt,
 		&user.LockedAt,
+		&user.LockedUntil,
+		&user.LockoutCount,
 		&user.PasswordChangedAt,
 		&user.PasswordHash,
+		&user.DeletionScheduledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -224,6 +252,60 @@ func (s *User) GetByEmail(ctx context.Context, email string) (*model.User, error
 	return &user, nil
 }
 
+// ListDueForDeletion lists the users whose scheduled deletion is before the given time.
+func (s *User) ListDueForDeletion(ctx context.Context, before time.Time) ([]*model.User, error) {
+	query := `SELECT
+			id, name, email, email_verified_at, failed_login_attempts,
+			image, last_active_at, last_logged_in_at, locked_at, locked_until, lockout_count,
+			password_changed_at, password_hash, deletion_scheduled_at, created_at, updated_at
+		FROM
+			users
+		WHERE
+			deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at < $1`
+
+	rows, err := s.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*model.User
+	for rows.Next() {
+		var user model.User
+		if err := rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.EmailVerifiedAt,
+			&user.FailedLoginAttempts,
+			&user.Image,
+			&user.LastActiveAt,
+			&user.LastLoggedInAt,
+			&user.LockedAt,
+			&user.LockedUntil,
+			&user.LockoutCount,
+			&user.PasswordChangedAt,
+			&user.PasswordHash,
+			&user.DeletionScheduledAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+// Touch records activity of a user.
+func (s *User) Touch(ctx context.Context, id string) error {
+	query := `UPDATE users SET last_active_at = NOW() WHERE id = $1`
+
+	_, err := s.ExecContext(ctx, query, id)
+	return err
+}
+
 // Update updates a user.
 func (s *User) Update(ctx context.Context, user *model.User) error {
 	query := `
@@ -236,10 +318,13 @@ func (s *User) Update(ctx context.Context, user *model.User) error {
 			last_active_at = $6,
 			last_logged_in_at = $7,
 			locked_at = $8,
-			password_changed_at = $9,
-			password_hash = $10,
-			updated_at = $11
-		WHERE id = $12
+			locked_until = $9,
+			lockout_count = $10,
+			password_changed_at = $11,
+			password_hash = $12,
+			deletion_scheduled_at = $13,
+			updated_at = $14
+		WHERE id = $15
 	`
 
 	result, err := s.ExecContext(
@@ -253,8 +338,11 @@ func (s *User) Update(ctx context.Context, user *model.User) error {
 		user.LastActiveAt,
 		user.LastLoggedInAt,
 		user.LockedAt,
+		user.LockedUntil,
+		user.LockoutCount,
 		user.PasswordChangedAt,
 		user.PasswordHash,
+		user.DeletionScheduledAt,
 		user.UpdatedAt,
 		user.ID,
 	)
@@ -310,7 +398,10 @@ func (s *User) GetVerificationByValue(ctx context.Context, context string, value
 		FROM
 			verifications
 		WHERE
-			context = $1 AND value = $2`
+			context = $1 AND value = $2
+		ORDER BY
+			created_at DESC
+		LIMIT 1`
 
 	var verification model.Verification
 	err := s.QueryRowContext(ctx, query, context, value).Scan(
@@ -10,6 +10,9 @@ import (
 // Verificationer is the store for verification operations.
 type Verificationer interface {
 	Delete(ctx context.Context, id string) error
+	DeleteByUser(ctx context.Context, userID, email string) error
+	DeleteByValue(ctx context.Context, context string, value string) error
+	DeleteExpired(ctx context.Context) (int64, error)
 	GetByValue(ctx context.Context, context string, value string) (*model.Verification, error)
 	WithQuerier(q core.Querier) Verificationer
 }
@@ -59,3 +62,35 @@ func (s *Verification) Delete(ctx context.Context, id string) error {
 	_, err := s.ExecContext(ctx, query, id)
 	return err
 }
+
+// DeleteByValue deletes all verifications of a context for a value.
+func (s *Verification) DeleteByValue(ctx context.Context, context string, value string) error {
+	query := `DELETE FROM verifications WHERE context = $1 AND value = $2`
+
+	_, err := s.ExecContext(ctx, query, context, value)
+	return err
+}
+
+// DeleteByUser deletes all verifications of a user, whose values are either
+// the user ID, the email or scoped to the user ID.
+func (s *Verification) DeleteByUser(ctx context.Context, userID, email string) error {
+	query := `
+		DELETE FROM verifications
+		WHERE context <> $3 AND (value IN ($1, $2) OR value LIKE $1 || ':%')
+	`
+
+	_, err := s.ExecContext(ctx, query, userID, email, model.VerificationContextSSOState)
+	return err
+}
+
+// DeleteExpired deletes all expired verifications, returning how many were deleted.
+func (s *Verification) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `DELETE FROM verifications WHERE expires_at < NOW()`
+
+	result, err := s.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -25,8 +25,8 @@ func BasePath(path string) string {
 }
 
 // AddRoutes adds the v1 API docs/routes to the http server
-func AddRoutes(container *app.Container, humaAPI huma.API, service *service.Manager, auth httpx.Authenticator) error {
-	api := httpx.InitHandler(humaAPI, container.Mode, auth)
+func AddRoutes(container *app.Container, humaAPI huma.API, service *service.Manager, auth httpx.Authenticator, rateLimiter httpx.RateLimiter) error {
+	api := httpx.InitHandler(humaAPI, container.Mode, auth, rateLimiter)
 	api.AddTags(&TagPayment)
 
 	v1 := &V1{
@@ -40,7 +40,7 @@ func AddRoutes(container *app.Container, humaAPI huma.API, service *service.Mana
 		Path:        BasePath("/payments"),
 		Summary:     "Create payment",
 		Tags:        []string{TagPayment.Name},
-	}, v1.CreatePayment, api.WithUnauthenticated())
+	}, v1.CreatePayment, api.WithUnauthenticated(), httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPayments))
 
 	return nil
 }
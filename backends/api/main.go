@@ -149,6 +149,13 @@ func initHTTPServer(container *app.Container, mods *internal.Module) (*core.HTTP
 			Country: "SG",
 		}
 	}
+
+	clientIPResolver, err := apimdw.NewClientIPResolver(container.Config.App.Server.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitConfig := apimdw.DefaultRateLimitConfig()
 	httpServer, err := core.NewHTTPServer(core.HTTPServerOptions{
 		Host:       container.Config.App.Server.Host,
 		Port:       container.Config.App.Server.Port,
@@ -157,6 +164,8 @@ func initHTTPServer(container *app.Container, mods *internal.Module) (*core.HTTP
 		Mailer:     container.Mailer,
 		Mode:       container.Mode,
 		Middlewares: []func(http.Handler) http.Handler{
+			apimdw.WithClientIP(clientIPResolver),
+			apimdw.WithRateLimit(container, rateLimitConfig),
 			middleware.WithDebug(container.Mode),
 			middleware.Logger(container.Mode, container.Logger),
 			middleware.WithOperationMode([]string{
@@ -188,7 +197,6 @@ func initHTTPServer(container *app.Container, mods *internal.Module) (*core.HTTP
 				MaxAge:           30,
 			}),
 			apimdw.WithInjectCountry(injectCfg),
-			apimdw.WithRateLimit(container, apimdw.DefaultRateLimitConfig()),
 			apimdw.WithRequestMetadata(),
 			apimdw.WithContainer(container),
 			apimdw.WithActiveEntity(),
@@ -226,13 +234,14 @@ func initHTTPServer(container *app.Container, mods *internal.Module) (*core.HTTP
 
 	apiV1 := initAPIV1(container, httpServer)
 	authenticator := identity.NewAuthentication(container, apiV1, mods.Identity.Service)
+	rateLimiter := apimdw.NewRateLimiter(container, apiV1, rateLimitConfig)
 
-	err = identityhandlerv1.AddRoutes(container, apiV1, mods.Identity.Service, authenticator)
+	err = identityhandlerv1.AddRoutes(container, apiV1, mods.Identity.Service, authenticator, rateLimiter)
 	if err != nil {
 		return nil, err
 	}
 
-	err = paymenthandlerv1.AddRoutes(container, apiV1, mods.Payment.Service, authenticator)
+	err = paymenthandlerv1.AddRoutes(container, apiV1, mods.Payment.Service, authenticator, rateLimiter)
 	if err != nil {
 		return nil, err
 	}
@@ -251,6 +260,11 @@ func initAPIV1(container *app.Container, httpServer *core.HTTPServer) huma.API {
 			Name:        "X-Api-Key",
 			Description: "API key authentication using X-Api-Key header",
 		},
+		"SCIM Bearer Token": {
+			Type:        "http",
+			Scheme:      "bearer",
+			Description: "Entity-scoped SCIM token using the Authorization header",
+		},
 	}
 	apiConfig.DocsPath = ""
 	apiConfig.OpenAPIPath = identityhandlerv1.BasePath("/openapi")
@@ -283,7 +297,7 @@ func initAPIV1(container *app.Container, httpServer *core.HTTPServer) huma.API {
 	return api
 }
 
-func addCommands(ctx context.Context, rootCmd *cobra.Command, container *app.Container, httpServer *core.HTTPServer, _ *internal.Module) {
+func addCommands(ctx context.Context, rootCmd *cobra.Command, container *app.Container, httpServer *core.HTTPServer, mods *internal.Module) {
 	databases := []core.DBer{
 		container.DB.Identity,
 		container.DB.Payment.Live,
@@ -300,6 +314,7 @@ func addCommands(ctx context.Context, rootCmd *cobra.Command, container *app.Con
 			log.Fatalf("Failed to close application: %v", errs)
 		}
 	}))
+	rootCmd.AddCommand(identity.NewAuditVerifyCmd(ctx, container.Logger, mods.Identity.Service.AuditLog))
 }
 
 func addDebugCommands(ctx context.Context, rootCmd *cobra.Command, container *app.Container, httpServer *core.HTTPServer) {
@@ -0,0 +1,36 @@
+-- migrate:up
+ALTER TABLE "users" ADD COLUMN "deletion_scheduled_at" TIMESTAMPTZ;
+CREATE INDEX "idx_users_deletion_scheduled_at" ON "users"("deletion_scheduled_at") WHERE "deletion_scheduled_at" IS NOT NULL;
+
+-- Audit logs outlive the user they belong to. Personal data is anonymized
+-- before the user is deleted and the reference is cleared on delete.
+ALTER TABLE "audit_logs" ALTER COLUMN "user_id" DROP NOT NULL;
+ALTER TABLE "audit_logs" DROP CONSTRAINT "audit_logs_user_id_fkey";
+ALTER TABLE "audit_logs" ADD CONSTRAINT "audit_logs_user_id_fkey"
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "passkeys" DROP CONSTRAINT "passkeys_user_id_fkey";
+ALTER TABLE "passkeys" ADD CONSTRAINT "passkeys_user_id_fkey"
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "compliance_records" DROP CONSTRAINT "compliance_records_verified_by_fkey";
+ALTER TABLE "compliance_records" ADD CONSTRAINT "compliance_records_verified_by_fkey"
+    FOREIGN KEY ("verified_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+-- migrate:down
+ALTER TABLE "compliance_records" DROP CONSTRAINT "compliance_records_verified_by_fkey";
+ALTER TABLE "compliance_records" ADD CONSTRAINT "compliance_records_verified_by_fkey"
+    FOREIGN KEY ("verified_by") REFERENCES "users" ("id");
+
+ALTER TABLE "passkeys" DROP CONSTRAINT "passkeys_user_id_fkey";
+ALTER TABLE "passkeys" ADD CONSTRAINT "passkeys_user_id_fkey"
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "audit_logs" DROP CONSTRAINT "audit_logs_user_id_fkey";
+DELETE FROM "audit_logs" WHERE "user_id" IS NULL;
+ALTER TABLE "audit_logs" ALTER COLUMN "user_id" SET NOT NULL;
+ALTER TABLE "audit_logs" ADD CONSTRAINT "audit_logs_user_id_fkey"
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+DROP INDEX "idx_users_deletion_scheduled_at";
+ALTER TABLE "users" DROP COLUMN "deletion_scheduled_at";
@@ -0,0 +1,19 @@
+-- migrate:up
+CREATE TABLE "sso_connections" (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT uuid7(),
+    "entity_id" UUID NOT NULL UNIQUE REFERENCES "entities" ("id") ON DELETE CASCADE,
+    "issuer" TEXT NOT NULL,
+    "client_id" TEXT NOT NULL,
+    "client_secret" TEXT NOT NULL,
+    "allowed_domains" JSONB NOT NULL DEFAULT '[]',
+    "default_role" TEXT NOT NULL DEFAULT 'viewer',
+    "is_enforced" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE "sso_connections" IS 'Manage OpenID Connect single sign-on connections of entities.';
+COMMENT ON COLUMN "sso_connections"."client_secret" IS 'Encrypted with the identity encryption key.';
+
+-- migrate:down
+DROP TABLE "sso_connections";
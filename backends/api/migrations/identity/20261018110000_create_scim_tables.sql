@@ -0,0 +1,31 @@
+-- migrate:up
+CREATE TABLE "scim_tokens" (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT uuid7(),
+    "entity_id" UUID NOT NULL REFERENCES "entities" ("id") ON DELETE CASCADE,
+    "name" TEXT NOT NULL,
+    "token_hash" TEXT NOT NULL UNIQUE,
+    "created_by" UUID REFERENCES "users" ("id") ON DELETE SET NULL,
+    "last_used_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_scim_tokens_entity_id ON scim_tokens(entity_id);
+
+COMMENT ON TABLE "scim_tokens" IS 'Manage bearer tokens of entity SCIM provisioning clients.';
+COMMENT ON COLUMN "scim_tokens"."token_hash" IS 'SHA-256 hash of the token, the token itself is only shown once.';
+
+CREATE TABLE "scim_users" (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT uuid7(),
+    "entity_id" UUID NOT NULL REFERENCES "entities" ("id") ON DELETE CASCADE,
+    "user_id" UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "external_id" TEXT,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE ("entity_id", "user_id")
+);
+
+COMMENT ON TABLE "scim_users" IS 'Track users provisioned into entities by SCIM clients.';
+
+-- migrate:down
+DROP TABLE "scim_users";
+DROP TABLE "scim_tokens";
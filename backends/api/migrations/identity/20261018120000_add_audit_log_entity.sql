@@ -0,0 +1,11 @@
+-- migrate:up
+ALTER TABLE "audit_logs" ADD COLUMN "entity_id" UUID REFERENCES "entities" ("id") ON DELETE CASCADE;
+
+-- Entity audit logs are paginated by ID, which is time ordered
+CREATE INDEX "idx_audit_logs_entity_id" ON "audit_logs"("entity_id", "id") WHERE "entity_id" IS NOT NULL;
+
+COMMENT ON COLUMN "audit_logs"."entity_id" IS 'The entity the action was taken in, if any.';
+
+-- migrate:down
+DROP INDEX "idx_audit_logs_entity_id";
+ALTER TABLE "audit_logs" DROP COLUMN "entity_id";
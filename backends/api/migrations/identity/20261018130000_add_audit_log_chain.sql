@@ -0,0 +1,90 @@
+-- migrate:up
+-- Each entity has its own hash chain of audit log entries, entries taken
+-- outside of an entity are chained under the nil UUID. The head of every chain
+-- is kept here, so appending an entry is a single statement that serializes
+-- concurrent writers on the chain row.
+CREATE TABLE "audit_log_chains" (
+    "chain_id" UUID NOT NULL PRIMARY KEY,
+    "sequence" BIGINT NOT NULL,
+    "previous_hash" TEXT NOT NULL,
+    "hash" TEXT NOT NULL,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE "audit_log_chains" IS 'Track the head of each audit log hash chain.';
+
+-- Entries written before chaining was introduced have no sequence and are not verified
+ALTER TABLE "audit_logs"
+    ADD COLUMN "sequence" BIGINT,
+    ADD COLUMN "previous_hash" TEXT,
+    ADD COLUMN "personal_hash" TEXT,
+    ADD COLUMN "hash" TEXT,
+    ADD COLUMN "anonymized_at" TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX "idx_audit_logs_chain_sequence" ON "audit_logs"(
+    COALESCE("entity_id", '00000000-0000-0000-0000-000000000000'), "sequence"
+) WHERE "sequence" IS NOT NULL;
+
+COMMENT ON COLUMN "audit_logs"."previous_hash" IS 'The hash of the previous entry in the chain.';
+COMMENT ON COLUMN "audit_logs"."personal_hash" IS 'SHA-256 hash of the personal data, kept to verify the chain once anonymized.';
+COMMENT ON COLUMN "audit_logs"."hash" IS 'SHA-256 hash of the previous hash and the entry contents.';
+
+-- Entities with audit logs can no longer be deleted, as that would cut their chain
+ALTER TABLE "audit_logs" DROP CONSTRAINT "audit_logs_entity_id_fkey";
+ALTER TABLE "audit_logs" ADD CONSTRAINT "audit_logs_entity_id_fkey"
+    FOREIGN KEY ("entity_id") REFERENCES "entities" ("id");
+
+-- Audit logs are append-only. The only change allowed is the removal of
+-- personal data when the entry is anonymized, which the chain tolerates through
+-- the personal hash.
+CREATE FUNCTION "audit_logs_append_only"() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'UPDATE' THEN
+        RAISE EXCEPTION 'audit_logs is append-only';
+    END IF;
+
+    IF (NEW."id", NEW."action", NEW."entity_id", NEW."resource_type", NEW."resource_id", NEW."created_at",
+        NEW."sequence", NEW."previous_hash", NEW."personal_hash", NEW."hash")
+        IS DISTINCT FROM
+       (OLD."id", OLD."action", OLD."entity_id", OLD."resource_type", OLD."resource_id", OLD."created_at",
+        OLD."sequence", OLD."previous_hash", OLD."personal_hash", OLD."hash") THEN
+        RAISE EXCEPTION 'audit_logs entries can''t be changed';
+    END IF;
+
+    IF NEW."anonymized_at" IS NULL AND
+       (NEW."user_id", NEW."ip_address", NEW."user_agent", NEW."metadata")
+        IS DISTINCT FROM
+       (OLD."user_id", OLD."ip_address", OLD."user_agent", OLD."metadata") THEN
+        RAISE EXCEPTION 'audit_logs personal data can only be changed by anonymization';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_logs_append_only"
+    BEFORE UPDATE OR DELETE ON "audit_logs"
+    FOR EACH ROW EXECUTE FUNCTION "audit_logs_append_only"();
+
+CREATE TRIGGER "audit_logs_append_only_truncate"
+    BEFORE TRUNCATE ON "audit_logs"
+    FOR EACH STATEMENT EXECUTE FUNCTION "audit_logs_append_only"();
+
+-- migrate:down
+DROP TRIGGER "audit_logs_append_only_truncate" ON "audit_logs";
+DROP TRIGGER "audit_logs_append_only" ON "audit_logs";
+DROP FUNCTION "audit_logs_append_only"();
+
+ALTER TABLE "audit_logs" DROP CONSTRAINT "audit_logs_entity_id_fkey";
+ALTER TABLE "audit_logs" ADD CONSTRAINT "audit_logs_entity_id_fkey"
+    FOREIGN KEY ("entity_id") REFERENCES "entities" ("id") ON DELETE CASCADE;
+
+DROP INDEX "idx_audit_logs_chain_sequence";
+ALTER TABLE "audit_logs"
+    DROP COLUMN "anonymized_at",
+    DROP COLUMN "hash",
+    DROP COLUMN "personal_hash",
+    DROP COLUMN "previous_hash",
+    DROP COLUMN "sequence";
+
+DROP TABLE "audit_log_chains";
@@ -0,0 +1,71 @@
+-- migrate:up
+-- Entries written before actors were recorded are left without one, as the
+-- chain doesn't allow them to be changed.
+ALTER TABLE "audit_logs"
+    ADD COLUMN "actor_type" TEXT CHECK ("actor_type" IN ('api_key', 'system', 'user')),
+    ADD COLUMN "actor_id" UUID,
+    ADD COLUMN "changes" JSONB;
+
+COMMENT ON COLUMN "audit_logs"."actor_type" IS 'The kind of actor that took the action: api_key, system or user.';
+COMMENT ON COLUMN "audit_logs"."actor_id" IS 'The API key that took the action, the acting user is in user_id.';
+COMMENT ON COLUMN "audit_logs"."changes" IS 'The before and after values of the fields changed by the action.';
+
+-- The actor is part of the chained contents and the changes of the personal data
+CREATE OR REPLACE FUNCTION "audit_logs_append_only"() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'UPDATE' THEN
+        RAISE EXCEPTION 'audit_logs is append-only';
+    END IF;
+
+    IF (NEW."id", NEW."action", NEW."entity_id", NEW."resource_type", NEW."resource_id", NEW."created_at",
+        NEW."actor_type", NEW."actor_id",
+        NEW."sequence", NEW."previous_hash", NEW."personal_hash", NEW."hash")
+        IS DISTINCT FROM
+       (OLD."id", OLD."action", OLD."entity_id", OLD."resource_type", OLD."resource_id", OLD."created_at",
+        OLD."actor_type", OLD."actor_id",
+        OLD."sequence", OLD."previous_hash", OLD."personal_hash", OLD."hash") THEN
+        RAISE EXCEPTION 'audit_logs entries can''t be changed';
+    END IF;
+
+    IF NEW."anonymized_at" IS NULL AND
+       (NEW."user_id", NEW."ip_address", NEW."user_agent", NEW."metadata", NEW."changes")
+        IS DISTINCT FROM
+       (OLD."user_id", OLD."ip_address", OLD."user_agent", OLD."metadata", OLD."changes") THEN
+        RAISE EXCEPTION 'audit_logs personal data can only be changed by anonymization';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- migrate:down
+CREATE OR REPLACE FUNCTION "audit_logs_append_only"() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'UPDATE' THEN
+        RAISE EXCEPTION 'audit_logs is append-only';
+    END IF;
+
+    IF (NEW."id", NEW."action", NEW."entity_id", NEW."resource_type", NEW."resource_id", NEW."created_at",
+        NEW."sequence", NEW."previous_hash", NEW."personal_hash", NEW."hash")
+        IS DISTINCT FROM
+       (OLD."id", OLD."action", OLD."entity_id", OLD."resource_type", OLD."resource_id", OLD."created_at",
+        OLD."sequence", OLD."previous_hash", OLD."personal_hash", OLD."hash") THEN
+        RAISE EXCEPTION 'audit_logs entries can''t be changed';
+    END IF;
+
+    IF NEW."anonymized_at" IS NULL AND
+       (NEW."user_id", NEW."ip_address", NEW."user_agent", NEW."metadata")
+        IS DISTINCT FROM
+       (OLD."user_id", OLD."ip_address", OLD."user_agent", OLD."metadata") THEN
+        RAISE EXCEPTION 'audit_logs personal data can only be changed by anonymization';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Dropping the columns rewrites no rows, so the trigger doesn't get in the way
+ALTER TABLE "audit_logs"
+    DROP COLUMN "changes",
+    DROP COLUMN "actor_id",
+    DROP COLUMN "actor_type";
@@ -0,0 +1,34 @@
+-- migrate:up
+-- A record is a draft until submitted, the reason explains a rejection
+ALTER TABLE "compliance_records"
+    ADD COLUMN "reason" TEXT,
+    ADD COLUMN "submitted_at" TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX "idx_compliance_records_subject" ON "compliance_records"("entity_type", "entity_id", "type");
+CREATE INDEX "idx_compliance_records_status" ON "compliance_records"("status", "submitted_at") WHERE "submitted_at" IS NOT NULL;
+
+COMMENT ON COLUMN "compliance_records"."entity_type" IS 'The kind of subject of the record, entity for KYB.';
+COMMENT ON COLUMN "compliance_records"."reason" IS 'The reviewer note, required when rejecting.';
+
+CREATE TABLE "compliance_documents" (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT uuid7(),
+    "record_id" UUID NOT NULL REFERENCES "compliance_records" ("id") ON DELETE CASCADE,
+    "key" TEXT NOT NULL UNIQUE,
+    "name" TEXT NOT NULL,
+    "content_type" TEXT NOT NULL,
+    "created_by" UUID REFERENCES "users" ("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX "idx_compliance_documents_record_id" ON "compliance_documents"("record_id");
+
+COMMENT ON TABLE "compliance_documents" IS 'Track the documents uploaded to identity storage for compliance records.';
+
+-- migrate:down
+DROP TABLE "compliance_documents";
+
+DROP INDEX "idx_compliance_records_status";
+DROP INDEX "idx_compliance_records_subject";
+ALTER TABLE "compliance_records"
+    DROP COLUMN "submitted_at",
+    DROP COLUMN "reason";
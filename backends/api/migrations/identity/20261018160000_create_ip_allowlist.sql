@@ -0,0 +1,15 @@
+-- migrate:up
+CREATE TABLE "ip_allowlist_entries" (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT uuid7(),
+    "entity_id" UUID NOT NULL REFERENCES "entities" ("id") ON DELETE CASCADE,
+    "cidr" CIDR NOT NULL,
+    "description" TEXT,
+    "created_by" UUID REFERENCES "users" ("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE ("entity_id", "cidr")
+);
+
+COMMENT ON TABLE "ip_allowlist_entries" IS 'Restrict access to an entity to these address ranges, any address is allowed without entries.';
+
+-- migrate:down
+DROP TABLE "ip_allowlist_entries";
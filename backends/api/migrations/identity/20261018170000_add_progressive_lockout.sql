@@ -0,0 +1,23 @@
+-- migrate:up
+ALTER TABLE "users" ADD COLUMN "locked_until" TIMESTAMPTZ;
+ALTER TABLE "users" ADD COLUMN "lockout_count" INTEGER NOT NULL DEFAULT 0;
+
+-- Accounts locked before were locked for 30 minutes
+UPDATE "users" SET "locked_until" = "locked_at" + INTERVAL '30 minutes', "lockout_count" = 1 WHERE "locked_at" IS NOT NULL;
+
+CREATE TABLE "failed_sign_ins" (
+    "email_hash" TEXT NOT NULL PRIMARY KEY,
+    "failed_attempts" INTEGER NOT NULL DEFAULT 0,
+    "lockout_count" INTEGER NOT NULL DEFAULT 0,
+    "locked_until" TIMESTAMPTZ,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX "idx_failed_sign_ins_updated_at" ON "failed_sign_ins"("updated_at");
+
+COMMENT ON TABLE "failed_sign_ins" IS 'Failed sign-in attempts against email addresses without an account, keyed by the hashed address, so they are locked out like accounts.';
+
+-- migrate:down
+DROP TABLE "failed_sign_ins";
+ALTER TABLE "users" DROP COLUMN "lockout_count";
+ALTER TABLE "users" DROP COLUMN "locked_until";
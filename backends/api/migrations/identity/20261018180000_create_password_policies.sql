@@ -0,0 +1,30 @@
+-- migrate:up
+CREATE TABLE "password_policies" (
+    "entity_id" UUID NOT NULL PRIMARY KEY REFERENCES "entities" ("id") ON DELETE CASCADE,
+    "min_strength_score" SMALLINT NOT NULL DEFAULT 0 CHECK ("min_strength_score" BETWEEN 0 AND 4),
+    "history_size" SMALLINT NOT NULL DEFAULT 0 CHECK ("history_size" BETWEEN 0 AND 10),
+    "updated_by" UUID REFERENCES "users" ("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE "password_policies" IS 'Password requirements of the members of an entity, the strictest policy of the entities of a user applies.';
+
+CREATE TABLE "password_history" (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT uuid7(),
+    "user_id" UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "password_hash" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX "idx_password_history_user_id_created_at" ON "password_history"("user_id", "created_at" DESC);
+
+COMMENT ON TABLE "password_history" IS 'Hashes of the latest passwords of a user, so password policies can ban reusing them.';
+
+-- The current passwords start the history
+INSERT INTO "password_history" ("user_id", "password_hash", "created_at")
+SELECT "id", "password_hash", COALESCE("password_changed_at", "created_at") FROM "users" WHERE "password_hash" IS NOT NULL;
+
+-- migrate:down
+DROP TABLE "password_history";
+DROP TABLE "password_policies";
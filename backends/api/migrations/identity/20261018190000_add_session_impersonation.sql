@@ -0,0 +1,13 @@
+-- migrate:up
+ALTER TABLE "sessions" ADD COLUMN "impersonator_id" UUID REFERENCES "users"("id") ON DELETE CASCADE;
+ALTER TABLE "sessions" ADD COLUMN "impersonator_entity_id" UUID REFERENCES "entities"("id") ON DELETE CASCADE;
+
+CREATE INDEX "idx_sessions_impersonator_id" ON "sessions"("impersonator_id") WHERE "impersonator_id" IS NOT NULL;
+
+COMMENT ON COLUMN "sessions"."impersonator_id" IS 'The platform member impersonating the user of the session, if any.';
+COMMENT ON COLUMN "sessions"."impersonator_entity_id" IS 'The platform entity the impersonation was started from.';
+
+-- migrate:down
+DROP INDEX "idx_sessions_impersonator_id";
+ALTER TABLE "sessions" DROP COLUMN "impersonator_entity_id";
+ALTER TABLE "sessions" DROP COLUMN "impersonator_id";
@@ -0,0 +1,20 @@
+-- migrate:up
+CREATE TABLE "trusted_devices" (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT uuid7(),
+    "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+    "device_class" TEXT NOT NULL,
+    "ip_address" TEXT,
+    "country" TEXT,
+    "user_agent" TEXT,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "last_used_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX "idx_trusted_devices_user_id" ON "trusted_devices"("user_id");
+CREATE INDEX "idx_trusted_devices_expires_at" ON "trusted_devices"("expires_at");
+
+COMMENT ON TABLE "trusted_devices" IS 'Browsers a user chose to trust, which skip two-factor authentication until they expire, are revoked, or the password or two-factor authentication changes.';
+
+-- migrate:down
+DROP TABLE "trusted_devices";
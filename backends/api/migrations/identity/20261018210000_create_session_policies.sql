@@ -0,0 +1,31 @@
+-- migrate:up
+ALTER TABLE "sessions" ADD COLUMN "last_active_at" TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE "sessions" ADD COLUMN "authenticated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE "sessions" ADD COLUMN "idle_timeout_seconds" INTEGER;
+
+-- Existing sessions were last active when last updated, and authenticated when created
+UPDATE "sessions" SET "last_active_at" = "updated_at", "authenticated_at" = "created_at";
+
+CREATE INDEX "idx_sessions_user_id_last_active_at" ON "sessions"("user_id", "last_active_at" DESC);
+
+COMMENT ON COLUMN "sessions"."authenticated_at" IS 'When the user signed in, carried over when the session is refreshed.';
+COMMENT ON COLUMN "sessions"."idle_timeout_seconds" IS 'How long the session lasts without activity, from the strictest session policy when it was created.';
+
+CREATE TABLE "session_policies" (
+    "entity_id" UUID NOT NULL PRIMARY KEY REFERENCES "entities" ("id") ON DELETE CASCADE,
+    "idle_timeout_minutes" INTEGER CHECK ("idle_timeout_minutes" > 0),
+    "max_session_minutes" INTEGER CHECK ("max_session_minutes" > 0),
+    "max_concurrent_sessions" INTEGER CHECK ("max_concurrent_sessions" > 0),
+    "updated_by" UUID REFERENCES "users" ("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE "session_policies" IS 'Session limits of the members of an entity, the strictest policy of the entities of a user applies.';
+
+-- migrate:down
+DROP TABLE "session_policies";
+DROP INDEX "idx_sessions_user_id_last_active_at";
+ALTER TABLE "sessions" DROP COLUMN "idle_timeout_seconds";
+ALTER TABLE "sessions" DROP COLUMN "authenticated_at";
+ALTER TABLE "sessions" DROP COLUMN "last_active_at";
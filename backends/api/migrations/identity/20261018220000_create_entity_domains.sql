@@ -0,0 +1,24 @@
+-- migrate:up
+CREATE TABLE "entity_domains" (
+    "id" UUID NOT NULL PRIMARY KEY DEFAULT uuid7(),
+    "entity_id" UUID NOT NULL REFERENCES "entities" ("id") ON DELETE CASCADE,
+    "domain" TEXT NOT NULL,
+    "verification_token" TEXT NOT NULL,
+    "verified_at" TIMESTAMPTZ,
+    "auto_join" BOOLEAN NOT NULL DEFAULT FALSE,
+    "default_role" TEXT NOT NULL DEFAULT 'viewer',
+    "created_by" UUID REFERENCES "users" ("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX "idx_entity_domains_entity_id_domain" ON "entity_domains"("entity_id", "domain");
+
+-- A domain can only be verified by one entity
+CREATE UNIQUE INDEX "idx_entity_domains_verified_domain" ON "entity_domains"("domain") WHERE "verified_at" IS NOT NULL;
+
+COMMENT ON TABLE "entity_domains" IS 'Domains claimed by entities, verified through a DNS TXT record. Users at a verified domain can join the entity if it allows automatic joining.';
+COMMENT ON COLUMN "entity_domains"."domain" IS 'Lowercase, without a trailing dot.';
+
+-- migrate:down
+DROP TABLE "entity_domains";
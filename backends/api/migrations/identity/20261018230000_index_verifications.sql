@@ -0,0 +1,7 @@
+-- migrate:up
+CREATE INDEX "idx_verifications_context_value" ON "verifications"("context", "value");
+CREATE INDEX "idx_verifications_expires_at" ON "verifications"("expires_at");
+
+-- migrate:down
+DROP INDEX "idx_verifications_expires_at";
+DROP INDEX "idx_verifications_context_value";
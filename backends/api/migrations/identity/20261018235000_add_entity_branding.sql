@@ -0,0 +1,9 @@
+-- migrate:up
+ALTER TABLE "entities"
+    ADD COLUMN "brand_color" TEXT CHECK ("brand_color" ~ '^#[0-9a-f]{6}$'),
+    ADD COLUMN "support_email" TEXT;
+
+-- migrate:down
+ALTER TABLE "entities"
+    DROP COLUMN "support_email",
+    DROP COLUMN "brand_color";
@@ -0,0 +1,14 @@
+-- migrate:up
+CREATE TABLE "sign_in_history" (
+    "user_id" UUID NOT NULL REFERENCES "users"("id") ON DELETE CASCADE,
+    "country" TEXT NOT NULL DEFAULT '',
+    "device_class" TEXT NOT NULL,
+    "first_seen_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "last_seen_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("user_id", "country", "device_class")
+);
+
+COMMENT ON TABLE "sign_in_history" IS 'The countries and device classes users signed in from, kept after their sessions end, to detect sign-ins from a new country or device.';
+
+-- migrate:down
+DROP TABLE "sign_in_history";
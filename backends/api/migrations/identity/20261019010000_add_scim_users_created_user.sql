@@ -0,0 +1,9 @@
+-- migrate:up
+ALTER TABLE "scim_users"
+    ADD COLUMN "created_user" BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN "scim_users"."created_user" IS 'Whether the SCIM client created the user, rather than linking an existing account. Only then may it change the profile of the user.';
+
+-- migrate:down
+ALTER TABLE "scim_users"
+    DROP COLUMN "created_user";
@@ -2,6 +2,9 @@ package app
 
 import (
 	"fmt"
+	"os"
+	"slices"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
@@ -74,6 +77,11 @@ type Config struct {
 		Server struct {
 			Host string `env:"HOST" envDefault:"0.0.0.0"`
 			Port string `env:"PORT" envDefault:"3001"`
+
+			// TrustedProxies are the CIDR ranges or addresses of the proxies in
+			// front of the server, whose X-Forwarded-For hops are followed.
+			// "cloudflare" trusts the Cloudflare ranges and CF-Connecting-IP.
+			TrustedProxies []string `env:"TRUSTED_PROXIES" envDefault:""`
 		}
 
 		// Service holds the service name
@@ -103,6 +111,22 @@ type Config struct {
 			PrimaryReaders []string `env:"IDENTITY_PRIMARY_READER_DB_URLS" envDefault:""`
 		}
 
+		// AuditLogSigningKey is the base64 encoded Ed25519 seed signing the audit log
+		// checkpoints. The default is public and refused in release mode.
+		AuditLogSigningKey string `env:"IDENTITY_AUDIT_LOG_SIGNING_KEY" envDefault:"ZGV2ZWxvcG1lbnQtYXVkaXQta2V5LW5vdC1mb3ItcHI="`
+
+		// EncryptionKey is the base64 encoded AES-256 key for secrets stored at rest.
+		// The default is public and refused in release mode.
+		EncryptionKey string `env:"IDENTITY_ENCRYPTION_KEY" envDefault:"ZGV2ZWxvcG1lbnQta2V5LW5vdC1mb3ItcHJvZC11c2U="`
+
+		// TrustedDeviceKey is the base64 encoded HMAC key signing the trusted device
+		// cookies. The default is public and refused in release mode.
+		TrustedDeviceKey string `env:"IDENTITY_TRUSTED_DEVICE_KEY" envDefault:"ZGV2ZWxvcG1lbnQtZGV2aWNlLWtleS1ub3QtcHJvZC4="`
+
+		// SessionIdleTimeout is how long a session lasts without activity, unless
+		// a session policy of the user's entities is stricter. Zero disables it.
+		SessionIdleTimeout time.Duration `env:"IDENTITY_SESSION_IDLE_TIMEOUT" envDefault:"168h"`
+
 		// Storage holds S3 storage configuration
 		Storage struct {
 			Endpoint        string `env:"AWS_ENDPOINT" envDefault:"http://localhost:9000"`
@@ -150,3 +174,31 @@ func NewConfig() (*Config, error) {
 
 	return cfg, nil
 }
+
+// devKeys are the environment variables of the keys whose defaults are public,
+// so they are only fit for development
+var devKeys = []string{
+	"IDENTITY_AUDIT_LOG_SIGNING_KEY",
+	"IDENTITY_ENCRYPTION_KEY",
+	"IDENTITY_TRUSTED_DEVICE_KEY",
+}
+
+// checkDevKeys returns an error if any of the keys is left at its default.
+func (c *Config) checkDevKeys() error {
+	params, err := env.GetFieldParams(c)
+	if err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	for _, param := range params {
+		if !slices.Contains(devKeys, param.Key) {
+			continue
+		}
+
+		if value := os.Getenv(param.Key); value == "" || value == param.DefaultValue {
+			return fmt.Errorf("%s must be set to a key of its own in release mode", param.Key)
+		}
+	}
+
+	return nil
+}
@@ -9,18 +9,21 @@ import (
 	"fmt"
 	"html/template"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// ContainerCache holds the cache connections for the container
+// ContainerCache holds the caches for the container. A cache is nil when its
+// Valkey is unreachable in release mode, and callers read from the database.
 type ContainerCache struct {
-	// Identity is the identity cache connection
-	Identity redis.UniversalClient
+	// Identity is the identity cache, namespaced by "identity"
+	Identity core.Cache
 
-	// Payment is the payment cache connection
-	Payment redis.UniversalClient
+	// Payment is the payment cache, namespaced by "payment". Namespace it
+	// further by operation mode, as live and test data must not mix.
+	Payment core.Cache
 }
 
 // PaymentDB holds the payment database connections
@@ -67,6 +70,9 @@ type Container struct {
 	// Cache holds the cache connections for the container
 	Cache ContainerCache
 
+	// Cipher encrypts secrets stored at rest
+	Cipher *core.Cipher
+
 	// Config is the application configuration
 	Config *Config
 
@@ -91,6 +97,9 @@ type Container struct {
 	// RateLimiter is the Valkey client for rate limiting
 	RateLimiter redis.UniversalClient
 
+	// Resolver looks up DNS records
+	Resolver Resolver
+
 	// Storage is the S3 storage
 	Storage ContainerS3
 
@@ -130,6 +139,13 @@ func NewContainer(ctx context.Context, opts ContainerOpts) (*Container, error) {
 		return nil, err
 	}
 
+	// The development keys are public, so anyone could forge what they sign
+	if opts.Mode == types.ReleaseMode {
+		if err := config.checkDevKeys(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize the tracer
 	tracerShutdown, err := core.NewTracer(
 		ctx,
@@ -167,6 +183,76 @@ func NewContainer(ctx context.Context, opts ContainerOpts) (*Container, error) {
 				"Name":            "John Doe",
 				"VerificationURL": fmt.Sprintf("%s/verify-email?token=01948450-988e-7976-a454-7163b6f1c6c6", config.App.DashboardURL),
 			},
+			"account_deletion": {
+				"AppName":   config.App.Name,
+				"AssetsURL": config.App.AssetsURL,
+				"CancelURL": fmt.Sprintf("%s/settings/profile", config.App.DashboardURL),
+				"Duration":  model.AccountDeletionGracePeriod.Hours() / 24,
+				"Name":      "John Doe",
+			},
+			"account_locked": {
+				"AppName":     config.App.Name,
+				"AssetsURL":   config.App.AssetsURL,
+				"Duration":    model.AccountUnlockDuration.Hours(),
+				"LockedUntil": "2025-01-05 07:55 UTC",
+				"Name":        "John Doe",
+				"UnlockURL":   fmt.Sprintf("%s/unlock-account?token=01948450-988e-7976-a454-7163b6f1c6c6", config.App.DashboardURL),
+			},
+			"audit_log_export": {
+				"AppName":     config.App.Name,
+				"AssetsURL":   config.App.AssetsURL,
+				"DownloadURL": "https://storage.example.com/audit-logs/01948450-988e-7976-a454-7163b6f1c6c6.csv",
+				"Duration":    model.AuditLogExportLinkDuration.Hours() / 24,
+				"EntityName":  "Acme Inc.",
+				"Name":        "John Doe",
+			},
+			"data_export": {
+				"AppName":     config.App.Name,
+				"AssetsURL":   config.App.AssetsURL,
+				"DownloadURL": "https://storage.example.com/exports/01948450-988e-7976-a454-7163b6f1c6c6.json",
+				"Duration":    model.DataExportLinkDuration.Hours() / 24,
+				"Name":        "John Doe",
+			},
+			"email_change": {
+				"AppName":    config.App.Name,
+				"AssetsURL":  config.App.AssetsURL,
+				"ConfirmURL": fmt.Sprintf("%s/confirm-email-change?token=01948450-988e-7976-a454-7163b6f1c6c6", config.App.DashboardURL),
+				"Duration":   model.EmailChangeDuration.Hours(),
+				"Name":       "John Doe",
+				"NewEmail":   "john.doe@example.com",
+			},
+			"email_change_requested": {
+				"AppName":   config.App.Name,
+				"AssetsURL": config.App.AssetsURL,
+				"Name":      "John Doe",
+				"NewEmail":  "john.doe@example.com",
+			},
+			"email_changed": {
+				"AppName":   config.App.Name,
+				"AssetsURL": config.App.AssetsURL,
+				"Duration":  model.EmailChangeRevertDuration.Hours() / 24,
+				"Name":      "John Doe",
+				"NewEmail":  "john.doe@example.com",
+				"RevertURL": fmt.Sprintf("%s/revert-email-change?token=01948450-988e-7976-a454-7163b6f1c6c6", config.App.DashboardURL),
+			},
+			"magic_link": {
+				"AppName":   config.App.Name,
+				"AssetsURL": config.App.AssetsURL,
+				"Duration":  model.MagicLinkDuration.Minutes(),
+				"Name":      "John Doe",
+				"SignInURL": fmt.Sprintf("%s/magic-link?token=01948450-988e-7976-a454-7163b6f1c6c6", config.App.DashboardURL),
+			},
+			"new_sign_in": {
+				"AppName":     config.App.Name,
+				"AssetsURL":   config.App.AssetsURL,
+				"Country":     "SG",
+				"DeviceClass": model.DeviceClassDesktop,
+				"Duration":    model.SignInRevokeDuration.Hours() / 24,
+				"IPAddress":   "203.0.113.42",
+				"Name":        "John Doe",
+				"RevokeURL":   fmt.Sprintf("%s/revoke-sign-in?token=01948450-988e-7976-a454-7163b6f1c6c6", config.App.DashboardURL),
+				"SignedInAt":  "2025-01-05 07:40 UTC",
+			},
 			"password_reset": {
 				"AppName":   config.App.Name,
 				"AssetsURL": config.App.AssetsURL,
@@ -226,6 +312,40 @@ func NewContainer(ctx context.Context, opts ContainerOpts) (*Container, error) {
 		return nil, err
 	}
 
+	// Initialize the identity cache
+	identityCache, closeIdentityCache := newCache(ctx, logger, opts.Mode, config.Identity.Cache.ValkeyURLs, "identity")
+	if closeIdentityCache != nil {
+		cleanUp = append(cleanUp, closeIdentityCache)
+	}
+
+	// Initialize the cipher for secrets stored at rest
+	cipher, err := core.NewCipher(config.Identity.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the payment cache
+	paymentCache, closePaymentCache := newCache(ctx, logger, opts.Mode, config.Payment.Cache.ValkeyURLs, "payment")
+	if closePaymentCache != nil {
+		cleanUp = append(cleanUp, closePaymentCache)
+	}
+
+	// Initialize the rate limiter, which falls back to counting in memory
+	// when Valkey is unreachable
+	var rateLimiter redis.UniversalClient
+	if urls := config.App.RateLimiter.ValkeyURLs; urls != "" {
+		client, err := core.NewRedis(ctx, core.RedisOptions{
+			URL:       urls,
+			IsCluster: strings.Contains(urls, ","),
+		})
+		if err != nil {
+			logger.Warn("Rate limiter unavailable", "error", err)
+		} else {
+			rateLimiter = client
+			cleanUp = append(cleanUp, client.Close)
+		}
+	}
+
 	// Initialize the payment databases
 	livePaymentDB, err := core.NewDB(ctx, core.DBOptions{
 		Identifier:   "payment",
@@ -264,7 +384,11 @@ func NewContainer(ctx context.Context, opts ContainerOpts) (*Container, error) {
 	})
 
 	return &Container{
-		Cache:   ContainerCache{},
+		Cache: ContainerCache{
+			Identity: identityCache,
+			Payment:  paymentCache,
+		},
+		Cipher:  cipher,
 		CleanUp: cleanUp,
 		Config:  config,
 		DB: ContainerDB{
@@ -279,10 +403,12 @@ func NewContainer(ctx context.Context, opts ContainerOpts) (*Container, error) {
 			Migrations: opts.FS.Migrations,
 			Templates:  opts.FS.Templates,
 		},
-		I18nBundle: i18nBundle,
-		Logger:     logger,
-		Mailer:     mailer,
-		Mode:       opts.Mode,
+		I18nBundle:  i18nBundle,
+		Logger:      logger,
+		Mailer:      mailer,
+		Mode:        opts.Mode,
+		RateLimiter: rateLimiter,
+		Resolver:    NewResolver(),
 		Storage: ContainerS3{
 			Identity: identityStorage,
 		},
@@ -290,6 +416,31 @@ func NewContainer(ctx context.Context, opts ContainerOpts) (*Container, error) {
 	}, nil
 }
 
+// newCache connects the cache of a module to its Valkey. Caches only speed up
+// reads, so the application runs against the database alone if Valkey is
+// unreachable, or against an in-memory cache in debug mode. The returned
+// function closes the connection, if any.
+func newCache(ctx context.Context, logger *core.Logger, mode types.Mode, urls string, module string) (core.Cache, func() error) {
+	if urls != "" {
+		client, err := core.NewRedis(ctx, core.RedisOptions{
+			URL:       urls,
+			IsCluster: strings.Contains(urls, ","),
+		})
+		if err == nil {
+			return core.NewValkeyCache(client, module), client.Close
+		}
+
+		logger.Warn("Cache unavailable", "module", module, "error", err)
+	}
+
+	if mode == types.DebugMode {
+		logger.Info("Using an in-memory cache", "module", module)
+		return core.NewMemoryCache().Namespace(module), nil
+	}
+
+	return nil, nil
+}
+
 // Close cleans up the container
 func (c *Container) Close() []error {
 	var errs []error
@@ -10,6 +10,101 @@ import (
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewMockResolver creates a new instance of MockResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockResolver {
+	mock := &MockResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockResolver is an autogenerated mock type for the Resolver type
+type MockResolver struct {
+	mock.Mock
+}
+
+type MockResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockResolver) EXPECT() *MockResolver_Expecter {
+	return &MockResolver_Expecter{mock: &_m.Mock}
+}
+
+// LookupTXT provides a mock function for the type MockResolver
+func (_mock *MockResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupTXT")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResolver_LookupTXT_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupTXT'
+type MockResolver_LookupTXT_Call struct {
+	*mock.Call
+}
+
+// LookupTXT is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockResolver_Expecter) LookupTXT(ctx interface{}, name interface{}) *MockResolver_LookupTXT_Call {
+	return &MockResolver_LookupTXT_Call{Call: _e.mock.On("LookupTXT", ctx, name)}
+}
+
+func (_c *MockResolver_LookupTXT_Call) Run(run func(ctx context.Context, name string)) *MockResolver_LookupTXT_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResolver_LookupTXT_Call) Return(ss []string, err error) *MockResolver_LookupTXT_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockResolver_LookupTXT_Call) RunAndReturn(run func(ctx context.Context, name string) ([]string, error)) *MockResolver_LookupTXT_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockTurnstiler creates a new instance of MockTurnstiler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockTurnstiler(t interface {
@@ -0,0 +1,17 @@
+package app
+
+import (
+	"context"
+	"net"
+)
+
+// Resolver is an interface that wraps the LookupTXT method
+type Resolver interface {
+	// LookupTXT returns the DNS TXT records for the given domain name
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NewResolver creates a new Resolver using the system DNS resolver
+func NewResolver() *net.Resolver {
+	return net.DefaultResolver
+}
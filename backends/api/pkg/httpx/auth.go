@@ -13,8 +13,20 @@ type AuthInfo struct {
 	UserID        string
 	Mode          types.OperationMode
 	APIKeyUsed    bool
+	CredentialID  string // The API key or SCIM token authenticated with, if any
 
 	EntityRole types.Role
+
+	// ImpersonatorID is the real user behind an impersonation session, and
+	// ImpersonatorEntityID the platform entity they impersonate from. UserID
+	// and EntityRole are then those of the impersonated user.
+	ImpersonatorID       string
+	ImpersonatorEntityID string
+}
+
+// IsImpersonating checks if the request is made by a user impersonating another
+func (a AuthInfo) IsImpersonating() bool {
+	return a.ImpersonatorID != ""
 }
 
 type Authenticator interface {
@@ -29,6 +41,9 @@ type Authenticator interface {
 
 	// RequireAuthenticated allows authentication via either a session cookie or a secret API key.
 	RequireAuthenticated(ctx huma.Context, next func(huma.Context))
+
+	// RequireSCIMToken enforces authentication using an entity's SCIM bearer token.
+	RequireSCIMToken(ctx huma.Context, next func(huma.Context))
 }
 
 func WithAuthInfo(ctx huma.Context, info AuthInfo) huma.Context {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -245,6 +246,14 @@ func TestPasswordValidation(t *testing.T) {
 		map[string]any{"value": ""},
 		ErrInvalidBody,
 	)
+	testValidation(
+		t,
+		struct {
+			Value Password `json:"value"`
+		}{},
+		map[string]any{"value": strings.Repeat("aB3$", 19)},
+		ErrInvalidBody,
+	)
 }
 
 func TestRegexValidation(t *testing.T) {
@@ -18,6 +18,7 @@ var errCodeMap = map[ErrorCode]Error{
 	ErrEntityNotFound:          mkErr("Entity not found.", http.StatusNotFound),
 	ErrUnauthenticated:         mkErr("Unauthenticated.", http.StatusUnauthorized),
 	ErrInsufficientPermissions: mkErr("Insufficient permissions.", http.StatusForbidden),
+	ErrRateLimitExceeded:       mkErr("Rate limit exceeded.", http.StatusTooManyRequests),
 
 	ErrInvalidBody:                  mkErr("Invalid request body", http.StatusBadRequest),
 	ErrRequired:                     mkErr("This field is required.", http.StatusBadRequest),
@@ -41,6 +42,8 @@ var errCodeMap = map[ErrorCode]Error{
 	ErrTooLarge:                     mkErr("Value is too large.", http.StatusBadRequest),
 	ErrInvalidImageFormat:           mkErr("Invalid image format.", http.StatusBadRequest),
 	ErrInvalidCursor:                mkErr("Invalid cursor format", http.StatusBadRequest),
+	ErrInvalidFilter:                mkErr("Invalid or unsupported filter.", http.StatusBadRequest),
+	ErrInvalidTimeRange:             mkErr("Invalid time range.", http.StatusBadRequest),
 	ErrInvalidTurnstileToken:        mkErr("Invalid Turnstile token.", http.StatusUnauthorized),
 	ErrFailedToVerifyTurnstileToken: mkErr("Failed to verify Turnstile token.", http.StatusUnauthorized),
 	ErrInvalidCurrency:              mkErr("Invalid currency code.", http.StatusBadRequest),
@@ -55,10 +58,43 @@ var errCodeMap = map[ErrorCode]Error{
 	ErrInvalidName:                  mkErr("Invalid API key name", http.StatusUnprocessableEntity),
 	ErrConnectionNotFound:           mkErr("Connection not found.", http.StatusNotFound),
 	ErrInvalidConnectionCredentials: mkErr("Invalid connection credentials.", http.StatusUnprocessableEntity),
+	ErrSSORequired:                  mkErr("Single sign-on is required for this account.", http.StatusForbidden),
+	ErrEmailDomainNotAllowed:        mkErr("The email domain is not allowed for this connection.", http.StatusForbidden),
 
 	ErrEmailExists:           mkErr("Email already exists.", http.StatusUnprocessableEntity),
 	ErrInvalidOrExpiredToken: mkErr("The verification token is invalid or expired.", http.StatusUnauthorized),
 	ErrUserNotFound:          mkErr("User not found.", http.StatusNotFound),
+	ErrLastEntityOwner:       mkErr("The user is the last owner of an entity.", http.StatusConflict),
+	ErrMemberExists:          mkErr("The user is already a member of the entity.", http.StatusConflict),
+	ErrGroupNotFound:         mkErr("Group not found.", http.StatusNotFound),
+	ErrImmutableAttribute:    mkErr("The attribute can't be changed.", http.StatusBadRequest),
+	ErrSCIMTokenNotFound:     mkErr("SCIM token not found.", http.StatusNotFound),
+
+	ErrComplianceRecordNotFound:   mkErr("Compliance record not found.", http.StatusNotFound),
+	ErrComplianceRecordLocked:     mkErr("The compliance record can't be changed while it is reviewed or once approved.", http.StatusConflict),
+	ErrComplianceDocumentNotFound: mkErr("Compliance document not found.", http.StatusNotFound),
+	ErrComplianceDocumentsMissing: mkErr("Every compliance document must be uploaded, and at least one is required.", http.StatusBadRequest),
+	ErrInvalidComplianceStatus:    mkErr("The compliance record can't be moved to this status.", http.StatusConflict),
+	ErrComplianceIncomplete:       mkErr("The required compliance records aren't approved.", http.StatusConflict),
+	ErrPlatformEntityRequired:     mkErr("This action requires an active platform entity.", http.StatusForbidden),
+
+	ErrIPNotAllowed:       mkErr("Access from this IP address is not allowed for this entity.", http.StatusForbidden),
+	ErrInvalidIPRange:     mkErr("Invalid IP address range.", http.StatusBadRequest),
+	ErrIPAllowlistLockout: mkErr("The IP allowlist must allow your current IP address.", http.StatusConflict),
+
+	ErrPasswordBreached: mkErr("The password has appeared in a data breach.", http.StatusBadRequest),
+	ErrPasswordTooWeak:  mkErr("The password is too easy to guess.", http.StatusBadRequest),
+	ErrPasswordReused:   mkErr("The password was used recently.", http.StatusBadRequest),
+
+	ErrImpersonationReadOnly:   mkErr("This action isn't allowed while impersonating a user.", http.StatusForbidden),
+	ErrImpersonationNotAllowed: mkErr("The user can't be impersonated.", http.StatusForbidden),
+	ErrNotImpersonating:        mkErr("The session isn't impersonating a user.", http.StatusBadRequest),
+
+	ErrDomainNotFound:           mkErr("Domain not found.", http.StatusNotFound),
+	ErrDomainExists:             mkErr("The entity has already claimed the domain.", http.StatusConflict),
+	ErrDomainClaimed:            mkErr("The domain is verified by another entity.", http.StatusConflict),
+	ErrDomainVerificationFailed: mkErr("The verification record wasn't found in the DNS of the domain.", http.StatusUnprocessableEntity),
+	ErrDomainNotVerified:        mkErr("The domain isn't verified by the entity.", http.StatusUnprocessableEntity),
 
 	ErrInvalidTwoFactorCode:    mkErr("Invalid two-factor code.", http.StatusUnauthorized),
 	ErrTwoFactorNotEnabled:     mkErr("Two-factor authentication is not enabled.", http.StatusBadRequest),
@@ -66,6 +102,7 @@ var errCodeMap = map[ErrorCode]Error{
 	ErrTwoFactorPending:        mkErr("Two-factor authentication verification pending.", http.StatusBadRequest),
 	ErrBackupCodeValidation:    mkErr("Invalid or used backup code.", http.StatusUnauthorized),
 	ErrTwoFactorLocked:         mkErr("Two-factor authentication is locked.", http.StatusTooManyRequests),
+	ErrTrustedDeviceNotFound:   mkErr("Trusted device not found.", http.StatusNotFound),
 
 	ErrPaymentNotFound: mkErr("Payment not found", http.StatusNotFound),
 
@@ -85,6 +122,7 @@ const (
 	ErrUnauthenticated
 	ErrEntityNotFound
 	ErrInsufficientPermissions
+	ErrRateLimitExceeded
 )
 
 // Validation Errors
@@ -124,6 +162,8 @@ const (
 
 	// Application Validation
 	ErrInvalidCursor
+	ErrInvalidFilter
+	ErrInvalidTimeRange
 	ErrInvalidTurnstileToken
 	ErrFailedToVerifyTurnstileToken
 	ErrInvalidCurrency
@@ -140,10 +180,43 @@ const (
 	ErrInvalidName
 	ErrConnectionNotFound
 	ErrInvalidConnectionCredentials
+	ErrSSORequired
+	ErrEmailDomainNotAllowed
 
 	ErrEmailExists
 	ErrInvalidOrExpiredToken
 	ErrUserNotFound
+	ErrLastEntityOwner
+	ErrMemberExists
+	ErrGroupNotFound
+	ErrImmutableAttribute
+	ErrSCIMTokenNotFound
+
+	ErrComplianceRecordNotFound
+	ErrComplianceRecordLocked
+	ErrComplianceDocumentNotFound
+	ErrComplianceDocumentsMissing
+	ErrInvalidComplianceStatus
+	ErrComplianceIncomplete
+	ErrPlatformEntityRequired
+
+	ErrIPNotAllowed
+	ErrInvalidIPRange
+	ErrIPAllowlistLockout
+
+	ErrPasswordBreached
+	ErrPasswordTooWeak
+	ErrPasswordReused
+
+	ErrImpersonationReadOnly
+	ErrImpersonationNotAllowed
+	ErrNotImpersonating
+
+	ErrDomainNotFound
+	ErrDomainExists
+	ErrDomainClaimed
+	ErrDomainVerificationFailed
+	ErrDomainNotVerified
 
 	ErrInvalidTwoFactorCode
 	ErrTwoFactorNotEnabled
@@ -151,6 +224,7 @@ const (
 	ErrTwoFactorPending
 	ErrBackupCodeValidation
 	ErrTwoFactorLocked
+	ErrTrustedDeviceNotFound
 
 	ErrPaymentNotFound
 
@@ -12,6 +12,7 @@ func _() {
 	_ = x[ErrUnauthenticated-2]
 	_ = x[ErrEntityNotFound-3]
 	_ = x[ErrInsufficientPermissions-4]
+	_ = x[ErrRateLimitExceeded-5]
 	_ = x[ErrInvalidBody-1000]
 	_ = x[ErrRequired-1001]
 	_ = x[ErrInvalidValue-1002]
@@ -34,11 +35,13 @@ func _() {
 	_ = x[ErrTooLarge-1019]
 	_ = x[ErrInvalidImageFormat-1020]
 	_ = x[ErrInvalidCursor-1021]
-	_ = x[ErrInvalidTurnstileToken-1022]
-	_ = x[ErrFailedToVerifyTurnstileToken-1023]
-	_ = x[ErrInvalidCurrency-1024]
-	_ = x[ErrInvalidCountry-1025]
-	_ = x[ErrInvalidFinancialAmount-1026]
+	_ = x[ErrInvalidFilter-1022]
+	_ = x[ErrInvalidTimeRange-1023]
+	_ = x[ErrInvalidTurnstileToken-1024]
+	_ = x[ErrFailedToVerifyTurnstileToken-1025]
+	_ = x[ErrInvalidCurrency-1026]
+	_ = x[ErrInvalidCountry-1027]
+	_ = x[ErrInvalidFinancialAmount-1028]
 	_ = x[ErrAccountLocked-10000]
 	_ = x[ErrEmailNotVerified-10001]
 	_ = x[ErrInvalidCredentials-10002]
@@ -46,71 +49,132 @@ func _() {
 	_ = x[ErrInvalidName-10004]
 	_ = x[ErrConnectionNotFound-10005]
 	_ = x[ErrInvalidConnectionCredentials-10006]
-	_ = x[ErrEmailExists-10007]
-	_ = x[ErrInvalidOrExpiredToken-10008]
-	_ = x[ErrUserNotFound-10009]
-	_ = x[ErrInvalidTwoFactorCode-10010]
-	_ = x[ErrTwoFactorNotEnabled-10011]
-	_ = x[ErrTwoFactorAlreadyEnabled-10012]
-	_ = x[ErrTwoFactorPending-10013]
-	_ = x[ErrBackupCodeValidation-10014]
-	_ = x[ErrTwoFactorLocked-10015]
-	_ = x[ErrPaymentNotFound-10016]
-	_ = x[ErrUnused-10017]
+	_ = x[ErrSSORequired-10007]
+	_ = x[ErrEmailDomainNotAllowed-10008]
+	_ = x[ErrEmailExists-10009]
+	_ = x[ErrInvalidOrExpiredToken-10010]
+	_ = x[ErrUserNotFound-10011]
+	_ = x[ErrLastEntityOwner-10012]
+	_ = x[ErrMemberExists-10013]
+	_ = x[ErrGroupNotFound-10014]
+	_ = x[ErrImmutableAttribute-10015]
+	_ = x[ErrSCIMTokenNotFound-10016]
+	_ = x[ErrComplianceRecordNotFound-10017]
+	_ = x[ErrComplianceRecordLocked-10018]
+	_ = x[ErrComplianceDocumentNotFound-10019]
+	_ = x[ErrComplianceDocumentsMissing-10020]
+	_ = x[ErrInvalidComplianceStatus-10021]
+	_ = x[ErrComplianceIncomplete-10022]
+	_ = x[ErrPlatformEntityRequired-10023]
+	_ = x[ErrIPNotAllowed-10024]
+	_ = x[ErrInvalidIPRange-10025]
+	_ = x[ErrIPAllowlistLockout-10026]
+	_ = x[ErrPasswordBreached-10027]
+	_ = x[ErrPasswordTooWeak-10028]
+	_ = x[ErrPasswordReused-10029]
+	_ = x[ErrImpersonationReadOnly-10030]
+	_ = x[ErrImpersonationNotAllowed-10031]
+	_ = x[ErrNotImpersonating-10032]
+	_ = x[ErrDomainNotFound-10033]
+	_ = x[ErrDomainExists-10034]
+	_ = x[ErrDomainClaimed-10035]
+	_ = x[ErrDomainVerificationFailed-10036]
+	_ = x[ErrDomainNotVerified-10037]
+	_ = x[ErrInvalidTwoFactorCode-10038]
+	_ = x[ErrTwoFactorNotEnabled-10039]
+	_ = x[ErrTwoFactorAlreadyEnabled-10040]
+	_ = x[ErrTwoFactorPending-10041]
+	_ = x[ErrBackupCodeValidation-10042]
+	_ = x[ErrTwoFactorLocked-10043]
+	_ = x[ErrTrustedDeviceNotFound-10044]
+	_ = x[ErrPaymentNotFound-10045]
+	_ = x[ErrUnused-10046]
 }
 
-const _ErrorCode_name = "UnknownUnauthenticatedEntityNotFoundInsufficientPermissionsInvalidBodyRequiredInvalidValueInvalidDateInvalidDateTimeInvalidTimeInvalidEmailInvalidHostnameInvalidIPv4InvalidIPv6InvalidUUIDMissingLowercaseMissingUppercaseMissingNumberMissingSpecialTooShortTooLongDuplicateItemsTooSmallTooLargeInvalidImageFormatInvalidCursorInvalidTurnstileTokenFailedToVerifyTurnstileTokenInvalidCurrencyInvalidCountryInvalidFinancialAmountAccountLockedEmailNotVerifiedInvalidCredentialsInvalidRefreshTokenInvalidNameConnectionNotFoundInvalidConnectionCredentialsEmailExistsInvalidOrExpiredTokenUserNotFoundInvalidTwoFactorCodeTwoFactorNotEnabledTwoFactorAlreadyEnabledTwoFactorPendingBackupCodeValidationTwoFactorLockedPaymentNotFoundUnused"
+const _ErrorCode_name = "UnknownUnauthenticatedEntityNotFoundInsufficientPermissionsRateLimitExceededInvalidBodyRequiredInvalidValueInvalidDateInvalidDateTimeInvalidTimeInvalidEmailInvalidHostnameInvalidIPv4InvalidIPv6InvalidUUIDMissingLowercaseMissingUppercaseMissingNumberMissingSpecialTooShortTooLongDuplicateItemsTooSmallTooLargeInvalidImageFormatInvalidCursorInvalidFilterInvalidTimeRangeInvalidTurnstileTokenFailedToVerifyTurnstileTokenInvalidCurrencyInvalidCountryInvalidFinancialAmountAccountLockedEmailNotVerifiedInvalidCredentialsInvalidRefreshTokenInvalidNameConnectionNotFoundInvalidConnectionCredentialsSSORequiredEmailDomainNotAllowedEmailExistsInvalidOrExpiredTokenUserNotFoundLastEntityOwnerMemberExistsGroupNotFoundImmutableAttributeSCIMTokenNotFoundComplianceRecordNotFoundComplianceRecordLockedComplianceDocumentNotFoundComplianceDocumentsMissingInvalidComplianceStatusComplianceIncompletePlatformEntityRequiredIPNotAllowedInvalidIPRangeIPAllowlistLockoutPasswordBreachedPasswordTooWeakPasswordReusedImpersonationReadOnlyImpersonationNotAllowedNotImpersonatingDomainNotFoundDomainExistsDomainClaimedDomainVerificationFailedDomainNotVerifiedInvalidTwoFactorCodeTwoFactorNotEnabledTwoFactorAlreadyEnabledTwoFactorPendingBackupCodeValidationTwoFactorLockedTrustedDeviceNotFoundPaymentNotFoundUnused"
 
 var _ErrorCode_map = map[ErrorCode]string{
 	1:     _ErrorCode_name[0:7],
 	2:     _ErrorCode_name[7:22],
 	3:     _ErrorCode_name[22:36],
 	4:     _ErrorCode_name[36:59],
-	1000:  _ErrorCode_name[59:70],
-	1001:  _ErrorCode_name[70:78],
-	1002:  _ErrorCode_name[78:90],
-	1003:  _ErrorCode_name[90:101],
-	1004:  _ErrorCode_name[101:116],
-	1005:  _ErrorCode_name[116:127],
-	1006:  _ErrorCode_name[127:139],
-	1007:  _ErrorCode_name[139:154],
-	1008:  _ErrorCode_name[154:165],
-	1009:  _ErrorCode_name[165:176],
-	1010:  _ErrorCode_name[176:187],
-	1011:  _ErrorCode_name[187:203],
-	1012:  _ErrorCode_name[203:219],
-	1013:  _ErrorCode_name[219:232],
-	1014:  _ErrorCode_name[232:246],
-	1015:  _ErrorCode_name[246:254],
-	1016:  _ErrorCode_name[254:261],
-	1017:  _ErrorCode_name[261:275],
-	1018:  _ErrorCode_name[275:283],
-	1019:  _ErrorCode_name[283:291],
-	1020:  _ErrorCode_name[291:309],
-	1021:  _ErrorCode_name[309:322],
-	1022:  _ErrorCode_name[322:343],
-	1023:  _ErrorCode_name[343:371],
-	1024:  _ErrorCode_name[371:386],
-	1025:  _ErrorCode_name[386:400],
-	1026:  _ErrorCode_name[400:422],
-	10000: _ErrorCode_name[422:435],
-	10001: _ErrorCode_name[435:451],
-	10002: _ErrorCode_name[451:469],
-	10003: _ErrorCode_name[469:488],
-	10004: _ErrorCode_name[488:499],
-	10005: _ErrorCode_name[499:517],
-	10006: _ErrorCode_name[517:545],
-	10007: _ErrorCode_name[545:556],
-	10008: _ErrorCode_name[556:577],
-	10009: _ErrorCode_name[577:589],
-	10010: _ErrorCode_name[589:609],
-	10011: _ErrorCode_name[609:628],
-	10012: _ErrorCode_name[628:651],
-	10013: _ErrorCode_name[651:667],
-	10014: _ErrorCode_name[667:687],
-	10015: _ErrorCode_name[687:702],
-	10016: _ErrorCode_name[702:717],
-	10017: _ErrorCode_name[717:723],
+	5:     _ErrorCode_name[59:76],
+	1000:  _ErrorCode_name[76:87],
+	1001:  _ErrorCode_name[87:95],
+	1002:  _ErrorCode_name[95:107],
+	1003:  _ErrorCode_name[107:118],
+	1004:  _ErrorCode_name[118:133],
+	1005:  _ErrorCode_name[133:144],
+	1006:  _ErrorCode_name[144:156],
+	1007:  _ErrorCode_name[156:171],
+	1008:  _ErrorCode_name[171:182],
+	1009:  _ErrorCode_name[182:193],
+	1010:  _ErrorCode_name[193:204],
+	1011:  _ErrorCode_name[204:220],
+	1012:  _ErrorCode_name[220:236],
+	1013:  _ErrorCode_name[236:249],
+	1014:  _ErrorCode_name[249:263],
+	1015:  _ErrorCode_name[263:271],
+	1016:  _ErrorCode_name[271:278],
+	1017:  _ErrorCode_name[278:292],
+	1018:  _ErrorCode_name[292:300],
+	1019:  _ErrorCode_name[300:308],
+	1020:  _ErrorCode_name[308:326],
+	1021:  _ErrorCode_name[326:339],
+	1022:  _ErrorCode_name[339:352],
+	1023:  _ErrorCode_name[352:368],
+	1024:  _ErrorCode_name[368:389],
+	1025:  _ErrorCode_name[389:417],
+	1026:  _ErrorCode_name[417:432],
+	1027:  _ErrorCode_name[432:446],
+	1028:  _ErrorCode_name[446:468],
+	10000: _ErrorCode_name[468:481],
+	10001: _ErrorCode_name[481:497],
+	10002: _ErrorCode_name[497:515],
+	10003: _ErrorCode_name[515:534],
+	10004: _ErrorCode_name[534:545],
+	10005: _ErrorCode_name[545:563],
+	10006: _ErrorCode_name[563:591],
+	10007: _ErrorCode_name[591:602],
+	10008: _ErrorCode_name[602:623],
+	10009: _ErrorCode_name[623:634],
+	10010: _ErrorCode_name[634:655],
+	10011: _ErrorCode_name[655:667],
+	10012: _ErrorCode_name[667:682],
+	10013: _ErrorCode_name[682:694],
+	10014: _ErrorCode_name[694:707],
+	10015: _ErrorCode_name[707:725],
+	10016: _ErrorCode_name[725:742],
+	10017: _ErrorCode_name[742:766],
+	10018: _ErrorCode_name[766:788],
+	10019: _ErrorCode_name[788:814],
+	10020: _ErrorCode_name[814:840],
+	10021: _ErrorCode_name[840:863],
+	10022: _ErrorCode_name[863:883],
+	10023: _ErrorCode_name[883:905],
+	10024: _ErrorCode_name[905:917],
+	10025: _ErrorCode_name[917:931],
+	10026: _ErrorCode_name[931:949],
+	10027: _ErrorCode_name[949:965],
+	10028: _ErrorCode_name[965:980],
+	10029: _ErrorCode_name[980:994],
+	10030: _ErrorCode_name[994:1015],
+	10031: _ErrorCode_name[1015:1038],
+	10032: _ErrorCode_name[1038:1054],
+	10033: _ErrorCode_name[1054:1068],
+	10034: _ErrorCode_name[1068:1080],
+	10035: _ErrorCode_name[1080:1093],
+	10036: _ErrorCode_name[1093:1117],
+	10037: _ErrorCode_name[1117:1134],
+	10038: _ErrorCode_name[1134:1154],
+	10039: _ErrorCode_name[1154:1173],
+	10040: _ErrorCode_name[1173:1196],
+	10041: _ErrorCode_name[1196:1212],
+	10042: _ErrorCode_name[1212:1232],
+	10043: _ErrorCode_name[1232:1247],
+	10044: _ErrorCode_name[1247:1268],
+	10045: _ErrorCode_name[1268:1283],
+	10046: _ErrorCode_name[1283:1289],
 }
 
 func (i ErrorCode) String() string {
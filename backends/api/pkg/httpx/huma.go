@@ -4,12 +4,16 @@ import (
 	"autopilot/backends/internal/types"
 	"context"
 	"net/http"
+	"reflect"
 
 	"github.com/danielgtaylor/huma/v2"
 )
 
 type HandlerOption func(*huma.Operation)
 
+// allowImpersonationKey is the operation metadata key of WithImpersonation
+const allowImpersonationKey = "allowImpersonation"
+
 var tooManyRequestsRef = &huma.Response{
 	Description: "Too many requests - rate limit exceeded",
 	Ref:         "#/components/responses/TooManyRequests",
@@ -17,14 +21,17 @@ var tooManyRequestsRef = &huma.Response{
 
 var securityAPIKey = map[string][]string{"API Key Authentication": {}}
 
+var securitySCIMToken = map[string][]string{"SCIM Bearer Token": {}}
+
 type API struct {
 	huma.API
 
 	mode          types.Mode
 	authenticator Authenticator
+	rateLimiter   RateLimiter
 }
 
-func InitHandler(api huma.API, mode types.Mode, authenticator Authenticator) API {
+func InitHandler(api huma.API, mode types.Mode, authenticator Authenticator, rateLimiter RateLimiter) API {
 	if api.OpenAPI().Components == nil {
 		api.OpenAPI().Components = &huma.Components{}
 	}
@@ -33,24 +40,9 @@ func InitHandler(api huma.API, mode types.Mode, authenticator Authenticator) API
 		api.OpenAPI().Components.Extensions = make(map[string]any)
 	}
 
-	// Add rate limit documentation to OpenAPI spec
-	api.OpenAPI().Components.Extensions["x-rate-limiting"] = map[string]any{
-		"public": map[string]any{
-			"description": "Rate limits for public endpoints that don't require authentication",
-			"rate": map[string]any{
-				"requests": 30,
-				"window":   "5 minutes",
-			},
-			"scope": "by IP address",
-		},
-		"private": map[string]any{
-			"description": "Rate limits for authenticated endpoints",
-			"rate": map[string]any{
-				"requests": 300,
-				"window":   "1 minute",
-			},
-			"scope": "by IP address and endpoint",
-		},
+	// Document the rate limit policies in the OpenAPI spec
+	if rateLimiter != nil {
+		api.OpenAPI().Components.Extensions["x-rate-limiting"] = rateLimitingExtension(rateLimiter.Policies())
 	}
 
 	// Initialize responses map if nil
@@ -63,19 +55,16 @@ func InitHandler(api huma.API, mode types.Mode, authenticator Authenticator) API
 		Description: "Too many requests - rate limit exceeded",
 		Content: map[string]*huma.MediaType{
 			"application/json": {
-				Schema: &huma.Schema{
-					Type: "object",
-					Properties: map[string]*huma.Schema{
-						"error": {
-							Type:        "string",
-							Description: "Error message",
-							Examples:    []any{"Rate limit exceeded for API operations"},
-						},
-					},
-				},
+				Schema: api.OpenAPI().Components.Schemas.Schema(reflect.TypeFor[Error](), true, ""),
 			},
 		},
 		Headers: map[string]*huma.Param{
+			"Retry-After": {
+				Description: "The number of seconds to wait before retrying",
+				Schema: &huma.Schema{
+					Type: "integer",
+				},
+			},
 			"X-RateLimit-Limit": {
 				Description: "The number of allowed requests in the current period",
 				Schema: &huma.Schema{
@@ -89,7 +78,7 @@ func InitHandler(api huma.API, mode types.Mode, authenticator Authenticator) API
 				},
 			},
 			"X-RateLimit-Reset": {
-				Description: "The remaining window before the rate limit resets in UTC epoch seconds",
+				Description: "The number of seconds before the rate limit fully resets",
 				Schema: &huma.Schema{
 					Type: "integer",
 				},
@@ -101,6 +90,7 @@ func InitHandler(api huma.API, mode types.Mode, authenticator Authenticator) API
 		API:           api,
 		mode:          mode,
 		authenticator: authenticator,
+		rateLimiter:   rateLimiter,
 	}
 }
 
@@ -133,19 +123,46 @@ func Register[I, O any](api API, op huma.Operation, handler func(context.Context
 		op.Security = append(op.Security, securityAPIKey)
 	}
 
+	// Rate limit once authenticated, so the limit can follow the caller
+	if api.rateLimiter != nil && IsRateLimited(&op) {
+		validateRateLimitPolicies(&op, api.rateLimiter.Policies())
+		if op.Extensions == nil {
+			op.Extensions = make(map[string]any, 1)
+		}
+		op.Extensions["x-rate-limit-policies"] = GetRateLimitPolicies(&op)
+		op.Middlewares = append(op.Middlewares, api.rateLimiter.Limit)
+	}
+
 	huma.Register(api, op, handler)
 }
 
-func WithoutRateLimit() HandlerOption {
+func WithPublish() HandlerOption {
 	return func(op *huma.Operation) {
-		delete(op.Responses, "429")
+		op.Hidden = false
 	}
 }
 
-func WithPublish() HandlerOption {
+// WithImpersonation allows an operation that isn't read-only to be called
+// while impersonating a user, which is otherwise refused.
+func WithImpersonation() HandlerOption {
 	return func(op *huma.Operation) {
-		op.Hidden = false
+		if op.Metadata == nil {
+			op.Metadata = make(map[string]any, 1)
+		}
+		op.Metadata[allowImpersonationKey] = true
+	}
+}
+
+// AllowsImpersonation checks if an operation can be called while impersonating
+// a user. Read-only operations always can.
+func AllowsImpersonation(op *huma.Operation) bool {
+	switch op.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
 	}
+
+	allowed, _ := op.Metadata[allowImpersonationKey].(bool)
+	return allowed
 }
 
 func (a API) WithUnauthenticated() HandlerOption {
@@ -170,6 +187,13 @@ func (a API) WithSecretKey() HandlerOption {
 	}
 }
 
+func (a API) WithSCIMToken() HandlerOption {
+	return func(op *huma.Operation) {
+		op.Middlewares = append(op.Middlewares, a.authenticator.RequireSCIMToken)
+		op.Security = append(op.Security, securitySCIMToken)
+	}
+}
+
 func (a API) WithPermission(resource types.Resource, action types.Action) HandlerOption {
 	return func(op *huma.Operation) {
 		if len(op.Middlewares) == 0 {
@@ -0,0 +1,161 @@
+package httpx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// The operation metadata keys of the rate limit handler options
+const (
+	disableRateLimitKey = "disableRateLimit"
+	rateLimitPolicyKey  = "rateLimitPolicy"
+)
+
+// The names of the rate limit policies
+const (
+	// RateLimitPolicyDefault applies to the operations without a policy
+	RateLimitPolicyDefault = "default"
+
+	// RateLimitPolicyPublic applies to the unauthenticated operations taking
+	// tokens or sending emails
+	RateLimitPolicyPublic = "public"
+
+	// RateLimitPolicySignIn applies to signing in with a password
+	RateLimitPolicySignIn = "sign-in"
+
+	// RateLimitPolicyPayments applies to the payment operations
+	RateLimitPolicyPayments = "payments"
+)
+
+// RateLimitScope is what the requests of a rate limit policy are counted by
+type RateLimitScope string
+
+const (
+	// RateLimitScopeAPIKey counts by the API key of the X-Api-Key header,
+	// even when the operation doesn't authenticate it, or by caller without one
+	RateLimitScopeAPIKey RateLimitScope = "api-key"
+
+	// RateLimitScopeCaller counts by API key or SCIM token, by the real user
+	// of a session, or by client IP when unauthenticated
+	RateLimitScopeCaller RateLimitScope = "caller"
+
+	// RateLimitScopeEmail counts by the email of the JSON request body
+	RateLimitScopeEmail RateLimitScope = "email"
+
+	// RateLimitScopeIP counts by client IP
+	RateLimitScopeIP RateLimitScope = "ip"
+
+	// RateLimitScopeOperation counts each operation separately
+	RateLimitScopeOperation RateLimitScope = "operation"
+)
+
+// rateLimitScopeNames are the names of the scopes in the OpenAPI spec
+var rateLimitScopeNames = map[RateLimitScope]string{
+	RateLimitScopeAPIKey:    "API key",
+	RateLimitScopeCaller:    "API key, user or IP address",
+	RateLimitScopeEmail:     "email",
+	RateLimitScopeIP:        "IP address",
+	RateLimitScopeOperation: "endpoint",
+}
+
+// RateLimitPolicy is a named rate limit, which operations opt into with
+// WithRateLimitPolicy
+type RateLimitPolicy struct {
+	// Description explains what the policy applies to
+	Description string
+
+	// Requests is the number of requests allowed per window
+	Requests int
+
+	// Window is the duration of the sliding window
+	Window time.Duration
+
+	// Scopes are what the requests are counted by, together
+	Scopes []RateLimitScope
+}
+
+// RateLimiter limits the rate of the requests made to the operations
+type RateLimiter interface {
+	// Limit enforces the rate limit policy of the operation of a request. It
+	// runs after authentication, so it can limit the caller of the request.
+	Limit(ctx huma.Context, next func(huma.Context))
+
+	// Policies returns the rate limit policies by name
+	Policies() map[string]RateLimitPolicy
+}
+
+// WithRateLimitPolicy limits an operation with a named rate limit policy,
+// instead of RateLimitPolicyDefault. It can be given several times, for a
+// request to be within every policy.
+func WithRateLimitPolicy(name string) HandlerOption {
+	return func(op *huma.Operation) {
+		if op.Metadata == nil {
+			op.Metadata = make(map[string]any, 1)
+		}
+		names, _ := op.Metadata[rateLimitPolicyKey].([]string)
+		op.Metadata[rateLimitPolicyKey] = append(names, name)
+	}
+}
+
+// WithoutRateLimit exempts an operation from rate limiting
+func WithoutRateLimit() HandlerOption {
+	return func(op *huma.Operation) {
+		delete(op.Responses, "429")
+		if op.Metadata == nil {
+			op.Metadata = make(map[string]any, 1)
+		}
+		op.Metadata[disableRateLimitKey] = true
+	}
+}
+
+// IsRateLimited checks if an operation is rate limited
+func IsRateLimited(op *huma.Operation) bool {
+	disabled, _ := op.Metadata[disableRateLimitKey].(bool)
+	return !disabled
+}
+
+// GetRateLimitPolicies returns the names of the rate limit policies of an
+// operation
+func GetRateLimitPolicies(op *huma.Operation) []string {
+	names, _ := op.Metadata[rateLimitPolicyKey].([]string)
+	if len(names) == 0 {
+		return []string{RateLimitPolicyDefault}
+	}
+	return names
+}
+
+// rateLimitingExtension documents the rate limit policies for the
+// x-rate-limiting OpenAPI extension
+func rateLimitingExtension(policies map[string]RateLimitPolicy) map[string]any {
+	extension := make(map[string]any, len(policies))
+	for name, policy := range policies {
+		scopes := make([]string, 0, len(policy.Scopes))
+		for _, scope := range policy.Scopes {
+			scopes = append(scopes, rateLimitScopeNames[scope])
+		}
+
+		extension[name] = map[string]any{
+			"description": policy.Description,
+			"rate": map[string]any{
+				"requests": policy.Requests,
+				"window":   policy.Window.String(),
+			},
+			"scope": "by " + strings.Join(scopes, " and "),
+		}
+	}
+
+	return extension
+}
+
+// validateRateLimitPolicies panics if an operation uses an unknown rate
+// limit policy, as it would otherwise go unlimited
+func validateRateLimitPolicies(op *huma.Operation, policies map[string]RateLimitPolicy) {
+	for _, name := range GetRateLimitPolicies(op) {
+		if policies[name].Requests == 0 {
+			panic(fmt.Sprintf("httpx: operation %s uses unknown rate limit policy %q", op.OperationID, name))
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package httpx
+
+import (
+	"autopilot/backends/internal/types"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRateLimiter records the rate limit policies of the requests it limits
+type testRateLimiter struct {
+	policies map[string]RateLimitPolicy
+	limited  [][]string
+}
+
+func (l *testRateLimiter) Limit(ctx huma.Context, next func(huma.Context)) {
+	l.limited = append(l.limited, GetRateLimitPolicies(ctx.Operation()))
+	next(ctx)
+}
+
+func (l *testRateLimiter) Policies() map[string]RateLimitPolicy {
+	return l.policies
+}
+
+func TestRateLimitPolicies(t *testing.T) {
+	t.Parallel()
+
+	newAPI := func(t *testing.T) (API, humatest.TestAPI, *testRateLimiter) {
+		_, humaAPI := humatest.New(t)
+		limiter := &testRateLimiter{
+			policies: map[string]RateLimitPolicy{
+				RateLimitPolicyDefault: {
+					Description: "Default",
+					Requests:    300,
+					Window:      time.Minute,
+					Scopes:      []RateLimitScope{RateLimitScopeCaller, RateLimitScopeOperation},
+				},
+				RateLimitPolicySignIn: {
+					Description: "Sign in",
+					Requests:    5,
+					Window:      time.Minute,
+					Scopes:      []RateLimitScope{RateLimitScopeEmail, RateLimitScopeIP},
+				},
+			},
+		}
+		return InitHandler(humaAPI, types.DebugMode, nil, limiter), humaAPI, limiter
+	}
+
+	handler := func(ctx context.Context, _ *struct{}) (*struct{}, error) {
+		return nil, nil
+	}
+
+	t.Run("should document the policies", func(t *testing.T) {
+		t.Parallel()
+		api, _, _ := newAPI(t)
+
+		assert.Equal(t, map[string]any{
+			"default": map[string]any{
+				"description": "Default",
+				"rate":        map[string]any{"requests": 300, "window": "1m0s"},
+				"scope":       "by API key, user or IP address and endpoint",
+			},
+			"sign-in": map[string]any{
+				"description": "Sign in",
+				"rate":        map[string]any{"requests": 5, "window": "1m0s"},
+				"scope":       "by email and IP address",
+			},
+		}, api.OpenAPI().Components.Extensions["x-rate-limiting"])
+	})
+
+	t.Run("should limit operations by their policies", func(t *testing.T) {
+		t.Parallel()
+		api, humaAPI, limiter := newAPI(t)
+
+		Register(api, huma.Operation{OperationID: "get-a", Method: http.MethodGet, Path: "/a"}, handler, api.WithUnauthenticated())
+		Register(api, huma.Operation{OperationID: "sign-in", Method: http.MethodPost, Path: "/sign-in"}, handler,
+			api.WithUnauthenticated(),
+			WithRateLimitPolicy(RateLimitPolicySignIn),
+		)
+		Register(api, huma.Operation{OperationID: "get-b", Method: http.MethodGet, Path: "/b"}, handler,
+			api.WithUnauthenticated(),
+			WithoutRateLimit(),
+		)
+
+		humaAPI.Get("/a")
+		humaAPI.Post("/sign-in")
+		humaAPI.Get("/b")
+		assert.Equal(t, [][]string{{RateLimitPolicyDefault}, {RateLimitPolicySignIn}}, limiter.limited)
+
+		operation := api.OpenAPI().Paths["/sign-in"].Post
+		assert.Equal(t, []string{RateLimitPolicySignIn}, operation.Extensions["x-rate-limit-policies"])
+		assert.Contains(t, operation.Responses, "429")
+		assert.NotContains(t, api.OpenAPI().Paths["/b"].Get.Responses, "429")
+	})
+
+	t.Run("should refuse unknown policies", func(t *testing.T) {
+		t.Parallel()
+		api, _, _ := newAPI(t)
+
+		assert.Panics(t, func() {
+			Register(api, huma.Operation{OperationID: "get-a", Method: http.MethodGet, Path: "/a"}, handler,
+				api.WithUnauthenticated(),
+				WithRateLimitPolicy("unknown"),
+			)
+		})
+	})
+}
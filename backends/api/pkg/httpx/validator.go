@@ -98,11 +98,16 @@ func (m Money) MarshalJSON() ([]byte, error) {
 
 var _ huma.ResolverWithPath = (*Password)(nil)
 
-const MinPasswordLength = 8
+const (
+	MinPasswordLength = 8
 
-// Password is a huma validation type that must be at least 8 characters
-// long and contain at least one uppercase letter, one lowercase letter, one
-// number, and one special character.
+	// MaxPasswordLength is the most bytes bcrypt hashes
+	MaxPasswordLength = 72
+)
+
+// Password is a huma validation type that must be 8 to 72 bytes long and
+// contain at least one uppercase letter, one lowercase letter, one number, and
+// one special character.
 type Password string
 
 // Resolve implements the huma.ResolverWithPath interface.
@@ -110,6 +115,9 @@ func (p Password) Resolve(ctx huma.Context, prefix *huma.PathBuffer) (errors []e
 	if len(p) < MinPasswordLength {
 		errors = append(errors, ErrTooShort.WithLocation(prefix.String()))
 	}
+	if len(p) > MaxPasswordLength {
+		errors = append(errors, ErrTooLong.WithLocation(prefix.String()))
+	}
 
 	var hasUpper, hasLower, hasNumber, hasSpecial bool
 
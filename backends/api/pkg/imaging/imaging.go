@@ -0,0 +1,104 @@
+// Package imaging turns uploaded images into square variants of fixed sizes,
+// re-encoded without the metadata of the original.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+
+	_ "image/jpeg"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// ContentType is the content type of the variants
+const ContentType = "image/png"
+
+// MaxPixels is the largest number of pixels of an image that is decoded, to
+// guard against decompression bombs
+const MaxPixels = 40_000_000
+
+// Sizes are the widths and heights, in pixels, of the variants of an image
+var Sizes = []int{64, 128, 512}
+
+var (
+	// ErrUnsupportedFormat is returned when an image is not a png, jpeg or webp
+	ErrUnsupportedFormat = errors.New("imaging: unsupported image format")
+
+	// ErrTooLarge is returned when an image has more than MaxPixels pixels
+	ErrTooLarge = errors.New("imaging: image is too large")
+)
+
+// Variant is a square variant of an image
+type Variant struct {
+	Size        int
+	ContentType string
+	Data        []byte
+}
+
+// Validate checks that the data is an image in a supported format and not too
+// large to process, without decoding it, and returns its format.
+func Validate(data []byte) (string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", ErrUnsupportedFormat
+	}
+	switch format {
+	case "png", "jpeg", "webp":
+	default:
+		return "", ErrUnsupportedFormat
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return "", ErrUnsupportedFormat
+	}
+	if config.Width*config.Height > MaxPixels {
+		return "", ErrTooLarge
+	}
+
+	return format, nil
+}
+
+// Process decodes an image, turns it upright according to its EXIF
+// orientation, crops it to a centred square and returns it scaled to each of
+// Sizes. Re-encoding drops the EXIF and other metadata of the original.
+func Process(data []byte) ([]*Variant, error) {
+	format, err := Validate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if format == "jpeg" {
+		img = orient(img, orientation(data))
+	}
+
+	crop := square(img.Bounds())
+	variants := make([]*Variant, 0, len(Sizes))
+	for _, size := range Sizes {
+		dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, dst); err != nil {
+			return nil, err
+		}
+		variants = append(variants, &Variant{Size: size, ContentType: ContentType, Data: buf.Bytes()})
+	}
+
+	return variants, nil
+}
+
+// square returns the largest square centred in the bounds
+func square(bounds image.Rectangle) image.Rectangle {
+	side := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	return image.Rect(x, y, x+side, y+side)
+}
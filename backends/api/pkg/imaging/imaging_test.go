@@ -0,0 +1,136 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+// encodeJPEG encodes a jpeg with an EXIF segment holding the orientation
+func encodeJPEG(t *testing.T, orientation uint16) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 2)), nil))
+	data := buf.Bytes()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	tiff = binary.BigEndian.AppendUint16(tiff, orientationTag)
+	tiff = append(tiff, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	return append(append(data[:2:2], app1...), data[2:]...)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	var gifData bytes.Buffer
+	require.NoError(t, gif.Encode(&gifData, image.NewPaletted(image.Rect(0, 0, 1, 1), []color.Color{color.Black}), nil))
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr error
+	}{
+		{name: "should accept a png", data: encodePNG(t, 10, 10), want: "png"},
+		{name: "should accept a jpeg", data: encodeJPEG(t, 1), want: "jpeg"},
+		{name: "should reject a gif", data: gifData.Bytes(), wantErr: ErrUnsupportedFormat},
+		{name: "should reject data that is not an image", data: []byte("not an image"), wantErr: ErrUnsupportedFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Validate(tt.data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	t.Parallel()
+
+	variants, err := Process(encodePNG(t, 300, 200))
+	require.NoError(t, err)
+	require.Len(t, variants, len(Sizes))
+
+	for i, variant := range variants {
+		assert.Equal(t, Sizes[i], variant.Size)
+		assert.Equal(t, ContentType, variant.ContentType)
+
+		config, format, err := image.DecodeConfig(bytes.NewReader(variant.Data))
+		require.NoError(t, err)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, variant.Size, config.Width)
+		assert.Equal(t, variant.Size, config.Height)
+	}
+}
+
+func TestOrientation(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 6, orientation(encodeJPEG(t, 6)))
+	assert.Equal(t, 1, orientation(encodeJPEG(t, 9)), "should ignore an invalid orientation")
+	assert.Equal(t, 1, orientation(encodePNG(t, 1, 1)), "should ignore data that is not a jpeg")
+}
+
+func TestOrient(t *testing.T) {
+	t.Parallel()
+
+	// A 2x1 image with a red pixel on the left
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+
+	tests := []struct {
+		name        string
+		orientation int
+		wantBounds  image.Rectangle
+		wantRed     image.Point
+	}{
+		{name: "should leave an upright image", orientation: 1, wantBounds: image.Rect(0, 0, 2, 1), wantRed: image.Pt(0, 0)},
+		{name: "should flip horizontally", orientation: 2, wantBounds: image.Rect(0, 0, 2, 1), wantRed: image.Pt(1, 0)},
+		{name: "should rotate clockwise", orientation: 6, wantBounds: image.Rect(0, 0, 1, 2), wantRed: image.Pt(0, 0)},
+		{name: "should rotate counter-clockwise", orientation: 8, wantBounds: image.Rect(0, 0, 1, 2), wantRed: image.Pt(0, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := orient(img, tt.orientation)
+			assert.Equal(t, tt.wantBounds, got.Bounds())
+			r, _, _, _ := got.At(tt.wantRed.X, tt.wantRed.Y).RGBA()
+			assert.Equal(t, uint32(0xFFFF), r)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+const (
+	// CFConnectingIPHeader is the header Cloudflare sets to the IP of the client
+	CFConnectingIPHeader = "CF-Connecting-IP"
+
+	// TrustedProxyCloudflare is the trusted proxy entry standing for the Cloudflare ranges
+	TrustedProxyCloudflare = "cloudflare"
+)
+
+// cloudflareRanges are the address ranges of Cloudflare, from https://www.cloudflare.com/ips/
+var cloudflareRanges = []string{
+	"173.245.48.0/20",
+	"103.21.244.0/22",
+	"103.22.200.0/22",
+	"103.31.4.0/22",
+	"141.101.64.0/18",
+	"108.162.192.0/18",
+	"190.93.240.0/20",
+	"188.114.96.0/20",
+	"197.234.240.0/22",
+	"198.41.128.0/17",
+	"162.158.0.0/15",
+	"104.16.0.0/13",
+	"104.24.0.0/14",
+	"172.64.0.0/13",
+	"131.0.72.0/22",
+	"2400:cb00::/32",
+	"2606:4700::/32",
+	"2803:f800::/32",
+	"2405:b500::/32",
+	"2405:8100::/32",
+	"2a06:98c0::/29",
+	"2c0f:f248::/32",
+}
+
+// ClientIPResolver resolves the IP address of the client behind the trusted
+// proxies a request passed through.
+type ClientIPResolver struct {
+	// cloudflare are the Cloudflare ranges, if they are trusted
+	cloudflare []netip.Prefix
+
+	// trusted are the ranges of the trusted proxies, including Cloudflare's
+	trusted []netip.Prefix
+}
+
+// NewClientIPResolver creates a ClientIPResolver trusting the proxies in the
+// given CIDR ranges or at the given addresses. The "cloudflare" entry trusts
+// the Cloudflare ranges. Without trusted proxies, the peer address is the client.
+func NewClientIPResolver(trustedProxies []string) (*ClientIPResolver, error) {
+	resolver := &ClientIPResolver{}
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		switch {
+		case proxy == "":
+			continue
+		case strings.EqualFold(proxy, TrustedProxyCloudflare):
+			for _, cidr := range cloudflareRanges {
+				resolver.cloudflare = append(resolver.cloudflare, netip.MustParsePrefix(cidr))
+			}
+			resolver.trusted = append(resolver.trusted, resolver.cloudflare...)
+		case strings.Contains(proxy, "/"):
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			resolver.trusted = append(resolver.trusted, prefix.Masked())
+		default:
+			addr, err := netip.ParseAddr(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			addr = addr.Unmap()
+			resolver.trusted = append(resolver.trusted, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+
+	return resolver, nil
+}
+
+// ClientIP returns the IP address of the client. Starting from the peer, it
+// walks the X-Forwarded-For hops from the right while they are trusted proxies,
+// and returns the first untrusted one, as anything further left can be made up
+// by the client. A CF-Connecting-IP header is only used when it reaches the
+// walk through a Cloudflare proxy and the Cloudflare ranges are trusted.
+func (c *ClientIPResolver) ClientIP(remoteAddr, forwardedFor, cfConnectingIP string) string {
+	clientIP, _ := c.resolve(remoteAddr, forwardedFor, cfConnectingIP)
+	return clientIP
+}
+
+// resolve returns the IP address of the client like ClientIP, and whether the
+// request came through a trusted Cloudflare proxy, which makes the headers
+// Cloudflare sets trustworthy.
+func (c *ClientIPResolver) resolve(remoteAddr, forwardedFor, cfConnectingIP string) (string, bool) {
+	addr, ok := parseIP(remoteAddr)
+	if !ok {
+		return remoteAddr, false
+	}
+
+	var hops []string
+	if forwardedFor != "" {
+		hops = strings.Split(forwardedFor, ",")
+	}
+
+	cloudflare := false
+	for i := len(hops); c.contains(c.trusted, addr); i-- {
+		if c.contains(c.cloudflare, addr) {
+			cloudflare = true
+			if client, ok := parseIP(cfConnectingIP); ok {
+				return client.String(), true
+			}
+		}
+
+		if i == 0 {
+			break
+		}
+
+		// A malformed hop can't be followed, so the last proxy stands in for the client
+		hop, ok := parseIP(hops[i-1])
+		if !ok {
+			break
+		}
+		addr = hop
+	}
+
+	return addr.String(), cloudflare
+}
+
+// contains checks if any of the ranges contains the address
+func (c *ClientIPResolver) contains(ranges []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range ranges {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIP parses an IP address, which may carry a port
+func parseIP(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+
+	return addr.Unmap(), true
+}
+
+// WithClientIP is a middleware that replaces the remote address of the request
+// with the IP address of the client, as resolved by the resolver. It runs
+// first, so the rate limiters, the request metadata and the logs all use the
+// same address. The CF-IPCountry header is dropped unless the request came
+// through a trusted Cloudflare proxy, as the client could make it up.
+func WithClientIP(resolver *ClientIPResolver) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientIP, cloudflare := resolver.resolve(r.RemoteAddr, strings.Join(r.Header.Values("X-Forwarded-For"), ","), r.Header.Get(CFConnectingIPHeader))
+			if !cloudflare {
+				r.Header.Del(CFCountryHeader)
+			}
+
+			r.RemoteAddr = clientIP
+			next.ServeHTTP(w, r)
+		})
+	}
+}
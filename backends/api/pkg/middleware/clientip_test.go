@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIPResolver(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		cfConnectingIP string
+		want           string
+	}{
+		{
+			name:         "should ignore X-Forwarded-For without trusted proxies",
+			remoteAddr:   "198.51.100.7:1234",
+			forwardedFor: "203.0.113.1",
+			want:         "198.51.100.7",
+		},
+		{
+			name:           "should ignore X-Forwarded-For from an untrusted peer",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "198.51.100.7:1234",
+			forwardedFor:   "203.0.113.1",
+			want:           "198.51.100.7",
+		},
+		{
+			name:           "should take the right-most untrusted hop",
+			trustedProxies: []string{"10.0.0.0/8", "192.0.2.10"},
+			remoteAddr:     "10.0.0.2:1234",
+			forwardedFor:   "203.0.113.99, 198.51.100.7, 192.0.2.10",
+			want:           "198.51.100.7",
+		},
+		{
+			name:           "should take the left-most hop when every hop is trusted",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:1234",
+			forwardedFor:   "10.0.0.3",
+			want:           "10.0.0.3",
+		},
+		{
+			name:           "should stop at a malformed hop",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:1234",
+			forwardedFor:   "203.0.113.1, unknown",
+			want:           "10.0.0.2",
+		},
+		{
+			name:           "should use CF-Connecting-IP from a trusted Cloudflare proxy",
+			trustedProxies: []string{"cloudflare"},
+			remoteAddr:     "172.64.0.1:1234",
+			forwardedFor:   "198.51.100.7",
+			cfConnectingIP: "203.0.113.1",
+			want:           "203.0.113.1",
+		},
+		{
+			name:           "should ignore CF-Connecting-IP unless Cloudflare is trusted",
+			trustedProxies: []string{"172.64.0.0/13"},
+			remoteAddr:     "172.64.0.1:1234",
+			forwardedFor:   "198.51.100.7",
+			cfConnectingIP: "203.0.113.1",
+			want:           "198.51.100.7",
+		},
+		{
+			name:           "should ignore CF-Connecting-IP from a direct client",
+			trustedProxies: []string{"cloudflare"},
+			remoteAddr:     "198.51.100.7:1234",
+			cfConnectingIP: "203.0.113.1",
+			want:           "198.51.100.7",
+		},
+		{
+			name:       "should unmap IPv4-mapped IPv6 addresses",
+			remoteAddr: "[::ffff:198.51.100.7]:1234",
+			want:       "198.51.100.7",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			resolver, err := NewClientIPResolver(tc.trustedProxies)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, resolver.ClientIP(tc.remoteAddr, tc.forwardedFor, tc.cfConnectingIP))
+		})
+	}
+
+	t.Run("should reject invalid trusted proxies", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewClientIPResolver([]string{"10.0.0.0/33"})
+		assert.Error(t, err)
+	})
+}
+
+func TestWithClientIP(t *testing.T) {
+	t.Parallel()
+
+	resolver, err := NewClientIPResolver([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	var got string
+	handler := WithClientIP(resolver)(WithRequestMetadata()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = GetRequestMetadata(r.Context()).IPAddress
+	})))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	req.Header.Add("X-Forwarded-For", "203.0.113.99")
+	req.Header.Add("X-Forwarded-For", "198.51.100.7")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "198.51.100.7", got)
+	assert.Equal(t, "198.51.100.7", req.RemoteAddr)
+}
+
+func TestWithClientIPCountry(t *testing.T) {
+	t.Parallel()
+
+	resolver, err := NewClientIPResolver([]string{TrustedProxyCloudflare, "10.0.0.0/8"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		wantCountry  string
+	}{
+		{
+			name:        "should keep the country set by Cloudflare",
+			remoteAddr:  "173.245.48.1:1234",
+			wantCountry: "SG",
+		},
+		{
+			name:         "should keep the country set by Cloudflare behind a trusted proxy",
+			remoteAddr:   "10.0.0.2:1234",
+			forwardedFor: "173.245.48.1",
+			wantCountry:  "SG",
+		},
+		{
+			name:       "should drop the country of a direct request",
+			remoteAddr: "198.51.100.7:1234",
+		},
+		{
+			name:         "should drop the country of a request through another trusted proxy",
+			remoteAddr:   "10.0.0.2:1234",
+			forwardedFor: "198.51.100.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			handler := WithClientIP(resolver)(WithRequestMetadata()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = GetRequestMetadata(r.Context()).Country
+			})))
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			req.Header.Set(CFCountryHeader, "SG")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.wantCountry, got)
+		})
+	}
+}
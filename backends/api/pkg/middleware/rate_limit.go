@@ -2,190 +2,468 @@ package middleware
 
 import (
 	"autopilot/backends/api/pkg/app"
-	"fmt"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/core"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/go-chi/httprate"
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/redis/go-redis/v9"
 )
 
-// RateLimitConfig holds rate limit configuration for different endpoints
+// RateLimitConfig holds the rate limit policies of the requests
 type RateLimitConfig struct {
-	// Rate limits for unauthenticated endpoints (sign-in, sign-up, etc)
-	Public struct {
-		Requests int
-		Window   time.Duration
-	}
+	// IP is the rate limit of every request by client IP, counted by
+	// WithRateLimit before authentication, so that it also covers the
+	// requests failing to authenticate and the routes outside of the API
+	IP httpx.RateLimitPolicy
 
-	// Rate limits for authenticated endpoints (all operations requiring auth)
-	Private struct {
-		Requests int
-		Window   time.Duration
-	}
+	// Policies are the rate limit policies by name. Operations are limited by
+	// httpx.RateLimitPolicyDefault unless registered with another one through
+	// httpx.WithRateLimitPolicy.
+	Policies map[string]httpx.RateLimitPolicy
 }
 
 // DefaultRateLimitConfig returns the default rate limit configuration
 func DefaultRateLimitConfig() RateLimitConfig {
-	cfg := RateLimitConfig{}
+	return RateLimitConfig{
+		IP: httpx.RateLimitPolicy{
+			Description: "Rate limits for every request of a client IP, before authentication",
+			Requests:    6000,
+			Window:      time.Minute,
+			Scopes:      []httpx.RateLimitScope{httpx.RateLimitScopeIP},
+		},
+		Policies: map[string]httpx.RateLimitPolicy{
+			httpx.RateLimitPolicyDefault: {
+				Description: "Rate limits for the endpoints without a specific policy",
+				Requests:    300,
+				Window:      time.Minute,
+				Scopes:      []httpx.RateLimitScope{httpx.RateLimitScopeCaller, httpx.RateLimitScopeOperation},
+			},
+			httpx.RateLimitPolicyPublic: {
+				Description: "Rate limits for the public endpoints taking tokens or sending emails, shared between them",
+				Requests:    30,
+				Window:      5 * time.Minute,
+				Scopes:      []httpx.RateLimitScope{httpx.RateLimitScopeIP},
+			},
+			httpx.RateLimitPolicySignIn: {
+				Description: "Rate limits for signing in with a password",
+				Requests:    5,
+				Window:      time.Minute,
+				Scopes:      []httpx.RateLimitScope{httpx.RateLimitScopeEmail, httpx.RateLimitScopeIP},
+			},
+			httpx.RateLimitPolicyPayments: {
+				Description: "Rate limits for the payment endpoints, shared between them",
+				Requests:    100,
+				Window:      time.Second,
+				Scopes:      []httpx.RateLimitScope{httpx.RateLimitScopeAPIKey},
+			},
+		},
+	}
+}
+
+// rateLimitSweepInterval is the number of requests between sweeps of the
+// replenished keys of a memoryRateLimitStore
+const rateLimitSweepInterval = 1000
+
+// rateLimitMaxBodyBytes is the size limit of the bodies read for an email,
+// when the operation doesn't set one, matching the default of huma
+const rateLimitMaxBodyBytes = 1024 * 1024
+
+// rateLimitScript implements the generic cell rate algorithm (GCRA) for
+// Valkey. A key stores the theoretical arrival time (TAT) of its next request
+// in milliseconds, which moves forward by window/limit with each allowed
+// request. A request is denied when it would move the TAT more than a window
+// ahead of now, so at most limit requests fit in any sliding window. The
+// server clock is used, so the instances of the API agree on the time. A
+// request is only checked, without moving the TAT, unless ARGV[3] is 1.
+//
+// It returns whether the request is allowed, the number of remaining
+// requests, and the milliseconds before a request is allowed and before the
+// limit fully resets.
+var rateLimitScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local consume = ARGV[3] == '1'
+local interval = window / limit
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + tonumber(time[2]) / 1000
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+
+local next_tat = tat + interval
+if next_tat - now > window then
+	return {0, 0, math.ceil(next_tat - window - now), math.ceil(tat - now)}
+end
+
+if consume then
+	redis.call('SET', KEYS[1], string.format('%.3f', next_tat), 'PX', math.ceil(next_tat - now))
+end
+return {1, math.floor((window - (next_tat - now)) / interval), 0, math.ceil(next_tat - now)}
+`)
+
+// rateLimitResult is the outcome of counting a request against a rate limit
+type rateLimitResult struct {
+	// Allowed reports whether the request is within the limit
+	Allowed bool
+
+	// Remaining is the number of requests left in the window
+	Remaining int
 
-	// Public endpoints (unauthenticated) - Stricter limits to prevent abuse
-	cfg.Public = struct {
-		Requests int
-		Window   time.Duration
-	}{
-		Requests: 30,              // 30 requests
-		Window:   5 * time.Minute, // per 5 minutes
+	// RetryAfter is the time before a request is allowed again, when denied
+	RetryAfter time.Duration
+
+	// Reset is the time before the limit fully resets
+	Reset time.Duration
+}
+
+// rateLimitStore counts the requests made under the rate limit keys
+type rateLimitStore interface {
+	// allow counts a request under a key, allowing limit requests per window.
+	// Unless consume is set, the request is only checked and not counted.
+	allow(ctx context.Context, key string, limit int, window time.Duration, consume bool) (rateLimitResult, error)
+}
+
+// valkeyRateLimitStore counts requests in Valkey, so that the limits are
+// shared by the instances of the API
+type valkeyRateLimitStore struct {
+	client redis.UniversalClient
+}
+
+// allow implements rateLimitStore.allow
+func (s *valkeyRateLimitStore) allow(ctx context.Context, key string, limit int, window time.Duration, consume bool) (rateLimitResult, error) {
+	flag := 0
+	if consume {
+		flag = 1
 	}
 
-	// Private endpoints (authenticated) - More generous limits for legitimate operations
-	cfg.Private = struct {
-		Requests int
-		Window   time.Duration
-	}{
-		Requests: 300,         // 300 requests
-		Window:   time.Minute, // per minute
+	values, err := rateLimitScript.Run(ctx, s.client, []string{key}, limit, window.Milliseconds(), flag).Int64Slice()
+	if err != nil {
+		return rateLimitResult{}, err
 	}
 
-	return cfg
+	return rateLimitResult{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		Reset:      time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
+
+// memoryRateLimitStore counts requests in the memory of the process with the
+// same algorithm as rateLimitScript, for when Valkey is unavailable
+type memoryRateLimitStore struct {
+	mu       sync.Mutex
+	tats     map[string]time.Time
+	requests int
+	now      func() time.Time
+}
+
+// newMemoryRateLimitStore creates a new memoryRateLimitStore instance
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	return &memoryRateLimitStore{
+		tats: make(map[string]time.Time),
+		now:  time.Now,
+	}
 }
 
-// ClusterRateLimit implements a rate limiting middleware that works with Valkey cluster
-func ClusterRateLimit(container *app.Container, client redis.UniversalClient, limit int, window time.Duration) func(http.Handler) http.Handler {
+// allow implements rateLimitStore.allow
+func (s *memoryRateLimitStore) allow(ctx context.Context, key string, limit int, window time.Duration, consume bool) (rateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.requests++
+	if s.requests%rateLimitSweepInterval == 0 {
+		for key, tat := range s.tats {
+			if !tat.After(now) {
+				delete(s.tats, key)
+			}
+		}
+	}
+
+	interval := window / time.Duration(limit)
+	tat, ok := s.tats[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	next := tat.Add(interval)
+	if next.Sub(now) > window {
+		return rateLimitResult{
+			RetryAfter: next.Add(-window).Sub(now),
+			Reset:      tat.Sub(now),
+		}, nil
+	}
+
+	if consume {
+		s.tats[key] = next
+	}
+	return rateLimitResult{
+		Allowed:   true,
+		Remaining: int((window - next.Sub(now)) / interval),
+		Reset:     next.Sub(now),
+	}, nil
+}
+
+// RateLimiter implements httpx.RateLimiter, limiting each operation by the
+// policy it was registered with
+type RateLimiter struct {
+	api    huma.API
+	config RateLimitConfig
+	logger *core.Logger
+	store  rateLimitStore
+}
+
+// NewRateLimiter creates a new RateLimiter instance counting requests in the
+// rate limiter Valkey of the container, or in memory if it is unavailable
+func NewRateLimiter(container *app.Container, api huma.API, config RateLimitConfig) *RateLimiter {
+	if container.RateLimiter == nil {
+		container.Logger.Warn("Valkey client unavailable for rate limiting, falling back to in-memory implementation")
+	}
+
+	return &RateLimiter{
+		api:    api,
+		config: config,
+		logger: container.Logger,
+		store:  newRateLimitStore(container),
+	}
+}
+
+// newRateLimitStore returns the rateLimitStore of the rate limiter Valkey of
+// the container, or a memoryRateLimitStore if it is unavailable
+func newRateLimitStore(container *app.Container) rateLimitStore {
+	if container.RateLimiter != nil {
+		return &valkeyRateLimitStore{client: container.RateLimiter}
+	}
+
+	return newMemoryRateLimitStore()
+}
+
+// WithRateLimit limits every request by its client IP with the IP policy of
+// the config, before the operations authenticate and apply their own
+// policies. It must come after WithClientIP.
+func WithRateLimit(container *app.Container, config RateLimitConfig) func(next http.Handler) http.Handler {
+	store := newRateLimitStore(container)
+	policy := config.IP
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Create a key from IP and path
-			ip := r.RemoteAddr
-			if fwdFor := r.Header.Get("X-Forwarded-For"); fwdFor != "" {
-				ip = fwdFor
+			if policy.Requests <= 0 {
+				next.ServeHTTP(w, r)
+				return
 			}
-			endpoint := r.URL.Path
-			key := fmt.Sprintf("ratelimit:%s:%s", ip, endpoint)
 
-			// Use Valkey for counting
-			val, err := client.Incr(r.Context(), key).Result()
+			result, err := store.allow(r.Context(), "ratelimit:ip:"+r.RemoteAddr, policy.Requests, policy.Window, true)
 			if err != nil {
-				// Fall back to allowing the request if Valkey fails
+				// Fall back to allowing the request if the store fails
+				container.Logger.Warn("Failed to check rate limit", "policy", "ip", "error", err)
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			// Set expiry on the key
-			if val == 1 {
-				client.Expire(r.Context(), key, window)
-			}
-
-			// Get TTL for the remaining time header
-			ttl, err := client.TTL(r.Context(), key).Result()
-			if err != nil {
-				ttl = window
-			}
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(policy.Requests))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
 
-			// If we've exceeded the limit, return 429
-			if val > int64(limit) {
-				w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
-				w.Header().Set("X-RateLimit-Remaining", "0")
-				w.Header().Set("X-RateLimit-Reset", strconv.Itoa(int(ttl.Seconds())))
-				w.Header().Set("Retry-After", strconv.Itoa(int(ttl.Seconds())))
-				http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_ = json.NewEncoder(w).Encode(huma.NewError(http.StatusTooManyRequests, "Rate limit exceeded", httpx.ErrRateLimitExceeded))
 				return
 			}
 
-			// Set rate limit headers
-			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
-			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(int64(limit)-val)))
-			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(int(ttl.Seconds())))
-
 			next.ServeHTTP(w, r)
 		})
 	}
 }
 
-// isPublicEndpoint determines if the given path is a public authentication endpoint
-func isPublicEndpoint(path string) bool {
-	return path == "/v1/identity/sign-in" ||
-		path == "/v1/identity/sign-up" ||
-		path == "/v1/identity/forgot-password" ||
-		path == "/v1/identity/reset-password" ||
-		path == "/v1/identity/verify-email" ||
-		path == "/v1/identity/verify-two-factor"
+// Policies implements httpx.RateLimiter.Policies
+func (l *RateLimiter) Policies() map[string]httpx.RateLimitPolicy {
+	return l.config.Policies
 }
 
-// WithMemoryRateLimit creates a rate limiter using in-memory storage via httprate
-func WithMemoryRateLimit(config RateLimitConfig) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			var limiter func(http.Handler) http.Handler
-			path := r.URL.Path
-
-			// Apply appropriate rate limits based on endpoint type
-			if isPublicEndpoint(path) {
-				limiter = httprate.Limit(
-					config.Public.Requests,
-					config.Public.Window,
-					httprate.WithKeyFuncs(httprate.KeyByIP),
-					httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
-						http.Error(w, "Rate limit exceeded for authentication operations", http.StatusTooManyRequests)
-					}),
-				)
-			} else {
-				// All other endpoints use private rate limits
-				limiter = httprate.Limit(
-					config.Private.Requests,
-					config.Private.Window,
-					httprate.WithKeyFuncs(httprate.KeyByIP, httprate.KeyByEndpoint),
-					httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
-						http.Error(w, "Rate limit exceeded for API operations", http.StatusTooManyRequests)
-					}),
-				)
+// Limit implements httpx.RateLimiter.Limit. A request is checked against
+// each policy of its operation before it is counted against any, so that a
+// request denied by one policy doesn't use up the others, and the headers
+// describe the closest one to its limit.
+func (l *RateLimiter) Limit(ctx huma.Context, next func(huma.Context)) {
+	type rateLimitCheck struct {
+		name   string
+		key    string
+		policy httpx.RateLimitPolicy
+	}
+
+	var checks []rateLimitCheck
+	for _, name := range httpx.GetRateLimitPolicies(ctx.Operation()) {
+		policy, ok := l.config.Policies[name]
+		if !ok {
+			l.logger.Error("Unknown rate limit policy", "policy", name, "operation", ctx.Operation().OperationID)
+			continue
+		}
+
+		key := "ratelimit:" + name
+		for _, scope := range policy.Scopes {
+			var part string
+			part, ctx = rateLimitScopeKey(ctx, scope)
+			key += ":" + part
+		}
+		checks = append(checks, rateLimitCheck{name: name, key: key, policy: policy})
+	}
+
+	var closest *rateLimitResult
+	var closestPolicy httpx.RateLimitPolicy
+	for _, consume := range []bool{false, true} {
+		closest = nil
+		for _, check := range checks {
+			result, err := l.store.allow(ctx.Context(), check.key, check.policy.Requests, check.policy.Window, consume)
+			if err != nil {
+				// Fall back to allowing the request if the store fails
+				l.logger.Warn("Failed to check rate limit", "policy", check.name, "error", err)
+				continue
 			}
 
-			// Apply the rate limiter
-			limiter(next).ServeHTTP(w, r)
-		})
+			if closest == nil || !result.Allowed || result.Remaining < closest.Remaining {
+				closest, closestPolicy = &result, check.policy
+			}
+			if !result.Allowed {
+				break
+			}
+		}
+
+		if closest != nil && !closest.Allowed {
+			break
+		}
+	}
+
+	if closest == nil {
+		next(ctx)
+		return
+	}
+
+	ctx.SetHeader("X-RateLimit-Limit", strconv.Itoa(closestPolicy.Requests))
+	ctx.SetHeader("X-RateLimit-Remaining", strconv.Itoa(closest.Remaining))
+	ctx.SetHeader("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(closest.Reset)))
+
+	if !closest.Allowed {
+		ctx.SetHeader("Retry-After", strconv.Itoa(ceilSeconds(closest.RetryAfter)))
+		_ = huma.WriteErr(l.api, ctx, http.StatusTooManyRequests, "Rate limit exceeded", httpx.ErrRateLimitExceeded)
+		return
 	}
+
+	next(ctx)
 }
 
-// WithClusterRateLimit creates a rate limiter using Redis/Valkey for distributed rate limiting
-func WithClusterRateLimit(container *app.Container, client redis.UniversalClient, config RateLimitConfig) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			path := r.URL.Path
-
-			// Apply appropriate rate limits based on endpoint type
-			if isPublicEndpoint(path) {
-				ClusterRateLimit(
-					container,
-					client,
-					config.Public.Requests,
-					config.Public.Window,
-				)(next).ServeHTTP(w, r)
-			} else {
-				// All other endpoints use private rate limits
-				ClusterRateLimit(
-					container,
-					client,
-					config.Private.Requests,
-					config.Private.Window,
-				)(next).ServeHTTP(w, r)
-			}
-		})
+// rateLimitScopeKey returns the part of the rate limit key of a request for a
+// scope. Reading the email consumes the body, so the returned context replays
+// it for the handler.
+func rateLimitScopeKey(ctx huma.Context, scope httpx.RateLimitScope) (string, huma.Context) {
+	switch scope {
+	case httpx.RateLimitScopeAPIKey:
+		return rateLimitAPIKey(ctx), ctx
+	case httpx.RateLimitScopeCaller:
+		return rateLimitSubject(ctx), ctx
+	case httpx.RateLimitScopeEmail:
+		email, ctx := rateLimitEmail(ctx)
+		return "email:" + email, ctx
+	case httpx.RateLimitScopeIP:
+		return "ip:" + ctx.RemoteAddr(), ctx
+	case httpx.RateLimitScopeOperation:
+		return "op:" + ctx.Operation().OperationID, ctx
+	default:
+		return string(scope), ctx
 	}
 }
 
-// WithRateLimit adds rate limiting middleware to the handler
-func WithRateLimit(container *app.Container, config RateLimitConfig) func(http.Handler) http.Handler {
-	// Use RateLimiter Valkey client from container
-	valkeyClient := container.RateLimiter
+// rateLimitEmail returns the hash of the normalized email of the JSON body of
+// a request, so that emails aren't stored in the rate limiter, along with a
+// context replaying the body. Bodies over the size limit of the operation
+// are left for the handler to refuse.
+func rateLimitEmail(ctx huma.Context) (string, huma.Context) {
+	maxBytes := ctx.Operation().MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = rateLimitMaxBodyBytes
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(ctx.BodyReader(), maxBytes))
+	ctx = &replayBodyContext{
+		humaContext: ctx,
+		body:        io.MultiReader(bytes.NewReader(data), ctx.BodyReader()),
+	}
 
-	// Choose appropriate rate limiting strategy based on available resources
-	if valkeyClient == nil {
-		// Log that we're falling back to memory-based rate limiting
-		container.Logger.Warn("Valkey client unavailable for rate limiting, falling back to in-memory implementation")
-		return WithMemoryRateLimit(config)
+	var body struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil || body.Email == "" {
+		return "", ctx
+	}
+
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(body.Email))))
+	return hex.EncodeToString(hash[:16]), ctx
+}
+
+// humaContext lets replayBodyContext embed a huma.Context, whose Context
+// method would otherwise clash with the name of the field
+type humaContext huma.Context
+
+// replayBodyContext is a huma.Context whose body was read and is replayed
+type replayBodyContext struct {
+	humaContext
+	body io.Reader
+}
+
+// BodyReader implements huma.Context.BodyReader
+func (c *replayBodyContext) BodyReader() io.Reader {
+	return c.body
+}
+
+// rateLimitSubject returns who a request is counted against: the API key or
+// SCIM token it authenticated with, the real user behind its session, or its
+// client IP as resolved by WithClientIP
+func rateLimitSubject(ctx huma.Context) string {
+	auth := httpx.GetAuthInfo(ctx.Context())
+	switch {
+	case auth.APIKeyUsed && auth.CredentialID != "":
+		return "key:" + auth.CredentialID
+	case auth.IsImpersonating():
+		return "user:" + auth.ImpersonatorID
+	case auth.UserID != "":
+		return "user:" + auth.UserID
+	default:
+		return "ip:" + ctx.RemoteAddr()
+	}
+}
+
+// rateLimitAPIKey returns the hash of the API key of the X-Api-Key header of
+// a request, so that keys aren't stored in the rate limiter, or its subject
+// without one. The key isn't verified, which the per IP limit of
+// WithRateLimit makes up for.
+func rateLimitAPIKey(ctx huma.Context) string {
+	key := ctx.Header("X-Api-Key")
+	if key == "" {
+		return rateLimitSubject(ctx)
 	}
 
-	// Use our cluster-compatible implementation
-	return WithClusterRateLimit(container, valkeyClient, config)
+	hash := sha256.Sum256([]byte(key))
+	return "api-key:" + hex.EncodeToString(hash[:16])
+}
+
+// ceilSeconds returns a duration in whole seconds, rounded up
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
 }
@@ -0,0 +1,339 @@
+package middleware
+
+import (
+	"autopilot/backends/api/pkg/app"
+	"autopilot/backends/api/pkg/httpx"
+	"autopilot/backends/internal/core"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("allows limit requests per window", func(t *testing.T) {
+		store := newMemoryRateLimitStore()
+		store.now = func() time.Time { return start }
+
+		for i := range 3 {
+			result, err := store.allow(ctx, "key", 3, time.Minute, true)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 2-i, result.Remaining)
+		}
+
+		result, err := store.allow(ctx, "key", 3, time.Minute, true)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 20*time.Second, result.RetryAfter)
+		assert.Equal(t, time.Minute, result.Reset)
+	})
+
+	t.Run("replenishes requests as the window slides", func(t *testing.T) {
+		store := newMemoryRateLimitStore()
+		now := start
+		store.now = func() time.Time { return now }
+
+		for range 3 {
+			_, err := store.allow(ctx, "key", 3, time.Minute, true)
+			require.NoError(t, err)
+		}
+
+		now = start.Add(20 * time.Second)
+		result, err := store.allow(ctx, "key", 3, time.Minute, true)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+
+		result, err = store.allow(ctx, "key", 3, time.Minute, true)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+	})
+
+	t.Run("limits keys separately", func(t *testing.T) {
+		store := newMemoryRateLimitStore()
+		store.now = func() time.Time { return start }
+
+		result, err := store.allow(ctx, "a", 1, time.Minute, true)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		result, err = store.allow(ctx, "b", 1, time.Minute, true)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("checks requests without counting them", func(t *testing.T) {
+		store := newMemoryRateLimitStore()
+		store.now = func() time.Time { return start }
+
+		for range 2 {
+			result, err := store.allow(ctx, "key", 1, time.Minute, false)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+		}
+
+		result, err := store.allow(ctx, "key", 1, time.Minute, true)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		result, err = store.allow(ctx, "key", 1, time.Minute, false)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	config := RateLimitConfig{
+		Policies: map[string]httpx.RateLimitPolicy{
+			httpx.RateLimitPolicyDefault: {
+				Requests: 2,
+				Window:   time.Minute,
+				Scopes:   []httpx.RateLimitScope{httpx.RateLimitScopeCaller, httpx.RateLimitScopeOperation},
+			},
+			httpx.RateLimitPolicyPublic: {
+				Requests: 3,
+				Window:   time.Minute,
+				Scopes:   []httpx.RateLimitScope{httpx.RateLimitScopeIP},
+			},
+			httpx.RateLimitPolicySignIn: {
+				Requests: 1,
+				Window:   time.Minute,
+				Scopes:   []httpx.RateLimitScope{httpx.RateLimitScopeEmail, httpx.RateLimitScopeIP},
+			},
+			httpx.RateLimitPolicyPayments: {
+				Requests: 1,
+				Window:   time.Minute,
+				Scopes:   []httpx.RateLimitScope{httpx.RateLimitScopeAPIKey},
+			},
+		},
+	}
+
+	type signInInput struct {
+		Body struct {
+			Email string `json:"email"`
+		}
+	}
+
+	newAPI := func(t *testing.T, auth httpx.AuthInfo) (humatest.TestAPI, *[]string) {
+		_, api := humatest.New(t)
+		limiter := NewRateLimiter(&app.Container{
+			Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard}),
+		}, api, config)
+
+		authenticate := func(ctx huma.Context, next func(huma.Context)) {
+			next(httpx.WithAuthInfo(ctx, auth))
+		}
+		for _, op := range []huma.Operation{
+			{OperationID: "get-a", Method: http.MethodGet, Path: "/a"},
+			{OperationID: "get-b", Method: http.MethodGet, Path: "/b"},
+		} {
+			op.Middlewares = huma.Middlewares{authenticate, limiter.Limit}
+			huma.Register(api, op, func(ctx context.Context, _ *struct{}) (*struct{}, error) {
+				return nil, nil
+			})
+		}
+
+		op := huma.Operation{
+			OperationID: "create-payment",
+			Method:      http.MethodPost,
+			Path:        "/payments",
+			Middlewares: huma.Middlewares{authenticate, limiter.Limit},
+		}
+		httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPayments)(&op)
+		huma.Register(api, op, func(ctx context.Context, _ *struct{}) (*struct{}, error) {
+			return nil, nil
+		})
+
+		var emails []string
+		op = huma.Operation{
+			OperationID: "sign-in",
+			Method:      http.MethodPost,
+			Path:        "/sign-in",
+			Middlewares: huma.Middlewares{authenticate, limiter.Limit},
+		}
+		httpx.WithRateLimitPolicy(httpx.RateLimitPolicyPublic)(&op)
+		httpx.WithRateLimitPolicy(httpx.RateLimitPolicySignIn)(&op)
+		huma.Register(api, op, func(ctx context.Context, in *signInInput) (*struct{}, error) {
+			emails = append(emails, in.Body.Email)
+			return nil, nil
+		})
+
+		return api, &emails
+	}
+
+	t.Run("should limit authenticated users per operation", func(t *testing.T) {
+		t.Parallel()
+		api, _ := newAPI(t, httpx.AuthInfo{Authenticated: true, UserID: "user-1"})
+
+		resp := api.Get("/a")
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		assert.Equal(t, "2", resp.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "1", resp.Header().Get("X-RateLimit-Remaining"))
+
+		assert.Equal(t, http.StatusNoContent, api.Get("/a").Code)
+		assert.Equal(t, http.StatusTooManyRequests, api.Get("/a").Code)
+		assert.Equal(t, http.StatusNoContent, api.Get("/b").Code)
+	})
+
+	t.Run("should limit signing in per email and IP", func(t *testing.T) {
+		t.Parallel()
+		api, emails := newAPI(t, httpx.AuthInfo{})
+
+		resp := api.Post("/sign-in", map[string]any{"email": "jane@example.com"})
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		assert.Equal(t, "1", resp.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "0", resp.Header().Get("X-RateLimit-Remaining"))
+
+		resp = api.Post("/sign-in", map[string]any{"email": " Jane@Example.com"})
+		assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+		assert.Equal(t, "60", resp.Header().Get("Retry-After"))
+
+		var body httpx.Error
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, httpx.ErrRateLimitExceeded, body.Code)
+
+		assert.Equal(t, http.StatusNoContent, api.Post("/sign-in", map[string]any{"email": "john@example.com"}).Code)
+		assert.Equal(t, []string{"jane@example.com", "john@example.com"}, *emails, "the body should reach the handler")
+	})
+
+	t.Run("should apply every policy of an operation", func(t *testing.T) {
+		t.Parallel()
+		api, _ := newAPI(t, httpx.AuthInfo{})
+
+		for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+			assert.Equal(t, http.StatusNoContent, api.Post("/sign-in", map[string]any{"email": email}).Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, api.Post("/sign-in", map[string]any{"email": "d@example.com"}).Code)
+	})
+
+	t.Run("should not count requests denied by another policy", func(t *testing.T) {
+		t.Parallel()
+		api, _ := newAPI(t, httpx.AuthInfo{})
+
+		for range 3 {
+			api.Post("/sign-in", map[string]any{"email": "a@example.com"})
+		}
+		assert.Equal(t, http.StatusNoContent, api.Post("/sign-in", map[string]any{"email": "b@example.com"}).Code)
+		assert.Equal(t, http.StatusNoContent, api.Post("/sign-in", map[string]any{"email": "c@example.com"}).Code)
+	})
+
+	t.Run("should limit payments per API key", func(t *testing.T) {
+		t.Parallel()
+		api, _ := newAPI(t, httpx.AuthInfo{})
+
+		assert.Equal(t, http.StatusNoContent, api.Post("/payments", "X-Api-Key: key-1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, api.Post("/payments", "X-Api-Key: key-1").Code)
+		assert.Equal(t, http.StatusNoContent, api.Post("/payments", "X-Api-Key: key-2").Code)
+	})
+}
+
+func TestWithRateLimit(t *testing.T) {
+	t.Parallel()
+
+	container := &app.Container{
+		Logger: core.NewLogger(core.LoggerOptions{Writer: io.Discard}),
+	}
+	handler := WithRateLimit(container, RateLimitConfig{
+		IP: httpx.RateLimitPolicy{
+			Requests: 2,
+			Window:   time.Minute,
+			Scopes:   []httpx.RateLimitScope{httpx.RateLimitScopeIP},
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	resp := serve("203.0.113.1")
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", resp.Header().Get("X-RateLimit-Remaining"))
+
+	assert.Equal(t, http.StatusUnauthorized, serve("203.0.113.1").Code, "failed authentications should be counted")
+
+	resp = serve("203.0.113.1")
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "30", resp.Header().Get("Retry-After"))
+
+	var body httpx.Error
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, httpx.ErrRateLimitExceeded, body.Code)
+
+	assert.Equal(t, http.StatusUnauthorized, serve("203.0.113.2").Code)
+}
+
+func TestRateLimitSubject(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		auth httpx.AuthInfo
+		want string
+	}{
+		{
+			name: "should use the client IP when unauthenticated",
+			want: "ip:192.0.2.1",
+		},
+		{
+			name: "should use the user of a session",
+			auth: httpx.AuthInfo{Authenticated: true, UserID: "user-1"},
+			want: "user:user-1",
+		},
+		{
+			name: "should use the impersonator of an impersonation session",
+			auth: httpx.AuthInfo{Authenticated: true, UserID: "user-1", ImpersonatorID: "user-2"},
+			want: "user:user-2",
+		},
+		{
+			name: "should use the credential of a token",
+			auth: httpx.AuthInfo{Authenticated: true, APIKeyUsed: true, CredentialID: "token-1"},
+			want: "key:token-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, api := humatest.New(t)
+
+			var got string
+			huma.Register(api, huma.Operation{
+				Method: http.MethodGet,
+				Path:   "/",
+				Middlewares: huma.Middlewares{func(ctx huma.Context, next func(huma.Context)) {
+					got = rateLimitSubject(httpx.WithAuthInfo(ctx, tt.auth))
+					next(ctx)
+				}},
+			}, func(ctx context.Context, _ *struct{}) (*struct{}, error) {
+				return nil, nil
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = "192.0.2.1"
+			api.Adapter().ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -10,7 +10,7 @@ const RequestMetadataKey contextKey = "request_metadata"
 
 // RequestMetadata contains metadata about the HTTP request
 type RequestMetadata struct {
-	IPAddress string // Client's IP address from X-Forwarded-For or RemoteAddr
+	IPAddress string // Client's IP address, as resolved by WithClientIP
 	UserAgent string // Client's User-Agent header
 	Country   string // Client IP's country
 }
@@ -18,24 +18,20 @@ type RequestMetadata struct {
 const CFCountryHeader = "CF-IPCountry"
 
 // WithRequestMetadata is a middleware that adds request metadata to the
-// context. It extracts client IP from X-Forwarded-For header if available,
-// falling back to RemoteAddr.
+// context. The client IP is the remote address, which WithClientIP resolves
+// from the trusted proxies, and the country is only set when WithClientIP
+// kept the CF-IPCountry header of a trusted Cloudflare proxy.
 func WithRequestMetadata() func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := AttachRequestMetadata(r.Context(), r.Header.Get("X-Forwarded-For"), r.RemoteAddr, r.UserAgent(), r.Header.Get(CFCountryHeader))
+			ctx := AttachRequestMetadata(r.Context(), r.RemoteAddr, r.UserAgent(), r.Header.Get(CFCountryHeader))
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
 // AttachRequestMetadata attaches request metadata to the context
-func AttachRequestMetadata(ctx context.Context, clientIP, remoteAddr, userAgent string, country string) context.Context {
-	// Extract client IP, preferring X-Forwarded-For header
-	if clientIP == "" {
-		clientIP = remoteAddr
-	}
-
+func AttachRequestMetadata(ctx context.Context, clientIP, userAgent string, country string) context.Context {
 	metadata := &RequestMetadata{
 		IPAddress: clientIP,
 		UserAgent: userAgent,
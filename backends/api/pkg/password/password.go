@@ -0,0 +1,243 @@
+// Package password estimates the strength of passwords and checks them
+// against an offline corpus of breached passwords.
+package password
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxScore is the highest strength score
+const MaxScore = 4
+
+// breachedCorpus holds common passwords from public breach corpora, one
+// lowercase password per line and most common first. It has a few thousand
+// entries, the most common passwords followed by the password list of zxcvbn
+// (MIT licensed, ranked from the Xato corpus), and can be replaced with a
+// larger corpus in the same format.
+//
+//go:embed breached.txt.gz
+var breachedCorpus []byte
+
+// wordList holds the breached passwords by rank
+type wordList struct {
+	ranks     map[string]int // The rank of each password in the corpus, starting at 1
+	maxLength int            // The length in characters of the longest password
+}
+
+// corpus loads the breached password corpus
+var corpus = sync.OnceValue(func() *wordList {
+	reader, err := gzip.NewReader(bytes.NewReader(breachedCorpus))
+	if err != nil {
+		panic("password: invalid breached password corpus: " + err.Error())
+	}
+	defer reader.Close()
+
+	list := &wordList{ranks: make(map[string]int)}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			if _, ok := list.ranks[line]; !ok {
+				list.ranks[line] = len(list.ranks) + 1
+				list.maxLength = max(list.maxLength, utf8.RuneCountInString(line))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		panic("password: invalid breached password corpus: " + err.Error())
+	}
+
+	return list
+})
+
+// IsBreached checks if the password, ignoring case, is in the breached
+// password corpus.
+func IsBreached(password string) bool {
+	_, ok := corpus().ranks[strings.ToLower(password)]
+	return ok
+}
+
+// keyboardRows are the rows of a QWERTY keyboard, for spotting keyboard walks
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// leetReplacer undoes common character substitutions before words are looked up
+var leetReplacer = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s", "!", "i")
+
+const (
+	// minWordLength is the shortest corpus word matched within a password
+	minWordLength = 4
+
+	// minUserInputLength is the shortest part of a user input matched within a password
+	minUserInputLength = 3
+)
+
+// Strength estimates how hard a password is to guess, as a score from 0 (too
+// guessable) to MaxScore (very unguessable), in the spirit of zxcvbn. Random
+// characters count for the size of their character pool, while breached
+// passwords, parts of the user inputs such as the email address and name,
+// repeats and keyboard or alphabetical sequences only count for a few bits.
+func Strength(password string, userInputs ...string) int {
+	bits := entropy(password, userInputs)
+	switch {
+	case bits < 20:
+		return 0
+	case bits < 30:
+		return 1
+	case bits < 45:
+		return 2
+	case bits < 60:
+		return 3
+	default:
+		return MaxScore
+	}
+}
+
+// entropy estimates the bits of entropy of a password.
+func entropy(password string, userInputs []string) float64 {
+	runes := []rune(password)
+	lower := []rune(strings.ToLower(password))
+	leet := []rune(leetReplacer.Replace(string(lower)))
+	if len(leet) != len(lower) {
+		leet = lower
+	}
+
+	words, maxLength := userInputWords(userInputs)
+	maxLength = max(maxLength, corpus().maxLength)
+	charBits := math.Log2(float64(poolSize(runes)))
+
+	var bits float64
+	for i := 0; i < len(runes); {
+		if length, rank := matchWord(lower, leet, i, words, maxLength); length > 0 {
+			bits += math.Log2(float64(rank) + 1)
+			if hasUpper(runes[i : i+length]) {
+				bits++
+			}
+			i += length
+			continue
+		}
+
+		switch {
+		case i > 0 && lower[i] == lower[i-1]:
+			bits++
+		case i > 0 && isSequence(lower[i-1], lower[i]):
+			bits += 2
+		default:
+			bits += charBits
+		}
+		i++
+	}
+
+	return bits
+}
+
+// matchWord returns the length and rank of the longest breached password or
+// user input word starting at i, or a zero length without a match. Only words
+// up to maxLength long are looked up, as no longer one can match.
+func matchWord(lower, leet []rune, i int, words map[string]struct{}, maxLength int) (length, rank int) {
+	ranks := corpus().ranks
+	for j := min(len(lower), i+maxLength); j > i; j-- {
+		n := j - i
+		for _, candidate := range []string{string(lower[i:j]), string(leet[i:j])} {
+			if _, ok := words[candidate]; ok && n >= minUserInputLength {
+				return n, 1
+			}
+			if r, ok := ranks[candidate]; ok && n >= minWordLength {
+				return n, r
+			}
+		}
+	}
+	return 0, 0
+}
+
+// userInputWords splits the user inputs into lowercase words, such as the
+// local part and domain of an email address or the parts of a name, and
+// returns the length in characters of the longest one.
+func userInputWords(userInputs []string) (map[string]struct{}, int) {
+	words := make(map[string]struct{})
+	maxLength := 0
+	for _, input := range userInputs {
+		fields := strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+		for _, field := range fields {
+			if length := utf8.RuneCountInString(field); length >= minUserInputLength {
+				words[field] = struct{}{}
+				maxLength = max(maxLength, length)
+			}
+		}
+	}
+	return words, maxLength
+}
+
+// poolSize returns the size of the character pool the password draws from.
+func poolSize(runes []rune) int {
+	var hasLower, hasUpper, hasNumber, hasSymbol, hasOther bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= '0' && r <= '9':
+			hasNumber = true
+		case r < unicode.MaxASCII:
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+	}
+
+	size := 0
+	if hasLower {
+		size += 26
+	}
+	if hasUpper {
+		size += 26
+	}
+	if hasNumber {
+		size += 10
+	}
+	if hasSymbol {
+		size += 33
+	}
+	if hasOther {
+		size += 100
+	}
+	return max(size, 1)
+}
+
+// isSequence checks if two characters follow each other in the alphabet, the
+// digits or on a keyboard row, in either direction.
+func isSequence(a, b rune) bool {
+	if (unicode.IsLetter(a) && unicode.IsLetter(b)) || (unicode.IsDigit(a) && unicode.IsDigit(b)) {
+		if diff := a - b; diff == 1 || diff == -1 {
+			return true
+		}
+	}
+
+	for _, row := range keyboardRows {
+		i, j := strings.IndexRune(row, a), strings.IndexRune(row, b)
+		if i >= 0 && j >= 0 && (i-j == 1 || j-i == 1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasUpper checks if any of the characters is uppercase.
+func hasUpper(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
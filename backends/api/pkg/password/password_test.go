@@ -0,0 +1,57 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBreached(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{name: "should find a breached password", password: "P@ssw0rd!", want: true},
+		{name: "should ignore case", password: "QWERTY123!", want: true},
+		{name: "should not find an unbreached password", password: "kX9#mQ2$vL7!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, IsBreached(tt.password))
+		})
+	}
+}
+
+func TestStrength(t *testing.T) {
+	t.Parallel()
+
+	userInputs := []string{"ada.lovelace@example.com", "Ada Lovelace"}
+	tests := []struct {
+		name     string
+		password string
+		want     int
+	}{
+		{name: "should score a breached password lowest", password: "Password1!", want: 0},
+		{name: "should discount common words with substitutions", password: "Summer2026!x", want: 0},
+		{name: "should discount the user inputs", password: "Ada.Lovelace1!", want: 1},
+		{name: "should discount repeats", password: "aaaaaaaaaaA1!", want: 1},
+		{name: "should discount sequences", password: "Lmnopqrs1!", want: 2},
+		{name: "should score a short random password", password: "Xk9#mQ2$", want: 3},
+		{name: "should score a long random password highest", password: "kX9#mQ2$vL7!", want: MaxScore},
+		{name: "should score a passphrase highest", password: "correct horse battery staple", want: MaxScore},
+		{name: "should score a long password", password: strings.Repeat("aB3$", 1000), want: MaxScore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Strength(tt.password, userInputs...))
+		})
+	}
+}
@@ -227,7 +227,6 @@ func Container(t *testing.T) (humatest.TestAPI, *app.Container, *internal.Module
 	api.UseMiddleware(
 		func(ctx huma.Context, next func(huma.Context)) {
 			ctx = huma.WithContext(ctx, middleware.AttachRequestMetadata(ctx.Context(),
-				ctx.Header("X-Forwarded-For"),
 				ctx.RemoteAddr(),
 				ctx.Header("User-Agent"),
 				ctx.Header(middleware.CFCountryHeader)))
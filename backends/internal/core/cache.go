@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache defines the interface for a key-value cache. Values are stored as
+// JSON, so they must round-trip through encoding/json. Entries expire after
+// their TTL, and can be tagged so that related entries are invalidated
+// together. A cache only speeds up reads, so callers should fall back to the
+// source of truth when it fails.
+type Cache interface {
+	// Get decodes the value of a key into dest, and reports whether it was found
+	Get(ctx context.Context, key string, dest any) (bool, error)
+
+	// Set stores the value of a key for ttl, tagged with the tags
+	Set(ctx context.Context, key string, value any, ttl time.Duration, tags ...string) error
+
+	// GetOrLoad decodes the value of a key into dest, or on a miss loads it,
+	// stores it for ttl tagged with the tags, and decodes it into dest.
+	// Concurrent misses of a key in the process share a single load.
+	GetOrLoad(ctx context.Context, key string, dest any, ttl time.Duration, load func(ctx context.Context) (any, error), tags ...string) error
+
+	// Delete removes the keys
+	Delete(ctx context.Context, keys ...string) error
+
+	// InvalidateTags removes the keys tagged with any of the tags
+	InvalidateTags(ctx context.Context, tags ...string) error
+
+	// Namespace returns a view of the cache whose keys and tags are prefixed
+	// with the parts, such as a module and an operation mode
+	Namespace(parts ...string) Cache
+}
+
+// CacheGet returns the value of a key, and whether it was found
+func CacheGet[T any](ctx context.Context, cache Cache, key string) (T, bool, error) {
+	var value T
+	found, err := cache.Get(ctx, key, &value)
+	return value, found, err
+}
+
+// CacheGetOrLoad returns the value of a key, loading and storing it on a miss.
+// See Cache.GetOrLoad.
+func CacheGetOrLoad[T any](ctx context.Context, cache Cache, key string, ttl time.Duration, load func(ctx context.Context) (T, error), tags ...string) (T, error) {
+	var value T
+	err := cache.GetOrLoad(ctx, key, &value, ttl, func(ctx context.Context) (any, error) {
+		return load(ctx)
+	}, tags...)
+	return value, err
+}
+
+// cacheNamespace returns the prefix of the keys of a namespace
+func cacheNamespace(prefix string, parts ...string) string {
+	if len(parts) == 0 {
+		return prefix
+	}
+
+	return prefix + strings.Join(parts, ":") + ":"
+}
+
+// cacheLoader deduplicates the concurrent loads of the keys of a cache
+type cacheLoader struct {
+	group singleflight.Group
+}
+
+// getOrLoad implements Cache.GetOrLoad on top of the Get and Set of a cache.
+// fullKey identifies the key across namespaces. A failure to read or write the
+// cache only means the value is loaded, or not stored, and is not returned.
+func (l *cacheLoader) getOrLoad(ctx context.Context, cache Cache, fullKey, key string, dest any, ttl time.Duration, load func(ctx context.Context) (any, error), tags []string) error {
+	if found, err := cache.Get(ctx, key, dest); err == nil && found {
+		return nil
+	}
+
+	data, err, _ := l.group.Do(fullKey, func() (any, error) {
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("encoding cached value: %w", err)
+		}
+
+		// The value is stored encoded, so it is not encoded twice
+		_ = cache.Set(ctx, key, json.RawMessage(data), ttl, tags...)
+		return data, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data.([]byte), dest); err != nil {
+		return fmt.Errorf("decoding cached value: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// memoryCacheSweepInterval is the number of writes between sweeps of the
+// expired entries of a MemoryCache
+const memoryCacheSweepInterval = 1000
+
+// memoryCacheEntry is an entry of a MemoryCache
+type memoryCacheEntry struct {
+	data      []byte
+	expiresAt time.Time
+	tags      []string
+}
+
+// memoryCacheStore holds the entries shared by the namespaces of a MemoryCache
+type memoryCacheStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryCacheEntry
+	tags    map[string]map[string]struct{}
+	writes  int
+	loader  cacheLoader
+}
+
+// MemoryCache implements the Cache interface in the memory of the process,
+// for tests and debug mode
+type MemoryCache struct {
+	store  *memoryCacheStore
+	prefix string
+}
+
+// NewMemoryCache creates a new MemoryCache instance
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		store: &memoryCacheStore{
+			entries: make(map[string]*memoryCacheEntry),
+			tags:    make(map[string]map[string]struct{}),
+		},
+	}
+}
+
+// Get implements Cache.Get
+func (c *MemoryCache) Get(ctx context.Context, key string, dest any) (bool, error) {
+	c.store.mu.Lock()
+	entry, ok := c.store.entries[c.prefix+key]
+	if ok && !time.Now().Before(entry.expiresAt) {
+		c.store.delete(c.prefix + key)
+		ok = false
+	}
+	c.store.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(entry.data, dest); err != nil {
+		return false, fmt.Errorf("decoding cached value: %w", err)
+	}
+
+	return true, nil
+}
+
+// Set implements Cache.Set
+func (c *MemoryCache) Set(ctx context.Context, key string, value any, ttl time.Duration, tags ...string) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encoding cached value: %w", err)
+	}
+
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	fullKey := c.prefix + key
+	c.store.delete(fullKey)
+
+	entry := &memoryCacheEntry{data: data, expiresAt: time.Now().Add(ttl)}
+	for _, tag := range tags {
+		tag = c.prefix + tag
+		entry.tags = append(entry.tags, tag)
+		if c.store.tags[tag] == nil {
+			c.store.tags[tag] = make(map[string]struct{})
+		}
+		c.store.tags[tag][fullKey] = struct{}{}
+	}
+	c.store.entries[fullKey] = entry
+
+	c.store.writes++
+	if c.store.writes%memoryCacheSweepInterval == 0 {
+		c.store.sweep()
+	}
+
+	return nil
+}
+
+// GetOrLoad implements Cache.GetOrLoad
+func (c *MemoryCache) GetOrLoad(ctx context.Context, key string, dest any, ttl time.Duration, load func(ctx context.Context) (any, error), tags ...string) error {
+	return c.store.loader.getOrLoad(ctx, c, c.prefix+key, key, dest, ttl, load, tags)
+}
+
+// Delete implements Cache.Delete
+func (c *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	for _, key := range keys {
+		c.store.delete(c.prefix + key)
+	}
+
+	return nil
+}
+
+// InvalidateTags implements Cache.InvalidateTags
+func (c *MemoryCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range c.store.tags[c.prefix+tag] {
+			c.store.delete(key)
+		}
+		delete(c.store.tags, c.prefix+tag)
+	}
+
+	return nil
+}
+
+// Namespace implements Cache.Namespace
+func (c *MemoryCache) Namespace(parts ...string) Cache {
+	return &MemoryCache{
+		store:  c.store,
+		prefix: cacheNamespace(c.prefix, parts...),
+	}
+}
+
+// delete removes an entry and its tag references. The lock must be held.
+func (s *memoryCacheStore) delete(key string) {
+	entry, ok := s.entries[key]
+	if !ok {
+		return
+	}
+
+	for _, tag := range entry.tags {
+		delete(s.tags[tag], key)
+		if len(s.tags[tag]) == 0 {
+			delete(s.tags, tag)
+		}
+	}
+	delete(s.entries, key)
+}
+
+// sweep removes the expired entries. The lock must be held.
+func (s *memoryCacheStore) sweep() {
+	now := time.Now()
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			s.delete(key)
+		}
+	}
+}
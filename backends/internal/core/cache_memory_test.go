@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	type profile struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("round trips a typed value", func(t *testing.T) {
+		cache := NewMemoryCache()
+		require.NoError(t, cache.Set(ctx, "profile", profile{Name: "Jane"}, time.Minute))
+
+		got, found, err := CacheGet[profile](ctx, cache, "profile")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile{Name: "Jane"}, got)
+
+		_, found, err = CacheGet[profile](ctx, cache, "missing")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("expires values after their TTL", func(t *testing.T) {
+		cache := NewMemoryCache()
+		require.NoError(t, cache.Set(ctx, "key", "value", time.Millisecond))
+		time.Sleep(5 * time.Millisecond)
+
+		_, found, err := CacheGet[string](ctx, cache, "key")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("invalidates the keys of a tag", func(t *testing.T) {
+		cache := NewMemoryCache()
+		require.NoError(t, cache.Set(ctx, "a", 1, time.Minute, "user:1"))
+		require.NoError(t, cache.Set(ctx, "b", 2, time.Minute, "user:1", "user:2"))
+		require.NoError(t, cache.Set(ctx, "c", 3, time.Minute, "user:2"))
+
+		require.NoError(t, cache.InvalidateTags(ctx, "user:1"))
+
+		for key, want := range map[string]bool{"a": false, "b": false, "c": true} {
+			_, found, err := CacheGet[int](ctx, cache, key)
+			require.NoError(t, err)
+			assert.Equal(t, want, found, key)
+		}
+	})
+
+	t.Run("isolates namespaces", func(t *testing.T) {
+		cache := NewMemoryCache()
+		live := cache.Namespace("payment", "live")
+		test := cache.Namespace("payment", "test")
+		require.NoError(t, live.Set(ctx, "key", "live", time.Minute, "tag"))
+		require.NoError(t, test.Set(ctx, "key", "test", time.Minute, "tag"))
+
+		got, _, err := CacheGet[string](ctx, test, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "test", got)
+
+		require.NoError(t, live.InvalidateTags(ctx, "tag"))
+		_, found, err := CacheGet[string](ctx, test, "key")
+		require.NoError(t, err)
+		assert.True(t, found, "invalidating a tag should not reach other namespaces")
+	})
+
+	t.Run("loads a missing value once for concurrent callers", func(t *testing.T) {
+		cache := NewMemoryCache()
+		var loads atomic.Int32
+		release := make(chan struct{})
+		load := func(ctx context.Context) (profile, error) {
+			loads.Add(1)
+			<-release
+			return profile{Name: "Jane"}, nil
+		}
+
+		var wg sync.WaitGroup
+		results := make([]profile, 10)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got, err := CacheGetOrLoad(ctx, cache, "profile", time.Minute, load)
+				assert.NoError(t, err)
+				results[i] = got
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), loads.Load())
+		for _, got := range results {
+			assert.Equal(t, profile{Name: "Jane"}, got)
+		}
+
+		got, err := CacheGetOrLoad(ctx, cache, "profile", time.Minute, func(ctx context.Context) (profile, error) {
+			return profile{}, errors.New("should be cached")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, profile{Name: "Jane"}, got)
+	})
+
+	t.Run("does not cache failed loads", func(t *testing.T) {
+		cache := NewMemoryCache()
+		_, err := CacheGetOrLoad(ctx, cache, "key", time.Minute, func(ctx context.Context) (string, error) {
+			return "", errors.New("unavailable")
+		})
+		require.Error(t, err)
+
+		_, found, err := CacheGet[string](ctx, cache, "key")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// valkeyCacheTagPrefix prefixes the keys of the sets indexing the keys of a tag
+const valkeyCacheTagPrefix = "_tag:"
+
+// ValkeyCache implements the Cache interface for Valkey. The keys of a tag are
+// indexed in a set that lives as long as its longest-lived key. Keys are
+// deleted one by one, as they may live in different cluster slots.
+type ValkeyCache struct {
+	client redis.UniversalClient
+	prefix string
+	loader *cacheLoader
+}
+
+// NewValkeyCache creates a new ValkeyCache instance whose keys are prefixed
+// with the namespace parts
+func NewValkeyCache(client redis.UniversalClient, parts ...string) *ValkeyCache {
+	return &ValkeyCache{
+		client: client,
+		prefix: cacheNamespace("", parts...),
+		loader: &cacheLoader{},
+	}
+}
+
+// Get implements Cache.Get
+func (c *ValkeyCache) Get(ctx context.Context, key string, dest any) (bool, error) {
+	data, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return false, fmt.Errorf("decoding cached value: %w", err)
+	}
+
+	return true, nil
+}
+
+// Set implements Cache.Set
+func (c *ValkeyCache) Set(ctx context.Context, key string, value any, ttl time.Duration, tags ...string) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encoding cached value: %w", err)
+	}
+
+	fullKey := c.prefix + key
+	_, err = c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, fullKey, data, ttl)
+		for _, tag := range tags {
+			tagKey := c.prefix + valkeyCacheTagPrefix + tag
+			pipe.SAdd(ctx, tagKey, fullKey)
+			pipe.ExpireNX(ctx, tagKey, ttl)
+			pipe.ExpireGT(ctx, tagKey, ttl)
+		}
+		return nil
+	})
+
+	return err
+}
+
+// GetOrLoad implements Cache.GetOrLoad
+func (c *ValkeyCache) GetOrLoad(ctx context.Context, key string, dest any, ttl time.Duration, load func(ctx context.Context) (any, error), tags ...string) error {
+	return c.loader.getOrLoad(ctx, c, c.prefix+key, key, dest, ttl, load, tags)
+}
+
+// Delete implements Cache.Delete
+func (c *ValkeyCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, c.prefix+key)
+		}
+		return nil
+	})
+
+	return err
+}
+
+// InvalidateTags implements Cache.InvalidateTags
+func (c *ValkeyCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		tagKey := c.prefix + valkeyCacheTagPrefix + tag
+		keys, err := c.client.SMembers(ctx, tagKey).Result()
+		if err != nil {
+			return err
+		}
+
+		_, err = c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				pipe.Del(ctx, key)
+			}
+			pipe.Del(ctx, tagKey)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Namespace implements Cache.Namespace
+func (c *ValkeyCache) Namespace(parts ...string) Cache {
+	return &ValkeyCache{
+		client: c.client,
+		prefix: cacheNamespace(c.prefix, parts...),
+		loader: c.loader,
+	}
+}
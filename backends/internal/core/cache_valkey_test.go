@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testValkeyURL = "redis://localhost:6379/15"
+
+// setupTestValkey returns a cache in a namespace of its own on the Valkey of
+// VALKEY_URL, skipping the test when it isn't reachable.
+func setupTestValkey(t *testing.T) (*ValkeyCache, redis.UniversalClient) {
+	t.Helper()
+
+	opts, err := redis.ParseURL(getEnvOrDefault("VALKEY_URL", testValkeyURL))
+	require.NoError(t, err)
+
+	client := redis.NewClient(opts)
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Valkey isn't reachable, set VALKEY_URL to run: %v", err)
+	}
+
+	return NewValkeyCache(client, "test", uuid.NewString()), client
+}
+
+func TestValkeyCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	type profile struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("round trips a typed value", func(t *testing.T) {
+		t.Parallel()
+		cache, _ := setupTestValkey(t)
+		require.NoError(t, cache.Set(ctx, "profile", profile{Name: "Jane"}, time.Minute))
+
+		got, found, err := CacheGet[profile](ctx, cache, "profile")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, profile{Name: "Jane"}, got)
+
+		_, found, err = CacheGet[profile](ctx, cache, "missing")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("expires values after their TTL", func(t *testing.T) {
+		t.Parallel()
+		cache, client := setupTestValkey(t)
+		require.NoError(t, cache.Set(ctx, "key", "value", 50*time.Millisecond, "tag"))
+
+		ttl, err := client.PTTL(ctx, cache.prefix+valkeyCacheTagPrefix+"tag").Result()
+		require.NoError(t, err)
+		assert.Positive(t, ttl, "the tag should expire with its keys")
+
+		time.Sleep(100 * time.Millisecond)
+		_, found, err := CacheGet[string](ctx, cache, "key")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("keeps a tag as long as its longest-lived key", func(t *testing.T) {
+		t.Parallel()
+		cache, client := setupTestValkey(t)
+		tagKey := cache.prefix + valkeyCacheTagPrefix + "tag"
+		require.NoError(t, cache.Set(ctx, "long", 1, time.Hour, "tag"))
+		require.NoError(t, cache.Set(ctx, "short", 2, time.Minute, "tag"))
+
+		ttl, err := client.TTL(ctx, tagKey).Result()
+		require.NoError(t, err)
+		assert.Greater(t, ttl, time.Minute)
+	})
+
+	t.Run("invalidates the keys of a tag", func(t *testing.T) {
+		t.Parallel()
+		cache, _ := setupTestValkey(t)
+		require.NoError(t, cache.Set(ctx, "a", 1, time.Minute, "user:1"))
+		require.NoError(t, cache.Set(ctx, "b", 2, time.Minute, "user:1", "user:2"))
+		require.NoError(t, cache.Set(ctx, "c", 3, time.Minute, "user:2"))
+
+		require.NoError(t, cache.InvalidateTags(ctx, "user:1"))
+
+		for key, want := range map[string]bool{"a": false, "b": false, "c": true} {
+			_, found, err := CacheGet[int](ctx, cache, key)
+			require.NoError(t, err)
+			assert.Equal(t, want, found, key)
+		}
+	})
+
+	t.Run("deletes keys", func(t *testing.T) {
+		t.Parallel()
+		cache, _ := setupTestValkey(t)
+		require.NoError(t, cache.Set(ctx, "a", 1, time.Minute))
+		require.NoError(t, cache.Set(ctx, "b", 2, time.Minute))
+
+		require.NoError(t, cache.Delete(ctx, "a"))
+
+		for key, want := range map[string]bool{"a": false, "b": true} {
+			_, found, err := CacheGet[int](ctx, cache, key)
+			require.NoError(t, err)
+			assert.Equal(t, want, found, key)
+		}
+	})
+
+	t.Run("isolates namespaces", func(t *testing.T) {
+		t.Parallel()
+		cache, _ := setupTestValkey(t)
+		live := cache.Namespace("payment", "live")
+		test := cache.Namespace("payment", "test")
+		require.NoError(t, live.Set(ctx, "key", "live", time.Minute, "tag"))
+		require.NoError(t, test.Set(ctx, "key", "test", time.Minute, "tag"))
+
+		got, _, err := CacheGet[string](ctx, test, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "test", got)
+
+		require.NoError(t, live.InvalidateTags(ctx, "tag"))
+		_, found, err := CacheGet[string](ctx, test, "key")
+		require.NoError(t, err)
+		assert.True(t, found, "invalidating a tag should not reach other namespaces")
+
+		_, found, err = CacheGet[string](ctx, live, "key")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCipher(t *testing.T) {
+	t.Parallel()
+
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+	t.Run("round trips a secret", func(t *testing.T) {
+		c, err := NewCipher(key)
+		require.NoError(t, err)
+
+		encrypted, err := c.Encrypt("client-secret")
+		require.NoError(t, err)
+		assert.NotContains(t, encrypted, "client-secret")
+
+		decrypted, err := c.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, "client-secret", decrypted)
+	})
+
+	t.Run("uses a fresh nonce for each encryption", func(t *testing.T) {
+		c, err := NewCipher(key)
+		require.NoError(t, err)
+
+		a, err := c.Encrypt("client-secret")
+		require.NoError(t, err)
+		b, err := c.Encrypt("client-secret")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("rejects tampered ciphertext", func(t *testing.T) {
+		c, err := NewCipher(key)
+		require.NoError(t, err)
+
+		encrypted, err := c.Encrypt("client-secret")
+		require.NoError(t, err)
+
+		raw, _ := base64.StdEncoding.DecodeString(encrypted)
+		raw[len(raw)-1] ^= 0xff
+
+		_, err = c.Decrypt(base64.StdEncoding.EncodeToString(raw))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects keys of the wrong length", func(t *testing.T) {
+		_, err := NewCipher(base64.StdEncoding.EncodeToString([]byte("short")))
+		assert.Error(t, err)
+	})
+}
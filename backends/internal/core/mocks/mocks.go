@@ -21,6 +21,473 @@ import (
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewMockCache creates a new instance of MockCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCache {
+	mock := &MockCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCache is an autogenerated mock type for the Cache type
+type MockCache struct {
+	mock.Mock
+}
+
+type MockCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCache) EXPECT() *MockCache_Expecter {
+	return &MockCache_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockCache
+func (_mock *MockCache) Delete(ctx context.Context, keys ...string) error {
+	var tmpRet mock.Arguments
+	if len(keys) > 0 {
+		tmpRet = _mock.Called(ctx, keys)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = returnFunc(ctx, keys...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCache_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockCache_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys ...string
+func (_e *MockCache_Expecter) Delete(ctx interface{}, keys ...interface{}) *MockCache_Delete_Call {
+	return &MockCache_Delete_Call{Call: _e.mock.On("Delete",
+		append([]interface{}{ctx}, keys...)...)}
+}
+
+func (_c *MockCache_Delete_Call) Run(run func(ctx context.Context, keys ...string)) *MockCache_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_Delete_Call) Return(err error) *MockCache_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCache_Delete_Call) RunAndReturn(run func(ctx context.Context, keys ...string) error) *MockCache_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockCache
+func (_mock *MockCache) Get(ctx context.Context, key string, dest any) (bool, error) {
+	ret := _mock.Called(ctx, key, dest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) (bool, error)); ok {
+		return returnFunc(ctx, key, dest)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) bool); ok {
+		r0 = returnFunc(ctx, key, dest)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, any) error); ok {
+		r1 = returnFunc(ctx, key, dest)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCache_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockCache_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - dest any
+func (_e *MockCache_Expecter) Get(ctx interface{}, key interface{}, dest interface{}) *MockCache_Get_Call {
+	return &MockCache_Get_Call{Call: _e.mock.On("Get", ctx, key, dest)}
+}
+
+func (_c *MockCache_Get_Call) Run(run func(ctx context.Context, key string, dest any)) *MockCache_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_Get_Call) Return(b bool, err error) *MockCache_Get_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockCache_Get_Call) RunAndReturn(run func(ctx context.Context, key string, dest any) (bool, error)) *MockCache_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrLoad provides a mock function for the type MockCache
+func (_mock *MockCache) GetOrLoad(ctx context.Context, key string, dest any, ttl time.Duration, load func(ctx context.Context) (any, error), tags ...string) error {
+	var tmpRet mock.Arguments
+	if len(tags) > 0 {
+		tmpRet = _mock.Called(ctx, key, dest, ttl, load, tags)
+	} else {
+		tmpRet = _mock.Called(ctx, key, dest, ttl, load)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrLoad")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any, time.Duration, func(ctx context.Context) (any, error), ...string) error); ok {
+		r0 = returnFunc(ctx, key, dest, ttl, load, tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCache_GetOrLoad_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrLoad'
+type MockCache_GetOrLoad_Call struct {
+	*mock.Call
+}
+
+// GetOrLoad is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - dest any
+//   - ttl time.Duration
+//   - load func(ctx context.Context) (any, error)
+//   - tags ...string
+func (_e *MockCache_Expecter) GetOrLoad(ctx interface{}, key interface{}, dest interface{}, ttl interface{}, load interface{}, tags ...interface{}) *MockCache_GetOrLoad_Call {
+	return &MockCache_GetOrLoad_Call{Call: _e.mock.On("GetOrLoad",
+		append([]interface{}{ctx, key, dest, ttl, load}, tags...)...)}
+}
+
+func (_c *MockCache_GetOrLoad_Call) Run(run func(ctx context.Context, key string, dest any, ttl time.Duration, load func(ctx context.Context) (any, error), tags ...string)) *MockCache_GetOrLoad_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		var arg4 func(ctx context.Context) (any, error)
+		if args[4] != nil {
+			arg4 = args[4].(func(ctx context.Context) (any, error))
+		}
+		var arg5 []string
+		var variadicArgs []string
+		if len(args) > 5 {
+			variadicArgs = args[5].([]string)
+		}
+		arg5 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_GetOrLoad_Call) Return(err error) *MockCache_GetOrLoad_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCache_GetOrLoad_Call) RunAndReturn(run func(ctx context.Context, key string, dest any, ttl time.Duration, load func(ctx context.Context) (any, error), tags ...string) error) *MockCache_GetOrLoad_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateTags provides a mock function for the type MockCache
+func (_mock *MockCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	var tmpRet mock.Arguments
+	if len(tags) > 0 {
+		tmpRet = _mock.Called(ctx, tags)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateTags")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = returnFunc(ctx, tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCache_InvalidateTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateTags'
+type MockCache_InvalidateTags_Call struct {
+	*mock.Call
+}
+
+// InvalidateTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tags ...string
+func (_e *MockCache_Expecter) InvalidateTags(ctx interface{}, tags ...interface{}) *MockCache_InvalidateTags_Call {
+	return &MockCache_InvalidateTags_Call{Call: _e.mock.On("InvalidateTags",
+		append([]interface{}{ctx}, tags...)...)}
+}
+
+func (_c *MockCache_InvalidateTags_Call) Run(run func(ctx context.Context, tags ...string)) *MockCache_InvalidateTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_InvalidateTags_Call) Return(err error) *MockCache_InvalidateTags_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCache_InvalidateTags_Call) RunAndReturn(run func(ctx context.Context, tags ...string) error) *MockCache_InvalidateTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Namespace provides a mock function for the type MockCache
+func (_mock *MockCache) Namespace(parts ...string) core.Cache {
+	var tmpRet mock.Arguments
+	if len(parts) > 0 {
+		tmpRet = _mock.Called(parts)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Namespace")
+	}
+
+	var r0 core.Cache
+	if returnFunc, ok := ret.Get(0).(func(...string) core.Cache); ok {
+		r0 = returnFunc(parts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.Cache)
+		}
+	}
+	return r0
+}
+
+// MockCache_Namespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Namespace'
+type MockCache_Namespace_Call struct {
+	*mock.Call
+}
+
+// Namespace is a helper method to define mock.On call
+//   - parts ...string
+func (_e *MockCache_Expecter) Namespace(parts ...interface{}) *MockCache_Namespace_Call {
+	return &MockCache_Namespace_Call{Call: _e.mock.On("Namespace",
+		append([]interface{}{}, parts...)...)}
+}
+
+func (_c *MockCache_Namespace_Call) Run(run func(parts ...string)) *MockCache_Namespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		var variadicArgs []string
+		if len(args) > 0 {
+			variadicArgs = args[0].([]string)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_Namespace_Call) Return(cache core.Cache) *MockCache_Namespace_Call {
+	_c.Call.Return(cache)
+	return _c
+}
+
+func (_c *MockCache_Namespace_Call) RunAndReturn(run func(parts ...string) core.Cache) *MockCache_Namespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type MockCache
+func (_mock *MockCache) Set(ctx context.Context, key string, value any, ttl time.Duration, tags ...string) error {
+	var tmpRet mock.Arguments
+	if len(tags) > 0 {
+		tmpRet = _mock.Called(ctx, key, value, ttl, tags)
+	} else {
+		tmpRet = _mock.Called(ctx, key, value, ttl)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any, time.Duration, ...string) error); ok {
+		r0 = returnFunc(ctx, key, value, ttl, tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCache_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockCache_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value any
+//   - ttl time.Duration
+//   - tags ...string
+func (_e *MockCache_Expecter) Set(ctx interface{}, key interface{}, value interface{}, ttl interface{}, tags ...interface{}) *MockCache_Set_Call {
+	return &MockCache_Set_Call{Call: _e.mock.On("Set",
+		append([]interface{}{ctx, key, value, ttl}, tags...)...)}
+}
+
+func (_c *MockCache_Set_Call) Run(run func(ctx context.Context, key string, value any, ttl time.Duration, tags ...string)) *MockCache_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		var arg4 []string
+		var variadicArgs []string
+		if len(args) > 4 {
+			variadicArgs = args[4].([]string)
+		}
+		arg4 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_Set_Call) Return(err error) *MockCache_Set_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCache_Set_Call) RunAndReturn(run func(ctx context.Context, key string, value any, ttl time.Duration, tags ...string) error) *MockCache_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockQuerier creates a new instance of MockQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockQuerier(t interface {
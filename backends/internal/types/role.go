@@ -10,11 +10,16 @@ type Action string
 const (
 	ActionCreate        Action = "create"
 	ActionDelete        Action = "delete"
+	ActionDeny          Action = "deny" // Access was refused, such as from a disallowed address
 	ActionDisable       Action = "disable"
 	ActionEnable        Action = "enable"
+	ActionExport        Action = "export"
+	ActionLock          Action = "lock"   // An account was locked after too many failed sign-ins
 	ActionManage        Action = "manage" // Implies full access
 	ActionRead          Action = "read"
+	ActionRequest       Action = "request" // A request was made, such as while impersonating a user
 	ActionResetPassword Action = "reset_password"
+	ActionUnlock        Action = "unlock"
 	ActionUpdate        Action = "update"
 	ActionVerify        Action = "verify"
 )
@@ -28,11 +33,19 @@ func (a Action) String() string {
 type Resource string
 
 const (
-	ResourceEntity    Resource = "entity"
-	ResourcePayment   Resource = "payment"
-	ResourceSession   Resource = "session"
-	ResourceTwoFactor Resource = "two_factor"
-	ResourceUser      Resource = "user"
+	ResourceAuditLog       Resource = "audit_log"
+	ResourceCompliance     Resource = "compliance"
+	ResourceEntity         Resource = "entity"
+	ResourceEntityDomain   Resource = "entity_domain"
+	ResourceImpersonation  Resource = "impersonation"
+	ResourceIPAllowlist    Resource = "ip_allowlist"
+	ResourcePasswordPolicy Resource = "password_policy"
+	ResourcePayment        Resource = "payment"
+	ResourceSCIMToken      Resource = "scim_token"
+	ResourceSession        Resource = "session"
+	ResourceSessionPolicy  Resource = "session_policy"
+	ResourceTwoFactor      Resource = "two_factor"
+	ResourceUser           Resource = "user"
 )
 
 // String returns the string representation of a resource
@@ -104,21 +117,32 @@ type Permission struct {
 var RolePermissions = map[Role]map[Resource][]Action{
 	RoleOwner: {
 		// Full access to everything
-		ResourceEntity:  {ActionManage},
-		ResourceUser:    {ActionManage},
-		ResourcePayment: {ActionManage},
+		ResourceAuditLog:   {ActionManage},
+		ResourceCompliance: {ActionManage},
+		ResourceEntity:     {ActionManage},
+		ResourceUser:       {ActionManage},
+		ResourcePayment:    {ActionManage},
+
+		// Only honoured in platform entities
+		ResourceImpersonation: {ActionManage},
 	},
 	RoleAdmin: {
 		// Full access except critical operations
-		ResourceEntity:  {ActionRead, ActionUpdate},
-		ResourceUser:    {ActionManage},
-		ResourcePayment: {ActionManage},
+		ResourceAuditLog:   {ActionRead},
+		ResourceCompliance: {ActionRead, ActionUpdate, ActionVerify},
+		ResourceEntity:     {ActionRead, ActionUpdate},
+		ResourceUser:       {ActionManage},
+		ResourcePayment:    {ActionManage},
+
+		// Only honoured in platform entities
+		ResourceImpersonation: {ActionCreate},
 	},
 	RoleViewer: {
 		// Read-only access
-		ResourceEntity:  {ActionRead},
-		ResourceUser:    {ActionManage},
-		ResourcePayment: {ActionRead},
+		ResourceCompliance: {ActionRead},
+		ResourceEntity:     {ActionRead},
+		ResourceUser:       {ActionManage},
+		ResourcePayment:    {ActionRead},
 	},
 
 	// API Key access for most services